@@ -107,6 +107,7 @@ func setDefaultValue(v *viper.Viper) {
 	v.SetDefault("RESUME_PIPELINES", true)
 	v.SetDefault("CORS_ALLOW_ORIGIN", "*")
 	v.SetDefault("CONSUME_PIPELINES", true)
+	v.SetDefault("MIGRATION_BACKUP_RETENTION_DAYS", 7)
 }
 
 func init() {
@@ -0,0 +1,332 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+)
+
+// dryRunDal passes all queries through to the underlying Dal and records statements which would
+// change the database instead of executing them
+type dryRunDal struct {
+	dal.Dal
+	statements []string
+}
+
+var _ dal.Dal = (*dryRunDal)(nil)
+
+func newDryRunDal(db dal.Dal) *dryRunDal {
+	return &dryRunDal{Dal: db}
+}
+
+func (d *dryRunDal) reset() {
+	d.statements = nil
+}
+
+func (d *dryRunDal) record(format string, args ...interface{}) errors.Error {
+	d.statements = append(d.statements, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (d *dryRunDal) quote(name string) string {
+	if d.Dialect() == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+func (d *dryRunDal) literal(param interface{}) string {
+	switch p := param.(type) {
+	case nil:
+		return "NULL"
+	case dal.ClauseTable:
+		return d.quote(p.Name)
+	case dal.ClauseColumn:
+		if p.Table != "" {
+			return d.quote(p.Table) + "." + d.quote(p.Name)
+		}
+		return d.quote(p.Name)
+	case string:
+		return "'" + strings.ReplaceAll(p, "'", "''") + "'"
+	case time.Time:
+		return "'" + p.Format("2006-01-02 15:04:05") + "'"
+	case *time.Time:
+		if p == nil {
+			return "NULL"
+		}
+		return d.literal(*p)
+	case []byte:
+		return d.literal(string(p))
+	}
+	v := reflect.ValueOf(param)
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = d.literal(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprintf("%v", param)
+}
+
+// explain renders the query with the params inlined, the result is for reading only
+func (d *dryRunDal) explain(query string, params ...interface{}) string {
+	var sb strings.Builder
+	i := 0
+	for _, c := range query {
+		if c == '?' && i < len(params) {
+			sb.WriteString(d.literal(params[i]))
+			i++
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func (d *dryRunDal) tableName(entityOrTable interface{}, clauses []dal.Clause) string {
+	for _, c := range clauses {
+		if c.Type != dal.FromClause {
+			continue
+		}
+		switch t := c.Data.(type) {
+		case string:
+			return t
+		case dal.ClauseTable:
+			return t.Name
+		case dal.Tabler:
+			return t.TableName()
+		}
+	}
+	switch t := entityOrTable.(type) {
+	case string:
+		return t
+	case dal.Tabler:
+		return t.TableName()
+	}
+	typ := reflect.TypeOf(entityOrTable)
+	for typ != nil && (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice) {
+		typ = typ.Elem()
+	}
+	if typ != nil {
+		if tabler, ok := reflect.New(typ).Interface().(dal.Tabler); ok {
+			return tabler.TableName()
+		}
+	}
+	return fmt.Sprintf("%T", entityOrTable)
+}
+
+func (d *dryRunDal) where(clauses []dal.Clause) string {
+	var conditions []string
+	for _, c := range clauses {
+		if c.Type == dal.WhereClause {
+			w := c.Data.(dal.DalClause)
+			conditions = append(conditions, "("+d.explain(w.Expr, w.Params...)+")")
+		}
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// AutoMigrate records the creation or alteration of the table
+func (d *dryRunDal) AutoMigrate(entity interface{}, clauses ...dal.Clause) errors.Error {
+	table := d.tableName(entity, clauses)
+	if d.HasTable(table) {
+		return d.record("ALTER TABLE %s /* add missing columns and indexes of %T */", d.quote(table), entity)
+	}
+	return d.record("CREATE TABLE %s /* columns and indexes of %T */", d.quote(table), entity)
+}
+
+// AddColumn records the addition of the column
+func (d *dryRunDal) AddColumn(table, columnName string, columnType dal.ColumnType) errors.Error {
+	return d.record("ALTER TABLE %s ADD COLUMN %s %s", d.quote(table), d.quote(columnName), columnType)
+}
+
+// DropColumns records the removal of the columns
+func (d *dryRunDal) DropColumns(table string, columnNames ...string) errors.Error {
+	for _, columnName := range columnNames {
+		_ = d.record("ALTER TABLE %s DROP COLUMN %s", d.quote(table), d.quote(columnName))
+	}
+	return nil
+}
+
+// Exec records the statement
+func (d *dryRunDal) Exec(query string, params ...interface{}) errors.Error {
+	return d.record("%s", d.explain(query, params...))
+}
+
+// Create records the insertion
+func (d *dryRunDal) Create(entity interface{}, clauses ...dal.Clause) errors.Error {
+	return d.record("INSERT INTO %s /* %T */", d.quote(d.tableName(entity, clauses)), entity)
+}
+
+// CreateWithMap records the insertion
+func (d *dryRunDal) CreateWithMap(entity interface{}, record map[string]interface{}) errors.Error {
+	return d.record("INSERT INTO %s /* %v */", d.quote(d.tableName(entity, nil)), record)
+}
+
+// CreateOrUpdate records the upsert
+func (d *dryRunDal) CreateOrUpdate(entity interface{}, clauses ...dal.Clause) errors.Error {
+	return d.record("INSERT INTO %s /* %T, update on conflict */", d.quote(d.tableName(entity, clauses)), entity)
+}
+
+// CreateIfNotExist records the insertion
+func (d *dryRunDal) CreateIfNotExist(entity interface{}, clauses ...dal.Clause) errors.Error {
+	return d.record("INSERT INTO %s /* %T, ignore on conflict */", d.quote(d.tableName(entity, clauses)), entity)
+}
+
+// Update records the update
+func (d *dryRunDal) Update(entity interface{}, clauses ...dal.Clause) errors.Error {
+	return d.record("UPDATE %s /* %T */%s", d.quote(d.tableName(entity, clauses)), entity, d.where(clauses))
+}
+
+// UpdateColumn records the update
+func (d *dryRunDal) UpdateColumn(entityOrTable interface{}, columnName string, value interface{}, clauses ...dal.Clause) errors.Error {
+	return d.UpdateColumns(entityOrTable, []dal.DalSet{{ColumnName: columnName, Value: value}}, clauses...)
+}
+
+// UpdateColumns records the update
+func (d *dryRunDal) UpdateColumns(entityOrTable interface{}, set []dal.DalSet, clauses ...dal.Clause) errors.Error {
+	assignments := make([]string, len(set))
+	for i, s := range set {
+		value := d.literal(s.Value)
+		if expr, ok := s.Value.(dal.DalClause); ok {
+			value = d.explain(expr.Expr, expr.Params...)
+		}
+		assignments[i] = fmt.Sprintf("%s = %s", d.quote(s.ColumnName), value)
+	}
+	return d.record(
+		"UPDATE %s SET %s%s",
+		d.quote(d.tableName(entityOrTable, clauses)),
+		strings.Join(assignments, ", "),
+		d.where(clauses),
+	)
+}
+
+// UpdateAllColumn records the update
+func (d *dryRunDal) UpdateAllColumn(entity interface{}, clauses ...dal.Clause) errors.Error {
+	return d.Update(entity, clauses...)
+}
+
+// Delete records the deletion
+func (d *dryRunDal) Delete(entity interface{}, clauses ...dal.Clause) errors.Error {
+	return d.record("DELETE FROM %s%s", d.quote(d.tableName(entity, clauses)), d.where(clauses))
+}
+
+// DropTables records the removal of the tables
+func (d *dryRunDal) DropTables(dst ...interface{}) errors.Error {
+	for _, entity := range dst {
+		_ = d.record("DROP TABLE %s", d.quote(d.tableName(entity, nil)))
+	}
+	return nil
+}
+
+// RenameTable records the renaming
+func (d *dryRunDal) RenameTable(oldName, newName string) errors.Error {
+	return d.record("ALTER TABLE %s RENAME TO %s", d.quote(oldName), d.quote(newName))
+}
+
+// RenameColumn records the renaming
+func (d *dryRunDal) RenameColumn(table, oldColumnName, newColumnName string) errors.Error {
+	return d.record(
+		"ALTER TABLE %s RENAME COLUMN %s TO %s",
+		d.quote(table), d.quote(oldColumnName), d.quote(newColumnName),
+	)
+}
+
+// ModifyColumnType records the alteration
+func (d *dryRunDal) ModifyColumnType(table, columnName, columnType string) errors.Error {
+	return d.record("ALTER TABLE %s ALTER COLUMN %s TYPE %s", d.quote(table), d.quote(columnName), columnType)
+}
+
+// DropIndexes records the removal of the indexes
+func (d *dryRunDal) DropIndexes(table string, indexes ...string) errors.Error {
+	for _, index := range indexes {
+		_ = d.record("DROP INDEX %s ON %s", d.quote(index), d.quote(table))
+	}
+	return nil
+}
+
+// DropIndex records the removal of the index
+func (d *dryRunDal) DropIndex(table string, columnNames ...string) errors.Error {
+	return d.DropIndexes(table, fmt.Sprintf("idx_%s_%s", table, strings.Join(columnNames, "_")))
+}
+
+// Session returns the dryRunDal itself since nothing would be executed anyway
+func (d *dryRunDal) Session(_ dal.SessionConfig) dal.Dal {
+	return d
+}
+
+// Begin returns a transaction recording to the same dryRunDal
+func (d *dryRunDal) Begin() dal.Transaction {
+	return &dryRunTransaction{dryRunDal: d}
+}
+
+type dryRunTransaction struct {
+	*dryRunDal
+}
+
+var _ dal.Transaction = (*dryRunTransaction)(nil)
+
+func (t *dryRunTransaction) Rollback() errors.Error {
+	return nil
+}
+
+func (t *dryRunTransaction) Commit() errors.Error {
+	return nil
+}
+
+func (t *dryRunTransaction) LockTables(_ dal.LockTables) errors.Error {
+	return nil
+}
+
+func (t *dryRunTransaction) UnlockTables() errors.Error {
+	return nil
+}
+
+// dryRunBasicRes replaces the Dal of the BasicRes with the dryRunDal
+type dryRunBasicRes struct {
+	context.BasicRes
+	db dal.Dal
+}
+
+func newDryRunBasicRes(basicRes context.BasicRes, db dal.Dal) context.BasicRes {
+	return &dryRunBasicRes{BasicRes: basicRes, db: db}
+}
+
+func (r *dryRunBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+func (r *dryRunBasicRes) NestedLogger(name string) context.BasicRes {
+	return newDryRunBasicRes(r.BasicRes.NestedLogger(name), r.db)
+}
+
+func (r *dryRunBasicRes) ReplaceLogger(logger log.Logger) context.BasicRes {
+	return newDryRunBasicRes(r.BasicRes.ReplaceLogger(logger), r.db)
+}
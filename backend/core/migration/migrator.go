@@ -20,6 +20,7 @@ package migration
 import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	core "github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -32,6 +33,16 @@ type scriptWithComment struct {
 	comment string
 }
 
+func (swc *scriptWithComment) info() plugin.MigrationScriptInfo {
+	_, reversible := swc.script.(plugin.ReversibleMigrationScript)
+	return plugin.MigrationScriptInfo{
+		Name:       swc.script.Name(),
+		Version:    swc.script.Version(),
+		Comment:    swc.comment,
+		Reversible: reversible,
+	}
+}
+
 type migratorImpl struct {
 	sync.Mutex
 	basicRes context.BasicRes
@@ -85,12 +96,16 @@ func (m *migratorImpl) Info(stage string) {
 	m.logger.Info("[%s] pending scripts: %d, executed scripts: %d, total: %d", stage, len(m.pending), len(m.executed), len(m.scripts))
 }
 
+func (m *migratorImpl) sortPending() {
+	sort.SliceStable(m.pending, func(i, j int) bool {
+		return m.pending[i].script.Version() < m.pending[j].script.Version()
+	})
+}
+
 // Execute all registered migration script in order and mark them as executed in migration_history table
 func (m *migratorImpl) Execute() errors.Error {
 	// sort the scripts by version
-	sort.Slice(m.pending, func(i, j int) bool {
-		return m.pending[i].script.Version() < m.pending[j].script.Version()
-	})
+	m.sortPending()
 	m.Info("Execute")
 	// execute them one by one
 	db := m.basicRes.GetDal()
@@ -120,6 +135,98 @@ func (m *migratorImpl) HasPendingScripts() bool {
 	return len(m.executed) > 0 && len(m.pending) > 0
 }
 
+// PendingScripts returns the pending scripts in the order they would be executed
+func (m *migratorImpl) PendingScripts() []plugin.MigrationScriptInfo {
+	m.Lock()
+	defer m.Unlock()
+	m.sortPending()
+	infos := make([]plugin.MigrationScriptInfo, 0, len(m.pending))
+	for _, swc := range m.pending {
+		infos = append(infos, swc.info())
+	}
+	return infos
+}
+
+// DryRun runs the pending scripts against a recording Dal, which passes queries through to the database
+// but only records the statements that would change it. Since nothing gets changed, a script reading
+// the outcome of a previous statement may fail, the error would be kept in the plan and the remaining
+// scripts would still be planned.
+func (m *migratorImpl) DryRun() []plugin.MigrationScriptPlan {
+	m.Lock()
+	defer m.Unlock()
+	m.sortPending()
+	recorder := newDryRunDal(m.basicRes.GetDal())
+	basicRes := newDryRunBasicRes(m.basicRes, recorder)
+	plans := make([]plugin.MigrationScriptPlan, 0, len(m.pending))
+	for _, swc := range m.pending {
+		recorder.reset()
+		plan := plugin.MigrationScriptPlan{MigrationScriptInfo: swc.info()}
+		err := dryRunScript(swc.script, basicRes)
+		if err != nil {
+			plan.Error = err.Error()
+		}
+		plan.Statements = recorder.statements
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+func dryRunScript(script plugin.MigrationScript, basicRes context.BasicRes) (err errors.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Default.New(fmt.Sprintf("migration script panicked during dry-run: %v", r))
+		}
+	}()
+	return script.Up(basicRes)
+}
+
+// Rollback reverts all executed scripts whose version is greater than or equal to the specified one
+// in the reversed order, and mark them as pending again. It refuses to do anything if any of them
+// is not reversible.
+func (m *migratorImpl) Rollback(version uint64) errors.Error {
+	m.Lock()
+	defer m.Unlock()
+	var targets []*scriptWithComment
+	for _, swc := range m.scripts {
+		if swc.script.Version() < version || !m.executed[getScriptId(swc.script.Name(), swc.script.Version())] {
+			continue
+		}
+		if _, ok := swc.script.(plugin.ReversibleMigrationScript); !ok {
+			return errors.BadInput.New(fmt.Sprintf(
+				"migration script %s is not reversible",
+				getScriptId(swc.script.Name(), swc.script.Version()),
+			))
+		}
+		targets = append(targets, swc)
+	}
+	if len(targets) == 0 {
+		return errors.BadInput.New(fmt.Sprintf("no executed migration script since version %d", version))
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].script.Version() > targets[j].script.Version()
+	})
+	db := m.basicRes.GetDal()
+	for _, swc := range targets {
+		scriptId := getScriptId(swc.script.Name(), swc.script.Version())
+		m.logger.Info("reverting migration script %s", scriptId)
+		err := swc.script.(plugin.ReversibleMigrationScript).Down(m.basicRes)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to revert migration script %s", scriptId))
+		}
+		err = db.Delete(
+			&MigrationHistory{},
+			dal.Where("script_name = ? AND script_version = ?", swc.script.Name(), swc.script.Version()),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to delete migration history of %s", scriptId))
+		}
+		delete(m.executed, scriptId)
+		m.pending = append(m.pending, swc)
+	}
+	m.Info("Rollback")
+	return nil
+}
+
 // NewMigrator returns a new Migrator instance, which
 // implemented based on migration_history from the same database
 func NewMigrator(basicRes context.BasicRes) (plugin.Migrator, errors.Error) {
@@ -18,6 +18,7 @@ limitations under the License.
 package migration
 
 import (
+	coreContext "github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
@@ -85,3 +86,123 @@ func TestHasPendingScripts(t *testing.T) {
 	// make sure all method got called
 	mockDal.AssertExpectations(t)
 }
+
+func newTestMigrator(t *testing.T, mockDal *mockdal.Dal, executed ...MigrationHistory) plugin.Migrator {
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		*i.(*[]MigrationHistory) = executed
+		return nil
+	}).Once()
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	return migrator
+}
+
+func TestPendingScripts(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	migrator := newTestMigrator(t, mockDal, MigrationHistory{ScriptName: "A", ScriptVersion: 1})
+
+	scriptA := new(mockplugin.MigrationScript)
+	scriptA.On("Version").Return(uint64(1))
+	scriptA.On("Name").Return("A")
+	scriptB := new(mockplugin.ReversibleMigrationScript)
+	scriptB.On("Version").Return(uint64(3))
+	scriptB.On("Name").Return("B")
+	scriptC := new(mockplugin.MigrationScript)
+	scriptC.On("Version").Return(uint64(2))
+	scriptC.On("Name").Return("C")
+	migrator.Register([]plugin.MigrationScript{scriptA, scriptB}, "Framework")
+	migrator.Register([]plugin.MigrationScript{scriptC}, "github")
+
+	assert.Equal(t, []plugin.MigrationScriptInfo{
+		{Name: "C", Version: 2, Comment: "github", Reversible: false},
+		{Name: "B", Version: 3, Comment: "Framework", Reversible: true},
+	}, migrator.PendingScripts())
+}
+
+func TestDryRun(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	migrator := newTestMigrator(t, mockDal, MigrationHistory{ScriptName: "A", ScriptVersion: 1})
+	mockDal.On("Dialect").Return("mysql")
+	mockDal.On("HasTable", "_tool_dry_run").Return(false)
+
+	scriptB := new(mockplugin.MigrationScript)
+	scriptB.On("Version").Return(uint64(2))
+	scriptB.On("Name").Return("B")
+	scriptB.On("Up", mock.Anything).Return(func(basicRes coreContext.BasicRes) errors.Error {
+		db := basicRes.GetDal()
+		errors.Must(db.AutoMigrate(&struct{}{}, dal.From("_tool_dry_run")))
+		errors.Must(db.Exec("UPDATE ? SET name = ? WHERE id IN ?", dal.ClauseTable{Name: "_tool_dry_run"}, "it's", []int{1, 2}))
+		errors.Must(db.RenameColumn("_tool_dry_run", "name", "title"))
+		return nil
+	})
+	scriptC := new(mockplugin.MigrationScript)
+	scriptC.On("Version").Return(uint64(3))
+	scriptC.On("Name").Return("C")
+	scriptC.On("Up", mock.Anything).Return(errors.Default.New("table not found"))
+	migrator.Register([]plugin.MigrationScript{scriptC, scriptB}, "UnitTest")
+
+	plans := migrator.DryRun()
+	assert.Equal(t, 2, len(plans))
+	assert.Equal(t, "B", plans[0].Name)
+	assert.Equal(t, []string{
+		"CREATE TABLE `_tool_dry_run` /* columns and indexes of *struct {} */",
+		"UPDATE `_tool_dry_run` SET name = 'it''s' WHERE id IN 1,2",
+		"ALTER TABLE `_tool_dry_run` RENAME COLUMN `name` TO `title`",
+	}, plans[0].Statements)
+	assert.Empty(t, plans[0].Error)
+	assert.Equal(t, "C", plans[1].Name)
+	assert.Empty(t, plans[1].Statements)
+	assert.Contains(t, plans[1].Error, "table not found")
+
+	// nothing should be changed, the mocked Dal would panic on any unexpected write
+	assert.True(t, migrator.HasPendingScripts())
+	mockDal.AssertExpectations(t)
+}
+
+func TestRollback(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	migrator := newTestMigrator(
+		t,
+		mockDal,
+		MigrationHistory{ScriptName: "A", ScriptVersion: 1},
+		MigrationHistory{ScriptName: "B", ScriptVersion: 2},
+		MigrationHistory{ScriptName: "C", ScriptVersion: 3},
+	)
+
+	var reverted []string
+	newScript := func(name string, version uint64) *mockplugin.ReversibleMigrationScript {
+		script := new(mockplugin.ReversibleMigrationScript)
+		script.On("Version").Return(version)
+		script.On("Name").Return(name)
+		script.On("Down", mock.Anything).Run(func(_ mock.Arguments) {
+			reverted = append(reverted, name)
+		}).Return(nil)
+		return script
+	}
+	scriptA := new(mockplugin.MigrationScript)
+	scriptA.On("Version").Return(uint64(1))
+	scriptA.On("Name").Return("A")
+	migrator.Register([]plugin.MigrationScript{scriptA, newScript("B", 2), newScript("C", 3)}, "UnitTest")
+	assert.False(t, migrator.HasPendingScripts())
+
+	// A is not reversible
+	err := migrator.Rollback(1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not reversible")
+	assert.Empty(t, reverted)
+
+	mockDal.On("Delete", &MigrationHistory{}, mock.Anything).Return(nil).Twice()
+	assert.Nil(t, migrator.Rollback(2))
+	assert.Equal(t, []string{"C", "B"}, reverted)
+	assert.Equal(t, []plugin.MigrationScriptInfo{
+		{Name: "B", Version: 2, Comment: "UnitTest", Reversible: true},
+		{Name: "C", Version: 3, Comment: "UnitTest", Reversible: true},
+	}, migrator.PendingScripts())
+	assert.True(t, migrator.HasPendingScripts())
+	mockDal.AssertExpectations(t)
+
+	// nothing left to be reverted
+	assert.NotNil(t, migrator.Rollback(2))
+}
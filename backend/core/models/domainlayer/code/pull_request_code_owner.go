@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	CODE_OWNER_TYPE_ACCOUNT    = "ACCOUNT"
+	CODE_OWNER_TYPE_TEAM       = "TEAM"
+	CODE_OWNER_TYPE_UNRESOLVED = "UNRESOLVED"
+)
+
+// PullRequestCodeOwner is an owner required by CODEOWNERS for the files changed by a pull request
+type PullRequestCodeOwner struct {
+	PullRequestId string `gorm:"primaryKey;type:varchar(255)"`
+	// OwnerId is the id of accounts or teams, or the owner as written in CODEOWNERS if it could not be resolved
+	OwnerId         string `gorm:"primaryKey;type:varchar(255)"`
+	OwnerName       string `gorm:"type:varchar(255)"`
+	OwnerType       string `gorm:"type:varchar(100)"`
+	OwnedFileCount  int
+	IsReviewed      bool
+	ReviewerId      string `gorm:"type:varchar(255)"`
+	FirstReviewDate *time.Time
+
+	common.NoPKModel
+}
+
+func (PullRequestCodeOwner) TableName() string {
+	return "pull_request_code_owners"
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectFlakyTest is how often a test case of a project both passed and failed on the same commit. A commit is flaky
+// for the test when its executions on that commit have both succeeded and failed.
+type ProjectFlakyTest struct {
+	common.NoPKModel
+	ProjectName      string `gorm:"primaryKey;type:varchar(100)"`
+	QaTestCaseId     string `gorm:"primaryKey;type:varchar(255)"`
+	QaProjectId      string `gorm:"index;type:varchar(255)"`
+	TestName         string `gorm:"type:varchar(255)"`
+	ExecutionCount   int
+	FailureCount     int
+	CommitCount      int
+	FlakyCommitCount int
+	// FlakeRate is FlakyCommitCount / CommitCount
+	FlakeRate float64
+	IsFlaky   bool
+	// FirstFlakyDate is when the test first contradicted an earlier result on the same commit
+	FirstFlakyDate *time.Time
+	LastFlakyDate  *time.Time
+}
+
+func (ProjectFlakyTest) TableName() string {
+	return "project_flaky_tests"
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// ProjectIssueLeadTime breaks the lead time of an issue down along the merged pull requests of the project linked to it.
+// The issue is in development from the first commit of its pull requests, in review from the first one opened, waiting
+// to deploy once the last one is merged, and in production when the last one is deployed. All spans are in minutes.
+type ProjectIssueLeadTime struct {
+	domainlayer.DomainEntity
+	ProjectName        string `gorm:"primaryKey;type:varchar(100)"`
+	PrCount            int
+	DeploymentCommitId string `gorm:"type:varchar(255)"`
+
+	BacklogTime         *int64
+	DevelopmentTime     *int64
+	ReviewTime          *int64
+	WaitingToDeployTime *int64
+	TimeToProduction    *int64
+
+	IssueCreatedDate       *time.Time
+	DevelopmentStartedDate *time.Time
+	ReviewStartedDate      *time.Time
+	LastMergedDate         *time.Time
+	DeployedDate           *time.Time
+}
+
+func (ProjectIssueLeadTime) TableName() string {
+	return "project_issue_lead_times"
+}
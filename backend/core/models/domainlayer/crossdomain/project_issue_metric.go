@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectIssueMetric holds the metrics of an issue which depend on the project it is measured in, e.g. on the project
+// calendar. An issue on the boards of several projects has one row per project.
+type ProjectIssueMetric struct {
+	common.NoPKModel
+	ProjectName string `gorm:"primaryKey;type:varchar(100)"`
+	IssueId     string `gorm:"primaryKey;type:varchar(255)"`
+	// LeadTimeBusinessMinutes is the lead time of the resolved issue counted in the working hours of the project calendar
+	LeadTimeBusinessMinutes *uint
+}
+
+func (ProjectIssueMetric) TableName() string {
+	return "project_issue_metrics"
+}
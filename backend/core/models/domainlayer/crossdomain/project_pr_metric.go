@@ -35,6 +35,13 @@ type ProjectPrMetric struct {
 	PrDeployTime       *int64
 	PrCycleTime        *int64
 
+	// business-time variants only count the working hours of the project calendar
+	PrCodingBusinessTime *int64
+	PrPickupBusinessTime *int64
+	PrReviewBusinessTime *int64
+	PrDeployBusinessTime *int64
+	PrCycleBusinessTime  *int64
+
 	FirstCommitAuthoredDate *time.Time
 	FirstCommentDate        *time.Time
 	PrCreatedDate           *time.Time
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	PR_SIZE_XS = "XS"
+	PR_SIZE_S  = "S"
+	PR_SIZE_M  = "M"
+	PR_SIZE_L  = "L"
+	PR_SIZE_XL = "XL"
+)
+
+// ProjectPrRisk is the size and the risk flags of a pull request, sized with the thresholds of the project
+type ProjectPrRisk struct {
+	domainlayer.DomainEntity
+	ProjectName string `gorm:"primaryKey;type:varchar(100)"`
+	// ChangedLines are the additions and deletions, ChangedFiles the distinct files of the commit_files of its commits
+	ChangedLines       int
+	ChangedFiles       int
+	Size               string `gorm:"type:varchar(2)"`
+	ReviewCommentCount int
+	// SensitiveComponents is a comma separated list of the components whose PathRegex matches a changed file
+	SensitiveComponents          string `gorm:"type:varchar(500)"`
+	IsSensitive                  bool
+	IsSelfMergedWithoutReview    bool
+	IsMergedOutsideBusinessHours bool
+	IsLargeWithoutReview         bool
+	// RiskScore is the number of risk flags raised
+	RiskScore int
+	IsRisky   bool
+}
+
+func (ProjectPrRisk) TableName() string {
+	return "project_pr_risks"
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// ProjectPrReviewMetric is how deeply a pull request was reviewed. Review activity is any comment or review event by
+// someone else than the author, a review round is the activity between two pushes, approximated by the authored dates
+// of the commits of the pull request.
+type ProjectPrReviewMetric struct {
+	domainlayer.DomainEntity
+	ProjectName  string `gorm:"primaryKey;type:varchar(100)"`
+	BaseRepoId   string `gorm:"index;type:varchar(255)"`
+	ChangedLines int
+	// ReviewCommentCount are the non-empty comments of the reviewers, approvals excluded
+	ReviewCommentCount        int
+	CommentsPer100Lines       *float64 `gorm:"column:comments_per_100_lines"`
+	ReviewRounds              int
+	IsApproved                bool
+	IsApprovedWithoutComments bool
+	// ReReviewMinutes is the average time from the last push to the activity opening the next review round
+	ReReviewMinutes *float64
+	MergedDate      *time.Time
+}
+
+func (ProjectPrReviewMetric) TableName() string {
+	return "project_pr_review_metrics"
+}
+
+// ProjectRepoReviewMetric aggregates the review metrics of the pull requests of a repo merged within a month
+type ProjectRepoReviewMetric struct {
+	common.NoPKModel
+	ProjectName                 string    `gorm:"primaryKey;type:varchar(100)"`
+	RepoId                      string    `gorm:"primaryKey;type:varchar(255)"`
+	Period                      time.Time `gorm:"primaryKey"`
+	PrCount                     int
+	AvgCommentsPer100Lines      *float64 `gorm:"column:avg_comments_per_100_lines"`
+	AvgReviewRounds             float64
+	UnreviewedRate              float64
+	ApprovedWithoutCommentsRate float64
+	AvgReReviewMinutes          *float64
+}
+
+func (ProjectRepoReviewMetric) TableName() string {
+	return "project_repo_review_metrics"
+}
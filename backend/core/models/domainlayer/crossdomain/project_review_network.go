@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectReviewEdge is how a reviewer reviewed the pull requests of an author within a month, a pull request
+// is reviewed in the month of the first comment of the reviewer
+type ProjectReviewEdge struct {
+	common.NoPKModel
+	ProjectName  string    `gorm:"primaryKey;type:varchar(100)"`
+	Period       time.Time `gorm:"primaryKey"`
+	ReviewerId   string    `gorm:"primaryKey;type:varchar(255)"`
+	AuthorId     string    `gorm:"primaryKey;type:varchar(255)"`
+	PrCount      int
+	CommentCount int
+	// MedianResponseMinutes is the median time from the creation of the pull requests to the first comment of the reviewer
+	MedianResponseMinutes *float64
+}
+
+func (ProjectReviewEdge) TableName() string {
+	return "project_review_edges"
+}
+
+// ProjectReviewerLoad is the open pull requests a reviewer is requested on
+type ProjectReviewerLoad struct {
+	common.NoPKModel
+	ProjectName     string `gorm:"primaryKey;type:varchar(100)"`
+	ReviewerId      string `gorm:"primaryKey;type:varchar(255)"`
+	OpenReviewCount int
+	// PendingReviewCount are the open reviews the reviewer has not commented on yet
+	PendingReviewCount       int
+	OldestPendingCreatedDate *time.Time
+}
+
+func (ProjectReviewerLoad) TableName() string {
+	return "project_reviewer_loads"
+}
+
+// ProjectReviewConcentration tells how the reviews of a month are spread across the reviewers
+type ProjectReviewConcentration struct {
+	common.NoPKModel
+	ProjectName   string    `gorm:"primaryKey;type:varchar(100)"`
+	Period        time.Time `gorm:"primaryKey"`
+	ReviewerCount int
+	AuthorCount   int
+	ReviewCount   int
+	// TopReviewerShare is the share of the reviews done by the busiest reviewer
+	TopReviewerShare float64
+	// Hhi is the Herfindahl-Hirschman index of the reviewer shares, from 1/ReviewerCount when evenly spread to 1
+	Hhi float64
+	// ReciprocalShare is the share of the reviews between pairs reviewing each other, high values hint at review cliques
+	ReciprocalShare float64
+}
+
+func (ProjectReviewConcentration) TableName() string {
+	return "project_review_concentrations"
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	RUNNER_OS_LINUX   = "LINUX"
+	RUNNER_OS_WINDOWS = "WINDOWS"
+	RUNNER_OS_MACOS   = "MACOS"
+)
+
+// CicdRunner is the runner, agent or executor a CICDTask ran on
+type CicdRunner struct {
+	domainlayer.DomainEntity
+	Name string `gorm:"type:varchar(255)"`
+	// Pool groups runners sharing a queue, e.g. a runner group, an agent pool or a node label
+	Pool string `gorm:"index;type:varchar(255)"`
+	// Labels is a comma separated list of the labels or tags of the runner
+	Labels       string `gorm:"type:varchar(500)"`
+	Os           string `gorm:"type:varchar(100)"`
+	IsSelfHosted bool
+}
+
+func (CicdRunner) TableName() string {
+	return "cicd_runners"
+}
+
+// ProjectRunnerPoolMetric is the daily utilisation and queue time of a runner pool within a project
+type ProjectRunnerPoolMetric struct {
+	common.NoPKModel
+	ProjectName string    `gorm:"primaryKey;type:varchar(100)"`
+	Pool        string    `gorm:"primaryKey;type:varchar(255)"`
+	Date        time.Time `gorm:"primaryKey"`
+	TaskCount   int
+	RunnerCount int
+	BusySec     float64
+	// Utilisation is the busy time over the capacity of the runners seen that day
+	Utilisation  float64
+	QueuedP50Sec *float64
+	QueuedP90Sec *float64
+	QueuedP95Sec *float64
+}
+
+func (ProjectRunnerPoolMetric) TableName() string {
+	return "project_runner_pool_metrics"
+}
+
+// GetRunnerOs guesses the operating system of a runner from its labels, image or architecture names
+func GetRunnerOs(labels ...string) string {
+	for _, label := range labels {
+		label = strings.ToLower(label)
+		switch {
+		case strings.Contains(label, "windows"):
+			return RUNNER_OS_WINDOWS
+		case strings.Contains(label, "macos"), strings.Contains(label, "mac os"), strings.Contains(label, "darwin"):
+			return RUNNER_OS_MACOS
+		case strings.Contains(label, "linux"), strings.Contains(label, "ubuntu"):
+			return RUNNER_OS_LINUX
+		}
+	}
+	return ""
+}
@@ -40,6 +40,7 @@ type CICDTask struct {
 	domainlayer.DomainEntity
 	Name              string `gorm:"type:varchar(255)"`
 	PipelineId        string `gorm:"index;type:varchar(255)"`
+	ParentTaskId      string `gorm:"index;type:varchar(255);comment: the enclosing task of a nested task, e.g. a branch of a parallel stage"`
 	Result            string `gorm:"type:varchar(100)"`
 	Status            string `gorm:"type:varchar(100)"`
 	OriginalStatus    string `gorm:"type:varchar(100)"`
@@ -51,6 +52,7 @@ type CICDTask struct {
 	TaskDatesInfo
 	//StartedDate  time.Time  // notice here
 	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	RunnerId    string `gorm:"index;type:varchar(255)"`
 }
 
 func (CICDTask) TableName() string {
@@ -18,6 +18,9 @@ limitations under the License.
 package domaininfo
 
 import (
+	"path"
+	"reflect"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
@@ -25,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"golang.org/x/exp/slices"
 )
 
 func GetDomainTablesInfo() []dal.Tabler {
@@ -39,6 +43,7 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&code.PullRequest{},
 		&code.PullRequestComment{},
 		&code.PullRequestCommit{},
+		&code.PullRequestCodeOwner{},
 		&code.PullRequestLabel{},
 		&code.PullRequestReviewer{},
 		&code.PullRequestAssignee{},
@@ -64,6 +69,15 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&crossdomain.ProjectMapping{},
 		&crossdomain.ProjectIncidentDeploymentRelationship{},
 		&crossdomain.ProjectPrMetric{},
+		&crossdomain.ProjectPrRisk{},
+		&crossdomain.ProjectReviewEdge{},
+		&crossdomain.ProjectReviewerLoad{},
+		&crossdomain.ProjectReviewConcentration{},
+		&crossdomain.ProjectIssueLeadTime{},
+		&crossdomain.ProjectIssueMetric{},
+		&crossdomain.ProjectPrReviewMetric{},
+		&crossdomain.ProjectRepoReviewMetric{},
+		&crossdomain.ProjectFlakyTest{},
 		&crossdomain.PullRequestIssue{},
 		&crossdomain.RefsIssuesDiffs{},
 		&crossdomain.Team{},
@@ -78,6 +92,8 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&devops.CicdScope{},
 		&devops.CICDDeployment{},
 		&devops.CicdRelease{},
+		&devops.CicdRunner{},
+		&devops.ProjectRunnerPoolMetric{},
 		// didgen no table
 		// ticket
 		&ticket.Board{},
@@ -95,10 +111,40 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.IssueCustomArrayField{},
 		&ticket.Incident{},
 		&ticket.IncidentAssignee{},
+		&ticket.BoardFlowStatus{},
+		&ticket.BoardFlowMetric{},
 		// qa
 		&qa.QaProject{},
 		&qa.QaApi{},
 		&qa.QaTestCase{},
 		&qa.QaTestCaseExecution{},
+		&qa.QaTestCoverage{},
 	}
 }
+
+// GetDomainLayers returns the names of domain layers, i.e. code, ticket
+func GetDomainLayers() []string {
+	var layers []string
+	for _, table := range GetDomainTablesInfo() {
+		layer := domainLayerOf(table)
+		if !slices.Contains(layers, layer) {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
+
+// GetDomainTablesInfoByLayer returns the tables of the domain layer, which is the name of package holding the models
+func GetDomainTablesInfoByLayer(layer string) []dal.Tabler {
+	var tables []dal.Tabler
+	for _, table := range GetDomainTablesInfo() {
+		if domainLayerOf(table) == layer {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+func domainLayerOf(table dal.Tabler) string {
+	return path.Base(reflect.TypeOf(table).Elem().PkgPath())
+}
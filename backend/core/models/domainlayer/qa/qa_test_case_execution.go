@@ -33,6 +33,11 @@ type QaTestCaseExecution struct {
 	FinishTime   time.Time `gorm:"comment:Test finish time"`
 	CreatorId    string    `gorm:"type:varchar(255);comment:Executor ID"`
 	Status       string    `gorm:"type:varchar(255);comment:Test execution status | PENDING | IN_PROGRESS | SUCCESS | FAILED"` // enum, using string
+
+	// the pipeline and the commit of an execution parsed from the test reports of a CI run
+	CicdPipelineId string  `gorm:"type:varchar(255);index;comment:CI pipeline ID"`
+	CommitSha      string  `gorm:"type:varchar(40);index;comment:Tested commit SHA"`
+	DurationSec    float64 `gorm:"comment:Test duration in seconds"`
 }
 
 func (QaTestCaseExecution) TableName() string {
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qa
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// QaTestCoverage represents the code coverage reported by a CI pipeline in the domain layer
+type QaTestCoverage struct {
+	domainlayer.DomainEntityExtended
+	QaProjectId     string    `gorm:"type:varchar(255);index;comment:Project ID"`
+	CicdPipelineId  string    `gorm:"type:varchar(255);index;comment:CI pipeline ID"`
+	CommitSha       string    `gorm:"type:varchar(40);comment:Tested commit SHA"`
+	FileName        string    `gorm:"type:varchar(255);comment:Coverage report file"`
+	LineRate        float64   `gorm:"comment:Covered lines / valid lines"`
+	BranchRate      float64   `gorm:"comment:Covered branches / valid branches"`
+	LinesCovered    int       `gorm:"comment:Covered lines"`
+	LinesValid      int       `gorm:"comment:Valid lines"`
+	BranchesCovered int       `gorm:"comment:Covered branches"`
+	BranchesValid   int       `gorm:"comment:Valid branches"`
+	CreateTime      time.Time `gorm:"comment:Coverage report creation time"`
+}
+
+func (QaTestCoverage) TableName() string {
+	return "qa_test_coverages"
+}
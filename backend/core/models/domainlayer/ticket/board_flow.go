@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// BoardFlowStatus is the number of issues of a board in a status at the end of a (UTC) day,
+// the rows of a board make up its cumulative flow diagram
+type BoardFlowStatus struct {
+	common.NoPKModel
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	Date           time.Time `gorm:"primaryKey"`
+	OriginalStatus string    `gorm:"primaryKey;type:varchar(255)"`
+	// Status is the standard status (category) the tool plugin mapped the original status to
+	Status     string `gorm:"type:varchar(100)"`
+	IssueCount int
+}
+
+func (BoardFlowStatus) TableName() string {
+	return "board_flow_statuses"
+}
+
+// BoardFlowMetric holds the daily Kanban flow metrics of a board
+type BoardFlowMetric struct {
+	common.NoPKModel
+	BoardId string    `gorm:"primaryKey;type:varchar(255)"`
+	Date    time.Time `gorm:"primaryKey"`
+	// Wip is the number of issues IN_PROGRESS at the end of the day
+	Wip int
+	// Throughput is the number of issues moved to DONE during the day
+	Throughput int
+	// The cycle time (from first IN_PROGRESS to DONE) percentiles and the flow efficiency
+	// cover the issues done within the 30 days ending with the day
+	CycleTimeP50Minutes *int64
+	CycleTimeP85Minutes *int64
+	CycleTimeP95Minutes *int64
+	// FlowEfficiency is the active time over the cycle time, nil if the board has no waiting statuses configured
+	FlowEfficiency *float64
+	// The age of the issues IN_PROGRESS at the end of the day, since they first went IN_PROGRESS
+	AvgWipAgeMinutes *int64
+	MaxWipAgeMinutes *int64
+}
+
+func (BoardFlowMetric) TableName() string {
+	return "board_flow_metrics"
+}
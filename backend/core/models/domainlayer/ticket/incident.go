@@ -52,8 +52,21 @@ type Incident struct {
 	ScopeId                 string `gorm:"index:idx_table_scope_id;type:varchar(255)"`
 	AssigneeId              string `gorm:"type:varchar(255)"`
 	AssigneeName            string `gorm:"type:varchar(255)"`
+	// normalized across tools, see INCIDENT_SEVERITY_*
+	StdSeverity         string `gorm:"type:varchar(100)"`
+	AffectedService     string `gorm:"type:varchar(255)"`
+	IsCustomerImpacting bool
+	// PrimaryIncidentId is set when the incident is the same outage as the primary one reported through another tool
+	PrimaryIncidentId string `gorm:"type:varchar(255)"`
 }
 
 func (Incident) TableName() string {
 	return "incidents"
 }
+
+const (
+	INCIDENT_SEVERITY_CRITICAL = "CRITICAL"
+	INCIDENT_SEVERITY_HIGH     = "HIGH"
+	INCIDENT_SEVERITY_MEDIUM   = "MEDIUM"
+	INCIDENT_SEVERITY_LOW      = "LOW"
+)
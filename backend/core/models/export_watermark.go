@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// ExportWatermark keeps track of the progress of exporting a table to an external destination
+type ExportWatermark struct {
+	// Destination identifies where the table was exported to, i.e. starrocks:host:port/database
+	Destination string `gorm:"primaryKey;type:varchar(255)" json:"destination"`
+	Table       string `gorm:"primaryKey;type:varchar(255)" json:"table"`
+	// Columns stores the exported columns separated by comma, for detecting columns added by migrations
+	Columns string `gorm:"type:text" json:"columns"`
+	// UpdatedUntil stores the greatest value of the update column among the exported rows
+	UpdatedUntil *time.Time `json:"updatedUntil"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+func (ExportWatermark) TableName() string {
+	return "_devlake_export_watermarks"
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addExportWatermarks)(nil)
+
+type exportWatermark20261019 struct {
+	Destination  string `gorm:"primaryKey;type:varchar(255)"`
+	Table        string `gorm:"primaryKey;type:varchar(255)"`
+	Columns      string `gorm:"type:text"`
+	UpdatedUntil *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (exportWatermark20261019) TableName() string {
+	return "_devlake_export_watermarks"
+}
+
+type addExportWatermarks struct{}
+
+func (script *addExportWatermarks) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&exportWatermark20261019{})
+}
+
+func (script *addExportWatermarks) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&exportWatermark20261019{})
+}
+
+func (*addExportWatermarks) Version() uint64 {
+	return 20261019093512
+}
+
+func (*addExportWatermarks) Name() string {
+	return "add _devlake_export_watermarks"
+}
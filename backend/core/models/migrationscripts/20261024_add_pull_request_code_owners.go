@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addPullRequestCodeOwners)(nil)
+
+type pullRequestCodeOwner20261024 struct {
+	PullRequestId   string `gorm:"primaryKey;type:varchar(255)"`
+	OwnerId         string `gorm:"primaryKey;type:varchar(255)"`
+	OwnerName       string `gorm:"type:varchar(255)"`
+	OwnerType       string `gorm:"type:varchar(100)"`
+	OwnedFileCount  int
+	IsReviewed      bool
+	ReviewerId      string `gorm:"type:varchar(255)"`
+	FirstReviewDate *time.Time
+
+	archived.NoPKModel
+}
+
+func (pullRequestCodeOwner20261024) TableName() string {
+	return "pull_request_code_owners"
+}
+
+type addPullRequestCodeOwners struct{}
+
+func (*addPullRequestCodeOwners) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&pullRequestCodeOwner20261024{})
+}
+
+func (*addPullRequestCodeOwners) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&pullRequestCodeOwner20261024{})
+}
+
+func (*addPullRequestCodeOwners) Version() uint64 {
+	return 20261024000001
+}
+
+func (*addPullRequestCodeOwners) Name() string {
+	return "add pull_request_code_owners table"
+}
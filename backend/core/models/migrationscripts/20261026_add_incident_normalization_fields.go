@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addIncidentNormalizationFields)(nil)
+
+type incident20261026 struct {
+	StdSeverity         string `gorm:"type:varchar(100)"`
+	AffectedService     string `gorm:"type:varchar(255)"`
+	IsCustomerImpacting bool
+	PrimaryIncidentId   string `gorm:"type:varchar(255)"`
+}
+
+func (incident20261026) TableName() string {
+	return "incidents"
+}
+
+type addIncidentNormalizationFields struct{}
+
+func (*addIncidentNormalizationFields) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&incident20261026{})
+}
+
+func (*addIncidentNormalizationFields) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns("incidents", "std_severity", "affected_service", "is_customer_impacting", "primary_incident_id")
+}
+
+func (*addIncidentNormalizationFields) Version() uint64 {
+	return 20261026000001
+}
+
+func (*addIncidentNormalizationFields) Name() string {
+	return "add std_severity, affected_service, is_customer_impacting and primary_incident_id to incidents"
+}
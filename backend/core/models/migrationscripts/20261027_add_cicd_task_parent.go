@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addCicdTaskParent)(nil)
+
+type cicdTask20261027 struct {
+	ParentTaskId string `gorm:"index;type:varchar(255)"`
+}
+
+func (cicdTask20261027) TableName() string {
+	return "cicd_tasks"
+}
+
+type addCicdTaskParent struct{}
+
+func (*addCicdTaskParent) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&cicdTask20261027{})
+}
+
+func (*addCicdTaskParent) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns("cicd_tasks", "parent_task_id")
+}
+
+func (*addCicdTaskParent) Version() uint64 {
+	return 20261027000001
+}
+
+func (*addCicdTaskParent) Name() string {
+	return "add parent_task_id to cicd_tasks"
+}
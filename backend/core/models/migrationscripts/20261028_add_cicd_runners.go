@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCicdRunners)(nil)
+
+type cicdRunner20261028 struct {
+	archived.DomainEntity
+	Name         string `gorm:"type:varchar(255)"`
+	Pool         string `gorm:"index;type:varchar(255)"`
+	Labels       string `gorm:"type:varchar(500)"`
+	Os           string `gorm:"type:varchar(100)"`
+	IsSelfHosted bool
+}
+
+func (cicdRunner20261028) TableName() string {
+	return "cicd_runners"
+}
+
+type cicdTask20261028 struct {
+	RunnerId string `gorm:"index;type:varchar(255)"`
+}
+
+func (cicdTask20261028) TableName() string {
+	return "cicd_tasks"
+}
+
+type projectRunnerPoolMetric20261028 struct {
+	ProjectName  string    `gorm:"primaryKey;type:varchar(100)"`
+	Pool         string    `gorm:"primaryKey;type:varchar(255)"`
+	Date         time.Time `gorm:"primaryKey"`
+	TaskCount    int
+	RunnerCount  int
+	BusySec      float64
+	Utilisation  float64
+	QueuedP50Sec *float64
+	QueuedP90Sec *float64
+	QueuedP95Sec *float64
+
+	archived.NoPKModel
+}
+
+func (projectRunnerPoolMetric20261028) TableName() string {
+	return "project_runner_pool_metrics"
+}
+
+type addCicdRunners struct{}
+
+func (*addCicdRunners) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&cicdRunner20261028{},
+		&cicdTask20261028{},
+		&projectRunnerPoolMetric20261028{},
+	)
+}
+
+func (*addCicdRunners) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("cicd_tasks", "runner_id"); err != nil {
+		return err
+	}
+	return db.DropTables(&cicdRunner20261028{}, &projectRunnerPoolMetric20261028{})
+}
+
+func (*addCicdRunners) Version() uint64 {
+	return 20261028000001
+}
+
+func (*addCicdRunners) Name() string {
+	return "add cicd_runners, project_runner_pool_metrics and runner_id to cicd_tasks"
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectCalendars)(nil)
+
+type projectCalendar20261029 struct {
+	ProjectName string `gorm:"primaryKey;type:varchar(255)"`
+	Timezone    string `gorm:"type:varchar(100)"`
+	WorkingDays string `gorm:"type:varchar(20)"`
+	WorkStart   string `gorm:"type:varchar(5)"`
+	WorkEnd     string `gorm:"type:varchar(5)"`
+	archived.NoPKModel
+}
+
+func (projectCalendar20261029) TableName() string {
+	return "project_calendars"
+}
+
+type projectHoliday20261029 struct {
+	ProjectName string `gorm:"primaryKey;type:varchar(255)"`
+	Date        string `gorm:"primaryKey;type:varchar(10)"`
+	Name        string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (projectHoliday20261029) TableName() string {
+	return "project_holidays"
+}
+
+type projectPrMetric20261029 struct {
+	PrCodingBusinessTime *int64
+	PrPickupBusinessTime *int64
+	PrReviewBusinessTime *int64
+	PrDeployBusinessTime *int64
+	PrCycleBusinessTime  *int64
+}
+
+func (projectPrMetric20261029) TableName() string {
+	return "project_pr_metrics"
+}
+
+type issue20261029 struct {
+	LeadTimeBusinessMinutes *uint
+}
+
+func (issue20261029) TableName() string {
+	return "issues"
+}
+
+type addProjectCalendars struct{}
+
+func (*addProjectCalendars) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&projectCalendar20261029{},
+		&projectHoliday20261029{},
+		&projectPrMetric20261029{},
+		&issue20261029{},
+	)
+}
+
+func (*addProjectCalendars) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("issues", "lead_time_business_minutes"); err != nil {
+		return err
+	}
+	if err := db.DropColumns("project_pr_metrics", "pr_coding_business_time", "pr_pickup_business_time", "pr_review_business_time", "pr_deploy_business_time", "pr_cycle_business_time"); err != nil {
+		return err
+	}
+	return db.DropTables(&projectCalendar20261029{}, &projectHoliday20261029{})
+}
+
+func (*addProjectCalendars) Version() uint64 {
+	return 20261029000001
+}
+
+func (*addProjectCalendars) Name() string {
+	return "add project calendars and holidays, and business-time variants of pr and issue lead times"
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBoardFlows)(nil)
+
+type boardFlowStatus20261030 struct {
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	Date           time.Time `gorm:"primaryKey"`
+	OriginalStatus string    `gorm:"primaryKey;type:varchar(255)"`
+	Status         string    `gorm:"type:varchar(100)"`
+	IssueCount     int
+	archived.NoPKModel
+}
+
+func (boardFlowStatus20261030) TableName() string {
+	return "board_flow_statuses"
+}
+
+type boardFlowMetric20261030 struct {
+	BoardId             string    `gorm:"primaryKey;type:varchar(255)"`
+	Date                time.Time `gorm:"primaryKey"`
+	Wip                 int
+	Throughput          int
+	CycleTimeP50Minutes *int64
+	CycleTimeP85Minutes *int64
+	CycleTimeP95Minutes *int64
+	FlowEfficiency      *float64
+	AvgWipAgeMinutes    *int64
+	MaxWipAgeMinutes    *int64
+	archived.NoPKModel
+}
+
+func (boardFlowMetric20261030) TableName() string {
+	return "board_flow_metrics"
+}
+
+type addBoardFlows struct{}
+
+func (*addBoardFlows) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&boardFlowStatus20261030{},
+		&boardFlowMetric20261030{},
+	)
+}
+
+func (*addBoardFlows) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&boardFlowStatus20261030{}, &boardFlowMetric20261030{})
+}
+
+func (*addBoardFlows) Version() uint64 {
+	return 20261030000001
+}
+
+func (*addBoardFlows) Name() string {
+	return "add board_flow_statuses and board_flow_metrics"
+}
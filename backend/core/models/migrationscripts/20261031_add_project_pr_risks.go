@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addProjectPrRisks)(nil)
+
+type projectPrRisk20261031 struct {
+	archived.DomainEntity
+	ProjectName                  string `gorm:"primaryKey;type:varchar(100)"`
+	ChangedLines                 int
+	ChangedFiles                 int
+	Size                         string `gorm:"type:varchar(2)"`
+	ReviewCommentCount           int
+	SensitiveComponents          string `gorm:"type:varchar(500)"`
+	IsSensitive                  bool
+	IsSelfMergedWithoutReview    bool
+	IsMergedOutsideBusinessHours bool
+	IsLargeWithoutReview         bool
+	RiskScore                    int
+	IsRisky                      bool
+}
+
+func (projectPrRisk20261031) TableName() string {
+	return "project_pr_risks"
+}
+
+type addProjectPrRisks struct{}
+
+func (*addProjectPrRisks) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&projectPrRisk20261031{})
+}
+
+func (*addProjectPrRisks) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&projectPrRisk20261031{})
+}
+
+func (*addProjectPrRisks) Version() uint64 {
+	return 20261031000001
+}
+
+func (*addProjectPrRisks) Name() string {
+	return "add project_pr_risks table"
+}
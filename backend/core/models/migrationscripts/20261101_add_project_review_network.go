@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectReviewNetwork)(nil)
+
+type projectReviewEdge20261101 struct {
+	archived.NoPKModel
+	ProjectName           string    `gorm:"primaryKey;type:varchar(100)"`
+	Period                time.Time `gorm:"primaryKey"`
+	ReviewerId            string    `gorm:"primaryKey;type:varchar(255)"`
+	AuthorId              string    `gorm:"primaryKey;type:varchar(255)"`
+	PrCount               int
+	CommentCount          int
+	MedianResponseMinutes *float64
+}
+
+func (projectReviewEdge20261101) TableName() string {
+	return "project_review_edges"
+}
+
+type projectReviewerLoad20261101 struct {
+	archived.NoPKModel
+	ProjectName              string `gorm:"primaryKey;type:varchar(100)"`
+	ReviewerId               string `gorm:"primaryKey;type:varchar(255)"`
+	OpenReviewCount          int
+	PendingReviewCount       int
+	OldestPendingCreatedDate *time.Time
+}
+
+func (projectReviewerLoad20261101) TableName() string {
+	return "project_reviewer_loads"
+}
+
+type projectReviewConcentration20261101 struct {
+	archived.NoPKModel
+	ProjectName      string    `gorm:"primaryKey;type:varchar(100)"`
+	Period           time.Time `gorm:"primaryKey"`
+	ReviewerCount    int
+	AuthorCount      int
+	ReviewCount      int
+	TopReviewerShare float64
+	Hhi              float64
+	ReciprocalShare  float64
+}
+
+func (projectReviewConcentration20261101) TableName() string {
+	return "project_review_concentrations"
+}
+
+type addProjectReviewNetwork struct{}
+
+func (*addProjectReviewNetwork) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&projectReviewEdge20261101{},
+		&projectReviewerLoad20261101{},
+		&projectReviewConcentration20261101{},
+	)
+}
+
+func (*addProjectReviewNetwork) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&projectReviewEdge20261101{}, &projectReviewerLoad20261101{}, &projectReviewConcentration20261101{})
+}
+
+func (*addProjectReviewNetwork) Version() uint64 {
+	return 20261101000001
+}
+
+func (*addProjectReviewNetwork) Name() string {
+	return "add project review network tables"
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addProjectIssueLeadTimes)(nil)
+
+type projectIssueLeadTime20261102 struct {
+	archived.DomainEntity
+	ProjectName            string `gorm:"primaryKey;type:varchar(100)"`
+	PrCount                int
+	DeploymentCommitId     string `gorm:"type:varchar(255)"`
+	BacklogTime            *int64
+	DevelopmentTime        *int64
+	ReviewTime             *int64
+	WaitingToDeployTime    *int64
+	TimeToProduction       *int64
+	IssueCreatedDate       *time.Time
+	DevelopmentStartedDate *time.Time
+	ReviewStartedDate      *time.Time
+	LastMergedDate         *time.Time
+	DeployedDate           *time.Time
+}
+
+func (projectIssueLeadTime20261102) TableName() string {
+	return "project_issue_lead_times"
+}
+
+type addProjectIssueLeadTimes struct{}
+
+func (*addProjectIssueLeadTimes) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&projectIssueLeadTime20261102{})
+}
+
+func (*addProjectIssueLeadTimes) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&projectIssueLeadTime20261102{})
+}
+
+func (*addProjectIssueLeadTimes) Version() uint64 {
+	return 20261102000001
+}
+
+func (*addProjectIssueLeadTimes) Name() string {
+	return "add project_issue_lead_times table"
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectReleaseNotes)(nil)
+
+type projectReleaseNotesTemplate20261103 struct {
+	ProjectName string `gorm:"primaryKey;type:varchar(255)"`
+	Template    string `gorm:"type:text"`
+	archived.NoPKModel
+}
+
+func (projectReleaseNotesTemplate20261103) TableName() string {
+	return "project_release_notes_templates"
+}
+
+type projectReleaseNote20261103 struct {
+	ProjectName        string `gorm:"primaryKey;type:varchar(255)"`
+	DeploymentCommitId string `gorm:"primaryKey;type:varchar(255)"`
+	Content            string `gorm:"type:text"`
+	archived.NoPKModel
+}
+
+func (projectReleaseNote20261103) TableName() string {
+	return "project_release_notes"
+}
+
+type addProjectReleaseNotes struct{}
+
+func (*addProjectReleaseNotes) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&projectReleaseNotesTemplate20261103{},
+		&projectReleaseNote20261103{},
+	)
+}
+
+func (*addProjectReleaseNotes) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&projectReleaseNotesTemplate20261103{}, &projectReleaseNote20261103{})
+}
+
+func (*addProjectReleaseNotes) Version() uint64 {
+	return 20261103000001
+}
+
+func (*addProjectReleaseNotes) Name() string {
+	return "add project release notes tables"
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectReviewMetrics)(nil)
+
+type projectPrReviewMetric20261104 struct {
+	archived.DomainEntity
+	ProjectName               string `gorm:"primaryKey;type:varchar(100)"`
+	BaseRepoId                string `gorm:"index;type:varchar(255)"`
+	ChangedLines              int
+	ReviewCommentCount        int
+	CommentsPer100Lines       *float64 `gorm:"column:comments_per_100_lines"`
+	ReviewRounds              int
+	IsApproved                bool
+	IsApprovedWithoutComments bool
+	ReReviewMinutes           *float64
+	MergedDate                *time.Time
+}
+
+func (projectPrReviewMetric20261104) TableName() string {
+	return "project_pr_review_metrics"
+}
+
+type projectRepoReviewMetric20261104 struct {
+	archived.NoPKModel
+	ProjectName                 string    `gorm:"primaryKey;type:varchar(100)"`
+	RepoId                      string    `gorm:"primaryKey;type:varchar(255)"`
+	Period                      time.Time `gorm:"primaryKey"`
+	PrCount                     int
+	AvgCommentsPer100Lines      *float64 `gorm:"column:avg_comments_per_100_lines"`
+	AvgReviewRounds             float64
+	UnreviewedRate              float64
+	ApprovedWithoutCommentsRate float64
+	AvgReReviewMinutes          *float64
+}
+
+func (projectRepoReviewMetric20261104) TableName() string {
+	return "project_repo_review_metrics"
+}
+
+type addProjectReviewMetrics struct{}
+
+func (*addProjectReviewMetrics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&projectPrReviewMetric20261104{},
+		&projectRepoReviewMetric20261104{},
+	)
+}
+
+func (*addProjectReviewMetrics) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&projectPrReviewMetric20261104{}, &projectRepoReviewMetric20261104{})
+}
+
+func (*addProjectReviewMetrics) Version() uint64 {
+	return 20261104000001
+}
+
+func (*addProjectReviewMetrics) Name() string {
+	return "add project review metrics tables"
+}
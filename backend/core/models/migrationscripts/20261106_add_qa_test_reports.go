@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addQaTestReports)(nil)
+
+type qaTestCaseExecution20261106 struct {
+	CicdPipelineId string  `gorm:"type:varchar(255);index;comment:CI pipeline ID"`
+	CommitSha      string  `gorm:"type:varchar(40);index;comment:Tested commit SHA"`
+	DurationSec    float64 `gorm:"comment:Test duration in seconds"`
+}
+
+func (qaTestCaseExecution20261106) TableName() string {
+	return "qa_test_case_executions"
+}
+
+type qaTestCoverage20261106 struct {
+	archived.DomainEntityExtended
+	QaProjectId     string    `gorm:"type:varchar(255);index;comment:Project ID"`
+	CicdPipelineId  string    `gorm:"type:varchar(255);index;comment:CI pipeline ID"`
+	CommitSha       string    `gorm:"type:varchar(40);comment:Tested commit SHA"`
+	FileName        string    `gorm:"type:varchar(255);comment:Coverage report file"`
+	LineRate        float64   `gorm:"comment:Covered lines / valid lines"`
+	BranchRate      float64   `gorm:"comment:Covered branches / valid branches"`
+	LinesCovered    int       `gorm:"comment:Covered lines"`
+	LinesValid      int       `gorm:"comment:Valid lines"`
+	BranchesCovered int       `gorm:"comment:Covered branches"`
+	BranchesValid   int       `gorm:"comment:Valid branches"`
+	CreateTime      time.Time `gorm:"comment:Coverage report creation time"`
+}
+
+func (qaTestCoverage20261106) TableName() string {
+	return "qa_test_coverages"
+}
+
+type projectFlakyTest20261106 struct {
+	archived.NoPKModel
+	ProjectName      string `gorm:"primaryKey;type:varchar(100)"`
+	QaTestCaseId     string `gorm:"primaryKey;type:varchar(255)"`
+	QaProjectId      string `gorm:"index;type:varchar(255)"`
+	TestName         string `gorm:"type:varchar(255)"`
+	ExecutionCount   int
+	FailureCount     int
+	CommitCount      int
+	FlakyCommitCount int
+	FlakeRate        float64
+	IsFlaky          bool
+	FirstFlakyDate   *time.Time
+	LastFlakyDate    *time.Time
+}
+
+func (projectFlakyTest20261106) TableName() string {
+	return "project_flaky_tests"
+}
+
+type addQaTestReports struct{}
+
+func (*addQaTestReports) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&qaTestCaseExecution20261106{},
+		&qaTestCoverage20261106{},
+		&projectFlakyTest20261106{},
+	)
+}
+
+func (*addQaTestReports) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("qa_test_case_executions", "cicd_pipeline_id", "commit_sha", "duration_sec"); err != nil {
+		return err
+	}
+	return db.DropTables(&qaTestCoverage20261106{}, &projectFlakyTest20261106{})
+}
+
+func (*addQaTestReports) Version() uint64 {
+	return 20261106000001
+}
+
+func (*addQaTestReports) Name() string {
+	return "add ci test report columns and tables to the qa domain"
+}
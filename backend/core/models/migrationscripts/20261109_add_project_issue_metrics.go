@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectIssueMetrics)(nil)
+
+type projectIssueMetric20261109 struct {
+	archived.NoPKModel
+	ProjectName             string `gorm:"primaryKey;type:varchar(100)"`
+	IssueId                 string `gorm:"primaryKey;type:varchar(255)"`
+	LeadTimeBusinessMinutes *uint
+}
+
+func (projectIssueMetric20261109) TableName() string {
+	return "project_issue_metrics"
+}
+
+type addProjectIssueMetrics struct{}
+
+// Up moves the business lead time of issues to a table per project, it depends on the calendar of the project
+func (*addProjectIssueMetrics) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(basicRes, &projectIssueMetric20261109{})
+	if err != nil {
+		return err
+	}
+	// the values are recalculated by the next run of issue_trace
+	return basicRes.GetDal().DropColumns("issues", "lead_time_business_minutes")
+}
+
+func (*addProjectIssueMetrics) Down(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(basicRes, &issue20261029{})
+	if err != nil {
+		return err
+	}
+	return basicRes.GetDal().DropTables(&projectIssueMetric20261109{})
+}
+
+func (*addProjectIssueMetrics) Version() uint64 {
+	return 20261109000001
+}
+
+func (*addProjectIssueMetrics) Name() string {
+	return "add project_issue_metrics for the business lead time of issues"
+}
@@ -139,5 +139,19 @@ func All() []plugin.MigrationScript {
 		new(increaseCqIssueComponentLength),
 		new(extendFieldSizeForCq),
 		new(addIssueFixVerion),
+		new(addExportWatermarks),
+		new(addPullRequestCodeOwners),
+		new(addIncidentNormalizationFields),
+		new(addCicdTaskParent),
+		new(addCicdRunners),
+		new(addProjectCalendars),
+		new(addBoardFlows),
+		new(addProjectPrRisks),
+		new(addProjectReviewNetwork),
+		new(addProjectIssueLeadTimes),
+		new(addProjectReleaseNotes),
+		new(addProjectReviewMetrics),
+		new(addQaTestReports),
+		new(addProjectIssueMetrics),
 	}
 }
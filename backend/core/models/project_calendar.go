@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// BaseProjectCalendar is the working calendar of a project, the business-time variants of
+// the lead time metrics only count the working hours of working days which are not holidays.
+type BaseProjectCalendar struct {
+	ProjectName string `json:"projectName" mapstructure:"projectName" gorm:"primaryKey;type:varchar(255)"`
+	// Timezone is an IANA time zone name, e.g. Europe/Berlin
+	Timezone string `json:"timezone" mapstructure:"timezone" gorm:"type:varchar(100)"`
+	// WorkingDays is a comma separated list of ISO weekdays, from 1 for Monday to 7 for Sunday
+	WorkingDays string `json:"workingDays" mapstructure:"workingDays" gorm:"type:varchar(20)"`
+	// WorkStart and WorkEnd are the local working hours in HH:MM
+	WorkStart string `json:"workStart" mapstructure:"workStart" gorm:"type:varchar(5)"`
+	WorkEnd   string `json:"workEnd" mapstructure:"workEnd" gorm:"type:varchar(5)"`
+}
+
+type ProjectCalendar struct {
+	BaseProjectCalendar `mapstructure:",squash"`
+	common.NoPKModel
+}
+
+func (ProjectCalendar) TableName() string {
+	return "project_calendars"
+}
+
+// ProjectHoliday is a non-working day of a project, Date is a local date in YYYY-MM-DD
+type ProjectHoliday struct {
+	ProjectName string `json:"projectName" mapstructure:"projectName" gorm:"primaryKey;type:varchar(255)"`
+	Date        string `json:"date" mapstructure:"date" gorm:"primaryKey;type:varchar(10)"`
+	Name        string `json:"name" mapstructure:"name" gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (ProjectHoliday) TableName() string {
+	return "project_holidays"
+}
+
+type ApiProjectCalendar struct {
+	BaseProjectCalendar `mapstructure:",squash"`
+	Holidays            []*ProjectHoliday `json:"holidays" mapstructure:"holidays"`
+}
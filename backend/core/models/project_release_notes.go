@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectReleaseNotesTemplate is the Go text/template rendering the release notes of the deployments of a project
+type ProjectReleaseNotesTemplate struct {
+	ProjectName string `json:"projectName" mapstructure:"projectName" gorm:"primaryKey;type:varchar(255)"`
+	Template    string `json:"template" mapstructure:"template" gorm:"type:text"`
+	common.NoPKModel
+}
+
+func (ProjectReleaseNotesTemplate) TableName() string {
+	return "project_release_notes_templates"
+}
+
+// ProjectReleaseNote is the rendered release notes of a deployment commit, generated after the deployment was collected
+type ProjectReleaseNote struct {
+	ProjectName        string `json:"projectName" gorm:"primaryKey;type:varchar(255)"`
+	DeploymentCommitId string `json:"deploymentCommitId" gorm:"primaryKey;type:varchar(255)"`
+	Content            string `json:"content" gorm:"type:text"`
+	common.NoPKModel
+}
+
+func (ProjectReleaseNote) TableName() string {
+	return "project_release_notes"
+}
@@ -29,11 +29,38 @@ type MigrationScript interface {
 	Name() string
 }
 
+// ReversibleMigrationScript is implemented by the MigrationScript which is able to revert the changes made by Up
+type ReversibleMigrationScript interface {
+	MigrationScript
+	Down(basicRes context.BasicRes) errors.Error
+}
+
+// MigrationScriptInfo describes a registered migration script
+type MigrationScriptInfo struct {
+	Name       string `json:"name"`
+	Version    uint64 `json:"version"`
+	Comment    string `json:"comment"`
+	Reversible bool   `json:"reversible"`
+}
+
+// MigrationScriptPlan holds the statements a pending migration script would execute
+type MigrationScriptPlan struct {
+	MigrationScriptInfo
+	Statements []string `json:"statements"`
+	Error      string   `json:"error,omitempty"`
+}
+
 // Migrator is responsible for making sure the registered scripts get applied to database and only once
 type Migrator interface {
 	Register(scripts []MigrationScript, comment string)
 	Execute() errors.Error
 	HasPendingScripts() bool
+	// PendingScripts returns the scripts to be executed in order
+	PendingScripts() []MigrationScriptInfo
+	// DryRun collects the statements of the pending scripts without changing the database
+	DryRun() []MigrationScriptPlan
+	// Rollback reverts all executed scripts whose version is greater than or equal to the specified one
+	Rollback(version uint64) errors.Error
 }
 
 // PluginMigration is implemented by the plugin to declare all migration script that have to be applied to the database
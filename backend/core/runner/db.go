@@ -121,6 +121,13 @@ func sanitizeQuery(query url.Values) string {
 }
 
 func MakeDbConnection(dbUrl string, conf *gorm.Config) (*gorm.DB, error) {
+	if IsEmbeddedDbUrl(dbUrl) {
+		dialector, err := newSqliteDialector(dbUrl)
+		if err != nil {
+			return nil, err
+		}
+		return gorm.Open(dialector, conf)
+	}
 	u, err := url.Parse(dbUrl)
 	if err != nil {
 		return nil, err
@@ -154,6 +161,8 @@ func MakeDbConnection(dbUrl string, conf *gorm.Config) (*gorm.DB, error) {
 		return gorm.Open(mysql.Open(dbUrl), conf)
 	case "postgresql", "postgres", "pg":
 		return gorm.Open(postgres.Open(dbUrl), conf)
+	case "duckdb":
+		return nil, fmt.Errorf("duckdb is not supported yet, please use sqlite:// for the embedded mode")
 	default:
 		return nil, fmt.Errorf("invalid DB_URL:%s", dbUrl)
 	}
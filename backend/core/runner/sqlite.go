@@ -0,0 +1,279 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormMigrator "gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// IsEmbeddedDbUrl returns true if the DB_URL points to an embedded database which requires no server
+func IsEmbeddedDbUrl(dbUrl string) bool {
+	scheme := strings.ToLower(strings.SplitN(dbUrl, "://", 2)[0])
+	return scheme == "sqlite" || scheme == "sqlite3"
+}
+
+// sqliteDsn converts `sqlite://path/to/file.db?_pragma=...` into the DSN accepted by the sqlite driver,
+// `sqlite://:memory:` and `sqlite://` are treated as an in-memory database shared by all connections
+func sqliteDsn(dbUrl string) string {
+	dsn := dbUrl[strings.Index(dbUrl, "://")+3:]
+	if dsn == "" || strings.HasPrefix(dsn, "?") || strings.HasPrefix(dsn, ":memory:") {
+		dsn = "file:devlake?mode=memory&cache=shared" + strings.Replace(strings.TrimPrefix(dsn, ":memory:"), "?", "&", 1)
+	}
+	pragmas := []string{"busy_timeout(10000)", "journal_mode(WAL)"}
+	for _, pragma := range pragmas {
+		name := pragma[:strings.Index(pragma, "(")]
+		if strings.Contains(dsn, "_pragma="+name) {
+			continue
+		}
+		if strings.Contains(dsn, "?") {
+			dsn += "&_pragma=" + pragma
+		} else {
+			dsn += "?_pragma=" + pragma
+		}
+	}
+	return dsn
+}
+
+// sqliteDialector works around the fact that index names are unique per database in sqlite
+// rather than per table as in mysql and postgres, so the same index name may be declared on
+// multiple tables (i.e. `uniqueIndex:idx_name_github`) and survive table renaming without conflicts
+type sqliteDialector struct {
+	sqlite.Dialector
+}
+
+var registerSqliteFunctions sync.Once
+
+// sqliteTimeFormat is the format in which the sqlite driver stores time values
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+func newSqliteDialector(dbUrl string) (gorm.Dialector, error) {
+	registerSqliteFunctions.Do(func() {
+		// mysql/postgres functions used by handwritten queries, the driver would return time.Time as unix seconds,
+		// so it is formatted the same way the driver stores time values to keep them comparable
+		gosqlite.MustRegisterScalarFunction("now", 0, func(_ *gosqlite.FunctionContext, _ []driver.Value) (driver.Value, error) {
+			return time.Now().UTC().Format(sqliteTimeFormat), nil
+		})
+	})
+	db, err := sql.Open(sqlite.DriverName, sqliteDsn(dbUrl))
+	if err != nil {
+		return nil, err
+	}
+	return sqliteDialector{sqlite.Dialector{Conn: &sqliteConnPool{db}}}, nil
+}
+
+func (dialector sqliteDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return sqliteMigrator{sqlite.Migrator{Migrator: gormMigrator.Migrator{Config: gormMigrator.Config{
+		DB:                          db,
+		Dialector:                   dialector,
+		CreateIndexAfterCreateTable: true,
+	}}}}
+}
+
+// DataTypeOf declares the autoincrement column as the primary key only when it is the sole primary key,
+// otherwise composite primary keys like (`connection_id`, `id`) would be lost
+func (dialector sqliteDialector) DataTypeOf(field *schema.Field) string {
+	if field.AutoIncrement && (!field.PrimaryKey || len(field.Schema.PrimaryFields) > 1) {
+		return "integer"
+	}
+	return dialector.Dialector.DataTypeOf(field)
+}
+
+type sqliteMigrator struct {
+	sqlite.Migrator
+}
+
+// sqliteIndexName returns the physical name of the index which is always prefixed with the table name
+func sqliteIndexName(table, name string) string {
+	return fmt.Sprintf("%s__%s", table, name)
+}
+
+func (m sqliteMigrator) CreateIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(name); idx != nil {
+				opts := m.BuildIndexOptions(idx.Fields, stmt)
+				values := []interface{}{
+					clause.Column{Name: sqliteIndexName(stmt.Table, idx.Name)},
+					clause.Table{Name: stmt.Table},
+					opts,
+				}
+				createIndexSQL := "CREATE "
+				if idx.Class != "" {
+					createIndexSQL += idx.Class + " "
+				}
+				createIndexSQL += "INDEX ? ON ??"
+				if idx.Where != "" {
+					createIndexSQL += " WHERE " + idx.Where
+				}
+				return m.DB.Exec(createIndexSQL, values...).Error
+			}
+		}
+		return fmt.Errorf("failed to create index with name %v", name)
+	})
+}
+
+func (m sqliteMigrator) HasIndex(value interface{}, name string) bool {
+	var count int
+	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(name); idx != nil {
+				name = idx.Name
+			}
+		}
+		if name != "" {
+			return m.DB.Raw(
+				"SELECT count(*) FROM sqlite_master WHERE type = ? AND tbl_name = ? AND name = ?",
+				"index", stmt.Table, sqliteIndexName(stmt.Table, name),
+			).Row().Scan(&count)
+		}
+		return nil
+	})
+	return count > 0
+}
+
+func (m sqliteMigrator) RenameIndex(value interface{}, oldName, newName string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.Migrator.RenameIndex(value, sqliteIndexName(stmt.Table, oldName), sqliteIndexName(stmt.Table, newName))
+	})
+}
+
+func (m sqliteMigrator) DropIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(name); idx != nil {
+				name = idx.Name
+			}
+		}
+		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: sqliteIndexName(stmt.Table, name)}).Error
+	})
+}
+
+func (m sqliteMigrator) RenameTable(oldName, newName interface{}) error {
+	oldTable, err := m.tableName(oldName)
+	if err != nil {
+		return err
+	}
+	newTable, err := m.tableName(newName)
+	if err != nil {
+		return err
+	}
+	var indexNames []string
+	err = m.DB.Raw("SELECT name FROM pragma_index_list(?) WHERE origin = 'c'", oldTable).Scan(&indexNames).Error
+	if err != nil {
+		return err
+	}
+	err = m.Migrator.RenameTable(oldName, newName)
+	if err != nil {
+		return err
+	}
+	// the table referenced by the index definitions are updated by sqlite, only the names have to be changed
+	for _, indexName := range indexNames {
+		newIndexName := sqliteIndexName(newTable, strings.TrimPrefix(indexName, oldTable+"__"))
+		err = m.Migrator.RenameIndex(newTable, indexName, newIndexName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m sqliteMigrator) tableName(value interface{}) (string, error) {
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	stmt := &gorm.Statement{DB: m.DB}
+	if err := stmt.Parse(value); err != nil {
+		return "", err
+	}
+	return stmt.Table, nil
+}
+
+// sqliteReservedColumnPattern matches qualified references to columns named after keywords,
+// i.e. `pm.table`, which are accepted by mysql and postgres but rejected by sqlite
+var sqliteReservedColumnPattern = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)\.(table)\b`)
+
+func rewriteSqliteQuery(query string) string {
+	return sqliteReservedColumnPattern.ReplaceAllString(query, `$1."$2"`)
+}
+
+// sqliteConnPool rewrites the handwritten queries scattered across plugins before handing them over to sqlite
+type sqliteConnPool struct {
+	db *sql.DB
+}
+
+func (p *sqliteConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, rewriteSqliteQuery(query))
+}
+
+func (p *sqliteConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.db.ExecContext(ctx, rewriteSqliteQuery(query), args...)
+}
+
+func (p *sqliteConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, rewriteSqliteQuery(query), args...)
+}
+
+func (p *sqliteConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.db.QueryRowContext(ctx, rewriteSqliteQuery(query), args...)
+}
+
+func (p *sqliteConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteTx{tx}, nil
+}
+
+func (p *sqliteConnPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+type sqliteTx struct {
+	*sql.Tx
+}
+
+func (t *sqliteTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.Tx.PrepareContext(ctx, rewriteSqliteQuery(query))
+}
+
+func (t *sqliteTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.Tx.ExecContext(ctx, rewriteSqliteQuery(query), args...)
+}
+
+func (t *sqliteTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.Tx.QueryContext(ctx, rewriteSqliteQuery(query), args...)
+}
+
+func (t *sqliteTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.Tx.QueryRowContext(ctx, rewriteSqliteQuery(query), args...)
+}
@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/migration"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_sqliteDsn(t *testing.T) {
+	tests := []struct {
+		dbUrl string
+		want  string
+	}{
+		{"sqlite:///tmp/devlake.db", "/tmp/devlake.db?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"},
+		{"sqlite://devlake.db", "devlake.db?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"},
+		{"sqlite://devlake.db?_pragma=busy_timeout(500)", "devlake.db?_pragma=busy_timeout(500)&_pragma=journal_mode(WAL)"},
+		{"sqlite://", "file:devlake?mode=memory&cache=shared&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"},
+		{"sqlite://:memory:", "file:devlake?mode=memory&cache=shared&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dbUrl, func(t *testing.T) {
+			assert.Equal(t, tt.want, sqliteDsn(tt.dbUrl))
+		})
+	}
+}
+
+func Test_rewriteSqliteQuery(t *testing.T) {
+	assert.Equal(
+		t,
+		`LEFT JOIN project_mapping pm ON (pm."table" = 'repos' AND pm.row_id = i.scope_id AND pm."table" = i."table")`,
+		rewriteSqliteQuery(`LEFT JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = i.scope_id AND pm.table = i.table)`),
+	)
+	assert.Equal(t, "SELECT * FROM `project_mapping` WHERE `table` = ?", rewriteSqliteQuery("SELECT * FROM `project_mapping` WHERE `table` = ?"))
+}
+
+func newSqliteTestDb(t *testing.T) *gorm.DB {
+	cfg := config.GetConfig()
+	cfg.Set("DB_URL", "sqlite://"+filepath.Join(t.TempDir(), "devlake.db"))
+	db, err := NewGormDb(cfg, logruslog.Global)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+type sqliteIndexTest struct {
+	Id   uint64 `gorm:"primaryKey"`
+	Name string `gorm:"index:idx_name"`
+}
+
+func (sqliteIndexTest) TableName() string {
+	return "embedded_index_tests"
+}
+
+type sqliteCompositeKeyTest struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey"`
+}
+
+func (sqliteCompositeKeyTest) TableName() string {
+	return "embedded_composite_key_tests"
+}
+
+func TestSqlite_IndexNamesPerTable(t *testing.T) {
+	d := dalgorm.NewDalgorm(newSqliteTestDb(t))
+	// the same index name declared by another table must not conflict
+	assert.Nil(t, d.AutoMigrate(&sqliteIndexTest{}))
+	assert.Nil(t, d.RenameTable("embedded_index_tests", "embedded_index_tests_old"))
+	assert.Nil(t, d.AutoMigrate(&sqliteIndexTest{}))
+	assert.True(t, d.HasTable("embedded_index_tests_old"))
+	// index can be dropped by its logical name just like mysql
+	assert.Nil(t, d.DropIndexes("embedded_index_tests_old", "idx_name"))
+	assert.Nil(t, d.DropColumns("embedded_index_tests", "name"))
+	assert.False(t, d.HasColumn("embedded_index_tests", "name"))
+}
+
+func TestSqlite_CompositePrimaryKey(t *testing.T) {
+	d := dalgorm.NewDalgorm(newSqliteTestDb(t))
+	assert.Nil(t, d.AutoMigrate(&sqliteCompositeKeyTest{}))
+	assert.Nil(t, d.CreateOrUpdate(&sqliteCompositeKeyTest{ConnectionId: 1, Id: "a"}))
+	assert.Nil(t, d.CreateOrUpdate(&sqliteCompositeKeyTest{ConnectionId: 1, Id: "b"}))
+	assert.Nil(t, d.CreateOrUpdate(&sqliteCompositeKeyTest{ConnectionId: 1, Id: "a"}))
+	count, err := d.Count(dal.From(&sqliteCompositeKeyTest{}))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestSqlite_CoreMigrationScripts(t *testing.T) {
+	db := newSqliteTestDb(t)
+	cfg := config.GetConfig()
+	cfg.Set(plugin.EncodeKeyEnvStr, "SQLITEENCRYPTIONSECRETFORTESTINGSQLITEENCRYPTIONSECRETFORTESTING")
+	dalgorm.Init(cfg.GetString(plugin.EncodeKeyEnvStr))
+	basicRes := CreateBasicRes(cfg, logruslog.Global, db)
+	migrator, err := migration.NewMigrator(basicRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrator.Register(migrationscripts.All(), "Framework")
+	assert.Nil(t, migrator.Execute())
+}
@@ -25,6 +25,7 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -42,7 +43,7 @@ type ProcessResponse struct {
 type ProcessStream struct {
 	receiveChannel <-chan *ProcessResponse
 	process        *os.Process
-	cancelled      bool
+	cancelled      atomic.Bool
 }
 
 // StreamProcessOptions options for streaming a process
@@ -83,14 +84,25 @@ func (p *ProcessStream) Receive() <-chan *ProcessResponse {
 
 // Cancel cancels the stream by sending a termination signal to the target.
 func (p *ProcessStream) Cancel() errors.Error {
+	p.cancelled.Store(true)
 	err := errors.Convert(p.process.Signal(syscall.SIGTERM))
 	if err != nil {
 		return err
 	}
-	p.cancelled = true
 	return nil
 }
 
+// Kill cancels the stream by killing the target, for the target doesn't respond to Cancel.
+func (p *ProcessStream) Kill() errors.Error {
+	p.cancelled.Store(true)
+	return errors.Convert(p.process.Kill())
+}
+
+// Cancelled returns true if the stream was cancelled or killed
+func (p *ProcessStream) Cancelled() bool {
+	return p.cancelled.Load()
+}
+
 func (resp *ProcessResponse) GetStdout() []byte {
 	return resp.stdout
 }
@@ -196,7 +208,7 @@ func StreamProcess(cmd *exec.Cmd, opts *StreamProcessOptions) (*ProcessStream, e
 	go func() {
 		defer pipes.close()
 		if err = errors.Convert(cmd.Wait()); err != nil {
-			if !processStream.cancelled {
+			if !processStream.Cancelled() {
 				receiveStream <- &ProcessResponse{err: errors.Default.Wrap(err, fmt.Sprintf("remote error response:\n%s", remoteErrorMsg))}
 			}
 		}
@@ -20,6 +20,7 @@ package e2ehelper
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
@@ -142,6 +143,10 @@ func (t *DataFlowTester) ImportCsvIntoRawTable(csvRelPath string, rawTableName s
 	for csvIter.HasNext() {
 		toInsertValues := csvIter.Fetch()
 		toInsertValues[`data`] = []byte(toInsertValues[`data`].(string))
+		// sqlite returns TEXT as string which can not be scanned into json.RawMessage
+		if input, ok := toInsertValues[`input`].(string); ok && t.Dal.Dialect() == `sqlite` {
+			toInsertValues[`input`] = []byte(input)
+		}
 		result := t.Db.Table(rawTableName).Create(toInsertValues)
 		if result.Error != nil {
 			panic(result.Error)
@@ -157,6 +162,7 @@ func (t *DataFlowTester) importCsv(csvRelPath string, dst schema.Tabler, nullabl
 	}
 	defer csvIter.Close()
 	t.FlushTabler(dst)
+	timeColumns := t.resolveSqliteTimeColumns(dst)
 	// load rows and insert into target table
 	for csvIter.HasNext() {
 		toInsertValues := csvIter.Fetch()
@@ -170,6 +176,9 @@ func (t *DataFlowTester) importCsv(csvRelPath string, dst schema.Tabler, nullabl
 					toInsertValues[i] = nil
 				}
 			}
+			if timeColumns[i] && toInsertValues[i] != nil {
+				toInsertValues[i] = parseCsvTime(toInsertValues[i].(string))
+			}
 		}
 		result := t.Db.Model(dst).Create(toInsertValues)
 		if result.Error != nil {
@@ -179,6 +188,42 @@ func (t *DataFlowTester) importCsv(csvRelPath string, dst schema.Tabler, nullabl
 	}
 }
 
+// resolveSqliteTimeColumns returns the time columns of the tabler when running on sqlite, which unlike mysql
+// does not accept loosely formatted datetime like `2022-1-11 4:51:47`
+func (t *DataFlowTester) resolveSqliteTimeColumns(dst schema.Tabler) map[string]bool {
+	timeColumns := make(map[string]bool)
+	if t.Dal.Dialect() != `sqlite` {
+		return timeColumns
+	}
+	stmt := &gorm.Statement{DB: t.Db}
+	if err := stmt.Parse(dst); err != nil {
+		panic(err)
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.GORMDataType == schema.Time {
+			timeColumns[field.DBName] = true
+		}
+	}
+	return timeColumns
+}
+
+var csvTimeLayouts = []string{
+	`2006-1-2T15:4:5.999999999Z07:00`,
+	`2006-1-2 15:4:5.999999999Z07:00`,
+	`2006-1-2T15:4:5.999999999`,
+	`2006-1-2 15:4:5.999999999`,
+	`2006-1-2`,
+}
+
+func parseCsvTime(value string) interface{} {
+	for _, layout := range csvTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
 // ImportCsvIntoTabler imports records from specified csv file into target tabler, the empty string will be taken as NULL. note that existing data would be deleted first.
 func (t *DataFlowTester) ImportCsvIntoTabler(csvRelPath string, dst schema.Tabler) {
 	t.importCsv(csvRelPath, dst, false)
@@ -341,13 +386,18 @@ func (t *DataFlowTester) CreateSnapshot(dst schema.Tabler, opts TableOptions) {
 	columnTypes, _ := dbCursor.ColumnTypes()
 	forScanValues := make([]interface{}, len(allFields))
 	for i, columnType := range columnTypes {
-		if columnType.ScanType().Name() == `Time` || columnType.ScanType().Name() == `NullTime` {
+		// sqlite derives the scan type from the value in the first row, which is nil for NULL
+		scanTypeName := ``
+		if columnType.ScanType() != nil {
+			scanTypeName = columnType.ScanType().Name()
+		}
+		if scanTypeName == `Time` || scanTypeName == `NullTime` {
 			forScanValues[i] = new(sql.NullTime)
-		} else if columnType.ScanType().Name() == `bool` {
+		} else if scanTypeName == `bool` {
 			forScanValues[i] = new(bool)
-		} else if columnType.ScanType().Name() == `RawBytes` {
+		} else if scanTypeName == `RawBytes` {
 			forScanValues[i] = new(sql.NullString)
-		} else if columnType.ScanType().Name() == `NullInt64` {
+		} else if scanTypeName == `NullInt64` {
 			forScanValues[i] = new(sql.NullInt64)
 		} else {
 			forScanValues[i] = new(sql.NullString)
@@ -578,6 +628,10 @@ func (t *DataFlowTester) VerifyTableWithOptions(dst schema.Tabler, opts TableOpt
 		for _, field := range targetFields {
 			expectation := formatDbValue(expected[field], opts.Nullable)
 			reality := formatDbValue(actual[field], opts.Nullable)
+			if expectation != reality && t.Dal.Dialect() == `sqlite` {
+				// sqlite keeps json verbatim while mysql normalizes it, which the snapshots were generated on
+				expectation, reality = normalizeJson(expectation), normalizeJson(reality)
+			}
 			if !assert.Equal(t.T, expectation, reality, fmt.Sprintf(`%s.%s not match (with params from csv %s)`, dst.TableName(), field, pkValues)) {
 				_ = t.T // useful for debugging
 			}
@@ -587,6 +641,23 @@ func (t *DataFlowTester) VerifyTableWithOptions(dst schema.Tabler, opts TableOpt
 	assert.Equal(t.T, expectedRowTotal, actualRowTotal, fmt.Sprintf(`records in %s count not match expectation count,[expected(CSV):%d][actual(DB):%d]`, dst.TableName(), expectedRowTotal, actualRowTotal))
 }
 
+// normalizeJson returns the json object or array with the keys sorted and whitespaces removed, other values are
+// returned as is
+func normalizeJson(value string) string {
+	if !strings.HasPrefix(value, `{`) && !strings.HasPrefix(value, `[`) {
+		return value
+	}
+	var parsed interface{}
+	if json.Unmarshal([]byte(value), &parsed) != nil {
+		return value
+	}
+	normalized, err := json.Marshal(parsed)
+	if err != nil {
+		return value
+	}
+	return string(normalized)
+}
+
 // DuplicateRecords duplicates all records in the specified table and change the specified fields to the specified values
 func DuplicateRecords[T dal.Tabler](t *DataFlowTester, fields map[string]interface{}) {
 	all := make([]*T, 0)
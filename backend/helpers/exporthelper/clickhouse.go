@@ -0,0 +1,304 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+var _ Exporter = (*ClickHouseExporter)(nil)
+
+// ClickHouseConfig holds the options to connect the HTTP interface of ClickHouse
+type ClickHouseConfig struct {
+	// Endpoint of the HTTP interface, i.e. http://localhost:8123
+	Endpoint string `mapstructure:"endpoint" json:"endpoint"`
+	User     string `mapstructure:"user" json:"user"`
+	Password string `mapstructure:"password" json:"password"`
+	Database string `mapstructure:"database" json:"database"`
+}
+
+// ClickHouseExporter writes tables to ClickHouse over HTTP. Tables are created with the ReplacingMergeTree engine
+// ordered by the primary keys, so upserted rows replace the previous versions once parts are merged, queries
+// should use FINAL for the latest version of rows.
+type ClickHouseExporter struct {
+	config *ClickHouseConfig
+	client *http.Client
+}
+
+// NewClickHouseExporter creates a ClickHouseExporter
+func NewClickHouseExporter(config *ClickHouseConfig) (*ClickHouseExporter, errors.Error) {
+	if config.Endpoint == "" {
+		return nil, errors.BadInput.New("endpoint of clickhouse is required")
+	}
+	if config.Database == "" {
+		config.Database = "default"
+	}
+	return &ClickHouseExporter{config: config, client: &http.Client{Timeout: 10 * time.Minute}}, nil
+}
+
+func (ch *ClickHouseExporter) Name() string {
+	endpoint := ch.config.Endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		endpoint = u.Host
+	}
+	return fmt.Sprintf("clickhouse:%s/%s", endpoint, ch.config.Database)
+}
+
+// query sends the query to ClickHouse, the body would be appended to the query as data
+func (ch *ClickHouseExporter) query(query string, body io.Reader) ([]byte, errors.Error) {
+	params := url.Values{}
+	params.Set("database", ch.config.Database)
+	params.Set("query", query)
+	params.Set("date_time_input_format", "best_effort")
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(ch.config.Endpoint, "/")+"/?"+params.Encode(), body)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	if ch.config.User != "" {
+		req.Header.Set("X-ClickHouse-User", ch.config.User)
+		req.Header.Set("X-ClickHouse-Key", ch.config.Password)
+	}
+	res, err := ch.client.Do(req)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to request clickhouse")
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("clickhouse responded %s for query [%s]", strings.TrimSpace(string(b)), query))
+	}
+	return b, nil
+}
+
+func (ch *ClickHouseExporter) columnType(c *Column) string {
+	var t string
+	switch c.Kind {
+	case KindInt:
+		t = "Int64"
+	case KindFloat:
+		t = "Float64"
+	case KindBool:
+		t = "Bool"
+	case KindTime:
+		t = "DateTime64(3, 'UTC')"
+	case KindArray:
+		// arrays can not be nullable
+		return "Array(String)"
+	default:
+		t = "String"
+	}
+	if c.PrimaryKey {
+		return t
+	}
+	return fmt.Sprintf("Nullable(%s)", t)
+}
+
+func quoteClickHouseIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "\\`") + "`"
+}
+
+func (ch *ClickHouseExporter) createTable(schema *TableSchema) errors.Error {
+	columns := make([]string, len(schema.Columns))
+	for i, c := range schema.Columns {
+		columns[i] = fmt.Sprintf("%s %s", quoteClickHouseIdentifier(c.Name), ch.columnType(c))
+	}
+	engine := "MergeTree ORDER BY tuple()"
+	if pks := schema.PrimaryKeys(); len(pks) > 0 {
+		names := make([]string, len(pks))
+		for i, pk := range pks {
+			names[i] = quoteClickHouseIdentifier(pk.Name)
+		}
+		engine = fmt.Sprintf("ReplacingMergeTree ORDER BY (%s)", strings.Join(names, ", "))
+	}
+	_, err := ch.query(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (%s) ENGINE = %s",
+		quoteClickHouseIdentifier(schema.Name),
+		strings.Join(columns, ", "),
+		engine,
+	), nil)
+	return err
+}
+
+func (ch *ClickHouseExporter) ResetTable(schema *TableSchema) errors.Error {
+	_, err := ch.query(fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteClickHouseIdentifier(schema.Name)), nil)
+	if err != nil {
+		return err
+	}
+	return ch.createTable(schema)
+}
+
+func (ch *ClickHouseExporter) SyncSchema(schema *TableSchema) errors.Error {
+	err := ch.createTable(schema)
+	if err != nil {
+		return err
+	}
+	alters := make([]string, 0, len(schema.Columns))
+	for _, c := range schema.Columns {
+		if c.PrimaryKey {
+			continue
+		}
+		alters = append(alters, fmt.Sprintf("ADD COLUMN IF NOT EXISTS %s %s", quoteClickHouseIdentifier(c.Name), ch.columnType(c)))
+	}
+	if len(alters) == 0 {
+		return nil
+	}
+	_, err = ch.query(fmt.Sprintf("ALTER TABLE %s %s", quoteClickHouseIdentifier(schema.Name), strings.Join(alters, ", ")), nil)
+	return err
+}
+
+func (ch *ClickHouseExporter) jsonValue(c *Column, v interface{}) interface{} {
+	v = NormalizeValue(c.Kind, v)
+	if t, ok := v.(time.Time); ok {
+		return t.UTC().Format("2006-01-02 15:04:05.000")
+	}
+	if v == nil && c.Kind == KindArray {
+		return []string{}
+	}
+	return v
+}
+
+func (ch *ClickHouseExporter) Upsert(schema *TableSchema, rows []Row) errors.Error {
+	if len(rows) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, row := range rows {
+		record := make(map[string]interface{}, len(schema.Columns))
+		for _, c := range schema.Columns {
+			record[c.Name] = ch.jsonValue(c, row[c.Name])
+		}
+		err := encoder.Encode(record)
+		if err != nil {
+			return errors.Convert(err)
+		}
+	}
+	_, err := ch.query(fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", quoteClickHouseIdentifier(schema.Name)), &body)
+	return err
+}
+
+// ScanKeys pages through the keys ordered by the primary keys
+func (ch *ClickHouseExporter) ScanKeys(schema *TableSchema, batchSize int, handle func(keys []Row) errors.Error) errors.Error {
+	pks := schema.PrimaryKeys()
+	if len(pks) == 0 {
+		return nil
+	}
+	names := make([]string, len(pks))
+	for i, pk := range pks {
+		names[i] = quoteClickHouseIdentifier(pk.Name)
+	}
+	for offset := 0; ; offset += batchSize {
+		b, err := ch.query(fmt.Sprintf(
+			"SELECT %s FROM %s FINAL ORDER BY %s LIMIT %d OFFSET %d FORMAT JSONEachRow",
+			strings.Join(names, ", "),
+			quoteClickHouseIdentifier(schema.Name),
+			strings.Join(names, ", "),
+			batchSize,
+			offset,
+		), nil)
+		if err != nil {
+			return err
+		}
+		var keys []Row
+		scanner := bufio.NewScanner(bytes.NewReader(b))
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			decoder := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+			decoder.UseNumber()
+			key := Row{}
+			if e := decoder.Decode(&key); e != nil {
+				return errors.Convert(e)
+			}
+			keys = append(keys, key)
+		}
+		if e := scanner.Err(); e != nil {
+			return errors.Convert(e)
+		}
+		if len(keys) > 0 {
+			err = handle(keys)
+			if err != nil {
+				return err
+			}
+		}
+		if len(keys) < batchSize {
+			return nil
+		}
+	}
+}
+
+func clickHouseLiteral(c *Column, v interface{}) string {
+	switch value := NormalizeValue(c.Kind, v).(type) {
+	case nil:
+		return "NULL"
+	case int64, float64, bool:
+		return fmt.Sprintf("%v", value)
+	case time.Time:
+		return fmt.Sprintf("toDateTime64('%s', 3, 'UTC')", value.UTC().Format("2006-01-02 15:04:05.000"))
+	case string:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+	default:
+		return "'" + fmt.Sprintf("%v", value) + "'"
+	}
+}
+
+func (ch *ClickHouseExporter) Delete(schema *TableSchema, keys []Row) errors.Error {
+	pks := schema.PrimaryKeys()
+	if len(keys) == 0 || len(pks) == 0 {
+		return nil
+	}
+	names := make([]string, len(pks))
+	for i, pk := range pks {
+		names[i] = quoteClickHouseIdentifier(pk.Name)
+	}
+	tuples := make([]string, len(keys))
+	for i, key := range keys {
+		values := make([]string, len(pks))
+		for j, pk := range pks {
+			values[j] = clickHouseLiteral(pk, key[pk.Name])
+		}
+		tuples[i] = "(" + strings.Join(values, ", ") + ")"
+	}
+	_, err := ch.query(fmt.Sprintf(
+		"ALTER TABLE %s DELETE WHERE (%s) IN (%s)",
+		quoteClickHouseIdentifier(schema.Name),
+		strings.Join(names, ", "),
+		strings.Join(tuples, ", "),
+	), nil)
+	return err
+}
+
+func (ch *ClickHouseExporter) Close() errors.Error {
+	ch.client.CloseIdleConnections()
+	return nil
+}
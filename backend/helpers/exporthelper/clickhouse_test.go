@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClickHouseExporter(t *testing.T) {
+	var queries, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "lake", r.URL.Query().Get("database"))
+		assert.Equal(t, "admin", r.Header.Get("X-ClickHouse-User"))
+		query := r.URL.Query().Get("query")
+		body, _ := io.ReadAll(r.Body)
+		queries = append(queries, query)
+		bodies = append(bodies, string(body))
+		if query == "SELECT `id` FROM `issues` FINAL ORDER BY `id` LIMIT 10 OFFSET 0 FORMAT JSONEachRow" {
+			_, _ = w.Write([]byte("{\"id\":\"1\"}\n{\"id\":\"2\"}\n"))
+		}
+	}))
+	defer server.Close()
+
+	exporter, err := NewClickHouseExporter(&ClickHouseConfig{Endpoint: server.URL, User: "admin", Database: "lake"})
+	assert.Nil(t, err)
+	schema := &TableSchema{
+		Name: "issues",
+		Columns: []*Column{
+			{Name: "id", Kind: KindString, PrimaryKey: true},
+			{Name: "created_date", Kind: KindTime},
+			{Name: "labels", Kind: KindArray},
+		},
+	}
+	assert.Nil(t, exporter.ResetTable(schema))
+	assert.Nil(t, exporter.Upsert(schema, []Row{
+		{"id": "1", "created_date": time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), "labels": []string{"a"}},
+		{"id": "2"},
+	}))
+	var keys []Row
+	assert.Nil(t, exporter.ScanKeys(schema, 10, func(batch []Row) errors.Error {
+		keys = append(keys, batch...)
+		return nil
+	}))
+	assert.Equal(t, []Row{{"id": "1"}, {"id": "2"}}, keys)
+	assert.Nil(t, exporter.Delete(schema, []Row{{"id": "it's"}}))
+
+	assert.Equal(t, []string{
+		"DROP TABLE IF EXISTS `issues`",
+		"CREATE TABLE IF NOT EXISTS `issues` (`id` String, `created_date` Nullable(DateTime64(3, 'UTC')), `labels` Array(String)) ENGINE = ReplacingMergeTree ORDER BY (`id`)",
+		"INSERT INTO `issues` FORMAT JSONEachRow",
+		"SELECT `id` FROM `issues` FINAL ORDER BY `id` LIMIT 10 OFFSET 0 FORMAT JSONEachRow",
+		"ALTER TABLE `issues` DELETE WHERE (`id`) IN (('it\\'s'))",
+	}, queries)
+	assert.Equal(t, "{\"created_date\":\"2023-01-02 03:04:05.000\",\"id\":\"1\",\"labels\":[\"a\"]}\n{\"created_date\":null,\"id\":\"2\",\"labels\":[]}\n", bodies[2])
+}
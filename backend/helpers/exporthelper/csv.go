@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+var csvFormat = &fileFormat{
+	ext:   ".csv",
+	write: WriteCsvFile,
+	read:  ReadCsvFile,
+}
+
+// NewCsvExporter creates a FileExporter writing csv files with header to the directory
+func NewCsvExporter(dir string) (*FileExporter, errors.Error) {
+	return newFileExporter("csv", dir, csvFormat)
+}
+
+// csvValue formats the value for csv, nil would be an empty string, time would be in RFC3339 and
+// arrays would be json strings
+func csvValue(kind Kind, v interface{}) string {
+	switch value := NormalizeValue(kind, v).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case time.Time:
+		return value.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	case []string:
+		b, _ := json.Marshal(value)
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// WriteCsvFile writes the rows to a new csv file with the column names as header, it would be written
+// to a temporary file and renamed after all rows are written
+func WriteCsvFile(path string, schema *TableSchema, rows []Row) errors.Error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to create %s", tmpPath))
+	}
+	err = writeCsv(f, schema, rows)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to write %s", path))
+	}
+	return nil
+}
+
+func writeCsv(w io.Writer, schema *TableSchema, rows []Row) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(schema.ColumnNames())
+	if err != nil {
+		return err
+	}
+	record := make([]string, len(schema.Columns))
+	for _, row := range rows {
+		for i, c := range schema.Columns {
+			record[i] = csvValue(c.Kind, row[c.Name])
+		}
+		err = writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCsvFile reads the columns of schema from a csv file written by WriteCsvFile,
+// empty values and columns missing in the file would be nil in the rows
+func ReadCsvFile(path string, schema *TableSchema) ([]Row, errors.Error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to open %s", path))
+	}
+	defer f.Close()
+	reader := csv.NewReader(f)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read %s", path))
+	}
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read %s", path))
+		}
+		row := make(Row, len(schema.Columns))
+		for _, c := range schema.Columns {
+			row[c.Name] = nil
+		}
+		for i, name := range header {
+			column := schema.Column(name)
+			if column == nil || i >= len(record) || record[i] == "" {
+				continue
+			}
+			row[name] = fromCsvValue(column.Kind, record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func fromCsvValue(kind Kind, value string) interface{} {
+	if kind == KindArray {
+		var arr []string
+		_ = json.Unmarshal([]byte(value), &arr)
+		return arr
+	}
+	return NormalizeValue(kind, value)
+}
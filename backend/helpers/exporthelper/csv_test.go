@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCsvExporter(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewCsvExporter(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "csv:"+dir, exporter.Name())
+	seq := int64(0)
+	exporter.now = func() time.Time {
+		seq++
+		return time.Unix(0, seq)
+	}
+	exporter.SetPartitionColumn("issues", "created_date")
+
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	schema := &TableSchema{
+		Name: "issues",
+		Columns: []*Column{
+			{Name: "id", Kind: KindString, PrimaryKey: true},
+			{Name: "created_date", Kind: KindTime},
+			{Name: "labels", Kind: KindArray},
+			{Name: "lead_time_minutes", Kind: KindInt},
+		},
+	}
+	assert.Nil(t, exporter.ResetTable(schema))
+	assert.Nil(t, exporter.Upsert(schema, []Row{
+		{"id": "1", "created_date": ts, "labels": []string{"a", "b"}, "lead_time_minutes": int64(10)},
+		{"id": "2", "created_date": ts.AddDate(0, 1, 0)},
+		{"id": "3"},
+	}))
+	assert.Nil(t, exporter.Delete(schema, []Row{{"id": "2"}}))
+	files, err := exporter.Files("issues")
+	assert.Nil(t, err)
+	for i, file := range files {
+		files[i] = strings.TrimPrefix(file, dir+string(os.PathSeparator))
+	}
+	assert.Equal(t, []string{
+		filepath.Join("issues", "created_date=2023-01", "00000000000000000001-part.csv"),
+		filepath.Join("issues", "created_date=2023-02", "00000000000000000002-part.csv"),
+		filepath.Join("issues", "created_date=__HIVE_DEFAULT_PARTITION__", "00000000000000000003-part.csv"),
+		filepath.Join("issues", "00000000000000000004-delete.csv"),
+	}, files)
+
+	content, _ := os.ReadFile(filepath.Join(dir, files[0]))
+	assert.Equal(t, "id,created_date,labels,lead_time_minutes\n1,2023-01-02T03:04:05.000Z,\"[\"\"a\"\",\"\"b\"\"]\",10\n", string(content))
+	rows, err := ReadCsvFile(filepath.Join(dir, files[0]), schema)
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "1", "created_date": ts, "labels": []string{"a", "b"}, "lead_time_minutes": int64(10)}}, rows)
+
+	keys, err := exporter.Keys(schema)
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "1"}, {"id": "3"}}, keys)
+}
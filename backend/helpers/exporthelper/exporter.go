@@ -0,0 +1,307 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"golang.org/x/exp/slices"
+)
+
+// Row holds the values of a record by column names
+type Row map[string]interface{}
+
+// Kind is the type of column which can be understood by all destinations
+type Kind string
+
+const (
+	KindString Kind = "string"
+	KindInt    Kind = "int"
+	KindFloat  Kind = "float"
+	KindBool   Kind = "bool"
+	KindTime   Kind = "time"
+	KindJson   Kind = "json"
+	KindArray  Kind = "array"
+)
+
+// KindOf returns the Kind of the database column type, i.e. varchar(255), bigint, timestamp(3), text[]
+func KindOf(columnType string) Kind {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+	switch {
+	case strings.HasSuffix(t, "[]"):
+		return KindArray
+	case t == "tinyint(1)" || t == "bool" || t == "boolean":
+		return KindBool
+	case strings.Contains(t, "int") || strings.Contains(t, "serial"):
+		return KindInt
+	case strings.HasPrefix(t, "float"), strings.HasPrefix(t, "double"), strings.HasPrefix(t, "real"),
+		strings.HasPrefix(t, "numeric"), strings.HasPrefix(t, "decimal"):
+		return KindFloat
+	case strings.HasPrefix(t, "datetime"), strings.HasPrefix(t, "timestamp"), t == "date":
+		return KindTime
+	case t == "json" || t == "jsonb":
+		return KindJson
+	}
+	return KindString
+}
+
+// Column describes a column of the exporting table
+type Column struct {
+	Name string
+	// Type is the column type in the source database
+	Type       string
+	Kind       Kind
+	PrimaryKey bool
+}
+
+// TableSchema describes the exporting table, primary key columns come first
+type TableSchema struct {
+	Name    string
+	Columns []*Column
+}
+
+// PrimaryKeys returns the primary key columns
+func (s *TableSchema) PrimaryKeys() []*Column {
+	var pks []*Column
+	for _, c := range s.Columns {
+		if c.PrimaryKey {
+			pks = append(pks, c)
+		}
+	}
+	return pks
+}
+
+// Column returns the column with the name or nil if it doesn't exist
+func (s *TableSchema) Column(name string) *Column {
+	for _, c := range s.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// ColumnNames returns the names of all columns
+func (s *TableSchema) ColumnNames() []string {
+	names := make([]string, len(s.Columns))
+	for i, c := range s.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Key returns a string identifying the row by the values of primary keys
+func (s *TableSchema) Key(row Row) string {
+	pks := s.PrimaryKeys()
+	values := make([]string, len(pks))
+	for i, pk := range pks {
+		value := NormalizeValue(pk.Kind, row[pk.Name])
+		if t, ok := value.(time.Time); ok {
+			// destinations might keep timestamps in milliseconds
+			value = t.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+		}
+		values[i] = fmt.Sprintf("%v", value)
+	}
+	return strings.Join(values, "\x00")
+}
+
+// LoadTableSchema reads the schema of the table from the database, the included/excluded columns are
+// optional for picking the columns to be exported
+func LoadTableSchema(db dal.Dal, table string, includedColumns, excludedColumns []string) (*TableSchema, errors.Error) {
+	columnMetas, err := db.GetColumns(dal.DefaultTabler{Name: table}, func(cm dal.ColumnMeta) bool {
+		if len(includedColumns) > 0 && !slices.Contains(includedColumns, cm.Name()) {
+			return false
+		}
+		return !slices.Contains(excludedColumns, cm.Name())
+	})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to load columns of table %s", table))
+	}
+	schema := &TableSchema{Name: table}
+	var others []*Column
+	for _, cm := range columnMetas {
+		columnType, ok := cm.ColumnType()
+		if !ok {
+			columnType = cm.DatabaseTypeName()
+		}
+		column := &Column{Name: cm.Name(), Type: columnType, Kind: KindOf(columnType)}
+		if isPrimaryKey, ok := cm.PrimaryKey(); ok && isPrimaryKey {
+			column.PrimaryKey = true
+			schema.Columns = append(schema.Columns, column)
+		} else {
+			others = append(others, column)
+		}
+	}
+	schema.Columns = append(schema.Columns, others...)
+	if len(schema.Columns) == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("no column found for table %s", table))
+	}
+	return schema, nil
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NormalizeValue converts the value scanned from a database or decoded from json to the Go type of the Kind,
+// which would be string, int64, float64, bool, time.Time or []string, nil is returned if it can not be converted
+func NormalizeValue(kind Kind, v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+	if p, ok := v.(*time.Time); ok {
+		if p == nil {
+			return nil
+		}
+		v = *p
+	}
+	if v == nil {
+		return nil
+	}
+	switch kind {
+	case KindInt:
+		switch n := v.(type) {
+		case int64:
+			return n
+		case int:
+			return int64(n)
+		case int32:
+			return int64(n)
+		case uint64:
+			return int64(n)
+		case float64:
+			return int64(n)
+		case bool:
+			if n {
+				return int64(1)
+			}
+			return int64(0)
+		case json.Number:
+			i, err := n.Int64()
+			if err == nil {
+				return i
+			}
+		case string:
+			i, err := strconv.ParseInt(n, 10, 64)
+			if err == nil {
+				return i
+			}
+		}
+		return nil
+	case KindFloat:
+		switch n := v.(type) {
+		case float64:
+			return n
+		case float32:
+			return float64(n)
+		case int64:
+			return float64(n)
+		case json.Number:
+			f, err := n.Float64()
+			if err == nil {
+				return f
+			}
+		case string:
+			f, err := strconv.ParseFloat(n, 64)
+			if err == nil {
+				return f
+			}
+		}
+		return nil
+	case KindBool:
+		switch b := v.(type) {
+		case bool:
+			return b
+		case int64:
+			return b != 0
+		case json.Number:
+			return b.String() != "0"
+		case string:
+			return b == "1" || strings.EqualFold(b, "true")
+		}
+		return nil
+	case KindTime:
+		switch t := v.(type) {
+		case time.Time:
+			return t
+		case string:
+			if parsed, ok := parseTime(t); ok {
+				return parsed
+			}
+		}
+		return nil
+	case KindArray:
+		switch a := v.(type) {
+		case []string:
+			return a
+		case []interface{}:
+			s := make([]string, len(a))
+			for i, item := range a {
+				s[i] = fmt.Sprintf("%v", item)
+			}
+			return s
+		}
+		return nil
+	}
+	switch s := v.(type) {
+	case string:
+		return s
+	case time.Time:
+		return s.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Exporter writes rows of DevLake tables to an external destination
+type Exporter interface {
+	// Name identifies the destination, watermarks are stored by the destination and table
+	Name() string
+	// ResetTable drops the table in the destination and creates it again for a full export
+	ResetTable(schema *TableSchema) errors.Error
+	// SyncSchema creates the table or adds the missing columns to it
+	SyncSchema(schema *TableSchema) errors.Error
+	// Upsert writes the rows and replaces the existing ones with the same primary keys
+	Upsert(schema *TableSchema, rows []Row) errors.Error
+	// ScanKeys passes the primary keys of all rows in the destination table to handle batch by batch
+	ScanKeys(schema *TableSchema, batchSize int, handle func(keys []Row) errors.Error) errors.Error
+	// Delete removes rows with the primary keys from the destination table
+	Delete(schema *TableSchema, keys []Row) errors.Error
+	// Close releases the resources held by the Exporter
+	Close() errors.Error
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	for columnType, kind := range map[string]Kind{
+		"varchar(255)":             KindString,
+		"text":                     KindString,
+		"uuid":                     KindString,
+		"bigint":                   KindInt,
+		"int unsigned":             KindInt,
+		"bigserial":                KindInt,
+		"tinyint(1)":               KindBool,
+		"boolean":                  KindBool,
+		"double precision":         KindFloat,
+		"decimal(10,2)":            KindFloat,
+		"datetime(3)":              KindTime,
+		"timestamp with time zone": KindTime,
+		"date":                     KindTime,
+		"json":                     KindJson,
+		"text[]":                   KindArray,
+	} {
+		assert.Equal(t, kind, KindOf(columnType), columnType)
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, int64(12), NormalizeValue(KindInt, []byte("12")))
+	assert.Equal(t, int64(12), NormalizeValue(KindInt, json.Number("12")))
+	assert.Equal(t, 1.5, NormalizeValue(KindFloat, "1.5"))
+	assert.Equal(t, true, NormalizeValue(KindBool, int64(1)))
+	assert.Equal(t, ts, NormalizeValue(KindTime, "2023-01-02 03:04:05"))
+	assert.Equal(t, ts, NormalizeValue(KindTime, &ts))
+	assert.Equal(t, "abc", NormalizeValue(KindString, []byte("abc")))
+	assert.Equal(t, []string{"a", "b"}, NormalizeValue(KindArray, []interface{}{"a", "b"}))
+	assert.Nil(t, NormalizeValue(KindInt, "abc"))
+	assert.Nil(t, NormalizeValue(KindTime, nil))
+}
+
+func TestTableSchemaKey(t *testing.T) {
+	schema := &TableSchema{
+		Name: "commits",
+		Columns: []*Column{
+			{Name: "repo_id", Kind: KindInt, PrimaryKey: true},
+			{Name: "sha", Kind: KindString, PrimaryKey: true},
+			{Name: "message", Kind: KindString},
+		},
+	}
+	assert.Equal(t, []string{"repo_id", "sha"}, []string{schema.PrimaryKeys()[0].Name, schema.PrimaryKeys()[1].Name})
+	// keys from different sources should be identical
+	assert.Equal(t,
+		schema.Key(Row{"repo_id": int64(1), "sha": []byte("abc"), "message": "foo"}),
+		schema.Key(Row{"repo_id": json.Number("1"), "sha": "abc"}),
+	)
+	assert.NotEqual(t, schema.Key(Row{"repo_id": 1, "sha": "abc"}), schema.Key(Row{"repo_id": 2, "sha": "abc"}))
+}
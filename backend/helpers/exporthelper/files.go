@@ -0,0 +1,245 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// HivePartitionDefault is the partition of rows without the partition value, same as hive
+const HivePartitionDefault = "__HIVE_DEFAULT_PARTITION__"
+
+var _ Exporter = (*FileExporter)(nil)
+
+// fileFormat reads and writes rows of a kind of files
+type fileFormat struct {
+	ext   string
+	write func(path string, schema *TableSchema, rows []Row) errors.Error
+	read  func(path string, schema *TableSchema) ([]Row, errors.Error)
+}
+
+// FileExporter writes tables to a local directory, each table has its own sub directory holding
+// a sequence of files named by their creation time. The `*-part` files contain the upserted rows
+// and `*-delete` files contain the primary keys of the deleted rows, consumers should apply them in the
+// order of file names. Upserted rows of a table with partition column are written to hive style sub
+// directories, i.e. `issues/created_date=2023-01/`, the deletes are always written to the table directory.
+type FileExporter struct {
+	kind             string
+	dir              string
+	format           *fileFormat
+	partitionColumns map[string]string
+	now              func() time.Time
+}
+
+func newFileExporter(kind, dir string, format *fileFormat) (*FileExporter, errors.Error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid directory %s", dir))
+	}
+	err = os.MkdirAll(absDir, 0755)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to create directory %s", absDir))
+	}
+	return &FileExporter{
+		kind:             kind,
+		dir:              absDir,
+		format:           format,
+		partitionColumns: make(map[string]string),
+		now:              time.Now,
+	}, nil
+}
+
+func (p *FileExporter) Name() string {
+	return p.kind + ":" + p.dir
+}
+
+// Dir returns the absolute path of the directory
+func (p *FileExporter) Dir() string {
+	return p.dir
+}
+
+// SetPartitionColumn partitions the upserted rows of the table by the column, rows would be partitioned
+// by month if it is a time column
+func (p *FileExporter) SetPartitionColumn(table, column string) {
+	p.partitionColumns[table] = column
+}
+
+func (p *FileExporter) tableDir(schema *TableSchema) string {
+	return filepath.Join(p.dir, schema.Name)
+}
+
+func (p *FileExporter) ResetTable(schema *TableSchema) errors.Error {
+	err := os.RemoveAll(p.tableDir(schema))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to remove %s", p.tableDir(schema)))
+	}
+	return p.SyncSchema(schema)
+}
+
+// SyncSchema only makes sure the directory exists since every file carries its own header
+func (p *FileExporter) SyncSchema(schema *TableSchema) errors.Error {
+	err := os.MkdirAll(p.tableDir(schema), 0755)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to create %s", p.tableDir(schema)))
+	}
+	return nil
+}
+
+func (p *FileExporter) Upsert(schema *TableSchema, rows []Row) errors.Error {
+	column := schema.Column(p.partitionColumns[schema.Name])
+	if column == nil {
+		return p.writeFile(schema, p.tableDir(schema), "part", rows)
+	}
+	partitions := make(map[string][]Row)
+	var names []string
+	for _, row := range rows {
+		name := partitionName(column, row[column.Name])
+		if _, ok := partitions[name]; !ok {
+			names = append(names, name)
+		}
+		partitions[name] = append(partitions[name], row)
+	}
+	for _, name := range names {
+		dir := filepath.Join(p.tableDir(schema), name)
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to create %s", dir))
+		}
+		if err := p.writeFile(schema, dir, "part", partitions[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func partitionName(column *Column, v interface{}) string {
+	value := HivePartitionDefault
+	switch v := NormalizeValue(column.Kind, v).(type) {
+	case nil:
+	case time.Time:
+		value = v.UTC().Format("2006-01")
+	default:
+		value = url.PathEscape(fmt.Sprintf("%v", v))
+	}
+	return column.Name + "=" + value
+}
+
+func (p *FileExporter) Delete(schema *TableSchema, keys []Row) errors.Error {
+	return p.writeFile(&TableSchema{Name: schema.Name, Columns: schema.PrimaryKeys()}, p.tableDir(schema), "delete", keys)
+}
+
+// Files returns all files of the table in the order of creation
+func (p *FileExporter) Files(table string) ([]string, errors.Error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(p.dir, table), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, p.format.ext) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to list files of %s", table))
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+	return files, nil
+}
+
+// Keys replays all files of the table to find out the primary keys of existing rows
+func (p *FileExporter) Keys(schema *TableSchema) ([]Row, errors.Error) {
+	keySchema := &TableSchema{Name: schema.Name, Columns: schema.PrimaryKeys()}
+	files, err := p.Files(schema.Name)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]Row)
+	var order []string
+	for _, file := range files {
+		rows, err := p.format.read(file, keySchema)
+		if err != nil {
+			return nil, err
+		}
+		deleting := strings.HasSuffix(file, "-delete"+p.format.ext)
+		for _, row := range rows {
+			key := keySchema.Key(row)
+			if deleting {
+				delete(keys, key)
+				continue
+			}
+			if _, ok := keys[key]; !ok {
+				order = append(order, key)
+			}
+			keys[key] = row
+		}
+	}
+	result := make([]Row, 0, len(keys))
+	for _, key := range order {
+		if row, ok := keys[key]; ok {
+			result = append(result, row)
+			delete(keys, key)
+		}
+	}
+	return result, nil
+}
+
+// ScanKeys passes the keys found by Keys batch by batch, all keys have to be loaded since the files must be replayed
+func (p *FileExporter) ScanKeys(schema *TableSchema, batchSize int, handle func(keys []Row) errors.Error) errors.Error {
+	keys, err := p.Keys(schema)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(keys); i += batchSize {
+		end := i + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		err = handle(keys[i:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *FileExporter) Close() errors.Error {
+	return nil
+}
+
+func (p *FileExporter) writeFile(schema *TableSchema, dir, suffix string, rows []Row) errors.Error {
+	if len(rows) == 0 {
+		return nil
+	}
+	name := fmt.Sprintf("%020d-%s%s", p.now().UnixNano(), suffix, p.format.ext)
+	return p.format.write(filepath.Join(dir, name), schema, rows)
+}
@@ -0,0 +1,218 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+var parquetFormat = &fileFormat{
+	ext:   ".parquet",
+	write: WriteParquetFile,
+	read:  ReadParquetFile,
+}
+
+// NewParquetExporter creates a FileExporter writing parquet files to the directory
+func NewParquetExporter(dir string) (*FileExporter, errors.Error) {
+	return newFileExporter("parquet", dir, parquetFormat)
+}
+
+// ParquetSchema converts the TableSchema to the json schema accepted by the parquet writer, all columns are optional
+func ParquetSchema(schema *TableSchema) string {
+	fields := make([]map[string]string, 0, len(schema.Columns))
+	for _, c := range schema.Columns {
+		var tag string
+		switch c.Kind {
+		case KindInt:
+			tag = "type=INT64"
+		case KindFloat:
+			tag = "type=DOUBLE"
+		case KindBool:
+			tag = "type=BOOLEAN"
+		case KindTime:
+			tag = "type=INT64, convertedtype=TIMESTAMP_MILLIS"
+		case KindJson:
+			tag = "type=BYTE_ARRAY, convertedtype=JSON"
+		default:
+			tag = "type=BYTE_ARRAY, convertedtype=UTF8"
+		}
+		fields = append(fields, map[string]string{
+			"Tag": fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", c.Name, tag),
+		})
+	}
+	b, _ := json.Marshal(map[string]interface{}{
+		"Tag":    "name=parquet_go_root, repetitiontype=REQUIRED",
+		"Fields": fields,
+	})
+	return string(b)
+}
+
+// parquetValue converts the value to what the json writer expects, time would be stored as milliseconds
+// and arrays would be stored as json string
+func parquetValue(kind Kind, v interface{}) interface{} {
+	v = NormalizeValue(kind, v)
+	switch value := v.(type) {
+	case time.Time:
+		return value.UnixMilli()
+	case []string:
+		b, _ := json.Marshal(value)
+		return string(b)
+	case nil, int64, float64, bool, string:
+		return value
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// parquetFile implements the source.ParquetFile on top of local files
+type parquetFile struct {
+	*os.File
+}
+
+func (f *parquetFile) Open(name string) (source.ParquetFile, error) {
+	if name == "" {
+		name = f.Name()
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetFile{file}, nil
+}
+
+func (f *parquetFile) Create(name string) (source.ParquetFile, error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetFile{file}, nil
+}
+
+// WriteParquetFile writes the rows to a new parquet file, it would be written to a temporary file and renamed
+// after all rows are written, so readers would never see a partial file
+func WriteParquetFile(path string, schema *TableSchema, rows []Row) errors.Error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to create %s", tmpPath))
+	}
+	err = writeParquet(&parquetFile{f}, schema, rows)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to write %s", path))
+	}
+	return nil
+}
+
+func writeParquet(f source.ParquetFile, schema *TableSchema, rows []Row) error {
+	pw, err := writer.NewJSONWriter(ParquetSchema(schema), f, 1)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make(map[string]interface{}, len(schema.Columns))
+		for _, c := range schema.Columns {
+			if value := parquetValue(c.Kind, row[c.Name]); value != nil {
+				record[c.Name] = value
+			}
+		}
+		b, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		err = pw.Write(string(b))
+		if err != nil {
+			return err
+		}
+	}
+	return pw.WriteStop()
+}
+
+// ReadParquetFile reads the columns of schema from a parquet file written by WriteParquetFile,
+// columns missing in the file would be nil in the rows
+func ReadParquetFile(path string, schema *TableSchema) ([]Row, errors.Error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to open %s", path))
+	}
+	defer f.Close()
+	pr, err := reader.NewParquetColumnReader(&parquetFile{f}, 1)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read %s", path))
+	}
+	defer pr.ReadStop()
+	num := pr.GetNumRows()
+	rows := make([]Row, num)
+	for i := range rows {
+		rows[i] = make(Row, len(schema.Columns))
+		for _, c := range schema.Columns {
+			rows[i][c.Name] = nil
+		}
+	}
+	for _, inPath := range pr.SchemaHandler.ValueColumns {
+		exPath := strings.Split(pr.SchemaHandler.InPathToExPath[inPath], common.PAR_GO_PATH_DELIMITER)
+		column := schema.Column(exPath[len(exPath)-1])
+		if column == nil {
+			continue
+		}
+		values, _, _, err := pr.ReadColumnByPath(inPath, num)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read column %s of %s", column.Name, path))
+		}
+		for i, value := range values {
+			if int64(i) < num {
+				rows[i][column.Name] = fromParquetValue(column.Kind, value)
+			}
+		}
+	}
+	return rows, nil
+}
+
+func fromParquetValue(kind Kind, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch kind {
+	case KindTime:
+		if ms, ok := value.(int64); ok {
+			return time.UnixMilli(ms).UTC()
+		}
+	case KindArray:
+		var arr []string
+		if s, ok := value.(string); ok {
+			_ = json.Unmarshal([]byte(s), &arr)
+		}
+		return arr
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParquetExporter(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewParquetExporter(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "parquet:"+dir, exporter.Name())
+	seq := int64(0)
+	exporter.now = func() time.Time {
+		seq++
+		return time.Unix(0, seq)
+	}
+
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	schema := &TableSchema{
+		Name: "issues",
+		Columns: []*Column{
+			{Name: "id", Kind: KindString, PrimaryKey: true},
+			{Name: "story_point", Kind: KindFloat},
+			{Name: "created_date", Kind: KindTime},
+			{Name: "labels", Kind: KindArray},
+			{Name: "is_subtask", Kind: KindBool},
+			{Name: "lead_time_minutes", Kind: KindInt},
+		},
+	}
+	assert.Nil(t, exporter.ResetTable(schema))
+	assert.Nil(t, exporter.Upsert(schema, []Row{
+		{"id": "1", "story_point": 1.5, "created_date": ts, "labels": []string{"a"}, "is_subtask": true, "lead_time_minutes": int64(10)},
+		{"id": "2", "story_point": nil, "created_date": nil, "labels": nil, "is_subtask": false, "lead_time_minutes": nil},
+		{"id": "3"},
+	}))
+	files, _ := filepath.Glob(filepath.Join(dir, "issues", "*.parquet"))
+	assert.Equal(t, 1, len(files))
+	rows, err := ReadParquetFile(files[0], schema)
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{
+		{"id": "1", "story_point": 1.5, "created_date": ts, "labels": []string{"a"}, "is_subtask": true, "lead_time_minutes": int64(10)},
+		{"id": "2", "story_point": nil, "created_date": nil, "labels": nil, "is_subtask": false, "lead_time_minutes": nil},
+		{"id": "3", "story_point": nil, "created_date": nil, "labels": nil, "is_subtask": nil, "lead_time_minutes": nil},
+	}, rows)
+
+	// deleted keys should be excluded
+	assert.Nil(t, exporter.Delete(schema, []Row{{"id": "2"}}))
+	assert.Nil(t, exporter.Upsert(schema, []Row{{"id": "2"}, {"id": "4"}}))
+	assert.Nil(t, exporter.Delete(schema, []Row{{"id": "1"}}))
+	keys, err := exporter.Keys(schema)
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "2"}, {"id": "3"}, {"id": "4"}}, keys)
+
+	// everything should be gone after reset
+	assert.Nil(t, exporter.ResetTable(schema))
+	keys, err = exporter.Keys(schema)
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
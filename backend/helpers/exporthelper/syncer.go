@@ -0,0 +1,363 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/lib/pq"
+	"golang.org/x/exp/slices"
+)
+
+const DefaultUpdateColumn = "updated_at"
+const defaultBatchSize = 1000
+
+// TableExportOptions controls how a table gets exported
+type TableExportOptions struct {
+	// UpdateColumn is the timestamp column for incremental export, defaults to updated_at
+	UpdateColumn    string
+	IncludedColumns []string
+	ExcludedColumns []string
+	// Where filters the rows to be exported
+	Where string
+	// BatchSize is the number of rows written to the destination at a time
+	BatchSize int
+	// PropagateDeletes removes rows from the destination if they no longer exist in the source
+	PropagateDeletes bool
+	// FullSync ignores the watermark and exports the whole table again
+	FullSync bool
+}
+
+// TableExportResult describes what was done by ExportTable
+type TableExportResult struct {
+	Schema *TableSchema
+	// Incremental is false if the whole table was exported
+	Incremental bool
+	// ExportedRows is the number of rows written to the destination
+	ExportedRows int
+	// DeletedRows is the number of rows deleted from the destination
+	DeletedRows int
+	// UpdatedUntil is the watermark saved after the export
+	UpdatedUntil *time.Time
+}
+
+// ExportTable exports the table from the src database to the destination. Only rows updated since the previous
+// export would be written if the table has both primary keys and the UpdateColumn, otherwise the table would be
+// exported as a whole. Columns added to the table since the previous export are propagated to the destination
+// and all rows would be written again to fill them up. The watermark is saved to the database of DevLake.
+func ExportTable(c plugin.SubTaskContext, src dal.Dal, exporter Exporter, table string, options *TableExportOptions) (*TableExportResult, errors.Error) {
+	logger := c.GetLogger()
+	db := c.GetDal()
+	if options == nil {
+		options = &TableExportOptions{}
+	}
+	updateColumn := options.UpdateColumn
+	if updateColumn == "" {
+		updateColumn = DefaultUpdateColumn
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	schema, err := LoadTableSchema(src, table, options.IncludedColumns, options.ExcludedColumns)
+	if err != nil {
+		return nil, err
+	}
+	watermark := &models.ExportWatermark{}
+	err = db.First(watermark, dal.Where("destination = ? AND ? = ?", exporter.Name(), dal.ClauseColumn{Name: "table"}, table))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to load export watermark of %s", table))
+		}
+		watermark = &models.ExportWatermark{Destination: exporter.Name(), Table: table}
+	}
+	incremental := !options.FullSync &&
+		watermark.UpdatedUntil != nil &&
+		schema.Column(updateColumn) != nil &&
+		len(schema.PrimaryKeys()) > 0
+
+	var since *time.Time
+	if incremental {
+		err = exporter.SyncSchema(schema)
+		if err != nil {
+			return nil, err
+		}
+		addedColumns := addedColumns(watermark.Columns, schema)
+		if len(addedColumns) > 0 {
+			logger.Info("columns %v were added to %s, exporting all rows again", addedColumns, table)
+		} else {
+			since = watermark.UpdatedUntil
+		}
+	} else {
+		logger.Info("exporting the whole table %s", table)
+		err = exporter.ResetTable(schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// upsert the changed rows
+	clauses := []dal.Clause{selectColumns(schema), dal.From(table)}
+	if options.Where != "" {
+		clauses = append(clauses, dal.Where(options.Where))
+	}
+	if since != nil {
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s >= ?", updateColumn), *since))
+	}
+	var updatedUntil *time.Time
+	count := 0
+	err = scanRows(c, src, schema, clauses, batchSize, func(rows []Row) errors.Error {
+		for _, row := range rows {
+			if updatedAt, ok := row[updateColumn].(time.Time); ok && (updatedUntil == nil || updatedAt.After(*updatedUntil)) {
+				updatedUntil = &updatedAt
+			}
+		}
+		count += len(rows)
+		return exporter.Upsert(schema, rows)
+	})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to export rows of %s", table))
+	}
+	logger.Info("%d rows of %s were exported to %s", count, table, exporter.Name())
+
+	// delete the rows no longer exist
+	deleted := 0
+	if incremental && options.PropagateDeletes {
+		deleted, err = propagateDeletes(c, src, exporter, schema, options.Where, batchSize)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to propagate deletes of %s", table))
+		}
+	}
+
+	// save the watermark
+	if updatedUntil == nil || (watermark.UpdatedUntil != nil && watermark.UpdatedUntil.After(*updatedUntil)) {
+		updatedUntil = watermark.UpdatedUntil
+	}
+	if updatedUntil == nil {
+		// nothing was exported from an empty table, the watermark is still needed for exporting incrementally
+		// next time, otherwise the table in the destination would be reset over and over again
+		epoch := time.Unix(0, 0).UTC()
+		updatedUntil = &epoch
+	}
+	if schema.Column(updateColumn) == nil {
+		updatedUntil = nil
+	}
+	watermark.UpdatedUntil = updatedUntil
+	watermark.Columns = strings.Join(schema.ColumnNames(), ",")
+	err = db.CreateOrUpdate(watermark)
+	if err != nil {
+		return nil, err
+	}
+	return &TableExportResult{
+		Schema:       schema,
+		Incremental:  incremental,
+		ExportedRows: count,
+		DeletedRows:  deleted,
+		UpdatedUntil: updatedUntil,
+	}, nil
+}
+
+func addedColumns(previousColumns string, schema *TableSchema) []string {
+	if previousColumns == "" {
+		return nil
+	}
+	previous := strings.Split(previousColumns, ",")
+	var added []string
+	for _, name := range schema.ColumnNames() {
+		if !slices.Contains(previous, name) {
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
+func selectColumns(schema *TableSchema) dal.Clause {
+	params := make([]interface{}, len(schema.Columns))
+	for i, c := range schema.Columns {
+		params[i] = dal.ClauseColumn{Name: c.Name}
+	}
+	return dal.Select(strings.TrimSuffix(strings.Repeat("?, ", len(params)), ", "), params...)
+}
+
+// scanRows reads the columns of schema from the database and pass them to the handler batch by batch
+func scanRows(
+	c plugin.SubTaskContext,
+	db dal.Dal,
+	schema *TableSchema,
+	clauses []dal.Clause,
+	batchSize int,
+	handle func(rows []Row) errors.Error,
+) errors.Error {
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	names, e := cursor.Columns()
+	if e != nil {
+		return errors.Convert(e)
+	}
+	columns := make([]*Column, len(names))
+	for i, name := range names {
+		columns[i] = schema.Column(name)
+		if columns[i] == nil {
+			columns[i] = &Column{Name: name, Kind: KindString}
+		}
+	}
+	batch := make([]Row, 0, batchSize)
+	for cursor.Next() {
+		select {
+		case <-c.GetContext().Done():
+			return errors.Convert(c.GetContext().Err())
+		default:
+		}
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i, column := range columns {
+			if column.Kind == KindArray && db.Dialect() == "postgres" {
+				var arr []string
+				values[i] = &arr
+				pointers[i] = pq.Array(&arr)
+			} else {
+				pointers[i] = &values[i]
+			}
+		}
+		e = cursor.Scan(pointers...)
+		if e != nil {
+			return errors.Convert(e)
+		}
+		row := make(Row, len(columns))
+		for i, column := range columns {
+			if arr, ok := values[i].(*[]string); ok {
+				values[i] = *arr
+			}
+			row[column.Name] = NormalizeValue(column.Kind, values[i])
+		}
+		batch = append(batch, row)
+		if len(batch) == batchSize {
+			err = handle(batch)
+			if err != nil {
+				return err
+			}
+			batch = make([]Row, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		return handle(batch)
+	}
+	return nil
+}
+
+// propagateDeletes removes the rows from the destination if they no longer exist in the source, the keys in the
+// destination are checked against the source batch by batch so neither side has to be loaded into memory as a whole
+func propagateDeletes(
+	c plugin.SubTaskContext,
+	src dal.Dal,
+	exporter Exporter,
+	schema *TableSchema,
+	where string,
+	batchSize int,
+) (int, errors.Error) {
+	keySchema := &TableSchema{Name: schema.Name, Columns: schema.PrimaryKeys()}
+	var deleted []Row
+	err := exporter.ScanKeys(keySchema, batchSize, func(keys []Row) errors.Error {
+		existing, err := existingKeys(c, src, keySchema, where, keys, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if !existing[keySchema.Key(key)] {
+				deleted = append(deleted, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	// the destination is modified after scanning so the pagination of keys wouldn't be affected
+	for i := 0; i < len(deleted); i += batchSize {
+		end := i + batchSize
+		if end > len(deleted) {
+			end = len(deleted)
+		}
+		err = exporter.Delete(keySchema, deleted[i:end])
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(deleted) > 0 {
+		c.GetLogger().Info("%d rows of %s were deleted from %s", len(deleted), schema.Name, exporter.Name())
+	}
+	return len(deleted), nil
+}
+
+// existingKeys returns the keys which can be found in the source table among the given ones
+func existingKeys(
+	c plugin.SubTaskContext,
+	src dal.Dal,
+	keySchema *TableSchema,
+	where string,
+	keys []Row,
+	batchSize int,
+) (map[string]bool, errors.Error) {
+	existing := make(map[string]bool, len(keys))
+	var conditions []string
+	var params []interface{}
+	for _, key := range keys {
+		conjunctions := make([]string, len(keySchema.Columns))
+		for i, pk := range keySchema.Columns {
+			column := dal.ClauseColumn{Name: pk.Name}
+			value := NormalizeValue(pk.Kind, key[pk.Name])
+			if t, ok := value.(time.Time); ok {
+				// destinations might keep timestamps in milliseconds
+				conjunctions[i] = "? >= ? AND ? < ?"
+				params = append(params, column, t, column, t.Add(time.Millisecond))
+			} else {
+				conjunctions[i] = "? = ?"
+				params = append(params, column, value)
+			}
+		}
+		conditions = append(conditions, "("+strings.Join(conjunctions, " AND ")+")")
+	}
+	if len(conditions) == 0 {
+		return existing, nil
+	}
+	clauses := []dal.Clause{
+		selectColumns(keySchema),
+		dal.From(keySchema.Name),
+		dal.Where("("+strings.Join(conditions, " OR ")+")", params...),
+	}
+	if where != "" {
+		clauses = append(clauses, dal.Where(where))
+	}
+	err := scanRows(c, src, keySchema, clauses, batchSize, func(rows []Row) errors.Error {
+		for _, row := range rows {
+			existing[keySchema.Key(row)] = true
+		}
+		return nil
+	})
+	return existing, err
+}
@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type exportedIssue struct {
+	Id        string `gorm:"primaryKey;type:varchar(255)"`
+	Title     string
+	UpdatedAt time.Time
+}
+
+func (exportedIssue) TableName() string {
+	return "exported_issues"
+}
+
+type exportedIssueWithPriority struct {
+	exportedIssue
+	Priority string
+}
+
+func newExportTestContext(t *testing.T) (plugin.SubTaskContext, dal.Dal) {
+	gormDb, err := runner.MakeDbConnection("sqlite://"+filepath.Join(t.TempDir(), "lake.db"), &gorm.Config{})
+	assert.Nil(t, err)
+	db := dalgorm.NewDalgorm(gormDb)
+	assert.Nil(t, db.AutoMigrate(&models.ExportWatermark{}))
+	assert.Nil(t, db.AutoMigrate(&exportedIssue{}))
+	c := new(mockplugin.SubTaskContext)
+	c.On("GetDal").Return(db)
+	c.On("GetLogger").Return(logruslog.Global)
+	c.On("GetContext").Return(context.Background())
+	return c, db
+}
+
+func TestExportTable(t *testing.T) {
+	c, db := newExportTestContext(t)
+	exporter, err := NewParquetExporter(t.TempDir())
+	assert.Nil(t, err)
+	options := &TableExportOptions{PropagateDeletes: true}
+	t1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	assert.Nil(t, db.CreateOrUpdate(&exportedIssue{Id: "1", Title: "a", UpdatedAt: t1}))
+	assert.Nil(t, db.CreateOrUpdate(&exportedIssue{Id: "2", Title: "b", UpdatedAt: t1}))
+
+	// the first export writes the whole table
+	result, err := ExportTable(c, db, exporter, "exported_issues", options)
+	assert.Nil(t, err)
+	assert.False(t, result.Incremental)
+	assert.Equal(t, 2, result.ExportedRows)
+	watermark := &models.ExportWatermark{}
+	assert.Nil(t, db.First(watermark))
+	assert.Equal(t, exporter.Name(), watermark.Destination)
+	assert.Equal(t, "id,title,updated_at", watermark.Columns)
+	assert.True(t, t1.Equal(*watermark.UpdatedUntil))
+	files, _ := filepath.Glob(filepath.Join(exporter.dir, "exported_issues", "*.parquet"))
+	assert.Equal(t, 1, len(files))
+
+	// only the changed rows would be written and deleted rows would be propagated, rows updated at the
+	// watermark are written again in case some of them were committed after the previous export
+	assert.Nil(t, db.CreateOrUpdate(&exportedIssue{Id: "3", Title: "c", UpdatedAt: t2}))
+	assert.Nil(t, db.Delete(&exportedIssue{Id: "1"}))
+	result, err = ExportTable(c, db, exporter, "exported_issues", options)
+	assert.Nil(t, err)
+	assert.True(t, result.Incremental)
+	assert.Equal(t, 2, result.ExportedRows)
+	assert.Equal(t, 1, result.DeletedRows)
+	files, _ = filepath.Glob(filepath.Join(exporter.dir, "exported_issues", "*.parquet"))
+	assert.Equal(t, 3, len(files))
+	rows, err := ReadParquetFile(files[1], &TableSchema{Columns: []*Column{{Name: "id", Kind: KindString}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "2"}, {"id": "3"}}, rows)
+	keys, err := exporter.Keys(&TableSchema{Name: "exported_issues", Columns: []*Column{{Name: "id", Kind: KindString, PrimaryKey: true}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "2"}, {"id": "3"}}, keys)
+	assert.Nil(t, db.First(watermark))
+	assert.True(t, t2.Equal(*watermark.UpdatedUntil))
+
+	// all rows would be written again once a column was added
+	assert.Nil(t, db.AutoMigrate(&exportedIssueWithPriority{}))
+	result, err = ExportTable(c, db, exporter, "exported_issues", options)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(result.Schema.Columns))
+	files, _ = filepath.Glob(filepath.Join(exporter.dir, "exported_issues", "*.parquet"))
+	assert.Equal(t, 4, len(files))
+	rows, err = ReadParquetFile(files[3], &TableSchema{Columns: []*Column{{Name: "id", Kind: KindString}, {Name: "priority", Kind: KindString}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "2", "priority": nil}, {"id": "3", "priority": nil}}, rows)
+	assert.Nil(t, db.First(watermark))
+	assert.Equal(t, "id,title,updated_at,priority", watermark.Columns)
+}
+
+func TestExportTableEmptyAndBatchedDeletes(t *testing.T) {
+	c, db := newExportTestContext(t)
+	exporter, err := NewParquetExporter(t.TempDir())
+	assert.Nil(t, err)
+	options := &TableExportOptions{PropagateDeletes: true, BatchSize: 1}
+
+	// the watermark is saved even if there was nothing to export
+	result, err := ExportTable(c, db, exporter, "exported_issues", options)
+	assert.Nil(t, err)
+	assert.False(t, result.Incremental)
+	assert.Equal(t, 0, result.ExportedRows)
+	assert.NotNil(t, result.UpdatedUntil)
+
+	// so the next export is incremental and picks up all rows
+	t1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"1", "2", "3"} {
+		assert.Nil(t, db.CreateOrUpdate(&exportedIssue{Id: id, Title: id, UpdatedAt: t1}))
+	}
+	result, err = ExportTable(c, db, exporter, "exported_issues", options)
+	assert.Nil(t, err)
+	assert.True(t, result.Incremental)
+	assert.Equal(t, 3, result.ExportedRows)
+
+	// keys are checked against the source batch by batch
+	assert.Nil(t, db.Delete(&exportedIssue{Id: "1"}))
+	assert.Nil(t, db.Delete(&exportedIssue{Id: "3"}))
+	result, err = ExportTable(c, db, exporter, "exported_issues", options)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.DeletedRows)
+	keys, err := exporter.Keys(&TableSchema{Name: "exported_issues", Columns: []*Column{{Name: "id", Kind: KindString, PrimaryKey: true}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []Row{{"id": "2"}}, keys)
+}
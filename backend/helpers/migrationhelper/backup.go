@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationhelper
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// maxTableNameLength is the longest table name accepted by mysql
+const maxTableNameLength = 64
+
+// MigrationBackup records a table copied by BackupTable, so it can be found for restoring and dropped after
+// the retention period
+type MigrationBackup struct {
+	BackupTable   string `gorm:"primaryKey;type:varchar(64)"`
+	SourceTable   string `gorm:"type:varchar(255)"`
+	ScriptName    string `gorm:"type:varchar(255)"`
+	ScriptVersion uint64
+	CreatedAt     time.Time
+}
+
+func (MigrationBackup) TableName() string {
+	return "_devlake_migration_backups"
+}
+
+// BackupTable copies the whole table before it gets transformed by the script, the copy is recorded in
+// _devlake_migration_backups so it can be found and restored manually if anything went wrong.
+// Backups are opt-in by setting ENABLE_MIGRATION_BACKUP to true, since every transformed table takes twice
+// the disk space until its backup is dropped by CleanupBackupTables after MIGRATION_BACKUP_RETENTION_DAYS
+func BackupTable(basicRes context.BasicRes, script plugin.MigrationScript, tableName string) errors.Error {
+	if !basicRes.GetConfigReader().GetBool("ENABLE_MIGRATION_BACKUP") {
+		return nil
+	}
+	db := basicRes.GetDal()
+	if !db.HasTable(tableName) {
+		return nil
+	}
+	err := db.AutoMigrate(&MigrationBackup{})
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to create the table of migration backups")
+	}
+	backupTableName := GetBackupTableName(script, tableName)
+	if db.HasTable(backupTableName) {
+		err = db.DropTables(backupTableName)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to drop the previous backup table [%s]", backupTableName))
+		}
+	}
+	err = db.Exec(
+		"CREATE TABLE ? AS SELECT * FROM ?",
+		dal.ClauseTable{Name: backupTableName},
+		dal.ClauseTable{Name: tableName},
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to backup table [%s] to [%s]", tableName, backupTableName))
+	}
+	err = db.CreateOrUpdate(&MigrationBackup{
+		BackupTable:   backupTableName,
+		SourceTable:   tableName,
+		ScriptName:    script.Name(),
+		ScriptVersion: script.Version(),
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to record the backup table [%s]", backupTableName))
+	}
+	basicRes.GetLogger().Info("table [%s] has been backed up to [%s]", tableName, backupTableName)
+	return nil
+}
+
+// GetBackupTableName returns the name of the table holding the backup created by BackupTable, which is
+// `<table>_bak_<hash>` with the table name truncated to fit in maxTableNameLength
+func GetBackupTableName(script plugin.MigrationScript, tableName string) string {
+	hasher := md5.New()
+	_, err := hasher.Write([]byte(fmt.Sprintf("%s:%v:%s", script.Name(), script.Version(), tableName)))
+	if err != nil {
+		panic(err)
+	}
+	suffix := "_bak_" + hex.EncodeToString(hasher.Sum(nil))[:8]
+	if len(tableName)+len(suffix) > maxTableNameLength {
+		tableName = tableName[:maxTableNameLength-len(suffix)]
+	}
+	return tableName + suffix
+}
+
+// CleanupBackupTables drops the backup tables created more than MIGRATION_BACKUP_RETENTION_DAYS ago
+func CleanupBackupTables(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if !db.HasTable(&MigrationBackup{}) {
+		return nil
+	}
+	retentionDays := basicRes.GetConfigReader().GetInt("MIGRATION_BACKUP_RETENTION_DAYS")
+	var backups []MigrationBackup
+	err := db.All(&backups, dal.Where("created_at < ?", time.Now().AddDate(0, 0, -retentionDays)))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load the expired migration backups")
+	}
+	for _, backup := range backups {
+		err = db.DropTables(backup.BackupTable)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to drop the backup table [%s]", backup.BackupTable))
+		}
+		err = db.Delete(&MigrationBackup{}, dal.Where("backup_table = ?", backup.BackupTable))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to delete the record of backup table [%s]", backup.BackupTable))
+		}
+		basicRes.GetLogger().Info("backup table [%s] of [%s] has been dropped", backup.BackupTable, backup.SourceTable)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationhelper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackupTable(t *testing.T) {
+	longTableName := "_tool_" + strings.Repeat("x", 70)
+	for _, tc := range []struct {
+		name         string
+		enabled      bool
+		tableName    string
+		tableExists  bool
+		backupExists bool
+	}{
+		{name: "disabled", enabled: false, tableName: TestTableNameSrc, tableExists: true},
+		{name: "table doesn't exist", enabled: true, tableName: TestTableNameSrc, tableExists: false},
+		{name: "first backup", enabled: true, tableName: TestTableNameSrc, tableExists: true},
+		{name: "previous backup replaced", enabled: true, tableName: TestTableNameSrc, tableExists: true, backupExists: true},
+		{name: "long table name", enabled: true, tableName: longTableName, tableExists: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			backupTableName := GetBackupTableName(&TestScript{}, tc.tableName)
+			assert.LessOrEqual(t, len(backupTableName), maxTableNameLength)
+			assert.Contains(t, backupTableName, "_bak_")
+
+			mockDal := new(mockdal.Dal)
+			if tc.enabled {
+				mockDal.On("HasTable", tc.tableName).Return(tc.tableExists).Once()
+			}
+			if tc.enabled && tc.tableExists {
+				mockDal.On("AutoMigrate", &MigrationBackup{}, mock.Anything).Return(nil).Once()
+				mockDal.On("HasTable", backupTableName).Return(tc.backupExists).Once()
+				if tc.backupExists {
+					mockDal.On("DropTables", []interface{}{backupTableName}).Return(nil).Once()
+				}
+				mockDal.On(
+					"Exec",
+					"CREATE TABLE ? AS SELECT * FROM ?",
+					[]interface{}{dal.ClauseTable{Name: backupTableName}, dal.ClauseTable{Name: tc.tableName}},
+				).Return(nil).Once()
+				mockDal.On("CreateOrUpdate", mock.MatchedBy(func(backup *MigrationBackup) bool {
+					return backup.BackupTable == backupTableName && backup.SourceTable == tc.tableName
+				}), mock.Anything).Return(nil).Once()
+			}
+
+			cfg := viper.New()
+			cfg.Set("ENABLE_MIGRATION_BACKUP", tc.enabled)
+			mockRes := new(mockcontext.BasicRes)
+			mockRes.On("GetDal").Return(mockDal)
+			mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+			mockRes.On("GetConfigReader").Return(cfg)
+
+			assert.Nil(t, BackupTable(mockRes, &TestScript{}, tc.tableName))
+			mockDal.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCleanupBackupTables(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("HasTable", &MigrationBackup{}).Return(true).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]MigrationBackup) = []MigrationBackup{
+			{BackupTable: "issues_bak_0123abcd", SourceTable: "issues"},
+		}
+	}).Return(nil).Once()
+	mockDal.On("DropTables", []interface{}{"issues_bak_0123abcd"}).Return(nil).Once()
+	mockDal.On("Delete", &MigrationBackup{}, mock.Anything).Return(nil).Once()
+
+	cfg := viper.New()
+	cfg.Set("MIGRATION_BACKUP_RETENTION_DAYS", 7)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockRes.On("GetConfigReader").Return(cfg)
+
+	assert.Nil(t, CleanupBackupTables(mockRes))
+	mockDal.AssertExpectations(t)
+}
@@ -52,8 +52,31 @@ func ChangeColumnsType[D any](
 	tableName string,
 	columns []string,
 	update func(tmpColumnParams []interface{}) errors.Error,
+) (err errors.Error) {
+	err = BackupTable(basicRes, script, tableName)
+	if err != nil {
+		return err
+	}
+	return changeColumnsType[D](basicRes, script, tableName, columns, update)
+}
+
+func changeColumnsType[D any](
+	basicRes context.BasicRes,
+	script plugin.MigrationScript,
+	tableName string,
+	columns []string,
+	update func(tmpColumnParams []interface{}) errors.Error,
 ) (err errors.Error) {
 	db := basicRes.GetDal()
+	// sqlite can not drop a primary key column, and since its columns are dynamically typed
+	// there is nothing to change but the indexes declared by D and the data itself
+	if db.Dialect() == "sqlite" {
+		err = db.AutoMigrate(new(D), dal.From(tableName))
+		if err != nil {
+			return err
+		}
+		return updateColumnsInPlace(columns, update)
+	}
 	tmpColumnsNames := make([]string, len(columns))
 	for i, v := range columns {
 		tmpColumnsNames[i] = fmt.Sprintf("%s_%s", v, hashScript(script))
@@ -118,6 +141,18 @@ func ChangeColumnsType[D any](
 	return nil
 }
 
+// updateColumnsInPlace applies the update with the columns themselves as the source of the data
+func updateColumnsInPlace(columns []string, update func(tmpColumnParams []interface{}) errors.Error) errors.Error {
+	if update == nil {
+		return nil
+	}
+	params := make([]interface{}, 0, len(columns))
+	for _, v := range columns {
+		params = append(params, dal.ClauseColumn{Name: v})
+	}
+	return update(params)
+}
+
 // ChangeColumnsType change the type of specified columns for the table
 func ChangePrimaryKeyColumnsType[D any](
 	basicRes context.BasicRes,
@@ -135,6 +170,15 @@ func ChangePrimaryKeyColumnsType[D any](
 	if errParse != nil {
 		return errors.Convert(errParse)
 	}
+	// sqlite can not drop the primary key, and since its columns are dynamically typed
+	// there is nothing to change but the data itself
+	if db.Dialect() == "sqlite" {
+		return updateColumnsInPlace(TargetPriColumns, update)
+	}
+	err = BackupTable(basicRes, script, tableName)
+	if err != nil {
+		return err
+	}
 	// Delete the primary key
 	if u.Scheme == "mysql" {
 		sql := fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY", tableName)
@@ -148,7 +192,7 @@ func ChangePrimaryKeyColumnsType[D any](
 		}
 	}
 	// Change the type of the primary key
-	err = ChangeColumnsType[D](basicRes, script, tableName, TargetPriColumns, update)
+	err = changeColumnsType[D](basicRes, script, tableName, TargetPriColumns, update)
 	if err != nil {
 		return err
 	}
@@ -239,6 +283,11 @@ func TransformTable[S any, D any](
 		return errors.Default.Wrap(err, "failed to check PrimarykeyIsAutoIncrement on TransformTable")
 	}
 
+	err = BackupTable(basicRes, script, tableName)
+	if err != nil {
+		return err
+	}
+
 	// rename the src to tmp in case of failure
 	err = db.RenameTable(tableName, tmpTableName)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -143,6 +144,7 @@ func TestTransformTable(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfigReader").Return(viper.New())
 
 	err := TransformTable(mockRes, &TestScript{}, TestTableNameSrc,
 		func(src *TestSrcTable) (*TestDstTable, errors.Error) {
@@ -214,6 +216,7 @@ func TestTransformTable_RollBack(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfigReader").Return(viper.New())
 
 	err := TransformTable(mockRes, &TestScript{}, TestTableNameSrc,
 		func(src *TestSrcTable) (*TestDstTable, errors.Error) {
@@ -400,6 +403,7 @@ func TestTransformColumns(t *testing.T) {
 	mockRows.On("Close").Return(nil).Twice()
 
 	mockDal := new(mockdal.Dal)
+	mockDal.On("Dialect").Return("mysql").Maybe()
 	mockDal.On("Cursor", mock.Anything).Return(mockRows, nil).Once()
 	mockDal.On("GetPrimaryKeyFields", mock.Anything).Return(
 		[]reflect.StructField{
@@ -470,6 +474,7 @@ func TestTransformColumns(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfigReader").Return(viper.New())
 
 	err := TransformColumns(mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -494,6 +499,7 @@ func TestTransformColumns_RollBack(t *testing.T) {
 	mockRows.On("Close").Return(nil).Twice()
 
 	mockDal := new(mockdal.Dal)
+	mockDal.On("Dialect").Return("mysql").Maybe()
 	mockDal.On("Cursor", mock.Anything).Return(mockRows, nil).Once()
 	mockDal.On("GetPrimaryKeyFields", mock.Anything).Return(
 		[]reflect.StructField{
@@ -547,6 +553,7 @@ func TestTransformColumns_RollBack(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfigReader").Return(viper.New())
 
 	err := TransformColumns(mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -567,6 +574,7 @@ func TestTransformColumns_RollBack(t *testing.T) {
 
 func TestChangeColumnsType(t *testing.T) {
 	mockDal := new(mockdal.Dal)
+	mockDal.On("Dialect").Return("mysql").Maybe()
 
 	// checking if it Create Drop and Rename the right table
 	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
@@ -598,6 +606,7 @@ func TestChangeColumnsType(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfigReader").Return(viper.New())
 
 	err := ChangeColumnsType[TestDstTable](mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -616,6 +625,7 @@ func TestChangeColumnsType(t *testing.T) {
 
 func TestChangeColumnsType_Rollback(t *testing.T) {
 	mockDal := new(mockdal.Dal)
+	mockDal.On("Dialect").Return("mysql").Maybe()
 
 	// checking if it AutoMigrate and Rename the right table
 	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
@@ -660,6 +670,7 @@ func TestChangeColumnsType_Rollback(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfigReader").Return(viper.New())
 
 	err := ChangeColumnsType[TestDstTable](mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
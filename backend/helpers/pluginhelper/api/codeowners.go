@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// CodeOwnersRule is a single pattern line of a CODEOWNERS file
+type CodeOwnersRule struct {
+	LineNumber int
+	Pattern    string
+	// Owners could be `@user`, `@org/team` or an email address, an empty list means the path is unowned
+	Owners  []string
+	matcher *regexp.Regexp
+}
+
+// NewCodeOwnersRule compiles the gitignore-style pattern of a CODEOWNERS line
+func NewCodeOwnersRule(lineNumber int, pattern string, owners []string) (*CodeOwnersRule, errors.Error) {
+	matcher, err := errors.Convert01(regexp.Compile(codeOwnersPatternToRegex(pattern)))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid CODEOWNERS pattern: %s", pattern))
+	}
+	return &CodeOwnersRule{
+		LineNumber: lineNumber,
+		Pattern:    pattern,
+		Owners:     owners,
+		matcher:    matcher,
+	}, nil
+}
+
+// Match reports whether the file path (relative to the repo root) is covered by the rule
+func (r *CodeOwnersRule) Match(path string) bool {
+	return r.matcher.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// ParseCodeOwners parses the content of a CODEOWNERS file, comments, blank lines, GitLab section
+// headers and lines with invalid patterns are skipped
+func ParseCodeOwners(content string) []*CodeOwnersRule {
+	var rules []*CodeOwnersRule
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		fields := strings.Fields(line)
+		var owners []string
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			owners = append(owners, field)
+		}
+		rule, err := NewCodeOwnersRule(lineNumber, fields[0], owners)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// FindCodeOwnersRule returns the rule applied to the path, the last matching rule takes precedence
+func FindCodeOwnersRule(rules []*CodeOwnersRule, path string) *CodeOwnersRule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Match(path) {
+			return rules[i]
+		}
+	}
+	return nil
+}
+
+func codeOwnersPatternToRegex(pattern string) string {
+	pattern = strings.ReplaceAll(pattern, `\#`, "#")
+	// a pattern with a leading or middle slash is relative to the root, otherwise it matches at any level
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// `dir/*` only matches the direct children of dir
+	directChildren := strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "/**/*")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+		case pattern[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	switch {
+	case directChildren:
+		sb.WriteString("$")
+	case dirOnly:
+		sb.WriteString("/.*$")
+	default:
+		sb.WriteString("(?:/.*)?$")
+	}
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	rules := ParseCodeOwners(`# default owners
+*       @global-owner1 @global-owner2
+
+[Frontend]
+*.js    @js-owner # inline comment
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/scripts/ @doctocat @octo-org/octocats
+/apps/github
+**/logs @octo-org/logs
+`)
+	assert.Len(t, rules, 8)
+	assert.Equal(t, 2, rules[0].LineNumber)
+	assert.Equal(t, []string{"@js-owner"}, rules[1].Owners)
+	assert.Nil(t, rules[6].Owners)
+
+	owners := func(path string) []string {
+		rule := FindCodeOwnersRule(rules, path)
+		if rule == nil {
+			return nil
+		}
+		return rule.Owners
+	}
+	assert.Equal(t, []string{"@global-owner1", "@global-owner2"}, owners("README.md"))
+	assert.Equal(t, []string{"@js-owner"}, owners("src/index.js"))
+	assert.Equal(t, []string{"@octo-org/logs"}, owners("build/logs/out.txt"))
+	assert.Equal(t, []string{"@octo-org/logs"}, owners("deeply/nested/logs/a.txt"))
+	assert.Equal(t, []string{"docs@example.com"}, owners("docs/getting-started.md"))
+	assert.Equal(t, []string{"@global-owner1", "@global-owner2"}, owners("docs/build-app/troubleshooting.md"))
+	assert.Equal(t, []string{"@octocat"}, owners("nested/apps/main.go"))
+	assert.Equal(t, []string{"@doctocat", "@octo-org/octocats"}, owners("scripts/run.sh"))
+	assert.Equal(t, []string{"@global-owner1", "@global-owner2"}, owners("src/scripts/run.sh"))
+	assert.Nil(t, owners("apps/github/main.go"))
+}
@@ -0,0 +1,292 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+)
+
+// the fields of a pipeline or a task a DeploymentRuleCondition can test
+const (
+	DEPLOYMENT_RULE_JOB_NAME      = "jobName"
+	DEPLOYMENT_RULE_PIPELINE_NAME = "pipelineName"
+	DEPLOYMENT_RULE_BRANCH        = "branch"
+	DEPLOYMENT_RULE_TAG           = "tag"
+	DEPLOYMENT_RULE_ENVIRONMENT   = "environment"
+	DEPLOYMENT_RULE_TRIGGER       = "trigger"
+	DEPLOYMENT_RULE_RESULT        = "result"
+)
+
+// the operators of a DeploymentRuleCondition, matches and equals hold when any of the values does,
+// their negations when none does, and equals ignores case
+const (
+	DEPLOYMENT_RULE_MATCHES     = "matches"
+	DEPLOYMENT_RULE_NOT_MATCHES = "notMatches"
+	DEPLOYMENT_RULE_EQUALS      = "equals"
+	DEPLOYMENT_RULE_NOT_EQUALS  = "notEquals"
+	DEPLOYMENT_RULE_EXISTS      = "exists"
+	DEPLOYMENT_RULE_NOT_EXISTS  = "notExists"
+)
+
+type DeploymentRuleCondition struct {
+	Field    string   `json:"field" mapstructure:"field"`
+	Operator string   `json:"operator" mapstructure:"operator"`
+	Values   []string `json:"values" mapstructure:"values"`
+}
+
+// DeploymentRule classifies the pipelines and tasks meeting all its conditions, a rule without conditions matches all
+type DeploymentRule struct {
+	Name       string                     `json:"name" mapstructure:"name"`
+	Conditions []*DeploymentRuleCondition `json:"conditions" mapstructure:"conditions"`
+	// IsDeployment is false for rules excluding pipelines or tasks ahead of broader rules
+	IsDeployment bool `json:"isDeployment" mapstructure:"isDeployment"`
+	// Environment is PRODUCTION, STAGING, TESTING or empty
+	Environment string `json:"environment" mapstructure:"environment"`
+}
+
+// DeploymentCandidate is what the rules know about a pipeline or a task, the fields a tool lacks are left empty
+type DeploymentCandidate struct {
+	JobName      string `json:"jobName"`
+	PipelineName string `json:"pipelineName"`
+	Branch       string `json:"branch"`
+	Tag          string `json:"tag"`
+	Environment  string `json:"environment"`
+	Trigger      string `json:"trigger"`
+	Result       string `json:"result"`
+}
+
+// NewDeploymentCandidate returns the candidate of a pipeline or a task run on the ref with the result, the ref is the
+// Tag when it is flagged as one or lies under refs/tags/ and the Branch otherwise, and the result is upper cased since
+// the tools spell it differently, the callers fill in the names, the environment and the trigger they know
+func NewDeploymentCandidate(ref string, isTag bool, result string) *DeploymentCandidate {
+	candidate := &DeploymentCandidate{Result: strings.ToUpper(result)}
+	if tag, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+		candidate.Tag = tag
+	} else if isTag {
+		candidate.Tag = ref
+	} else {
+		candidate.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	}
+	return candidate
+}
+
+// DeploymentCandidates are the candidates of the pipelines, the tasks and the deployments of a scope by the ids of the
+// cicd_pipelines, cicd_tasks and cicd_deployments they are converted to
+type DeploymentCandidates struct {
+	Pipelines   map[string]*DeploymentCandidate
+	Tasks       map[string]*DeploymentCandidate
+	Deployments map[string]*DeploymentCandidate
+}
+
+func NewDeploymentCandidates() *DeploymentCandidates {
+	return &DeploymentCandidates{
+		Pipelines:   make(map[string]*DeploymentCandidate),
+		Tasks:       make(map[string]*DeploymentCandidate),
+		Deployments: make(map[string]*DeploymentCandidate),
+	}
+}
+
+// Merge adds the candidates of another scope
+func (c *DeploymentCandidates) Merge(other *DeploymentCandidates) {
+	for id, candidate := range other.Pipelines {
+		c.Pipelines[id] = candidate
+	}
+	for id, candidate := range other.Tasks {
+		c.Tasks[id] = candidate
+	}
+	for id, candidate := range other.Deployments {
+		c.Deployments[id] = candidate
+	}
+}
+
+// DeploymentCandidateSource is implemented by the plugins classifying their pipelines by deployment rules, it describes
+// the pipelines, tasks and deployments of the scope created since the time to the rules the way the plugin does when
+// it collects them, so the rules could be tried against them without collecting them again
+type DeploymentCandidateSource interface {
+	DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*DeploymentCandidates, errors.Error)
+}
+
+// DeploymentClassification is the type and the environment given by the first matching rule
+type DeploymentClassification struct {
+	Rule        string `json:"rule"`
+	Type        string `json:"type"`
+	Environment string `json:"environment"`
+}
+
+type deploymentRuleCondition struct {
+	field    string
+	operator string
+	values   []string
+	patterns []*regexp.Regexp
+}
+
+type deploymentRule struct {
+	name           string
+	conditions     []*deploymentRuleCondition
+	classification *DeploymentClassification
+}
+
+// DeploymentRuleEngine evaluates ordered deployment rules, the first matching rule wins
+type DeploymentRuleEngine struct {
+	rules []*deploymentRule
+}
+
+// NewDeploymentRuleEngine validates and compiles the rules, it returns nil when there are none
+func NewDeploymentRuleEngine(rules []*DeploymentRule) (*DeploymentRuleEngine, errors.Error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	engine := &DeploymentRuleEngine{}
+	for i, rule := range rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+		environment := strings.ToUpper(rule.Environment)
+		switch environment {
+		case "", devops.PRODUCTION, devops.STAGING, devops.TESTING:
+		default:
+			return nil, errors.BadInput.New(fmt.Sprintf("%s: unknown environment %s", name, rule.Environment))
+		}
+		compiled := &deploymentRule{
+			name:           name,
+			classification: &DeploymentClassification{Rule: name, Environment: environment},
+		}
+		if rule.IsDeployment {
+			compiled.classification.Type = devops.DEPLOYMENT
+		}
+		for _, condition := range rule.Conditions {
+			c, err := compileDeploymentRuleCondition(condition)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, name)
+			}
+			compiled.conditions = append(compiled.conditions, c)
+		}
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, nil
+}
+
+func compileDeploymentRuleCondition(condition *DeploymentRuleCondition) (*deploymentRuleCondition, errors.Error) {
+	switch condition.Field {
+	case DEPLOYMENT_RULE_JOB_NAME, DEPLOYMENT_RULE_PIPELINE_NAME, DEPLOYMENT_RULE_BRANCH, DEPLOYMENT_RULE_TAG,
+		DEPLOYMENT_RULE_ENVIRONMENT, DEPLOYMENT_RULE_TRIGGER, DEPLOYMENT_RULE_RESULT:
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unknown field %s", condition.Field))
+	}
+	compiled := &deploymentRuleCondition{field: condition.Field, operator: condition.Operator}
+	switch condition.Operator {
+	case DEPLOYMENT_RULE_MATCHES, DEPLOYMENT_RULE_NOT_MATCHES:
+		for _, value := range condition.Values {
+			pattern, err := errors.Convert01(regexp.Compile(value))
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid pattern %s of %s", value, condition.Field))
+			}
+			compiled.patterns = append(compiled.patterns, pattern)
+		}
+	case DEPLOYMENT_RULE_EQUALS, DEPLOYMENT_RULE_NOT_EQUALS:
+		compiled.values = condition.Values
+	case DEPLOYMENT_RULE_EXISTS, DEPLOYMENT_RULE_NOT_EXISTS:
+		return compiled, nil
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unknown operator %s of %s", condition.Operator, condition.Field))
+	}
+	if len(condition.Values) == 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s of %s expects values", condition.Operator, condition.Field))
+	}
+	return compiled, nil
+}
+
+func (c *deploymentRuleCondition) holds(candidate *DeploymentCandidate) bool {
+	var value string
+	switch c.field {
+	case DEPLOYMENT_RULE_JOB_NAME:
+		value = candidate.JobName
+	case DEPLOYMENT_RULE_PIPELINE_NAME:
+		value = candidate.PipelineName
+	case DEPLOYMENT_RULE_BRANCH:
+		value = candidate.Branch
+	case DEPLOYMENT_RULE_TAG:
+		value = candidate.Tag
+	case DEPLOYMENT_RULE_ENVIRONMENT:
+		value = candidate.Environment
+	case DEPLOYMENT_RULE_TRIGGER:
+		value = candidate.Trigger
+	case DEPLOYMENT_RULE_RESULT:
+		value = candidate.Result
+	}
+	switch c.operator {
+	case DEPLOYMENT_RULE_EXISTS:
+		return value != ""
+	case DEPLOYMENT_RULE_NOT_EXISTS:
+		return value == ""
+	}
+	found := false
+	for _, pattern := range c.patterns {
+		if pattern.MatchString(value) {
+			found = true
+			break
+		}
+	}
+	for _, v := range c.values {
+		if strings.EqualFold(v, value) {
+			found = true
+			break
+		}
+	}
+	if c.operator == DEPLOYMENT_RULE_NOT_MATCHES || c.operator == DEPLOYMENT_RULE_NOT_EQUALS {
+		return !found
+	}
+	return found
+}
+
+// Match returns the classification of the first rule the candidate meets, or nil, a nil candidate meets none
+func (e *DeploymentRuleEngine) Match(candidate *DeploymentCandidate) *DeploymentClassification {
+	if e == nil || candidate == nil {
+		return nil
+	}
+	for _, rule := range e.rules {
+		matched := true
+		for _, condition := range rule.conditions {
+			if !condition.holds(candidate) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.classification
+		}
+	}
+	return nil
+}
+
+// Classify returns the type and the environment of the first rule the candidate meets, or the fallbacks given by
+// the deploymentPattern and productionPattern of the scope config when no rule does
+func (e *DeploymentRuleEngine) Classify(candidate *DeploymentCandidate, fallbackType, fallbackEnvironment string) (string, string) {
+	if classification := e.Match(candidate); classification != nil {
+		return classification.Type, classification.Environment
+	}
+	return fallbackType, fallbackEnvironment
+}
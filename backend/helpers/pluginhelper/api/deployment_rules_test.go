@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentRuleEngine(t *testing.T) {
+	engine, err := NewDeploymentRuleEngine([]*DeploymentRule{
+		{
+			Name: "skip dry runs",
+			Conditions: []*DeploymentRuleCondition{
+				{Field: DEPLOYMENT_RULE_JOB_NAME, Operator: DEPLOYMENT_RULE_MATCHES, Values: []string{"(?i)dry.?run"}},
+			},
+		},
+		{
+			Name: "release tags",
+			Conditions: []*DeploymentRuleCondition{
+				{Field: DEPLOYMENT_RULE_JOB_NAME, Operator: DEPLOYMENT_RULE_MATCHES, Values: []string{"deploy", "release"}},
+				{Field: DEPLOYMENT_RULE_TAG, Operator: DEPLOYMENT_RULE_MATCHES, Values: []string{`^v\d+`}},
+				{Field: DEPLOYMENT_RULE_TRIGGER, Operator: DEPLOYMENT_RULE_NOT_EQUALS, Values: []string{"schedule"}},
+			},
+			IsDeployment: true,
+			Environment:  "production",
+		},
+		{
+			Name: "main branch",
+			Conditions: []*DeploymentRuleCondition{
+				{Field: DEPLOYMENT_RULE_JOB_NAME, Operator: DEPLOYMENT_RULE_MATCHES, Values: []string{"deploy"}},
+				{Field: DEPLOYMENT_RULE_BRANCH, Operator: DEPLOYMENT_RULE_EQUALS, Values: []string{"main", "master"}},
+				{Field: DEPLOYMENT_RULE_TAG, Operator: DEPLOYMENT_RULE_NOT_EXISTS},
+			},
+			IsDeployment: true,
+			Environment:  devops.STAGING,
+		},
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, engine.Match(&DeploymentCandidate{JobName: "build"}))
+	assert.Equal(t, &DeploymentClassification{Rule: "skip dry runs"}, engine.Match(&DeploymentCandidate{JobName: "deploy dry-run", Tag: "v1.0.0"}))
+	assert.Equal(t, &DeploymentClassification{Rule: "release tags", Type: devops.DEPLOYMENT, Environment: devops.PRODUCTION},
+		engine.Match(&DeploymentCandidate{JobName: "deploy", Tag: "v1.0.0", Trigger: "push"}))
+	assert.Nil(t, engine.Match(&DeploymentCandidate{JobName: "deploy", Tag: "v1.0.0", Trigger: "SCHEDULE"}))
+	assert.Equal(t, "main branch", engine.Match(&DeploymentCandidate{JobName: "deploy", Branch: "Main"}).Rule)
+	assert.Nil(t, engine.Match(nil))
+
+	deploymentType, environment := engine.Classify(&DeploymentCandidate{JobName: "build"}, devops.DEPLOYMENT, devops.PRODUCTION)
+	assert.Equal(t, devops.DEPLOYMENT, deploymentType)
+	assert.Equal(t, devops.PRODUCTION, environment)
+	deploymentType, environment = engine.Classify(&DeploymentCandidate{JobName: "deploy dryrun"}, devops.DEPLOYMENT, devops.PRODUCTION)
+	assert.Equal(t, "", deploymentType)
+	assert.Equal(t, "", environment)
+
+	// without rules the fallbacks are kept
+	var none *DeploymentRuleEngine
+	none, err = NewDeploymentRuleEngine(nil)
+	assert.Nil(t, err)
+	deploymentType, environment = none.Classify(&DeploymentCandidate{JobName: "deploy"}, devops.DEPLOYMENT, "")
+	assert.Equal(t, devops.DEPLOYMENT, deploymentType)
+	assert.Equal(t, "", environment)
+}
+
+func TestNewDeploymentRuleEngineInvalid(t *testing.T) {
+	for _, rule := range []*DeploymentRule{
+		{Environment: "qa"},
+		{Conditions: []*DeploymentRuleCondition{{Field: "author", Operator: DEPLOYMENT_RULE_EXISTS}}},
+		{Conditions: []*DeploymentRuleCondition{{Field: DEPLOYMENT_RULE_BRANCH, Operator: "like", Values: []string{"main"}}}},
+		{Conditions: []*DeploymentRuleCondition{{Field: DEPLOYMENT_RULE_BRANCH, Operator: DEPLOYMENT_RULE_MATCHES, Values: []string{"("}}}},
+		{Conditions: []*DeploymentRuleCondition{{Field: DEPLOYMENT_RULE_BRANCH, Operator: DEPLOYMENT_RULE_EQUALS}}},
+	} {
+		_, err := NewDeploymentRuleEngine([]*DeploymentRule{rule})
+		assert.NotNil(t, err)
+	}
+}
+
+func TestNewDeploymentCandidate(t *testing.T) {
+	assert.Equal(t, &DeploymentCandidate{Branch: "main", Result: "SUCCESS"}, NewDeploymentCandidate("refs/heads/main", false, "success"))
+	assert.Equal(t, &DeploymentCandidate{Branch: "main", Result: "FAILED"}, NewDeploymentCandidate("main", false, "Failed"))
+	assert.Equal(t, &DeploymentCandidate{Tag: "v1.0.0"}, NewDeploymentCandidate("refs/tags/v1.0.0", false, ""))
+	assert.Equal(t, &DeploymentCandidate{Tag: "v1.0.0", Result: "SUCCESS"}, NewDeploymentCandidate("v1.0.0", true, "SUCCESS"))
+}
+
+func TestDeploymentCandidatesMerge(t *testing.T) {
+	candidates := NewDeploymentCandidates()
+	candidates.Pipelines["github:GithubRun:1:1:1"] = &DeploymentCandidate{PipelineName: "build"}
+	other := NewDeploymentCandidates()
+	other.Pipelines["jenkins:JenkinsBuild:1:deploy#1"] = &DeploymentCandidate{JobName: "deploy", PipelineName: "deploy#1"}
+	other.Tasks["jenkins:JenkinsBuild:1:deploy#1"] = &DeploymentCandidate{JobName: "deploy", PipelineName: "deploy#1"}
+	other.Deployments["bamboo:bambooDeployBuildEx:1:1"] = &DeploymentCandidate{Environment: "prod"}
+	candidates.Merge(other)
+
+	assert.Len(t, candidates.Pipelines, 2)
+	assert.Equal(t, "deploy", candidates.Tasks["jenkins:JenkinsBuild:1:deploy#1"].JobName)
+	assert.Equal(t, "prod", candidates.Deployments["bamboo:bambooDeployBuildEx:1:1"].Environment)
+}
@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// ICalHoliday is a day covered by an event of an iCalendar file
+type ICalHoliday struct {
+	// Date is the local date in YYYY-MM-DD
+	Date string
+	Name string
+}
+
+// ParseICalHolidays reads the VEVENTs of an iCalendar (RFC 5545) file, e.g. a public holiday calendar,
+// and returns every day they cover. DTEND is exclusive, so an event ending at midnight doesn't cover that day,
+// events without DTEND last a day.
+func ParseICalHolidays(r io.Reader) ([]*ICalHoliday, errors.Error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var holidays []*ICalHoliday
+	var inEvent bool
+	var name string
+	var start, end *time.Time
+	for _, line := range lines {
+		property, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		// drop the parameters, e.g. DTSTART;VALUE=DATE:20241225
+		property, _, _ = strings.Cut(property, ";")
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, name, start, end = true, "", nil, nil
+			}
+		case "SUMMARY":
+			name = unescapeICalText(value)
+		case "DTSTART":
+			start, err = parseICalDate(value)
+		case "DTEND":
+			end, err = parseICalDate(value)
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start == nil {
+				return nil, errors.BadInput.New(fmt.Sprintf("event %s has no DTSTART", name))
+			}
+			last := *start
+			if end != nil && end.After(*start) {
+				last = *end
+				if last.Equal(icalDay(last)) {
+					last = last.AddDate(0, 0, -1)
+				}
+			}
+			for day := icalDay(*start); !day.After(last); day = day.AddDate(0, 0, 1) {
+				holidays = append(holidays, &ICalHoliday{Date: day.Format(time.DateOnly), Name: name})
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return holidays, nil
+}
+
+// unfoldICalLines joins the continuation lines, which start with a space or a tab
+func unfoldICalLines(r io.Reader) ([]string, errors.Error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.BadInput.Wrap(err, "error reading iCalendar file")
+	}
+	return lines, nil
+}
+
+// parseICalDate parses a DATE or DATE-TIME value as its wall clock time. The days covered by an event
+// are the ones of the time zone it was written in, so neither TZID nor the UTC suffix are converted.
+func parseICalDate(value string) (*time.Time, errors.Error) {
+	layout := "20060102"
+	if len(value) > len(layout) {
+		layout = "20060102T150405"
+	}
+	if len(value) < len(layout) {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid iCalendar date %s", value))
+	}
+	date, err := time.Parse(layout, value[:len(layout)])
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid iCalendar date %s", value))
+	}
+	return &date, nil
+}
+
+// icalDay truncates a time to the midnight starting its day
+func icalDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func unescapeICalText(text string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
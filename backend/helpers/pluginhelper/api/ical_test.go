@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseICalHolidays(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20241225",
+		"DTEND;VALUE=DATE:20241227",
+		"SUMMARY:Christmas Day\\, Boxing",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250101T000000Z",
+		"SUMMARY:New Year",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Europe/Berlin:20250217T140000",
+		"DTEND;TZID=Europe/Berlin:20250219T120000",
+		"SUMMARY:Offsite",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250303T220000Z",
+		"DTEND:20250304T000000Z",
+		"SUMMARY:Maintenance",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	holidays, err := ParseICalHolidays(strings.NewReader(ics))
+	assert.Nil(t, err)
+	assert.Equal(t, []*ICalHoliday{
+		{Date: "2024-12-25", Name: "Christmas Day, Boxing Day"},
+		{Date: "2024-12-26", Name: "Christmas Day, Boxing Day"},
+		{Date: "2025-01-01", Name: "New Year"},
+		{Date: "2025-02-17", Name: "Offsite"},
+		{Date: "2025-02-18", Name: "Offsite"},
+		{Date: "2025-02-19", Name: "Offsite"},
+		{Date: "2025-03-03", Name: "Maintenance"},
+	}, holidays)
+
+	_, err = ParseICalHolidays(strings.NewReader("BEGIN:VEVENT\nSUMMARY:No date\nEND:VEVENT\n"))
+	assert.NotNil(t, err)
+	_, err = ParseICalHolidays(strings.NewReader("BEGIN:VEVENT\nDTSTART:2024\nEND:VEVENT\n"))
+	assert.NotNil(t, err)
+	_, err = ParseICalHolidays(strings.NewReader("BEGIN:VEVENT\nDTSTART:20240101T09\nEND:VEVENT\n"))
+	assert.NotNil(t, err)
+}
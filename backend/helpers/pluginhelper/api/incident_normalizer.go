@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// DefaultIncidentSeverityMappings maps the severity, priority and urgency vocabularies of the common incident tools
+var DefaultIncidentSeverityMappings = map[string]string{
+	"p1": ticket.INCIDENT_SEVERITY_CRITICAL, "sev1": ticket.INCIDENT_SEVERITY_CRITICAL, "sev-1": ticket.INCIDENT_SEVERITY_CRITICAL,
+	"critical": ticket.INCIDENT_SEVERITY_CRITICAL, "blocker": ticket.INCIDENT_SEVERITY_CRITICAL, "highest": ticket.INCIDENT_SEVERITY_CRITICAL,
+	"p2": ticket.INCIDENT_SEVERITY_HIGH, "sev2": ticket.INCIDENT_SEVERITY_HIGH, "sev-2": ticket.INCIDENT_SEVERITY_HIGH,
+	"high": ticket.INCIDENT_SEVERITY_HIGH, "major": ticket.INCIDENT_SEVERITY_HIGH,
+	"p3": ticket.INCIDENT_SEVERITY_MEDIUM, "sev3": ticket.INCIDENT_SEVERITY_MEDIUM, "sev-3": ticket.INCIDENT_SEVERITY_MEDIUM,
+	"medium": ticket.INCIDENT_SEVERITY_MEDIUM, "moderate": ticket.INCIDENT_SEVERITY_MEDIUM,
+	"p4": ticket.INCIDENT_SEVERITY_LOW, "sev4": ticket.INCIDENT_SEVERITY_LOW, "sev-4": ticket.INCIDENT_SEVERITY_LOW,
+	"p5": ticket.INCIDENT_SEVERITY_LOW, "sev5": ticket.INCIDENT_SEVERITY_LOW, "sev-5": ticket.INCIDENT_SEVERITY_LOW,
+	"low": ticket.INCIDENT_SEVERITY_LOW, "minor": ticket.INCIDENT_SEVERITY_LOW, "lowest": ticket.INCIDENT_SEVERITY_LOW,
+	"info": ticket.INCIDENT_SEVERITY_LOW,
+}
+
+// IncidentNormalizationRules customizes how incidents from different tools are normalized
+type IncidentNormalizationRules struct {
+	// SeverityMappings maps original severity/priority/urgency values (case-insensitive) to INCIDENT_SEVERITY_*,
+	// they take precedence over DefaultIncidentSeverityMappings
+	SeverityMappings map[string]string `json:"severityMappings" mapstructure:"severityMappings"`
+	// CustomerImpactingPattern is matched against the title, description and component of incidents
+	CustomerImpactingPattern string `json:"customerImpactingPattern" mapstructure:"customerImpactingPattern"`
+	// CustomerImpactingSeverities flags incidents with these normalized severities as customer-impacting,
+	// defaults to CRITICAL
+	CustomerImpactingSeverities []string `json:"customerImpactingSeverities" mapstructure:"customerImpactingSeverities"`
+}
+
+// IncidentRulesScopeConfig is implemented by the scope configs of boards whose issues could be incidents
+type IncidentRulesScopeConfig interface {
+	GetIncidentRules() *IncidentNormalizationRules
+}
+
+// IncidentNormalizer fills the normalized fields of incidents
+type IncidentNormalizer struct {
+	severityMappings            map[string]string
+	customerImpactingPattern    *regexp.Regexp
+	customerImpactingSeverities map[string]bool
+}
+
+// NewIncidentNormalizer creates an IncidentNormalizer, rules could be nil to use the defaults
+func NewIncidentNormalizer(rules *IncidentNormalizationRules) (*IncidentNormalizer, errors.Error) {
+	if rules == nil {
+		rules = &IncidentNormalizationRules{}
+	}
+	normalizer := &IncidentNormalizer{
+		severityMappings:            make(map[string]string),
+		customerImpactingSeverities: make(map[string]bool),
+	}
+	for k, v := range DefaultIncidentSeverityMappings {
+		normalizer.severityMappings[k] = v
+	}
+	for k, v := range rules.SeverityMappings {
+		normalizer.severityMappings[strings.ToLower(strings.TrimSpace(k))] = strings.ToUpper(v)
+	}
+	if rules.CustomerImpactingPattern != "" {
+		pattern, err := errors.Convert01(regexp.Compile(rules.CustomerImpactingPattern))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid customerImpactingPattern: %s", rules.CustomerImpactingPattern))
+		}
+		normalizer.customerImpactingPattern = pattern
+	}
+	severities := rules.CustomerImpactingSeverities
+	if len(severities) == 0 {
+		severities = []string{ticket.INCIDENT_SEVERITY_CRITICAL}
+	}
+	for _, severity := range severities {
+		normalizer.customerImpactingSeverities[strings.ToUpper(severity)] = true
+	}
+	return normalizer, nil
+}
+
+// Normalize sets StdSeverity, AffectedService and IsCustomerImpacting of the incident, the service name is used as
+// the affected service when the incident has no component
+func (n *IncidentNormalizer) Normalize(incident *ticket.Incident, serviceName string) {
+	incident.StdSeverity = ""
+	// severity is the most specific one, then priority and urgency
+	for _, value := range []string{incident.Severity, incident.Priority, incident.Urgency} {
+		if stdSeverity, ok := n.severityMappings[strings.ToLower(strings.TrimSpace(value))]; ok {
+			incident.StdSeverity = stdSeverity
+			break
+		}
+	}
+	incident.AffectedService = incident.Component
+	if incident.AffectedService == "" {
+		incident.AffectedService = serviceName
+	}
+	incident.IsCustomerImpacting = n.customerImpactingSeverities[incident.StdSeverity]
+	if !incident.IsCustomerImpacting && n.customerImpactingPattern != nil {
+		for _, text := range []string{incident.Title, incident.Description, incident.Component} {
+			if n.customerImpactingPattern.MatchString(text) {
+				incident.IsCustomerImpacting = true
+				break
+			}
+		}
+	}
+}
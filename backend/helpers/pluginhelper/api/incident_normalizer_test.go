@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncidentNormalizer(t *testing.T) {
+	normalizer, err := NewIncidentNormalizer(&IncidentNormalizationRules{
+		SeverityMappings:         map[string]string{"Urgent": "high"},
+		CustomerImpactingPattern: "(?i)customer|checkout",
+	})
+	assert.Nil(t, err)
+
+	pagerduty := &ticket.Incident{Priority: "P1", Urgency: "high"}
+	normalizer.Normalize(pagerduty, "Payment Service")
+	assert.Equal(t, ticket.INCIDENT_SEVERITY_CRITICAL, pagerduty.StdSeverity)
+	assert.Equal(t, "Payment Service", pagerduty.AffectedService)
+	assert.True(t, pagerduty.IsCustomerImpacting)
+
+	webhook := &ticket.Incident{Severity: "urgent", Component: "checkout", Title: "Checkout is slow"}
+	normalizer.Normalize(webhook, "webhook board")
+	assert.Equal(t, ticket.INCIDENT_SEVERITY_HIGH, webhook.StdSeverity)
+	assert.Equal(t, "checkout", webhook.AffectedService)
+	assert.True(t, webhook.IsCustomerImpacting)
+
+	internal := &ticket.Incident{Priority: "P4", Title: "Nightly job failed"}
+	normalizer.Normalize(internal, "Batch")
+	assert.Equal(t, ticket.INCIDENT_SEVERITY_LOW, internal.StdSeverity)
+	assert.False(t, internal.IsCustomerImpacting)
+
+	unknown := &ticket.Incident{Priority: "whatever"}
+	normalizer.Normalize(unknown, "")
+	assert.Equal(t, "", unknown.StdSeverity)
+
+	_, err = NewIncidentNormalizer(&IncidentNormalizationRules{CustomerImpactingPattern: "("})
+	assert.NotNil(t, err)
+}
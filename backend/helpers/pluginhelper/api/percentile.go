@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// NearestRank returns the p-th percentile of the ascending sorted values by the nearest-rank method,
+// i.e. the smallest value which at least p percent of the values are less than or equal to, or nil if there are none
+func NearestRank[T constraints.Integer | constraints.Float](sorted []T, p float64) *T {
+	if len(sorted) == 0 {
+		return nil
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	value := sorted[rank-1]
+	return &value
+}
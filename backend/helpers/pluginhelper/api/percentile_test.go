@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearestRank(t *testing.T) {
+	assert.Nil(t, NearestRank([]float64{}, 50))
+	assert.Equal(t, 15.0, *NearestRank([]float64{15, 20, 35, 40, 50}, 5))
+	assert.Equal(t, 20.0, *NearestRank([]float64{15, 20, 35, 40, 50}, 30))
+	assert.Equal(t, 35.0, *NearestRank([]float64{15, 20, 35, 40, 50}, 50))
+	assert.Equal(t, 50.0, *NearestRank([]float64{15, 20, 35, 40, 50}, 100))
+	assert.Equal(t, int64(3), *NearestRank([]int64{1, 2, 3, 4}, 75))
+	assert.Equal(t, 1, *NearestRank([]int{1, 2, 3, 4}, 0))
+}
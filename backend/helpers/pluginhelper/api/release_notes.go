@@ -0,0 +1,282 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// DefaultReleaseNotesTemplate renders Markdown release notes, used by projects without a template
+const DefaultReleaseNotesTemplate = `## {{ .Name }}{{ if .FinishedDate }} ({{ .FinishedDate.Format "2006-01-02" }}){{ end }}
+{{ range .IssueGroups }}
+### {{ .Type }}
+
+{{ range .Issues }}- {{ if .Url }}[{{ .IssueKey }}]({{ .Url }}){{ else }}{{ .IssueKey }}{{ end }} {{ .Title }}
+{{ end }}{{ end }}{{ if .PullRequests }}
+### Pull Requests
+
+{{ range .PullRequests }}- {{ if .Url }}[#{{ .PullRequestKey }}]({{ .Url }}){{ else }}#{{ .PullRequestKey }}{{ end }} {{ .Title }} by {{ .AuthorName }}
+{{ end }}{{ end }}{{ if .Commits }}
+### Commits
+
+{{ range .Commits }}- {{ shortSha .Sha }} {{ firstLine .Message }}
+{{ end }}{{ end }}`
+
+// releaseNotesIssueTypes are listed first, other issue types follow in alphabetical order
+var releaseNotesIssueTypes = []string{ticket.REQUIREMENT, ticket.BUG, ticket.INCIDENT}
+
+// ReleaseNotes is what shipped with a deployment commit since the previous successful deployment commit of its scope
+type ReleaseNotes struct {
+	DeploymentCommitId     string                     `json:"deploymentCommitId"`
+	PrevDeploymentCommitId string                     `json:"prevDeploymentCommitId"`
+	Name                   string                     `json:"name"`
+	Environment            string                     `json:"environment"`
+	RepoUrl                string                     `json:"repoUrl"`
+	CommitSha              string                     `json:"commitSha"`
+	PrevCommitSha          string                     `json:"prevCommitSha"`
+	FinishedDate           *time.Time                 `json:"finishedDate"`
+	Commits                []*ReleaseNotesCommit      `json:"commits"`
+	PullRequests           []*ReleaseNotesPullRequest `json:"pullRequests"`
+	IssueGroups            []*ReleaseNotesIssueGroup  `json:"issueGroups"`
+}
+
+type ReleaseNotesCommit struct {
+	Sha          string    `json:"sha"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"authorName"`
+	AuthoredDate time.Time `json:"authoredDate"`
+}
+
+type ReleaseNotesPullRequest struct {
+	Id             string     `json:"id"`
+	PullRequestKey int        `json:"pullRequestKey"`
+	Title          string     `json:"title"`
+	Url            string     `json:"url"`
+	AuthorName     string     `json:"authorName"`
+	MergedDate     *time.Time `json:"mergedDate"`
+}
+
+type ReleaseNotesIssue struct {
+	Id             string   `json:"id"`
+	IssueKey       string   `json:"issueKey"`
+	Title          string   `json:"title"`
+	Url            string   `json:"url"`
+	Type           string   `json:"type"`
+	Status         string   `json:"status"`
+	PullRequestIds []string `json:"pullRequestIds"`
+}
+
+type ReleaseNotesIssueGroup struct {
+	Type   string               `json:"type"`
+	Issues []*ReleaseNotesIssue `json:"issues"`
+}
+
+type releaseNotesPrIssue struct {
+	PullRequestId string
+	ReleaseNotesIssue
+}
+
+// BuildReleaseNotes lists the commits between the deployment commit and its previous successful deployment commit,
+// as calculated by refdiff, the pull requests merged by them and the issues linked to those pull requests. The first
+// deployment of a scope has no previous deployment to diff with and lists nothing.
+func BuildReleaseNotes(db dal.Dal, deploymentCommitId string) (*ReleaseNotes, errors.Error) {
+	deploymentCommit := &devops.CicdDeploymentCommit{}
+	err := db.First(deploymentCommit, dal.Where("id = ?", deploymentCommitId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("deployment commit %s not found", deploymentCommitId))
+		}
+		return nil, errors.Default.Wrap(err, "error getting deployment commit")
+	}
+	notes := &ReleaseNotes{
+		DeploymentCommitId:     deploymentCommit.Id,
+		PrevDeploymentCommitId: deploymentCommit.PrevSuccessDeploymentCommitId,
+		Name:                   deploymentCommit.Name,
+		Environment:            deploymentCommit.Environment,
+		RepoUrl:                deploymentCommit.RepoUrl,
+		CommitSha:              deploymentCommit.CommitSha,
+		FinishedDate:           deploymentCommit.FinishedDate,
+	}
+	if notes.PrevDeploymentCommitId == "" {
+		return notes, nil
+	}
+	prev := &devops.CicdDeploymentCommit{}
+	err = db.First(prev, dal.Where("id = ?", notes.PrevDeploymentCommitId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting previous deployment commit")
+	}
+	notes.PrevCommitSha = prev.CommitSha
+
+	err = db.All(
+		&notes.Commits,
+		dal.Select("c.sha, c.message, c.author_name, c.authored_date"),
+		dal.From("commits_diffs cd"),
+		dal.Join("JOIN commits c ON c.sha = cd.commit_sha"),
+		dal.Where("cd.new_commit_sha = ? AND cd.old_commit_sha = ?", notes.CommitSha, notes.PrevCommitSha),
+		dal.Orderby("cd.sorting_index"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting commits of deployment")
+	}
+	if len(notes.Commits) == 0 {
+		return notes, nil
+	}
+	shas := make([]string, len(notes.Commits))
+	for i, commit := range notes.Commits {
+		shas[i] = commit.Sha
+	}
+	err = db.All(
+		&notes.PullRequests,
+		dal.Select("id, pull_request_key, title, url, author_name, merged_date"),
+		dal.From("pull_requests"),
+		dal.Where("merge_commit_sha IN ?", shas),
+		dal.Orderby("merged_date, id"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting pull requests of deployment")
+	}
+	if len(notes.PullRequests) == 0 {
+		return notes, nil
+	}
+	prIds := make([]string, len(notes.PullRequests))
+	for i, pr := range notes.PullRequests {
+		prIds[i] = pr.Id
+	}
+	var prIssues []*releaseNotesPrIssue
+	err = db.All(
+		&prIssues,
+		dal.Select("pri.pull_request_id, i.id, i.issue_key, i.title, i.url, i.type, i.status"),
+		dal.From("pull_request_issues pri"),
+		dal.Join("JOIN issues i ON i.id = pri.issue_id"),
+		dal.Where("pri.pull_request_id IN ?", prIds),
+		dal.Orderby("i.id, pri.pull_request_id"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting issues of deployment")
+	}
+	notes.IssueGroups = groupReleaseNotesIssues(prIssues)
+	return notes, nil
+}
+
+// groupReleaseNotesIssues merges the issues linked to several pull requests and groups them by type
+func groupReleaseNotesIssues(prIssues []*releaseNotesPrIssue) []*ReleaseNotesIssueGroup {
+	issues := make(map[string]*ReleaseNotesIssue)
+	groups := make(map[string]*ReleaseNotesIssueGroup)
+	for _, prIssue := range prIssues {
+		issue, ok := issues[prIssue.Id]
+		if !ok {
+			issue = &prIssue.ReleaseNotesIssue
+			issues[issue.Id] = issue
+			issueType := issue.Type
+			if issueType == "" {
+				issueType = ticket.OTHER
+			}
+			group, ok := groups[issueType]
+			if !ok {
+				group = &ReleaseNotesIssueGroup{Type: issueType}
+				groups[issueType] = group
+			}
+			group.Issues = append(group.Issues, issue)
+		}
+		issue.PullRequestIds = append(issue.PullRequestIds, prIssue.PullRequestId)
+	}
+	types := make([]string, 0, len(groups))
+	for issueType := range groups {
+		types = append(types, issueType)
+	}
+	rank := func(issueType string) int {
+		for i, t := range releaseNotesIssueTypes {
+			if t == issueType {
+				return i
+			}
+		}
+		return len(releaseNotesIssueTypes)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if rank(types[i]) != rank(types[j]) {
+			return rank(types[i]) < rank(types[j])
+		}
+		return types[i] < types[j]
+	})
+	result := make([]*ReleaseNotesIssueGroup, len(types))
+	for i, issueType := range types {
+		result[i] = groups[issueType]
+	}
+	return result
+}
+
+var releaseNotesFuncs = template.FuncMap{
+	"shortSha": func(sha string) string {
+		if len(sha) > 7 {
+			return sha[:7]
+		}
+		return sha
+	},
+	"firstLine": func(s string) string {
+		line, _, _ := strings.Cut(s, "\n")
+		return strings.TrimSpace(line)
+	},
+}
+
+// ParseReleaseNotesTemplate parses a release notes template, which may use the shortSha and firstLine functions
+func ParseReleaseNotesTemplate(text string) (*template.Template, errors.Error) {
+	tmpl, err := template.New("releaseNotes").Funcs(releaseNotesFuncs).Parse(text)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid release notes template")
+	}
+	return tmpl, nil
+}
+
+// RenderReleaseNotes renders the release notes with the template, the default one if empty
+func RenderReleaseNotes(notes *ReleaseNotes, text string) (string, errors.Error) {
+	if text == "" {
+		text = DefaultReleaseNotesTemplate
+	}
+	tmpl, err := ParseReleaseNotesTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	out := &strings.Builder{}
+	if err := tmpl.Execute(out, notes); err != nil {
+		return "", errors.BadInput.Wrap(err, "error rendering release notes")
+	}
+	return out.String(), nil
+}
+
+// LoadReleaseNotesTemplate returns the release notes template of the project, or the default one if it was never set
+func LoadReleaseNotesTemplate(db dal.Dal, projectName string) (string, errors.Error) {
+	tmpl := &models.ProjectReleaseNotesTemplate{}
+	err := db.First(tmpl, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return DefaultReleaseNotesTemplate, nil
+		}
+		return "", errors.Default.Wrap(err, "error getting release notes template")
+	}
+	return tmpl.Template, nil
+}
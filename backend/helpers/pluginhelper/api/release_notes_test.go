@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupReleaseNotesIssues(t *testing.T) {
+	groups := groupReleaseNotesIssues([]*releaseNotesPrIssue{
+		{PullRequestId: "pr1", ReleaseNotesIssue: ReleaseNotesIssue{Id: "i1", Type: "TASK"}},
+		{PullRequestId: "pr1", ReleaseNotesIssue: ReleaseNotesIssue{Id: "i2", Type: "BUG"}},
+		{PullRequestId: "pr2", ReleaseNotesIssue: ReleaseNotesIssue{Id: "i2", Type: "BUG"}},
+		{PullRequestId: "pr2", ReleaseNotesIssue: ReleaseNotesIssue{Id: "i3", Type: "REQUIREMENT"}},
+		{PullRequestId: "pr2", ReleaseNotesIssue: ReleaseNotesIssue{Id: "i4"}},
+	})
+	assert.Len(t, groups, 4)
+	assert.Equal(t, "REQUIREMENT", groups[0].Type)
+	assert.Equal(t, "BUG", groups[1].Type)
+	assert.Equal(t, []string{"pr1", "pr2"}, groups[1].Issues[0].PullRequestIds)
+	assert.Equal(t, "OTHER", groups[2].Type)
+	assert.Equal(t, "TASK", groups[3].Type)
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	finished := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	notes := &ReleaseNotes{
+		Name:         "deploy #42",
+		FinishedDate: &finished,
+		Commits: []*ReleaseNotesCommit{
+			{Sha: "0123456789abcdef", Message: "Fix login\n\nLong description"},
+		},
+		PullRequests: []*ReleaseNotesPullRequest{
+			{PullRequestKey: 7, Title: "Fix login", Url: "https://example.com/pr/7", AuthorName: "alice"},
+		},
+		IssueGroups: []*ReleaseNotesIssueGroup{
+			{Type: "BUG", Issues: []*ReleaseNotesIssue{{IssueKey: "PRJ-1", Title: "Cannot log in"}}},
+		},
+	}
+	markdown, err := RenderReleaseNotes(notes, "")
+	assert.Nil(t, err)
+	assert.Equal(t, `## deploy #42 (2024-03-05)
+
+### BUG
+
+- PRJ-1 Cannot log in
+
+### Pull Requests
+
+- [#7](https://example.com/pr/7) Fix login by alice
+
+### Commits
+
+- 0123456 Fix login
+`, markdown)
+
+	text, err := RenderReleaseNotes(notes, "{{ range .PullRequests }}{{ .Title }}{{ end }}")
+	assert.Nil(t, err)
+	assert.Equal(t, "Fix login", text)
+
+	_, err = RenderReleaseNotes(notes, "{{ .Unknown }}")
+	assert.NotNil(t, err)
+	_, err = ParseReleaseNotesTemplate("{{ range }}")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,534 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+)
+
+// the formats of the test reports
+const (
+	TEST_REPORT_JUNIT     = "JUNIT"
+	TEST_REPORT_TRX       = "TRX"
+	TEST_REPORT_COBERTURA = "COBERTURA"
+)
+
+// the results of the test cases, SUCCESS and FAILED are the statuses of qa_test_case_executions as well
+const (
+	TEST_RESULT_SUCCESS = "SUCCESS"
+	TEST_RESULT_FAILED  = "FAILED"
+	TEST_RESULT_SKIPPED = "SKIPPED"
+)
+
+// TEST_REPORT_PATTERN names the regex of the scope configs matching the artifacts (or jobs) which carry test reports
+const TEST_REPORT_PATTERN = "testReportPattern"
+
+// test report files larger than this are skipped
+const maxTestReportSize = 64 << 20
+
+// test report responses, e.g. artifact archives, larger than this are skipped
+const maxTestReportResponseSize = 256 << 20
+
+// test case names longer than this are shortened by TestCaseKey
+const maxTestCaseKeyLength = 180
+
+// TestReport is a JUnit XML, TRX or Cobertura file found in the artifacts of a CI run
+type TestReport struct {
+	File      string            `json:"file"`
+	Format    string            `json:"format"`
+	TestCases []*TestCaseResult `json:"testCases,omitempty"`
+	Coverage  *TestCoverage     `json:"coverage,omitempty"`
+}
+
+// TestCaseResult is an execution of a test case, retried tests yield one result per attempt
+type TestCaseResult struct {
+	Suite       string     `json:"suite"`
+	ClassName   string     `json:"className"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	DurationSec float64    `json:"durationSec"`
+	StartTime   *time.Time `json:"startTime,omitempty"`
+	Message     string     `json:"message,omitempty"`
+}
+
+// FullName identifies the test case across runs
+func (r *TestCaseResult) FullName() string {
+	if r.ClassName == "" || strings.HasPrefix(r.Name, r.ClassName+".") {
+		return r.Name
+	}
+	return r.ClassName + "." + r.Name
+}
+
+// TestCaseKey returns the full name as the key of the test case, long names are cut and suffixed with their hash
+// to fit in the ids of the domain layer
+func TestCaseKey(fullName string) string {
+	if len(fullName) <= maxTestCaseKeyLength {
+		return fullName
+	}
+	sum := sha1.Sum([]byte(fullName))
+	hash := hex.EncodeToString(sum[:])
+	return fullName[:maxTestCaseKeyLength-len(hash)-1] + "#" + hash
+}
+
+// TestReportSource locates the test reports of a CI run in the domain layer
+type TestReportSource struct {
+	QaProjectId    string
+	CicdPipelineId string
+	CommitSha      string
+}
+
+// ConvertTestCaseResult converts a test case result into a test case of the qa project and its execution, the test
+// case is identified by its full name within the qa project, skipped tests are not executions
+func ConvertTestCaseResult(source *TestReportSource, executionId string, result *TestCaseResult) (*qa.QaTestCase, *qa.QaTestCaseExecution) {
+	if result.Status == TEST_RESULT_SKIPPED {
+		return nil, nil
+	}
+	fullName := result.FullName()
+	var startTime time.Time
+	if result.StartTime != nil {
+		startTime = *result.StartTime
+	}
+	name := fullName
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	testCase := &qa.QaTestCase{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{Id: source.QaProjectId + ":" + TestCaseKey(fullName)},
+		Name:                 name,
+		CreateTime:           startTime,
+		Type:                 "functional",
+		QaProjectId:          source.QaProjectId,
+	}
+	execution := &qa.QaTestCaseExecution{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{Id: executionId},
+		QaProjectId:          source.QaProjectId,
+		QaTestCaseId:         testCase.Id,
+		CreateTime:           startTime,
+		StartTime:            startTime,
+		FinishTime:           startTime.Add(time.Duration(result.DurationSec * float64(time.Second))),
+		Status:               result.Status,
+		CicdPipelineId:       source.CicdPipelineId,
+		CommitSha:            source.CommitSha,
+		DurationSec:          result.DurationSec,
+	}
+	return testCase, execution
+}
+
+// ConvertTestCoverage converts the summary of a Cobertura report into the domain layer
+func ConvertTestCoverage(source *TestReportSource, id string, file string, coverage *TestCoverage, createTime time.Time) *qa.QaTestCoverage {
+	if len(file) > 255 {
+		file = file[len(file)-255:]
+	}
+	return &qa.QaTestCoverage{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{Id: id},
+		QaProjectId:          source.QaProjectId,
+		CicdPipelineId:       source.CicdPipelineId,
+		CommitSha:            source.CommitSha,
+		FileName:             file,
+		LineRate:             coverage.LineRate,
+		BranchRate:           coverage.BranchRate,
+		LinesCovered:         coverage.LinesCovered,
+		LinesValid:           coverage.LinesValid,
+		BranchesCovered:      coverage.BranchesCovered,
+		BranchesValid:        coverage.BranchesValid,
+		CreateTime:           createTime,
+	}
+}
+
+// TestCoverage is the summary of a Cobertura report
+type TestCoverage struct {
+	LineRate        float64 `json:"lineRate"`
+	BranchRate      float64 `json:"branchRate"`
+	LinesCovered    int     `json:"linesCovered"`
+	LinesValid      int     `json:"linesValid"`
+	BranchesCovered int     `json:"branchesCovered"`
+	BranchesValid   int     `json:"branchesValid"`
+}
+
+// IsTestReportFile tells whether the file may be a test report by its extension
+func IsTestReportFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".xml" || ext == ".trx"
+}
+
+// ParseTestReport detects the format of the file by its root element and parses it, it returns nil for XML files
+// which are not test reports
+func ParseTestReport(name string, content []byte) (*TestReport, errors.Error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	var root xml.StartElement
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid XML in %s", name))
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			root = start
+			break
+		}
+	}
+	report := &TestReport{File: name}
+	var err errors.Error
+	switch root.Name.Local {
+	case "testsuites", "testsuite":
+		report.Format = TEST_REPORT_JUNIT
+		report.TestCases, err = parseJUnit(decoder, root)
+	case "TestRun":
+		report.Format = TEST_REPORT_TRX
+		report.TestCases, err = parseTrx(decoder, root)
+	case "coverage":
+		report.Format = TEST_REPORT_COBERTURA
+		report.Coverage, err = parseCobertura(decoder, root)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid %s report %s", report.Format, name))
+	}
+	return report, nil
+}
+
+// ParseTestReportArchive parses the test reports in a zip archive, e.g. a GitHub Actions artifact
+func ParseTestReportArchive(content []byte) ([]*TestReport, errors.Error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid zip archive")
+	}
+	reports := make([]*TestReport, 0)
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !IsTestReportFile(file.Name) || file.UncompressedSize64 > maxTestReportSize {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+		report, parseErr := ParseTestReport(file.Name, data)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		if report != nil {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// ParseTestReportDir parses the test reports in a local directory and its subdirectories, the files are named
+// relatively to the directory, the malformed ones are skipped with a warning
+func ParseTestReportDir(dir string, logger log.Logger) ([]*TestReport, errors.Error) {
+	reports := make([]*TestReport, 0)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !IsTestReportFile(path) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxTestReportSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		report, parseErr := ParseTestReport(filepath.ToSlash(name), data)
+		if parseErr != nil {
+			logger.Warn(parseErr, "skip the malformed test report %s", name)
+			return nil
+		}
+		if report != nil {
+			reports = append(reports, report)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	return reports, nil
+}
+
+// ReadTestReportResponse reads the body of a response carrying test reports, it returns nil for the bodies larger
+// than the limit, which are rejected by their Content-Length or else cut off while reading
+func ReadTestReportResponse(res *http.Response) ([]byte, errors.Error) {
+	defer res.Body.Close()
+	if res.ContentLength > maxTestReportResponseSize {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxTestReportResponseSize+1))
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	if len(body) > maxTestReportResponseSize {
+		return nil, nil
+	}
+	return body, nil
+}
+
+// NewTestReportArchiveParser returns the ResponseParser of the collectors downloading zip archives of test reports,
+// the reports of an archive are kept as one record so the results could be numbered across the reports, and the
+// archives too large or malformed are skipped with a warning
+func NewTestReportArchiveParser(logger log.Logger) func(res *http.Response) ([]json.RawMessage, errors.Error) {
+	return func(res *http.Response) ([]json.RawMessage, errors.Error) {
+		body, err := ReadTestReportResponse(res)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			logger.Warn(nil, "skip the test reports of %s larger than %d bytes", res.Request.URL.Path, maxTestReportResponseSize)
+			return nil, nil
+		}
+		reports, err := ParseTestReportArchive(body)
+		if err != nil {
+			logger.Warn(err, "failed to parse the test reports of %s", res.Request.URL.Path)
+			return nil, nil
+		}
+		if len(reports) == 0 {
+			return nil, nil
+		}
+		raw, err := errors.Convert01(json.Marshal(reports))
+		if err != nil {
+			return nil, err
+		}
+		return []json.RawMessage{raw}, nil
+	}
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (f *junitFailure) message() string {
+	if f.Message != "" {
+		return f.Message
+	}
+	return strings.TrimSpace(f.Text)
+}
+
+type junitTestCase struct {
+	Name          string          `xml:"name,attr"`
+	ClassName     string          `xml:"classname,attr"`
+	Time          string          `xml:"time,attr"`
+	Failures      []*junitFailure `xml:"failure"`
+	Errors        []*junitFailure `xml:"error"`
+	Skipped       *junitFailure   `xml:"skipped"`
+	FlakyFailures []*junitFailure `xml:"flakyFailure"`
+	FlakyErrors   []*junitFailure `xml:"flakyError"`
+}
+
+type junitTestSuite struct {
+	Name       string            `xml:"name,attr"`
+	Timestamp  string            `xml:"timestamp,attr"`
+	TestCases  []*junitTestCase  `xml:"testcase"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+func parseJUnit(decoder *xml.Decoder, root xml.StartElement) ([]*TestCaseResult, errors.Error) {
+	suite := &junitTestSuite{}
+	if err := decoder.DecodeElement(suite, &root); err != nil {
+		return nil, errors.Convert(err)
+	}
+	results := make([]*TestCaseResult, 0)
+	var walk func(suite *junitTestSuite)
+	walk = func(suite *junitTestSuite) {
+		startTime := parseTestReportTime(suite.Timestamp)
+		for _, testCase := range suite.TestCases {
+			result := &TestCaseResult{
+				Suite:       suite.Name,
+				ClassName:   testCase.ClassName,
+				Name:        testCase.Name,
+				Status:      TEST_RESULT_SUCCESS,
+				DurationSec: parseTestReportSeconds(testCase.Time),
+				StartTime:   startTime,
+			}
+			if len(testCase.Failures) > 0 {
+				result.Status = TEST_RESULT_FAILED
+				result.Message = testCase.Failures[0].message()
+			} else if len(testCase.Errors) > 0 {
+				result.Status = TEST_RESULT_FAILED
+				result.Message = testCase.Errors[0].message()
+			} else if testCase.Skipped != nil {
+				result.Status = TEST_RESULT_SKIPPED
+				result.Message = testCase.Skipped.message()
+			}
+			// the attempts failing before a rerun passed, as reported by the maven surefire plugin
+			for _, flaky := range append(testCase.FlakyFailures, testCase.FlakyErrors...) {
+				attempt := *result
+				attempt.Status = TEST_RESULT_FAILED
+				attempt.DurationSec = 0
+				attempt.Message = flaky.message()
+				results = append(results, &attempt)
+			}
+			results = append(results, result)
+		}
+		for _, child := range suite.TestSuites {
+			walk(child)
+		}
+	}
+	walk(suite)
+	return results, nil
+}
+
+type trxUnitTestResult struct {
+	TestId    string `xml:"testId,attr"`
+	TestName  string `xml:"testName,attr"`
+	Outcome   string `xml:"outcome,attr"`
+	Duration  string `xml:"duration,attr"`
+	StartTime string `xml:"startTime,attr"`
+	Message   string `xml:"Output>ErrorInfo>Message"`
+}
+
+type trxUnitTest struct {
+	Id         string `xml:"id,attr"`
+	TestMethod struct {
+		ClassName string `xml:"className,attr"`
+	} `xml:"TestMethod"`
+}
+
+type trxTestRun struct {
+	Name            string               `xml:"name,attr"`
+	Results         []*trxUnitTestResult `xml:"Results>UnitTestResult"`
+	TestDefinitions []*trxUnitTest       `xml:"TestDefinitions>UnitTest"`
+}
+
+func parseTrx(decoder *xml.Decoder, root xml.StartElement) ([]*TestCaseResult, errors.Error) {
+	run := &trxTestRun{}
+	if err := decoder.DecodeElement(run, &root); err != nil {
+		return nil, errors.Convert(err)
+	}
+	classNames := make(map[string]string, len(run.TestDefinitions))
+	for _, test := range run.TestDefinitions {
+		classNames[test.Id] = test.TestMethod.ClassName
+	}
+	results := make([]*TestCaseResult, 0, len(run.Results))
+	for _, testResult := range run.Results {
+		result := &TestCaseResult{
+			Suite:       run.Name,
+			ClassName:   classNames[testResult.TestId],
+			Name:        testResult.TestName,
+			DurationSec: parseTrxDuration(testResult.Duration),
+			StartTime:   parseTestReportTime(testResult.StartTime),
+			Message:     strings.TrimSpace(testResult.Message),
+		}
+		switch testResult.Outcome {
+		case "Passed", "PassedButRunAborted", "Warning":
+			result.Status = TEST_RESULT_SUCCESS
+		case "Failed", "Error", "Timeout", "Aborted":
+			result.Status = TEST_RESULT_FAILED
+		default:
+			result.Status = TEST_RESULT_SKIPPED
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+type coberturaCoverage struct {
+	LineRate        float64 `xml:"line-rate,attr"`
+	BranchRate      float64 `xml:"branch-rate,attr"`
+	LinesCovered    int     `xml:"lines-covered,attr"`
+	LinesValid      int     `xml:"lines-valid,attr"`
+	BranchesCovered int     `xml:"branches-covered,attr"`
+	BranchesValid   int     `xml:"branches-valid,attr"`
+}
+
+func parseCobertura(decoder *xml.Decoder, root xml.StartElement) (*TestCoverage, errors.Error) {
+	coverage := &coberturaCoverage{}
+	if err := decoder.DecodeElement(coverage, &root); err != nil {
+		return nil, errors.Convert(err)
+	}
+	return &TestCoverage{
+		LineRate:        coverage.LineRate,
+		BranchRate:      coverage.BranchRate,
+		LinesCovered:    coverage.LinesCovered,
+		LinesValid:      coverage.LinesValid,
+		BranchesCovered: coverage.BranchesCovered,
+		BranchesValid:   coverage.BranchesValid,
+	}, nil
+}
+
+// parseTestReportSeconds reads the time of a JUnit test case, which some tools format with thousands separators
+func parseTestReportSeconds(text string) float64 {
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(text), ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return seconds
+}
+
+// parseTrxDuration reads a TRX duration like 00:01:02.5000000
+func parseTrxDuration(text string) float64 {
+	parts := strings.Split(text, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	hours, err1 := strconv.Atoi(parts[0])
+	minutes, err2 := strconv.Atoi(parts[1])
+	seconds, err3 := strconv.ParseFloat(parts[2], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0
+	}
+	return float64(hours*3600+minutes*60) + seconds
+}
+
+func parseTestReportTime(text string) *time.Time {
+	if text == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const junitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="utils" timestamp="2026-10-01T08:00:00">
+    <testcase classname="com.example.UtilsTest" name="parses" time="0.5"/>
+    <testcase classname="com.example.UtilsTest" name="formats" time="1,200.25">
+      <failure message="expected 1 but was 2">stack trace</failure>
+    </testcase>
+    <testcase classname="com.example.UtilsTest" name="ignored"><skipped/></testcase>
+    <testsuite name="nested">
+      <testcase classname="com.example.RetryTest" name="com.example.RetryTest.retried" time="2">
+        <flakyFailure message="timeout"/>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+const trxReport = `<?xml version="1.0" encoding="utf-8"?>
+<TestRun id="1" name="api tests" xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+  <Results>
+    <UnitTestResult testId="a" testName="Creates" outcome="Passed" duration="00:00:01.5000000" startTime="2026-10-01T08:00:00.0000000+00:00"/>
+    <UnitTestResult testId="b" testName="Deletes" outcome="Failed" duration="00:01:00">
+      <Output><ErrorInfo><Message>not found</Message></ErrorInfo></Output>
+    </UnitTestResult>
+    <UnitTestResult testId="c" testName="Updates" outcome="NotExecuted"/>
+  </Results>
+  <TestDefinitions>
+    <UnitTest id="a"><TestMethod className="Api.Tests.UserTests"/></UnitTest>
+    <UnitTest id="b"><TestMethod className="Api.Tests.UserTests"/></UnitTest>
+  </TestDefinitions>
+</TestRun>`
+
+const coberturaReport = `<?xml version="1.0" ?>
+<coverage line-rate="0.75" branch-rate="0.5" lines-covered="75" lines-valid="100" branches-covered="5" branches-valid="10">
+  <packages/>
+</coverage>`
+
+func TestParseTestReportJUnit(t *testing.T) {
+	report, err := ParseTestReport("junit.xml", []byte(junitReport))
+	assert.Nil(t, err)
+	assert.Equal(t, TEST_REPORT_JUNIT, report.Format)
+	assert.Len(t, report.TestCases, 5)
+
+	parses := report.TestCases[0]
+	assert.Equal(t, "utils", parses.Suite)
+	assert.Equal(t, "com.example.UtilsTest.parses", parses.FullName())
+	assert.Equal(t, TEST_RESULT_SUCCESS, parses.Status)
+	assert.Equal(t, 0.5, parses.DurationSec)
+	assert.Equal(t, "2026-10-01T08:00:00Z", parses.StartTime.Format("2006-01-02T15:04:05Z07:00"))
+
+	formats := report.TestCases[1]
+	assert.Equal(t, TEST_RESULT_FAILED, formats.Status)
+	assert.Equal(t, "expected 1 but was 2", formats.Message)
+	assert.Equal(t, 1200.25, formats.DurationSec)
+
+	assert.Equal(t, TEST_RESULT_SKIPPED, report.TestCases[2].Status)
+
+	// a rerun which passed after a failed attempt
+	assert.Equal(t, "nested", report.TestCases[3].Suite)
+	assert.Equal(t, "com.example.RetryTest.retried", report.TestCases[3].FullName())
+	assert.Equal(t, TEST_RESULT_FAILED, report.TestCases[3].Status)
+	assert.Equal(t, "timeout", report.TestCases[3].Message)
+	assert.Equal(t, TEST_RESULT_SUCCESS, report.TestCases[4].Status)
+	assert.Equal(t, 2.0, report.TestCases[4].DurationSec)
+}
+
+func TestParseTestReportTrx(t *testing.T) {
+	report, err := ParseTestReport("results.trx", []byte(trxReport))
+	assert.Nil(t, err)
+	assert.Equal(t, TEST_REPORT_TRX, report.Format)
+	assert.Len(t, report.TestCases, 3)
+
+	assert.Equal(t, "Api.Tests.UserTests.Creates", report.TestCases[0].FullName())
+	assert.Equal(t, TEST_RESULT_SUCCESS, report.TestCases[0].Status)
+	assert.Equal(t, 1.5, report.TestCases[0].DurationSec)
+	assert.NotNil(t, report.TestCases[0].StartTime)
+
+	assert.Equal(t, TEST_RESULT_FAILED, report.TestCases[1].Status)
+	assert.Equal(t, 60.0, report.TestCases[1].DurationSec)
+	assert.Equal(t, "not found", report.TestCases[1].Message)
+
+	assert.Equal(t, "Updates", report.TestCases[2].FullName())
+	assert.Equal(t, TEST_RESULT_SKIPPED, report.TestCases[2].Status)
+}
+
+func TestParseTestReportCobertura(t *testing.T) {
+	report, err := ParseTestReport("coverage.xml", []byte(coberturaReport))
+	assert.Nil(t, err)
+	assert.Equal(t, TEST_REPORT_COBERTURA, report.Format)
+	assert.Equal(t, &TestCoverage{
+		LineRate:        0.75,
+		BranchRate:      0.5,
+		LinesCovered:    75,
+		LinesValid:      100,
+		BranchesCovered: 5,
+		BranchesValid:   10,
+	}, report.Coverage)
+}
+
+func TestParseTestReportOthers(t *testing.T) {
+	report, err := ParseTestReport("pom.xml", []byte(`<project><modelVersion>4.0.0</modelVersion></project>`))
+	assert.Nil(t, err)
+	assert.Nil(t, report)
+
+	_, err = ParseTestReport("broken.xml", []byte(`<testsuite><testcase`))
+	assert.NotNil(t, err)
+}
+
+func TestParseTestReportArchive(t *testing.T) {
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"reports/junit.xml":    junitReport,
+		"reports/results.trx":  trxReport,
+		"reports/coverage.xml": coberturaReport,
+		"reports/pom.xml":      `<project/>`,
+		"reports/output.log":   "not a report",
+	} {
+		w, err := archive.Create(name)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, archive.Close())
+
+	reports, err := ParseTestReportArchive(buf.Bytes())
+	assert.Nil(t, err)
+	formats := make(map[string]string)
+	for _, report := range reports {
+		formats[report.File] = report.Format
+	}
+	assert.Equal(t, map[string]string{
+		"reports/junit.xml":    TEST_REPORT_JUNIT,
+		"reports/results.trx":  TEST_REPORT_TRX,
+		"reports/coverage.xml": TEST_REPORT_COBERTURA,
+	}, formats)
+}
+
+func TestParseTestReportDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "module"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "module", "TEST-utils.xml"), []byte(junitReport), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "build.log"), []byte("not a report"), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "TEST-broken.xml"), []byte(`<testsuite><testcase name="cut`), 0o600))
+
+	logger := unithelper.DummyLogger()
+	logger.On("Warn", mock.Anything, "skip the malformed test report %s", []interface{}{"TEST-broken.xml"}).Once()
+	reports, err := ParseTestReportDir(dir, logger)
+	assert.Nil(t, err)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, "module/TEST-utils.xml", reports[0].File)
+	assert.Len(t, reports[0].TestCases, 5)
+	logger.AssertExpectations(t)
+}
+
+func TestReadTestReportResponse(t *testing.T) {
+	body, err := ReadTestReportResponse(&http.Response{Body: io.NopCloser(strings.NewReader(junitReport)), ContentLength: -1})
+	assert.Nil(t, err)
+	assert.Equal(t, junitReport, string(body))
+
+	body, err = ReadTestReportResponse(&http.Response{Body: io.NopCloser(strings.NewReader(junitReport)), ContentLength: maxTestReportResponseSize + 1})
+	assert.Nil(t, err)
+	assert.Nil(t, body)
+}
+
+func TestTestCaseKey(t *testing.T) {
+	assert.Equal(t, "pkg.FooTest.testBar", TestCaseKey("pkg.FooTest.testBar"))
+
+	long := strings.Repeat("pkg.FooTest.testBar", 20)
+	key := TestCaseKey(long)
+	assert.Equal(t, maxTestCaseKeyLength, len(key))
+	assert.True(t, strings.HasPrefix(key, long[:100]))
+	assert.NotEqual(t, key, TestCaseKey(long+"x"))
+}
+
+func TestConvertTestCaseResult(t *testing.T) {
+	source := &TestReportSource{QaProjectId: "github:GithubRepo:1:100", CicdPipelineId: "github:GithubRun:1:100:7", CommitSha: "abc"}
+	startTime := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	testCase, execution := ConvertTestCaseResult(source, "execution:1", &TestCaseResult{
+		ClassName: "pkg.FooTest", Name: "testBar", Status: TEST_RESULT_FAILED, DurationSec: 1.5, StartTime: &startTime,
+	})
+	assert.Equal(t, "github:GithubRepo:1:100:pkg.FooTest.testBar", testCase.Id)
+	assert.Equal(t, "pkg.FooTest.testBar", testCase.Name)
+	assert.Equal(t, testCase.Id, execution.QaTestCaseId)
+	assert.Equal(t, "execution:1", execution.Id)
+	assert.Equal(t, TEST_RESULT_FAILED, execution.Status)
+	assert.Equal(t, source.CicdPipelineId, execution.CicdPipelineId)
+	assert.Equal(t, "abc", execution.CommitSha)
+	assert.Equal(t, startTime.Add(1500*time.Millisecond), execution.FinishTime)
+
+	testCase, execution = ConvertTestCaseResult(source, "execution:2", &TestCaseResult{Name: "testBaz", Status: TEST_RESULT_SKIPPED})
+	assert.Nil(t, testCase)
+	assert.Nil(t, execution)
+}
@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// DefaultProjectCalendar is used by projects without a calendar: Monday to Friday, 9 to 5 UTC
+var DefaultProjectCalendar = models.BaseProjectCalendar{
+	Timezone:    "UTC",
+	WorkingDays: "1,2,3,4,5",
+	WorkStart:   "09:00",
+	WorkEnd:     "17:00",
+}
+
+// WorkingCalendar measures durations in working hours
+type WorkingCalendar struct {
+	location    *time.Location
+	workingDays map[time.Weekday]bool
+	workStart   time.Duration
+	workEnd     time.Duration
+	holidays    map[string]bool
+}
+
+// NewWorkingCalendar validates the calendar, holidays are local dates in YYYY-MM-DD
+func NewWorkingCalendar(calendar *models.BaseProjectCalendar, holidays ...string) (*WorkingCalendar, errors.Error) {
+	location, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid timezone %s", calendar.Timezone))
+	}
+	workingDays := make(map[time.Weekday]bool)
+	for _, day := range strings.Split(calendar.WorkingDays, ",") {
+		isoDay, err := strconv.Atoi(strings.TrimSpace(day))
+		if err != nil || isoDay < 1 || isoDay > 7 {
+			return nil, errors.BadInput.New(fmt.Sprintf("invalid working day %s, expecting 1 (Monday) to 7 (Sunday)", day))
+		}
+		workingDays[time.Weekday(isoDay%7)] = true
+	}
+	workStart, e := parseClock(calendar.WorkStart)
+	if e != nil {
+		return nil, e
+	}
+	workEnd, e := parseClock(calendar.WorkEnd)
+	if e != nil {
+		return nil, e
+	}
+	if workEnd <= workStart {
+		return nil, errors.BadInput.New(fmt.Sprintf("working hours %s-%s end before they start", calendar.WorkStart, calendar.WorkEnd))
+	}
+	holidaySet := make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		if _, err := time.Parse(time.DateOnly, holiday); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid holiday %s", holiday))
+		}
+		holidaySet[holiday] = true
+	}
+	return &WorkingCalendar{
+		location:    location,
+		workingDays: workingDays,
+		workStart:   workStart,
+		workEnd:     workEnd,
+		holidays:    holidaySet,
+	}, nil
+}
+
+// LoadWorkingCalendar loads the calendar and holidays of the project, or the DefaultProjectCalendar
+func LoadWorkingCalendar(db dal.Dal, projectName string) (*WorkingCalendar, errors.Error) {
+	calendar := &models.ProjectCalendar{}
+	err := db.First(calendar, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, "error loading project calendar")
+		}
+		calendar.BaseProjectCalendar = DefaultProjectCalendar
+	}
+	var holidays []models.ProjectHoliday
+	err = db.All(&holidays, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading project holidays")
+	}
+	dates := make([]string, 0, len(holidays))
+	for _, holiday := range holidays {
+		dates = append(dates, holiday.Date)
+	}
+	return NewWorkingCalendar(&calendar.BaseProjectCalendar, dates...)
+}
+
+// BusinessDuration returns the working time between start and end, 0 if end is before start
+func (c *WorkingCalendar) BusinessDuration(start, end time.Time) time.Duration {
+	start, end = start.In(c.location), end.In(c.location)
+	var total time.Duration
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, c.location)
+	for !day.After(end) {
+		if c.workingDays[day.Weekday()] && !c.holidays[day.Format(time.DateOnly)] {
+			// add the clock times to the date so that days with a DST switch keep their working hours
+			from := clockTime(day, c.workStart)
+			to := clockTime(day, c.workEnd)
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			if to.After(from) {
+				total += to.Sub(from)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+// BusinessMinutes is the business-time counterpart of a wall-clock span in minutes, rounded up.
+// It returns nil when either end is missing or end is before start.
+func (c *WorkingCalendar) BusinessMinutes(start, end *time.Time) *int64 {
+	if start == nil || end == nil || end.Before(*start) {
+		return nil
+	}
+	minutes := int64(math.Ceil(c.BusinessDuration(*start, *end).Minutes()))
+	return &minutes
+}
+
+// IsWorkingTime tells whether t falls within the working hours of a working day
+func (c *WorkingCalendar) IsWorkingTime(t time.Time) bool {
+	t = t.In(c.location)
+	if !c.workingDays[t.Weekday()] || c.holidays[t.Format(time.DateOnly)] {
+		return false
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.location)
+	return !t.Before(clockTime(day, c.workStart)) && t.Before(clockTime(day, c.workEnd))
+}
+
+func parseClock(clock string) (time.Duration, errors.Error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, fmt.Sprintf("invalid time %s, expecting HH:MM", clock))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func clockTime(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkingCalendar(t *testing.T) {
+	calendar, err := NewWorkingCalendar(&DefaultProjectCalendar)
+	assert.Nil(t, err)
+	friday := time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	// one hour on Friday and one on Monday, the weekend does not count
+	assert.Equal(t, 2*time.Hour, calendar.BusinessDuration(friday, monday))
+	assert.Equal(t, int64(120), *calendar.BusinessMinutes(&friday, &monday))
+	assert.Nil(t, calendar.BusinessMinutes(&monday, &friday))
+	assert.Nil(t, calendar.BusinessMinutes(nil, &monday))
+
+	// partial minutes are rounded up
+	start := time.Date(2024, 3, 5, 9, 0, 30, 0, time.UTC)
+	end := time.Date(2024, 3, 5, 9, 1, 0, 0, time.UTC)
+	assert.Equal(t, int64(1), *calendar.BusinessMinutes(&start, &end))
+
+	calendar, err = NewWorkingCalendar(&DefaultProjectCalendar, "2024-03-04")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, calendar.BusinessDuration(friday, monday))
+	assert.True(t, calendar.IsWorkingTime(friday))
+	assert.False(t, calendar.IsWorkingTime(monday))
+	assert.False(t, calendar.IsWorkingTime(friday.Add(time.Hour)))
+	assert.False(t, calendar.IsWorkingTime(friday.AddDate(0, 0, 1)))
+
+	// 00:00-05:00 UTC is 08:00-13:00 in Shanghai
+	calendar, err = NewWorkingCalendar(&models.BaseProjectCalendar{
+		Timezone:    "Asia/Shanghai",
+		WorkingDays: "1,2,3,4,5",
+		WorkStart:   "09:00",
+		WorkEnd:     "17:00",
+	})
+	assert.Nil(t, err)
+	start = time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	end = time.Date(2024, 3, 5, 5, 0, 0, 0, time.UTC)
+	assert.Equal(t, 4*time.Hour, calendar.BusinessDuration(start, end))
+
+	// Sunday only
+	calendar, err = NewWorkingCalendar(&models.BaseProjectCalendar{Timezone: "UTC", WorkingDays: "7", WorkStart: "10:00", WorkEnd: "12:30"})
+	assert.Nil(t, err)
+	assert.Equal(t, 150*time.Minute, calendar.BusinessDuration(friday, monday))
+}
+
+func TestWorkingCalendarInvalid(t *testing.T) {
+	for _, calendar := range []models.BaseProjectCalendar{
+		{Timezone: "Mars/Olympus", WorkingDays: "1", WorkStart: "09:00", WorkEnd: "17:00"},
+		{Timezone: "UTC", WorkingDays: "0", WorkStart: "09:00", WorkEnd: "17:00"},
+		{Timezone: "UTC", WorkingDays: "1", WorkStart: "9am", WorkEnd: "17:00"},
+		{Timezone: "UTC", WorkingDays: "1", WorkStart: "17:00", WorkEnd: "09:00"},
+	} {
+		_, err := NewWorkingCalendar(&calendar)
+		assert.NotNil(t, err, calendar)
+	}
+	_, err := NewWorkingCalendar(&DefaultProjectCalendar, "2024/12/25")
+	assert.NotNil(t, err)
+}
@@ -143,6 +143,9 @@ func (d *Dalgorm) Exec(query string, params ...interface{}) errors.Error {
 	if err != nil {
 		return err
 	}
+	if d.isSqlite() && isSqliteNoopDDL(query) {
+		return nil
+	}
 	return d.convertGormError(d.db.Exec(query, transformParams(params)...).Error)
 }
 
@@ -197,6 +200,9 @@ func (d *Dalgorm) CursorTx(clauses ...dal.Clause) *gorm.DB {
 // Fetch loads row data from `cursor` into `dst`
 func (d *Dalgorm) Fetch(cursor dal.Rows, dst interface{}) errors.Error {
 	if rows, ok := cursor.(*sql.Rows); ok {
+		if m, ok := dst.(*map[string]interface{}); ok && d.isSqlite() {
+			return d.fetchSqliteMap(rows, *m)
+		}
 		return d.convertGormError(d.db.ScanRows(rows, dst))
 	} else {
 		return errors.Default.New(fmt.Sprintf("can not support type %s to be a dal.Rows interface", reflect.TypeOf(cursor).String()))
@@ -341,6 +347,13 @@ func (d *Dalgorm) DropColumns(table string, columnNames ...string) errors.Error
 		_ = d.Exec("SELECT * FROM ? LIMIT 1", clause.Table{Name: table})
 	}()
 	for _, columnName := range columnNames {
+		if d.isSqlite() {
+			// sqlite refuses to drop a column which is still referenced by an index
+			err := d.dropSqliteColumnIndexes(table, columnName)
+			if err != nil {
+				return err
+			}
+		}
 		err := d.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: columnName})
 		// err := d.db.Migrator().DropColumn(table, columnName)
 		if err != nil {
@@ -374,6 +387,10 @@ func (d *Dalgorm) RenameColumn(table, oldColumnName, newColumnName string) error
 
 // ModifyColumnType modify column type
 func (d *Dalgorm) ModifyColumnType(table, columnName, columnType string) errors.Error {
+	if d.isSqlite() {
+		// sqlite columns are dynamically typed, the declared type is merely an affinity
+		return nil
+	}
 	// work around the error `cached plan must not change result type` for postgres
 	// wrap in func(){} to make the linter happy
 	defer func() {
@@ -395,6 +412,8 @@ func (d *Dalgorm) AllTables() ([]string, errors.Error) {
 	var tableSql string
 	if d.db.Dialector.Name() == "mysql" {
 		tableSql = "show tables"
+	} else if d.isSqlite() {
+		tableSql = "select name from sqlite_master where type = 'table' and name not like 'sqlite_%'"
 	} else {
 		tableSql = "select table_name from information_schema.tables where table_schema = 'public' and table_name not like '_devlake%'"
 	}
@@ -493,7 +512,8 @@ func (d *Dalgorm) IsErrorNotFound(err error) bool {
 
 // IsDuplicationError checking if the sql error is not found.
 func (d *Dalgorm) IsDuplicationError(err error) bool {
-	return strings.Contains(strings.ToLower(err.Error()), "duplicate")
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "duplicate") || strings.Contains(msg, "unique constraint failed")
 }
 
 // IsCachedPlanError checks if the error is related to postgres cached query plan
@@ -510,11 +530,14 @@ func (d *Dalgorm) IsJsonOrderError(err error) bool {
 
 // IsTableExist checks if table exists
 func (d *Dalgorm) IsTableExist(err error) bool {
-	return strings.Contains(err.Error(), "Unknown table")
+	return strings.Contains(err.Error(), "Unknown table") || strings.Contains(err.Error(), "no such table")
 }
 
 // RawCursor (Deprecated) executes raw sql query and returns a database cursor
 func (d *Dalgorm) RawCursor(query string, params ...interface{}) (*sql.Rows, errors.Error) {
+	if d.isSqlite() {
+		query = trimSqliteQuery(query)
+	}
 	rows, err := d.db.Raw(query, params...).Rows()
 	return rows, d.convertGormError(err)
 }
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"gorm.io/gorm/clause"
+)
+
+// SqliteDialect is the dialect name reported by the embedded sqlite driver
+const SqliteDialect = "sqlite"
+
+func (d *Dalgorm) isSqlite() bool {
+	return d.db.Dialector.Name() == SqliteDialect
+}
+
+// sqliteNoopDDLPattern matches the mysql/postgres statements altering column types, nullability or primary keys
+// in place, which sqlite can not express. Its columns are dynamically typed and the primary key can only be changed
+// by recreating the table (see migrationhelper.TransformTable), so these statements are skipped rather than failing
+// the historical migration scripts
+var sqliteNoopDDLPattern = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+\S+\s+(MODIFY|ALTER\s+COLUMN|DROP\s+PRIMARY\s+KEY|DROP\s+CONSTRAINT|ADD\s+PRIMARY\s+KEY)\b`)
+
+func isSqliteNoopDDL(query string) bool {
+	return sqliteNoopDDLPattern.MatchString(query)
+}
+
+// trimSqliteQuery removes the trailing whitespaces, the sqlite driver takes whatever follows the last `;` as another
+// statement and returns the (empty) result of it
+func trimSqliteQuery(query string) string {
+	return strings.TrimRightFunc(query, unicode.IsSpace)
+}
+
+// dropSqliteColumnIndexes drops all user-defined indexes that reference the specified column
+func (d *Dalgorm) dropSqliteColumnIndexes(table, columnName string) errors.Error {
+	var indexNames []string
+	err := d.db.Raw(
+		`SELECT DISTINCT il.name FROM pragma_index_list(?) il, pragma_index_info(il.name) ii
+		WHERE il.origin = 'c' AND ii.name = ?`,
+		table, columnName,
+	).Scan(&indexNames).Error
+	if err != nil {
+		return d.convertGormError(err)
+	}
+	for _, indexName := range indexNames {
+		err = d.db.Exec("DROP INDEX ?", clause.Column{Name: indexName}).Error
+		if err != nil {
+			return d.convertGormError(err)
+		}
+	}
+	return nil
+}
+
+// fetchSqliteMap loads the current row into the map, the sqlite driver reports `[][]byte` as the scan type of BLOB
+// values which gorm would allocate and fail to scan into, so the values are scanned as they come from the driver
+func (d *Dalgorm) fetchSqliteMap(rows *sql.Rows, dst map[string]interface{}) errors.Error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return d.convertGormError(err)
+	}
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		values[i] = new(interface{})
+	}
+	err = rows.Scan(values...)
+	if err != nil {
+		return d.convertGormError(err)
+	}
+	for i, column := range columns {
+		dst[column] = *(values[i].(*interface{}))
+	}
+	return nil
+}
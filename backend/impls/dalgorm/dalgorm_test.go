@@ -48,3 +48,24 @@ func Test_validateQuery(t *testing.T) {
 		assert.Nil(t, validateQuery(target), "failed text: `%s`", target)
 	}
 }
+
+func Test_isSqliteNoopDDL(t *testing.T) {
+	for _, target := range []string{
+		"ALTER TABLE _tool_q_dev_s3_file_meta MODIFY processed_time DATETIME NULL",
+		"ALTER TABLE _tool_sonarqube_file_metrics DROP PRIMARY KEY",
+		"ALTER TABLE _tool_sonarqube_file_metrics DROP CONSTRAINT _tool_sonarqube_file_metrics_pkey",
+		"alter table _tool_sonarqube_file_metrics add primary key (connection_id, file_metrics_key)",
+		"ALTER TABLE issues ALTER COLUMN id TYPE varchar(255)",
+		"\n  ALTER TABLE issues\n  MODIFY COLUMN id varchar(255)",
+	} {
+		assert.True(t, isSqliteNoopDDL(target), "failed text: `%s`", target)
+	}
+	for _, target := range []string{
+		"ALTER TABLE issues ADD COLUMN priority varchar(255)",
+		"ALTER TABLE issues DROP COLUMN priority",
+		"ALTER TABLE issues RENAME COLUMN priority TO severity",
+		"UPDATE issues SET priority = 'MODIFY'",
+	} {
+		assert.False(t, isSqliteNoopDDL(target), "failed text: `%s`", target)
+	}
+}
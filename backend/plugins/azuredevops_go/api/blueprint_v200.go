@@ -34,6 +34,7 @@ import (
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/helpers/srvhelper"
 	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/tasks"
 )
 
 func MakePipelinePlanV200(
@@ -69,6 +70,7 @@ func makeScopeV200(
 	scopeDetails []*srvhelper.ScopeDetail[models.AzuredevopsRepo, models.AzuredevopsScopeConfig],
 ) ([]plugin.Scope, errors.Error) {
 	sc := make([]plugin.Scope, 0, 3*len(scopeDetails))
+	boardIds := make(map[string]bool)
 
 	for _, scope := range scopeDetails {
 		azuredevopsRepo, scopeConfig := scope.Scope, scope.ScopeConfig
@@ -90,10 +92,17 @@ func makeScopeV200(
 			sc = append(sc, scopeCICD)
 		}
 
-		// add board to scopes
+		// add board to scopes, repos of the same project and area path share the board
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
-			scopeTicket := ticket.NewBoard(id, azuredevopsRepo.Name)
-			sc = append(sc, scopeTicket)
+			boardId := tasks.GenerateBoardId(connectionId, azuredevopsRepo.ProjectId, scopeConfig.AreaPath)
+			if !boardIds[boardId] {
+				boardIds[boardId] = true
+				boardName := scopeConfig.AreaPath
+				if boardName == "" {
+					boardName = azuredevopsRepo.ProjectId
+				}
+				sc = append(sc, ticket.NewBoard(boardId, boardName))
+			}
 		}
 	}
 
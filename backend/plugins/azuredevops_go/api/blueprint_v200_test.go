@@ -39,6 +39,7 @@ const (
 	connectionID        uint64 = 1
 	azuredevopsRepoId          = "ad05901f-c9b0-4938-bc8a-a22eb2467ceb"
 	expectDomainScopeId        = "azuredevops_go:AzuredevopsRepo:1:ad05901f-c9b0-4938-bc8a-a22eb2467ceb"
+	expectBoardId              = `azuredevops_go:AzuredevopsBoard:1:test-project:test-project\Team A`
 )
 
 func mockAzuredevopsPlugin(t *testing.T) {
@@ -60,6 +61,9 @@ func TestMakeScopes(t *testing.T) {
 					Scope: common.Scope{
 						ConnectionId: connectionID,
 					},
+					AzureDevOpsPK: models.AzureDevOpsPK{
+						ProjectId: "test-project",
+					},
 					Id:   azuredevopsRepoId,
 					Type: models.RepositoryTypeADO,
 				},
@@ -67,15 +71,35 @@ func TestMakeScopes(t *testing.T) {
 					ScopeConfig: common.ScopeConfig{
 						Entities: []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD},
 					},
+					AreaPath: `test-project\Team A`,
+				},
+			},
+			{
+				Scope: models.AzuredevopsRepo{
+					Scope: common.Scope{
+						ConnectionId: connectionID,
+					},
+					AzureDevOpsPK: models.AzureDevOpsPK{
+						ProjectId: "test-project",
+					},
+					Id:   "0d50ba13-f9ad-49b0-9b21-d29eda50ca33",
+					Type: models.RepositoryTypeADO,
+				},
+				ScopeConfig: &models.AzuredevopsScopeConfig{
+					ScopeConfig: common.ScopeConfig{
+						Entities: []string{plugin.DOMAIN_TYPE_TICKET},
+					},
+					AreaPath: `test-project\Team A`,
 				},
 			},
 		},
 	)
 	assert.Nil(t, err)
+	// the repos share the board of the area path
 	assert.Equal(t, 3, len(actualScopes))
 	assert.Equal(t, actualScopes[0].ScopeId(), expectDomainScopeId)
 	assert.Equal(t, actualScopes[1].ScopeId(), expectDomainScopeId)
-	assert.Equal(t, actualScopes[2].ScopeId(), expectDomainScopeId)
+	assert.Equal(t, actualScopes[2].ScopeId(), expectBoardId)
 }
 
 func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
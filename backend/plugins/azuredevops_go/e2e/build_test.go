@@ -70,6 +70,9 @@ func TestAzuredevopsBuildDataFlow(t *testing.T) {
 			"source_branch",
 			"source_version",
 			"tags",
+			"pool_id",
+			"pool_name",
+			"pool_is_hosted",
 		},
 	)
 
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/azuredevops_go/impl"
 	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
@@ -45,6 +47,8 @@ func TestAzureDevopsRepoDataFlow(t *testing.T) {
 	// verify conversion
 	dataflowTester.FlushTabler(&code.Repo{})
 	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&crossdomain.BoardRepo{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_azuredevops_go_repos.csv", &models.AzuredevopsRepo{})
 	dataflowTester.Subtask(tasks.ConvertRepoMeta, taskData)
 	dataflowTester.VerifyTable(
@@ -64,4 +68,14 @@ func TestAzureDevopsRepoDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/cicd_scopes.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	dataflowTester.VerifyTableWithOptions(&ticket.Board{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/boards.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.VerifyTableWithOptions(&crossdomain.BoardRepo{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_repos.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
@@ -71,6 +71,7 @@ func TestAzuredevopsTimelineDataFlow(t *testing.T) {
 			"state",
 			"result",
 			"change_id",
+			"worker_name",
 		},
 	)
 
@@ -95,6 +96,22 @@ func TestAzuredevopsTimelineDataFlow(t *testing.T) {
 			"duration_sec",
 			"queued_duration_sec",
 			"cicd_scope_id",
+			"runner_id",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdRunner{})
+	dataflowTester.Subtask(tasks.ConvertAgentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdRunner{},
+		"./snapshot_tables/cicd_runners.csv",
+		[]string{
+			"id",
+			"name",
+			"pool",
+			"labels",
+			"os",
+			"is_self_hosted",
 		},
 	)
 }
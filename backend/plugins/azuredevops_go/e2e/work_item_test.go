@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/impl"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/tasks"
+)
+
+func TestAzuredevopsWorkItemDataFlow(t *testing.T) {
+
+	var azuredevops impl.Azuredevops
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azuredevops_go", azuredevops)
+
+	taskData := &tasks.AzuredevopsTaskData{
+		Options: &tasks.AzuredevopsOptions{
+			ConnectionId:   1,
+			ProjectId:      "test-project",
+			OrganizationId: "johndoe",
+			RepositoryId:   "0d50ba13-f9ad-49b0-9b21-d29eda50ca33",
+			ScopeConfig: &models.AzuredevopsScopeConfig{
+				AreaPath:       `test-project\Team A`,
+				StatusMappings: map[string]string{"Code Review": "in_progress"},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_go_api_iterations.csv",
+		"_raw_azuredevops_go_api_iterations")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_go_api_work_items.csv",
+		"_raw_azuredevops_go_api_work_items")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_go_api_work_item_updates.csv",
+		"_raw_azuredevops_go_api_work_item_updates")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzuredevopsIteration{})
+	dataflowTester.FlushTabler(&models.AzuredevopsWorkItem{})
+	dataflowTester.FlushTabler(&models.AzuredevopsWorkItemLink{})
+	dataflowTester.FlushTabler(&models.AzuredevopsWorkItemUpdate{})
+	dataflowTester.Subtask(tasks.ExtractApiIterationsMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemsMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemUpdatesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsIteration{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azuredevops_go_iterations.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsWorkItem{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azuredevops_go_work_items.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsWorkItemLink{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azuredevops_go_work_item_links.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsWorkItemUpdate{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azuredevops_go_work_item_updates.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Sprint{})
+	dataflowTester.FlushTabler(&ticket.BoardSprint{})
+	dataflowTester.Subtask(tasks.ConvertIterationsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Sprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardSprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.SprintIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprint_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueAssignee{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_assignees.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemUpdatesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&crossdomain.PullRequestIssue{})
+	dataflowTester.FlushTabler(&crossdomain.IssueCommit{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemLinksMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.PullRequestIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.IssueCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -46,6 +46,7 @@ var _ interface {
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
 	plugin.CloseablePluginTask
+	helper.DeploymentCandidateSource
 } = (*Azuredevops)(nil)
 
 var sortedSubtaskMetas []plugin.SubTaskMeta
@@ -84,6 +85,7 @@ func (p Azuredevops) GetTablesInfo() []dal.Tabler {
 		&models.AzuredevopsBuild{},
 		&models.AzuredevopsCommit{},
 		&models.AzuredevopsConnection{},
+		&models.AzuredevopsIteration{},
 		&models.AzuredevopsPrCommit{},
 		&models.AzuredevopsPrLabel{},
 		&models.AzuredevopsProject{},
@@ -93,6 +95,9 @@ func (p Azuredevops) GetTablesInfo() []dal.Tabler {
 		&models.AzuredevopsScopeConfig{},
 		&models.AzuredevopsTimelineRecord{},
 		&models.AzuredevopsUser{},
+		&models.AzuredevopsWorkItem{},
+		&models.AzuredevopsWorkItemLink{},
+		&models.AzuredevopsWorkItemUpdate{},
 	}
 }
 
@@ -173,11 +178,16 @@ func (p Azuredevops) PrepareTaskData(taskCtx plugin.TaskContext, options map[str
 	if err = regexEnricher.TryAdd(devops.PRODUCTION, op.ScopeConfig.ProductionPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
 	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.ScopeConfig.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 
 	taskData := &tasks.AzuredevopsTaskData{
-		Options:       op,
-		ApiClient:     apiClient,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	if op.TimeAfter != "" {
@@ -265,3 +275,7 @@ func (p Azuredevops) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+func (p Azuredevops) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	return tasks.DeploymentCandidates(db, connectionId, scopeId, since)
+}
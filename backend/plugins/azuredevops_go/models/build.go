@@ -39,6 +39,10 @@ type AzuredevopsBuild struct {
 	QueueTime  *time.Time
 	StartTime  *time.Time
 	FinishTime *time.Time
+	// the agent pool the build was queued to
+	PoolId       int
+	PoolName     string `gorm:"type:varchar(255)"`
+	PoolIsHosted bool
 }
 
 func (AzuredevopsBuild) TableName() string {
@@ -65,6 +69,15 @@ type AzuredevopsApiBuild struct {
 		QueueStatus string        `json:"queueStatus"`
 		Revision    int           `json:"revision"`
 	} `json:"definition"`
+	Queue struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+		Pool struct {
+			Id       int    `json:"id"`
+			Name     string `json:"name"`
+			IsHosted bool   `json:"isHosted"`
+		} `json:"pool"`
+	} `json:"queue"`
 	BuildNumberRevision int      `json:"buildNumberRevision"`
 	Uri                 string   `json:"uri"`
 	SourceBranch        string   `json:"sourceBranch"`
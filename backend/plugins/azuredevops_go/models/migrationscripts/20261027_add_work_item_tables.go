@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models/migrationscripts/archived"
+)
+
+type addWorkItemTables struct{}
+
+type azuredevopsScopeConfig20261027 struct {
+	AreaPath       string `gorm:"type:varchar(255)"`
+	TypeMappings   string `gorm:"type:text"`
+	StatusMappings string `gorm:"type:text"`
+}
+
+func (azuredevopsScopeConfig20261027) TableName() string {
+	return "_tool_azuredevops_go_scope_configs"
+}
+
+func (*addWorkItemTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&azuredevopsScopeConfig20261027{},
+		&archived.AzuredevopsWorkItem{},
+		&archived.AzuredevopsWorkItemUpdate{},
+		&archived.AzuredevopsWorkItemLink{},
+		&archived.AzuredevopsIteration{},
+	)
+}
+
+func (*addWorkItemTables) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_azuredevops_go_scope_configs", "area_path", "type_mappings", "status_mappings"); err != nil {
+		return err
+	}
+	return db.DropTables(&archived.AzuredevopsWorkItem{}, &archived.AzuredevopsWorkItemUpdate{}, &archived.AzuredevopsWorkItemLink{}, &archived.AzuredevopsIteration{})
+}
+
+func (*addWorkItemTables) Version() uint64 {
+	return 20261027000001
+}
+
+func (*addWorkItemTables) Name() string {
+	return "add work item, work item update, work item link and iteration tables, and work item settings to scope configs"
+}
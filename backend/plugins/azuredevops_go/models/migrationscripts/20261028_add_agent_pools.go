@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addAgentPools struct{}
+
+type azuredevopsBuild20261028 struct {
+	PoolId       int
+	PoolName     string `gorm:"type:varchar(255)"`
+	PoolIsHosted bool
+}
+
+func (azuredevopsBuild20261028) TableName() string {
+	return "_tool_azuredevops_go_builds"
+}
+
+type azuredevopsTimelineRecord20261028 struct {
+	WorkerName string `gorm:"type:varchar(255)"`
+}
+
+func (azuredevopsTimelineRecord20261028) TableName() string {
+	return "_tool_azuredevops_go_timeline_records"
+}
+
+func (*addAgentPools) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&azuredevopsBuild20261028{},
+		&azuredevopsTimelineRecord20261028{},
+	)
+}
+
+func (*addAgentPools) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_azuredevops_go_builds", "pool_id", "pool_name", "pool_is_hosted"); err != nil {
+		return err
+	}
+	return db.DropColumns("_tool_azuredevops_go_timeline_records", "worker_name")
+}
+
+func (*addAgentPools) Version() uint64 {
+	return 20261028000001
+}
+
+func (*addAgentPools) Name() string {
+	return "add agent pool to builds and worker name to timeline records"
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type AzuredevopsIteration struct {
+	archived.NoPKModel
+
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	AzuredevopsId int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId     string `gorm:"type:varchar(255)"`
+	Identifier    string `gorm:"type:varchar(255)"`
+	Name          string `gorm:"type:varchar(255)"`
+	Path          string `gorm:"type:varchar(255)"`
+	StartDate     *time.Time
+	FinishDate    *time.Time
+	Url           string `gorm:"type:varchar(255)"`
+}
+
+func (AzuredevopsIteration) TableName() string {
+	return "_tool_azuredevops_go_iterations"
+}
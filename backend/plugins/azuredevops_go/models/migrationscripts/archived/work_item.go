@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type AzuredevopsWorkItem struct {
+	archived.NoPKModel
+
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	AzuredevopsId    int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId        string `gorm:"type:varchar(255)"`
+	Rev              int
+	Type             string `gorm:"type:varchar(100)"`
+	State            string `gorm:"type:varchar(100)"`
+	Reason           string `gorm:"type:varchar(255)"`
+	Title            string
+	Description      string
+	AreaPath         string `gorm:"type:varchar(255)"`
+	IterationId      int
+	IterationPath    string `gorm:"type:varchar(255)"`
+	Priority         string `gorm:"type:varchar(255)"`
+	Severity         string `gorm:"type:varchar(255)"`
+	StoryPoints      *float64
+	OriginalEstimate *float64
+	RemainingWork    *float64
+	CompletedWork    *float64
+	AssignedToId     string `gorm:"type:varchar(255)"`
+	AssignedToName   string `gorm:"type:varchar(255)"`
+	CreatedById      string `gorm:"type:varchar(255)"`
+	CreatedByName    string `gorm:"type:varchar(255)"`
+	CreatedDate      *time.Time
+	ChangedDate      *time.Time
+	ResolvedDate     *time.Time
+	ClosedDate       *time.Time
+	ParentId         int
+	Tags             string
+	Url              string `gorm:"type:varchar(255)"`
+}
+
+func (AzuredevopsWorkItem) TableName() string {
+	return "_tool_azuredevops_go_work_items"
+}
@@ -26,5 +26,8 @@ func All() []plugin.MigrationScript {
 	return []plugin.MigrationScript{
 		new(addInitTables),
 		new(extendRepoTable),
+		new(addWorkItemTables),
+		new(addAgentPools),
+		new(addDeploymentRules),
 	}
 }
@@ -20,6 +20,7 @@ package models
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"gorm.io/datatypes"
 )
 
@@ -31,6 +32,17 @@ type AzuredevopsScopeConfig struct {
 	DeploymentPattern string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern"`
 	ProductionPattern string            `mapstructure:"productionPattern,omitempty" json:"productionPattern"`
 	Refdiff           datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+
+	// AreaPath limits the board of the repo to the work items under the given area path and its children, the board holds the whole project when empty.
+	// Work items are collected once per project and shared by all repos of the project
+	AreaPath string `mapstructure:"areaPath,omitempty" json:"areaPath" gorm:"type:varchar(255)"`
+	// TypeMappings maps work item types (e.g. User Story) to REQUIREMENT, BUG, INCIDENT or TASK
+	TypeMappings map[string]string `mapstructure:"typeMappings,omitempty" json:"typeMappings" gorm:"serializer:json"`
+	// StatusMappings maps work item states (e.g. Active) to TODO, IN_PROGRESS or DONE
+	StatusMappings map[string]string `mapstructure:"statusMappings,omitempty" json:"statusMappings" gorm:"serializer:json"`
+
+	// DeploymentRules classify the pipelines and tasks ahead of deploymentPattern and productionPattern
+	DeploymentRules []*api.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
 }
 
 // GetConnectionId implements plugin.ToolLayerScopeConfig.
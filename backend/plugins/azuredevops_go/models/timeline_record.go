@@ -37,6 +37,7 @@ type AzuredevopsTimelineRecord struct {
 	Result       string
 	ChangeId     int
 	LastModified string
+	WorkerName   string `gorm:"type:varchar(255)"`
 }
 
 func (AzuredevopsTimelineRecord) TableName() string {
@@ -56,4 +57,5 @@ type AzuredevopsApiTimelineRecord struct {
 	ChangeId     int        `json:"changeId"`
 	LastModified string     `json:"lastModified"`
 	Identifier   string     `json:"identifier"`
+	WorkerName   string     `json:"workerName"`
 }
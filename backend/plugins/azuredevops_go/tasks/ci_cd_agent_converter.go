@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertAgentsMeta)
+}
+
+var ConvertAgentsMeta = plugin.SubTaskMeta{
+	Name:             "convertAgents",
+	EntryPoint:       ConvertAgents,
+	EnabledByDefault: true,
+	Description:      "Convert the agents which ran timeline records into domain layer table cicd_runners",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	DependencyTables: []string{
+		models.AzuredevopsTimelineRecord{}.TableName(),
+		models.AzuredevopsBuild{}.TableName(),
+	},
+	ProductTables: []string{devops.CicdRunner{}.TableName()},
+}
+
+// AzuredevopsAgent is an agent of a pool, the api only names the agent (workerName) on timeline
+// records while the pool is set on the build, agent names are unique within a pool only.
+type AzuredevopsAgent struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PoolId       int    `gorm:"primaryKey"`
+	WorkerName   string `gorm:"primaryKey"`
+	PoolName     string
+	PoolIsHosted bool
+	common.RawDataOrigin
+}
+
+func ConvertAgents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawTimelineRecordTable)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select(`b.connection_id, b.pool_id, r.worker_name, b.pool_name, b.pool_is_hosted,
+			r._raw_data_params, r._raw_data_table, max(r._raw_data_id) as _raw_data_id`),
+		dal.From("_tool_azuredevops_go_timeline_records r"),
+		dal.Join(`join _tool_azuredevops_go_builds b on b.connection_id = r.connection_id and b.azuredevops_id = r.build_id`),
+		dal.Where("b.repository_id = ? and b.connection_id = ? and r.worker_name != ''",
+			data.Options.RepositoryId, data.Options.ConnectionId),
+		dal.Groupby(`b.connection_id, b.pool_id, r.worker_name, b.pool_name, b.pool_is_hosted,
+			r._raw_data_params, r._raw_data_table`),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	agentIdGen := didgen.NewDomainIdGenerator(&AzuredevopsAgent{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(AzuredevopsAgent{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			agent := inputRow.(*AzuredevopsAgent)
+			return []interface{}{
+				&devops.CicdRunner{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: agentIdGen.Generate(agent.ConnectionId, agent.PoolId, agent.WorkerName),
+					},
+					Name:         agent.WorkerName,
+					Pool:         agent.PoolName,
+					Os:           devops.GetRunnerOs(agent.PoolName, agent.WorkerName),
+					IsSelfHosted: !agent.PoolIsHosted,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
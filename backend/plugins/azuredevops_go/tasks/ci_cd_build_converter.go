@@ -104,6 +104,10 @@ func ConvertBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				},
 			}
 
+			domainPipeline.Type, domainPipeline.Environment = data.DeploymentRules.Classify(
+				newBuildDeploymentCandidate(&build.AzuredevopsBuild), domainPipeline.Type, domainPipeline.Environment,
+			)
+
 			pipelineCommit := &devops.CiCDPipelineCommit{
 				PipelineId: domainPipeline.Id,
 				CommitSha:  build.SourceVersion,
@@ -76,6 +76,9 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				StartTime:     buildApi.StartTime,
 				FinishTime:    buildApi.FinishTime,
 				Tags:          string(tagsB),
+				PoolId:        buildApi.Queue.Pool.Id,
+				PoolName:      buildApi.Queue.Pool.Name,
+				PoolIsHosted:  buildApi.Queue.Pool.IsHosted,
 			}
 
 			results = append(results, build)
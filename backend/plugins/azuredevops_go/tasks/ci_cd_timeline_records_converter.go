@@ -63,7 +63,22 @@ func ConvertApiTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
 		return err
 	}
 
+	// the pool of an agent is only known to the build it ran
+	var builds []models.AzuredevopsBuild
+	err = db.All(&builds,
+		dal.Select("azuredevops_id, pool_id"),
+		dal.Where("repository_id = ? and connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	buildPools := make(map[int]int, len(builds))
+	for _, build := range builds {
+		buildPools[build.AzuredevopsId] = build.PoolId
+	}
+
 	tlRecordIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsTimelineRecord{})
+	agentIdGen := didgen.NewDomainIdGenerator(&AzuredevopsAgent{})
 	repoIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{})
 	buildIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsBuild{})
 
@@ -106,6 +121,14 @@ func ConvertApiTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
 				CicdScopeId: repoIdGen.Generate(data.Options.ConnectionId, data.Options.RepositoryId),
 			}
 
+			domainTask.Type, domainTask.Environment = data.DeploymentRules.Classify(
+				newTimelineRecordDeploymentCandidate(tlRecord), domainTask.Type, domainTask.Environment,
+			)
+
+			if tlRecord.WorkerName != "" {
+				domainTask.RunnerId = agentIdGen.Generate(data.Options.ConnectionId, buildPools[tlRecord.BuildId], tlRecord.WorkerName)
+			}
+
 			return []interface{}{
 				domainTask,
 			}, nil
@@ -82,6 +82,7 @@ func ExtractApiTimelineTasks(taskCtx plugin.SubTaskContext) errors.Error {
 				Result:       recordApi.Result,
 				ChangeId:     recordApi.ChangeId,
 				LastModified: recordApi.LastModified,
+				WorkerName:   recordApi.WorkerName,
 			}
 
 			results = append(results, record)
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+// newBuildDeploymentCandidate describes a build to the deployment rules
+func newBuildDeploymentCandidate(build *models.AzuredevopsBuild) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate(build.SourceBranch, false, build.Result)
+	candidate.PipelineName = build.Name
+	return candidate
+}
+
+// newTimelineRecordDeploymentCandidate describes a timeline record of a build to the deployment rules
+func newTimelineRecordDeploymentCandidate(tlRecord *models.AzuredevopsTimelineRecord) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, tlRecord.Result)
+	candidate.JobName = tlRecord.Name
+	return candidate
+}
+
+// DeploymentCandidates describes the builds of the repository queued since the time and their timeline records to the
+// deployment rules by the ids of the cicd_pipelines and the cicd_tasks they are converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, repositoryId string, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var builds []*models.AzuredevopsBuild
+	err := db.All(&builds, dal.Where("connection_id = ? AND repository_id = ? AND queue_time >= ?", connectionId, repositoryId, since))
+	if err != nil {
+		return nil, err
+	}
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsBuild{})
+	for _, build := range builds {
+		candidates.Pipelines[buildIdGen.Generate(connectionId, build.AzuredevopsId)] = newBuildDeploymentCandidate(build)
+	}
+	var tlRecords []*models.AzuredevopsTimelineRecord
+	err = db.All(
+		&tlRecords,
+		dal.Select("r.*"),
+		dal.From("_tool_azuredevops_go_timeline_records r"),
+		dal.Join("JOIN _tool_azuredevops_go_builds b ON b.connection_id = r.connection_id AND b.azuredevops_id = r.build_id"),
+		dal.Where("r.connection_id = ? AND b.repository_id = ? AND b.queue_time >= ?", connectionId, repositoryId, since),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tlRecordIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsTimelineRecord{})
+	for _, tlRecord := range tlRecords {
+		taskId := tlRecordIdGen.Generate(connectionId, tlRecord.RecordId, tlRecord.BuildId)
+		candidates.Tasks[taskId] = newTimelineRecordDeploymentCandidate(tlRecord)
+	}
+	return candidates, nil
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiIterationsMeta)
+}
+
+const RawIterationTable = "azuredevops_go_api_iterations"
+
+var CollectApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiIterations",
+	EntryPoint:       CollectApiIterations,
+	EnabledByDefault: true,
+	Description:      "Collect iterations of the project from Azure DevOps API.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{},
+	ProductTables:    []string{RawIterationTable},
+}
+
+func CollectApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawIterationTable)
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/wit/classificationnodes/Iterations?$depth=100&api-version=7.1",
+		ResponseParser:     parseIterationTree,
+		AfterResponse:      change203To401,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
+
+// parseIterationTree flattens the iteration classification tree, the root node stands for the project itself and is skipped
+func parseIterationTree(res *http.Response) ([]json.RawMessage, errors.Error) {
+	root := &models.AzuredevopsApiIteration{}
+	err := api.UnmarshalResponse(res, root)
+	if err != nil {
+		return nil, err
+	}
+	var result []json.RawMessage
+	queue := root.Children
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = append(queue[1:], node.Children...)
+		node.Children = nil
+		raw, err := errors.Convert01(json.Marshal(node))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, raw)
+	}
+	return result, nil
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIterationsMeta)
+}
+
+var ConvertIterationsMeta = plugin.SubTaskMeta{
+	Name:             "convertIterations",
+	EntryPoint:       ConvertIterations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_azuredevops_go_iterations into domain layer table sprints and board_sprints",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{models.AzuredevopsIteration{}.TableName()},
+	ProductTables: []string{
+		ticket.Sprint{}.TableName(),
+		ticket.BoardSprint{}.TableName(),
+	},
+}
+
+func ConvertIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawIterationTable)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsIteration{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardId := GenerateBoardId(data.Options.ConnectionId, data.Options.ProjectId, data.Options.ScopeConfig.AreaPath)
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	now := time.Now()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsIteration{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			iteration := inputRow.(*models.AzuredevopsIteration)
+			sprint := &ticket.Sprint{
+				DomainEntity:    domainlayer.DomainEntity{Id: sprintIdGen.Generate(iteration.ConnectionId, iteration.AzuredevopsId)},
+				Name:            iteration.Name,
+				Url:             iteration.Url,
+				Status:          getSprintStatus(iteration, now),
+				StartedDate:     iteration.StartDate,
+				EndedDate:       iteration.FinishDate,
+				OriginalBoardID: boardId,
+			}
+			if sprint.Status == "CLOSED" {
+				sprint.CompletedDate = iteration.FinishDate
+			}
+			return []interface{}{
+				sprint,
+				&ticket.BoardSprint{
+					BoardId:  boardId,
+					SprintId: sprint.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getSprintStatus derives the status from the iteration dates since Azure DevOps doesn't close iterations explicitly
+func getSprintStatus(iteration *models.AzuredevopsIteration, now time.Time) string {
+	if iteration.FinishDate != nil && iteration.FinishDate.Before(now) {
+		return "CLOSED"
+	}
+	if iteration.StartDate != nil && !iteration.StartDate.After(now) {
+		return "ACTIVE"
+	}
+	return "FUTURE"
+}
@@ -22,12 +22,15 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
 	"reflect"
+	"strings"
 )
 
 func init() {
@@ -38,7 +41,7 @@ var ConvertRepoMeta = plugin.SubTaskMeta{
 	Name:             "convertRepo",
 	EntryPoint:       ConvertRepo,
 	EnabledByDefault: true,
-	Description:      "Convert tool layer table _tool_azuredevops_go_repos into domain layer table repos and cicd scope",
+	Description:      "Convert tool layer table _tool_azuredevops_go_repos into domain layer table repos, cicd scope and board",
 	DomainTypes: []string{
 		plugin.DOMAIN_TYPE_CODE,
 		plugin.DOMAIN_TYPE_TICKET,
@@ -50,7 +53,9 @@ var ConvertRepoMeta = plugin.SubTaskMeta{
 	},
 	ProductTables: []string{
 		code.Repo{}.TableName(),
-		devops.CicdScope{}.TableName()},
+		devops.CicdScope{}.TableName(),
+		ticket.Board{}.TableName(),
+		crossdomain.BoardRepo{}.TableName()},
 }
 
 func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
@@ -76,9 +81,15 @@ func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
 
 			domainRepository := convertToRepositoryModel(repository)
 			domainCiCdScope := convertToCicdScopeModel(repository)
+			domainBoard := convertToBoardModel(repository, data.Options)
 			return []interface{}{
 				domainRepository,
 				domainCiCdScope,
+				domainBoard,
+				&crossdomain.BoardRepo{
+					BoardId: domainBoard.Id,
+					RepoId:  domainRepository.Id,
+				},
 			}, nil
 		},
 	})
@@ -112,3 +123,23 @@ func convertToRepositoryModel(repo *models.AzuredevopsRepo) *code.Repo {
 	}
 	return domainRepository
 }
+
+// convertToBoardModel maps the work items of the project under the area path of the scope config to a board,
+// the board is named after the area path, or the project when no area path is configured
+func convertToBoardModel(repo *models.AzuredevopsRepo, options *AzuredevopsOptions) *ticket.Board {
+	name := options.ScopeConfig.AreaPath
+	if name == "" {
+		name = repo.ProjectId
+	}
+	board := &ticket.Board{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: GenerateBoardId(repo.ConnectionId, repo.ProjectId, options.ScopeConfig.AreaPath),
+		},
+		Name: name,
+		Type: "azuredevops",
+	}
+	if i := strings.LastIndex(repo.Url, "/_git/"); i >= 0 {
+		board.Url = repo.Url[:i] + "/_boards"
+	}
+	return board
+}
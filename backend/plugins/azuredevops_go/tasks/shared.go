@@ -22,10 +22,14 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // Build and TimeLine Record State and Result types can be found here:
@@ -62,6 +66,35 @@ func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api
 	return RawDataSubTaskArgs, data
 }
 
+// AzuredevopsProjectParams identifies the raw data shared by all repos of a project, i.e. work items and iterations
+type AzuredevopsProjectParams struct {
+	ConnectionId   uint64
+	OrganizationId string
+	ProjectId      string
+}
+
+// CreateProjectRawDataSubTaskArgs is like CreateRawDataSubTaskArgs, but the raw data belongs to the project instead
+// of the repo, so it is collected once no matter how many repos of the project are in scope
+func CreateProjectRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *AzuredevopsTaskData) {
+	data := taskCtx.GetData().(*AzuredevopsTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: AzuredevopsProjectParams{
+			ConnectionId:   data.Options.ConnectionId,
+			OrganizationId: data.Options.OrganizationId,
+			ProjectId:      data.Options.ProjectId,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GenerateBoardId returns the id of the board holding the work items of the project under the area path,
+// repos of the same project and area path share the board
+func GenerateBoardId(connectionId uint64, projectId string, areaPath string) string {
+	return didgen.NewDomainIdGenerator(&models.AzuredevopsBoard{}).Generate(connectionId, projectId, areaPath)
+}
+
 func ParseRawMessageFromValue(res *http.Response) ([]json.RawMessage, errors.Error) {
 	var data struct {
 		Value []json.RawMessage `json:"value"`
@@ -151,3 +184,59 @@ func ignoreDeletedBuilds(res *http.Response) errors.Error {
 	}
 	return nil
 }
+
+// default mappings of the work item types and states of the built-in Agile, Scrum, CMMI and Basic processes
+var defaultWorkItemTypeMappings = map[string]string{
+	"Bug":                  ticket.BUG,
+	"Epic":                 ticket.REQUIREMENT,
+	"Feature":              ticket.REQUIREMENT,
+	"Issue":                ticket.REQUIREMENT,
+	"Product Backlog Item": ticket.REQUIREMENT,
+	"Requirement":          ticket.REQUIREMENT,
+	"User Story":           ticket.REQUIREMENT,
+	"Task":                 ticket.TASK,
+}
+
+var defaultWorkItemStatusMappings = map[string]string{
+	"New":         ticket.TODO,
+	"To Do":       ticket.TODO,
+	"Proposed":    ticket.TODO,
+	"Approved":    ticket.TODO,
+	"Design":      ticket.TODO,
+	"Active":      ticket.IN_PROGRESS,
+	"Committed":   ticket.IN_PROGRESS,
+	"Doing":       ticket.IN_PROGRESS,
+	"In Progress": ticket.IN_PROGRESS,
+	"Resolved":    ticket.DONE,
+	"Closed":      ticket.DONE,
+	"Done":        ticket.DONE,
+	"Removed":     ticket.DONE,
+}
+
+func getStdWorkItemType(scopeConfig *models.AzuredevopsScopeConfig, workItemType string) string {
+	if stdType, ok := scopeConfig.TypeMappings[workItemType]; ok && stdType != "" {
+		return strings.ToUpper(stdType)
+	}
+	if stdType, ok := defaultWorkItemTypeMappings[workItemType]; ok {
+		return stdType
+	}
+	return strings.ToUpper(workItemType)
+}
+
+func getStdWorkItemStatus(scopeConfig *models.AzuredevopsScopeConfig, state string) string {
+	if stdStatus, ok := scopeConfig.StatusMappings[state]; ok && stdStatus != "" {
+		return strings.ToUpper(stdStatus)
+	}
+	if stdStatus, ok := defaultWorkItemStatusMappings[state]; ok {
+		return stdStatus
+	}
+	return ticket.OTHER
+}
+
+// isUnderAreaPath tells if the area path equals to or is a descendant of the filter, an empty filter accepts everything
+func isUnderAreaPath(areaPath, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	return strings.EqualFold(areaPath, filter) || strings.HasPrefix(strings.ToLower(areaPath), strings.ToLower(filter)+`\`)
+}
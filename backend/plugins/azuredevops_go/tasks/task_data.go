@@ -40,10 +40,11 @@ type AzuredevopsOptions struct {
 }
 
 type AzuredevopsTaskData struct {
-	Options       *AzuredevopsOptions
-	ApiClient     *helper.ApiAsyncClient
-	TimeAfter     *time.Time
-	RegexEnricher *helper.RegexEnricher
+	Options         *AzuredevopsOptions
+	ApiClient       *helper.ApiAsyncClient
+	TimeAfter       *time.Time
+	RegexEnricher   *helper.RegexEnricher
+	DeploymentRules *helper.DeploymentRuleEngine
 }
 
 func DecodeTaskOptions(options map[string]interface{}) (*AzuredevopsOptions, errors.Error) {
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiWorkItemsMeta)
+}
+
+const RawWorkItemTable = "azuredevops_go_api_work_items"
+
+// a single WIQL query returns at most 20000 work items, and the batch api accepts at most 200 ids
+const (
+	wiqlPageSize      = 20000
+	workItemBatchSize = 200
+)
+
+var CollectApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiWorkItems",
+	EntryPoint:       CollectApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Collect work items from Azure DevOps API by WIQL, supports timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{},
+	ProductTables:    []string{RawWorkItemTable},
+}
+
+type WorkItemBatch struct {
+	Ids []int
+}
+
+func CollectApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+
+	apiCollector, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	ids, err := queryWorkItemIds(data, apiCollector.GetSince())
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("%d work items changed since last collection", len(ids))
+	iterator := api.NewQueueIterator()
+	for len(ids) > 0 {
+		n := workItemBatchSize
+		if len(ids) < n {
+			n = len(ids)
+		}
+		iterator.Push(&WorkItemBatch{Ids: ids[:n]})
+		ids = ids[n:]
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		Method:             http.MethodPost,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/wit/workitemsbatch?api-version=7.1",
+		RequestBody: func(reqData *api.RequestData) map[string]interface{} {
+			return map[string]interface{}{
+				"ids":         reqData.Input.(*WorkItemBatch).Ids,
+				"$expand":     "relations",
+				"errorPolicy": "omit",
+			}
+		},
+		ResponseParser: ParseRawMessageFromValue,
+		AfterResponse:  change203To401,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
+
+// queryWorkItemIds runs WIQL queries page by page to list ids of work items of the project changed since the given time,
+// the area path of the scope config is applied by the converters since the work items are shared by all repos of the project
+func queryWorkItemIds(data *AzuredevopsTaskData, since *time.Time) ([]int, errors.Error) {
+	conditions := []string{"[System.TeamProject] = @project"}
+	if since != nil {
+		conditions = append(conditions, fmt.Sprintf("[System.ChangedDate] >= '%s'", since.UTC().Format(time.RFC3339)))
+	}
+	path := fmt.Sprintf("%s/%s/_apis/wit/wiql", data.Options.OrganizationId, data.Options.ProjectId)
+	query := url.Values{}
+	query.Set("api-version", "7.1")
+	query.Set("timePrecision", "true")
+	query.Set("$top", fmt.Sprint(wiqlPageSize))
+
+	var ids []int
+	lastId := 0
+	for {
+		wiql := fmt.Sprintf(
+			"SELECT [System.Id] FROM WorkItems WHERE %s AND [System.Id] > %d ORDER BY [System.Id] ASC",
+			strings.Join(conditions, " AND "), lastId,
+		)
+		res, err := data.ApiClient.Post(path, query, map[string]string{"query": wiql}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err = change203To401(res); err != nil {
+			return nil, err
+		}
+		var result struct {
+			WorkItems []struct {
+				Id int `json:"id"`
+			} `json:"workItems"`
+		}
+		err = api.UnmarshalResponse(res, &result)
+		if err != nil {
+			return nil, err
+		}
+		for _, workItem := range result.WorkItems {
+			ids = append(ids, workItem.Id)
+			lastId = workItem.Id
+		}
+		if len(result.WorkItems) < wiqlPageSize {
+			return ids, nil
+		}
+	}
+}
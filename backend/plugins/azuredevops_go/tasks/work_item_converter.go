@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertWorkItemsMeta)
+}
+
+var ConvertWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItems",
+	EntryPoint:       ConvertWorkItems,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_azuredevops_go_work_items into domain layer table issues, board_issues, sprint_issues and issue_assignees",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.AzuredevopsWorkItem{}.TableName(),
+		models.AzuredevopsIteration{}.TableName(),
+	},
+	ProductTables: []string{
+		ticket.Issue{}.TableName(),
+		ticket.BoardIssue{}.TableName(),
+		ticket.SprintIssue{}.TableName(),
+		ticket.IssueAssignee{}.TableName(),
+	},
+}
+
+func ConvertWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+	db := taskCtx.GetDal()
+
+	var iterationIds []int
+	err := db.Pluck("azuredevops_id", &iterationIds,
+		dal.From(&models.AzuredevopsIteration{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	iterations := make(map[int]bool, len(iterationIds))
+	for _, id := range iterationIds {
+		iterations[id] = true
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsWorkItem{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	scopeConfig := data.Options.ScopeConfig
+	boardId := GenerateBoardId(data.Options.ConnectionId, data.Options.ProjectId, data.Options.ScopeConfig.AreaPath)
+	issueIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItem{})
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsWorkItem{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			workItem := inputRow.(*models.AzuredevopsWorkItem)
+			if !isUnderAreaPath(workItem.AreaPath, scopeConfig.AreaPath) {
+				return nil, nil
+			}
+
+			issue := &ticket.Issue{
+				DomainEntity:    domainlayer.DomainEntity{Id: issueIdGen.Generate(workItem.ConnectionId, workItem.AzuredevopsId)},
+				Url:             workItem.Url,
+				IssueKey:        strconv.Itoa(workItem.AzuredevopsId),
+				Title:           workItem.Title,
+				Description:     workItem.Description,
+				Type:            getStdWorkItemType(scopeConfig, workItem.Type),
+				OriginalType:    workItem.Type,
+				Status:          getStdWorkItemStatus(scopeConfig, workItem.State),
+				OriginalStatus:  workItem.State,
+				StoryPoint:      workItem.StoryPoints,
+				CreatedDate:     workItem.CreatedDate,
+				UpdatedDate:     workItem.ChangedDate,
+				CreatorName:     workItem.CreatedByName,
+				AssigneeName:    workItem.AssignedToName,
+				Priority:        workItem.Priority,
+				Severity:        workItem.Severity,
+				Component:       workItem.AreaPath,
+				OriginalProject: workItem.ProjectId,
+			}
+			if workItem.CreatedById != "" {
+				issue.CreatorId = userIdGen.Generate(workItem.ConnectionId, workItem.CreatedById)
+			}
+			if workItem.AssignedToId != "" {
+				issue.AssigneeId = userIdGen.Generate(workItem.ConnectionId, workItem.AssignedToId)
+			}
+			if workItem.ParentId != 0 {
+				issue.ParentIssueId = issueIdGen.Generate(workItem.ConnectionId, workItem.ParentId)
+			}
+			issue.OriginalEstimateMinutes = hoursToMinutes(workItem.OriginalEstimate)
+			issue.TimeSpentMinutes = hoursToMinutes(workItem.CompletedWork)
+			issue.TimeRemainingMinutes = hoursToMinutes(workItem.RemainingWork)
+			if issue.Status == ticket.DONE {
+				issue.ResolutionDate = workItem.ResolvedDate
+				if issue.ResolutionDate == nil {
+					issue.ResolutionDate = workItem.ClosedDate
+				}
+			}
+			if issue.ResolutionDate != nil && issue.CreatedDate != nil {
+				temp := uint(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+				issue.LeadTimeMinutes = &temp
+			}
+
+			results := []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}
+			if iterations[workItem.IterationId] {
+				results = append(results, &ticket.SprintIssue{
+					SprintId: sprintIdGen.Generate(workItem.ConnectionId, workItem.IterationId),
+					IssueId:  issue.Id,
+				})
+			}
+			if issue.AssigneeId != "" {
+				results = append(results, &ticket.IssueAssignee{
+					IssueId:      issue.Id,
+					AssigneeId:   issue.AssigneeId,
+					AssigneeName: issue.AssigneeName,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func hoursToMinutes(hours *float64) *int64 {
+	if hours == nil {
+		return nil
+	}
+	minutes := int64(*hours * 60)
+	return &minutes
+}
+
+// loadWorkItemIdsInScope returns ids of the work items of the project under the area path of the scope config
+func loadWorkItemIdsInScope(db dal.Dal, data *AzuredevopsTaskData) (map[int]bool, errors.Error) {
+	var workItems []SimpleWorkItem
+	err := db.All(&workItems,
+		dal.Select("azuredevops_id, area_path"),
+		dal.From(&models.AzuredevopsWorkItem{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int]bool, len(workItems))
+	for _, workItem := range workItems {
+		if isUnderAreaPath(workItem.AreaPath, data.Options.ScopeConfig.AreaPath) {
+			ids[workItem.AzuredevopsId] = true
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiWorkItemsMeta)
+}
+
+var ExtractApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItems",
+	EntryPoint:       ExtractApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Extract raw work items data into tool layer table _tool_azuredevops_go_work_items and _tool_azuredevops_go_work_item_links",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{RawWorkItemTable},
+	ProductTables: []string{
+		models.AzuredevopsWorkItem{}.TableName(),
+		models.AzuredevopsWorkItemLink{}.TableName(),
+	},
+}
+
+func ExtractApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiWorkItem := &models.AzuredevopsApiWorkItem{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiWorkItem))
+			if err != nil {
+				return nil, err
+			}
+
+			workItem := convertAzuredevopsWorkItem(apiWorkItem, data.Options)
+			results := []interface{}{workItem}
+			for _, relation := range apiWorkItem.Relations {
+				if relation.Rel != "ArtifactLink" {
+					continue
+				}
+				artifactType, repositoryId, artifactId := parseGitArtifactLink(relation.Url)
+				if artifactType != models.WorkItemLinkPullRequest && artifactType != models.WorkItemLinkCommit {
+					continue
+				}
+				results = append(results, &models.AzuredevopsWorkItemLink{
+					ConnectionId: data.Options.ConnectionId,
+					WorkItemId:   workItem.AzuredevopsId,
+					ArtifactType: artifactType,
+					RepositoryId: repositoryId,
+					ArtifactId:   artifactId,
+					Url:          relation.Url,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps work item extractor")
+	}
+
+	return extractor.Execute()
+}
+
+func convertAzuredevopsWorkItem(apiWorkItem *models.AzuredevopsApiWorkItem, options *AzuredevopsOptions) *models.AzuredevopsWorkItem {
+	fields := &apiWorkItem.Fields
+	workItem := &models.AzuredevopsWorkItem{
+		ConnectionId:     options.ConnectionId,
+		AzuredevopsId:    apiWorkItem.Id,
+		ProjectId:        options.ProjectId,
+		Rev:              apiWorkItem.Rev,
+		Type:             fields.WorkItemType,
+		State:            fields.State,
+		Reason:           fields.Reason,
+		Title:            fields.Title,
+		Description:      fields.Description,
+		AreaPath:         fields.AreaPath,
+		IterationId:      fields.IterationId,
+		IterationPath:    fields.IterationPath,
+		Severity:         fields.Severity,
+		StoryPoints:      fields.StoryPoints,
+		OriginalEstimate: fields.OriginalEstimate,
+		RemainingWork:    fields.RemainingWork,
+		CompletedWork:    fields.CompletedWork,
+		CreatedDate:      common.Iso8601TimeToTime(fields.CreatedDate),
+		ChangedDate:      common.Iso8601TimeToTime(fields.ChangedDate),
+		ResolvedDate:     common.Iso8601TimeToTime(fields.ResolvedDate),
+		ClosedDate:       common.Iso8601TimeToTime(fields.ClosedDate),
+		ParentId:         fields.Parent,
+		Tags:             fields.Tags,
+		Url:              apiWorkItem.Links.Html.Href,
+	}
+	// the Scrum process estimates backlog items with Effort instead of Story Points
+	if workItem.StoryPoints == nil {
+		workItem.StoryPoints = fields.Effort
+	}
+	if fields.Priority != nil {
+		workItem.Priority = strconv.Itoa(*fields.Priority)
+	}
+	if fields.AssignedTo != nil {
+		workItem.AssignedToId = fields.AssignedTo.Id
+		workItem.AssignedToName = fields.AssignedTo.DisplayName
+	}
+	if fields.CreatedBy != nil {
+		workItem.CreatedById = fields.CreatedBy.Id
+		workItem.CreatedByName = fields.CreatedBy.DisplayName
+	}
+	if workItem.Url == "" {
+		workItem.Url = apiWorkItem.Url
+	}
+	return workItem
+}
+
+// parseGitArtifactLink splits links like vstfs:///Git/PullRequestId/{projectId}%2F{repositoryId}%2F{pullRequestId}
+// or vstfs:///Git/Commit/{projectId}%2F{repositoryId}%2F{commitSha} into their parts
+func parseGitArtifactLink(link string) (artifactType string, repositoryId string, artifactId string) {
+	const prefix = "vstfs:///Git/"
+	if !strings.HasPrefix(link, prefix) {
+		return "", "", ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(link, prefix), "/", 2)
+	if len(parts) != 2 {
+		return "", "", ""
+	}
+	decoded, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return "", "", ""
+	}
+	segments := strings.Split(decoded, "/")
+	if len(segments) != 3 {
+		return "", "", ""
+	}
+	return parts[0], segments[1], segments[2]
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertWorkItemLinksMeta)
+}
+
+var ConvertWorkItemLinksMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItemLinks",
+	EntryPoint:       ConvertWorkItemLinks,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_azuredevops_go_work_item_links into domain layer table pull_request_issues and issue_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{
+		models.AzuredevopsWorkItem{}.TableName(),
+		models.AzuredevopsWorkItemLink{}.TableName(),
+	},
+	ProductTables: []string{
+		crossdomain.PullRequestIssue{}.TableName(),
+		crossdomain.IssueCommit{}.TableName(),
+	},
+}
+
+func ConvertWorkItemLinks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+	db := taskCtx.GetDal()
+
+	workItemIds, err := loadWorkItemIdsInScope(db, data)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsWorkItemLink{}),
+		dal.Where(
+			"connection_id = ? and work_item_id in (select azuredevops_id from _tool_azuredevops_go_work_items where connection_id = ? and project_id = ?)",
+			data.Options.ConnectionId, data.Options.ConnectionId, data.Options.ProjectId,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItem{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsPullRequest{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsWorkItemLink{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			link := inputRow.(*models.AzuredevopsWorkItemLink)
+			if !workItemIds[link.WorkItemId] {
+				return nil, nil
+			}
+			issueId := issueIdGen.Generate(link.ConnectionId, link.WorkItemId)
+
+			switch link.ArtifactType {
+			case models.WorkItemLinkPullRequest:
+				pullRequestKey, err := strconv.Atoi(link.ArtifactId)
+				if err != nil {
+					return nil, nil
+				}
+				return []interface{}{
+					&crossdomain.PullRequestIssue{
+						PullRequestId:  prIdGen.Generate(link.ConnectionId, pullRequestKey),
+						IssueId:        issueId,
+						PullRequestKey: pullRequestKey,
+						IssueKey:       strconv.Itoa(link.WorkItemId),
+					},
+				}, nil
+			case models.WorkItemLinkCommit:
+				return []interface{}{
+					&crossdomain.IssueCommit{
+						IssueId:   issueId,
+						CommitSha: link.ArtifactId,
+					},
+				}, nil
+			}
+			return nil, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
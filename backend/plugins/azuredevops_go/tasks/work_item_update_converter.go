@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertWorkItemUpdatesMeta)
+}
+
+var ConvertWorkItemUpdatesMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItemUpdates",
+	EntryPoint:       ConvertWorkItemUpdates,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_azuredevops_go_work_item_updates into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.AzuredevopsWorkItem{}.TableName(),
+		models.AzuredevopsWorkItemUpdate{}.TableName(),
+	},
+	ProductTables: []string{ticket.IssueChangelogs{}.TableName()},
+}
+
+func ConvertWorkItemUpdates(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawWorkItemUpdateTable)
+	db := taskCtx.GetDal()
+
+	workItemIds, err := loadWorkItemIdsInScope(db, data)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsWorkItemUpdate{}),
+		dal.Where(
+			"connection_id = ? and work_item_id in (select azuredevops_id from _tool_azuredevops_go_work_items where connection_id = ? and project_id = ?)",
+			data.Options.ConnectionId, data.Options.ConnectionId, data.Options.ProjectId,
+		),
+		dal.Orderby("work_item_id, update_id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	scopeConfig := data.Options.ScopeConfig
+	changelogIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItemUpdate{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItem{})
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsWorkItemUpdate{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			update := inputRow.(*models.AzuredevopsWorkItemUpdate)
+			if !workItemIds[update.WorkItemId] {
+				return nil, nil
+			}
+
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: changelogIdGen.Generate(update.ConnectionId, update.WorkItemId, update.UpdateId, update.FieldName),
+				},
+				IssueId:           issueIdGen.Generate(update.ConnectionId, update.WorkItemId),
+				AuthorName:        update.RevisedByName,
+				FieldId:           update.FieldName,
+				FieldName:         update.FieldName,
+				OriginalFromValue: update.OldValue,
+				OriginalToValue:   update.NewValue,
+				FromValue:         update.OldValue,
+				ToValue:           update.NewValue,
+			}
+			if update.RevisedById != "" {
+				changelog.AuthorId = userIdGen.Generate(update.ConnectionId, update.RevisedById)
+			}
+			if update.ChangedDate != nil {
+				changelog.CreatedDate = *update.ChangedDate
+			}
+
+			switch update.FieldName {
+			case fieldState:
+				changelog.FieldName = "status"
+				if update.OldValue != "" {
+					changelog.FromValue = getStdWorkItemStatus(scopeConfig, update.OldValue)
+				}
+				if update.NewValue != "" {
+					changelog.ToValue = getStdWorkItemStatus(scopeConfig, update.NewValue)
+				}
+			case fieldAssignedTo:
+				changelog.FieldName = "assignee"
+				changelog.FromValue, changelog.ToValue = "", ""
+				if update.OldValueId != "" {
+					changelog.FromValue = userIdGen.Generate(update.ConnectionId, update.OldValueId)
+				}
+				if update.NewValueId != "" {
+					changelog.ToValue = userIdGen.Generate(update.ConnectionId, update.NewValueId)
+				}
+			case fieldIterationId:
+				changelog.FieldName = "Sprint"
+				changelog.FromValue, changelog.ToValue = "", ""
+				if iterationId, e := strconv.Atoi(update.OldValue); e == nil {
+					changelog.FromValue = sprintIdGen.Generate(update.ConnectionId, iterationId)
+				}
+				if iterationId, e := strconv.Atoi(update.NewValue); e == nil {
+					changelog.ToValue = sprintIdGen.Generate(update.ConnectionId, iterationId)
+				}
+			case fieldWorkItemType:
+				changelog.FieldName = "type"
+			case fieldStoryPoints, fieldEffort:
+				changelog.FieldName = "story_points"
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
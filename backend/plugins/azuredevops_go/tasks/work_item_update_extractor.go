@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiWorkItemUpdatesMeta)
+}
+
+var ExtractApiWorkItemUpdatesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItemUpdates",
+	EntryPoint:       ExtractApiWorkItemUpdates,
+	EnabledByDefault: true,
+	Description:      "Extract raw work item updates data into tool layer table _tool_azuredevops_go_work_item_updates",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{RawWorkItemUpdateTable},
+	ProductTables:    []string{models.AzuredevopsWorkItemUpdate{}.TableName()},
+}
+
+const (
+	fieldState        = "System.State"
+	fieldAssignedTo   = "System.AssignedTo"
+	fieldIterationId  = "System.IterationId"
+	fieldWorkItemType = "System.WorkItemType"
+	fieldAreaPath     = "System.AreaPath"
+	fieldPriority     = "Microsoft.VSTS.Common.Priority"
+	fieldStoryPoints  = "Microsoft.VSTS.Scheduling.StoryPoints"
+	fieldEffort       = "Microsoft.VSTS.Scheduling.Effort"
+	fieldChangedDate  = "System.ChangedDate"
+)
+
+// trackedWorkItemFields are the fields whose changes are kept as changelogs
+var trackedWorkItemFields = []string{
+	fieldState,
+	fieldAssignedTo,
+	fieldIterationId,
+	fieldWorkItemType,
+	fieldAreaPath,
+	fieldPriority,
+	fieldStoryPoints,
+	fieldEffort,
+}
+
+func ExtractApiWorkItemUpdates(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemUpdateTable)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiUpdate := &models.AzuredevopsApiWorkItemUpdate{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiUpdate))
+			if err != nil {
+				return nil, err
+			}
+			changedDate, ok := apiUpdate.Fields[fieldChangedDate]
+			if !ok {
+				// updates without ChangedDate only touch relations or comments
+				return nil, nil
+			}
+			changedAt, e := common.ConvertStringToTime(fmt.Sprint(changedDate.NewValue))
+			if e != nil {
+				return nil, errors.Convert(e)
+			}
+
+			results := make([]interface{}, 0, len(trackedWorkItemFields))
+			for _, field := range trackedWorkItemFields {
+				change, ok := apiUpdate.Fields[field]
+				if !ok {
+					continue
+				}
+				update := &models.AzuredevopsWorkItemUpdate{
+					ConnectionId:  data.Options.ConnectionId,
+					WorkItemId:    apiUpdate.WorkItemId,
+					UpdateId:      apiUpdate.Id,
+					FieldName:     field,
+					Rev:           apiUpdate.Rev,
+					RevisedById:   apiUpdate.RevisedBy.Id,
+					RevisedByName: apiUpdate.RevisedBy.DisplayName,
+					ChangedDate:   &changedAt,
+				}
+				update.OldValue, update.OldValueId = formatWorkItemFieldValue(change.OldValue)
+				update.NewValue, update.NewValueId = formatWorkItemFieldValue(change.NewValue)
+				results = append(results, update)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps work item update extractor")
+	}
+
+	return extractor.Execute()
+}
+
+// formatWorkItemFieldValue returns the display value of a field, and the identity id if the field holds an identity
+func formatWorkItemFieldValue(value interface{}) (string, string) {
+	switch v := value.(type) {
+	case nil:
+		return "", ""
+	case string:
+		return v, ""
+	case map[string]interface{}:
+		displayName, _ := v["displayName"].(string)
+		id, _ := v["id"].(string)
+		return displayName, id
+	default:
+		return fmt.Sprint(v), ""
+	}
+}
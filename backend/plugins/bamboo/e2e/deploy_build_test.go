@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/plugins/bamboo/impl"
 	"github.com/apache/incubator-devlake/plugins/bamboo/models"
 	"github.com/apache/incubator-devlake/plugins/bamboo/tasks"
+	"github.com/stretchr/testify/assert"
 )
 
 func getFakeAPIClient() *helper.ApiAsyncClient {
@@ -61,6 +62,8 @@ func TestBambooDeployBuildDataFlow(t *testing.T) {
 	// verify extraction
 	dataflowTester.FlushTabler(&models.BambooDeployBuild{})
 	dataflowTester.FlushTabler(&models.BambooPlanBuildVcsRevision{})
+	dataflowTester.FlushTabler(&models.BambooAgent{})
+	dataflowTester.FlushTabler(&models.BambooJobBuild{})
 	dataflowTester.Subtask(tasks.ExtractDeployBuildMeta, taskData)
 
 	dataflowTester.VerifyTable(
@@ -88,6 +91,19 @@ func TestBambooDeployBuildDataFlow(t *testing.T) {
 			"allowed_to_create_version",
 			"allowed_to_set_version_status",
 			"environment",
+			"agent_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.BambooAgent{},
+		"./snapshot_tables/_tool_bamboo_agents.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"agent_id",
+			"name",
+			"type",
+			"active",
+			"enabled",
 		),
 	)
 
@@ -109,4 +125,62 @@ func TestBambooDeployBuildDataFlow(t *testing.T) {
 		IgnoreTypes:  []interface{}{common.NoPKModel{}},
 		IgnoreFields: []string{"created_date", "queued_date", "started_date", "finished_date"},
 	})
+
+	dataflowTester.FlushTabler(&devops.CicdRunner{})
+	dataflowTester.Subtask(tasks.ConvertAgentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CicdRunner{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cicd_runners.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
+
+func TestBambooDeployBuildDeploymentRulesDataFlow(t *testing.T) {
+	var bamboo impl.Bamboo
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bamboo", bamboo)
+	deploymentRules, err := helper.NewDeploymentRuleEngine([]*helper.DeploymentRule{
+		{
+			Name: "failed deploy builds",
+			Conditions: []*helper.DeploymentRuleCondition{
+				{Field: helper.DEPLOYMENT_RULE_RESULT, Operator: helper.DEPLOYMENT_RULE_EQUALS, Values: []string{"failed"}},
+			},
+		},
+		{
+			Name: "release-3 on dev",
+			Conditions: []*helper.DeploymentRuleCondition{
+				{Field: helper.DEPLOYMENT_RULE_PIPELINE_NAME, Operator: helper.DEPLOYMENT_RULE_MATCHES, Values: []string{"/release-3$"}},
+				{Field: helper.DEPLOYMENT_RULE_ENVIRONMENT, Operator: helper.DEPLOYMENT_RULE_EQUALS, Values: []string{"dev"}},
+			},
+			IsDeployment: true,
+			Environment:  devops.PRODUCTION,
+		},
+	})
+	assert.Nil(t, err)
+	taskData := &tasks.BambooOptions{
+		Options: &models.BambooOptions{
+			ConnectionId:      1,
+			PlanKey:           "TEST-PLA2",
+			BambooScopeConfig: &models.BambooScopeConfig{},
+		},
+		RegexEnricher:   helper.NewRegexEnricher(),
+		DeploymentRules: deploymentRules,
+		ApiClient:       getFakeAPIClient(),
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_bamboo_plan_build_commits.csv", &models.BambooPlanBuildVcsRevision{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_bamboo_deploy_builds.csv", &models.BambooDeployBuild{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_bamboo_plans.csv", models.BambooPlan{})
+
+	// the rules decide the environment ahead of envNamePattern
+	dataflowTester.FlushTabler(&devops.CICDDeployment{})
+	dataflowTester.Subtask(tasks.ConvertDeployBuildsToDeploymentMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CICDDeployment{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/cicd_deployments_with_rules.csv",
+		TargetFields: []string{"id", "name", "result", "original_result", "environment", "original_environment"},
+	})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeployBuildsToDeploymentCommitsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CicdDeploymentCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/cicd_deployment_commits_with_rules.csv",
+		TargetFields: []string{"id", "commit_sha", "name", "result", "original_result", "environment", "original_environment"},
+	})
 }
@@ -113,10 +113,12 @@ func TestBambooJobBuildDataFlow(t *testing.T) {
 			"queue_started_time",
 			"queue_duration",
 			"queue_duration_in_seconds",
+			"agent_name",
 		),
 	)
 
 	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_bamboo_agents.csv", &models.BambooAgent{})
 	dataflowTester.FlushTabler(&devops.CICDTask{})
 	dataflowTester.Subtask(tasks.ConvertJobBuildsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&devops.CICDTask{}, e2ehelper.TableOptions{
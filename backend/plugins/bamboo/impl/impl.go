@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -43,6 +44,7 @@ var _ interface {
 	plugin.DataSourcePluginBlueprintV200
 	plugin.CloseablePluginTask
 	plugin.PluginSource
+	helper.DeploymentCandidateSource
 } = (*Bamboo)(nil)
 
 type Bamboo struct{}
@@ -81,6 +83,7 @@ func (p Bamboo) GetTablesInfo() []dal.Tabler {
 		&models.BambooJobBuild{},
 		&models.BambooDeployBuild{},
 		&models.BambooDeployEnvironment{},
+		&models.BambooAgent{},
 		&models.BambooScopeConfig{},
 	}
 }
@@ -118,6 +121,7 @@ func (p Bamboo) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertPlanVcsMeta,
 		tasks.ConvertDeployBuildsToDeploymentCommitsMeta,
 		tasks.ConvertDeployBuildsToDeploymentMeta,
+		tasks.ConvertAgentsMeta,
 	}
 }
 
@@ -185,10 +189,15 @@ func (p Bamboo) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err := regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, op.EnvNamePattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
 	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 	return &tasks.BambooOptions{
-		Options:       op,
-		ApiClient:     apiClient,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}, nil
 }
 
@@ -262,3 +271,7 @@ func (p Bamboo) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+func (p Bamboo) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	return tasks.DeploymentCandidates(db, connectionId, scopeId, since)
+}
@@ -40,6 +40,7 @@ type BambooDeployBuild struct {
 	PlanKey               string     `json:"plan_key" gorm:"index"`
 	Environment           string     `gorm:"type:varchar(255)"`
 	PlanBranchName        string     `gorm:"type:varchar(255)"`
+	AgentId               uint64     `gorm:"index"`
 	ApiBambooOperations
 	common.NoPKModel
 }
@@ -82,6 +83,7 @@ func (api *ApiBambooDeployBuild) Convert(op *BambooOptions, envName string) []*B
 		Environment:           envName,
 		PlanBranchName:        api.DeploymentVersion.PlanBranchName,
 		EnvKey:                api.Key.EntityKey.Key,
+		AgentId:               api.Agent.Id,
 	}
 	for _, item := range api.DeploymentVersion.Items {
 		build := tmpl
@@ -93,6 +95,21 @@ func (api *ApiBambooDeployBuild) Convert(op *BambooOptions, envName string) []*B
 	return result
 }
 
+// ToBambooAgent returns nil for deployments which never got an agent
+func (agent ApiBambooDeployBuildAgent) ToBambooAgent(connectionId uint64) *BambooAgent {
+	if agent.Id == 0 {
+		return nil
+	}
+	return &BambooAgent{
+		ConnectionId: connectionId,
+		AgentId:      agent.Id,
+		Name:         agent.Name,
+		Type:         agent.Type,
+		Active:       agent.Active,
+		Enabled:      agent.Enable,
+	}
+}
+
 type ApiBambooDeployBuildAgent struct {
 	Id   uint64 `json:"id"`
 	Name string `json:"name"`
@@ -18,6 +18,7 @@ limitations under the License.
 package models
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/models/common"
@@ -69,6 +70,7 @@ type BambooJobBuild struct {
 	Type                     string     `gorm:"type:varchar(255)"`
 	Environment              string     `gorm:"type:varchar(255)"`
 	JobResultKey             string
+	AgentName                string `gorm:"type:varchar(255)"`
 	common.NoPKModel
 }
 
@@ -76,7 +78,27 @@ func (BambooJobBuild) TableName() string {
 	return "_tool_bamboo_job_builds"
 }
 
-type ApiBambooJobBuild ApiBambooPlanBuild
+type ApiBambooJobBuild struct {
+	ApiBambooPlanBuild
+	LogEntries struct {
+		LogEntry []struct {
+			UnstyledLog string `json:"unstyledLog"`
+		} `json:"logEntry"`
+	} `json:"logEntries"`
+}
+
+// the job results api does not expose the agent, bamboo writes it into the first lines of the build log
+var agentLogPattern = regexp.MustCompile(`started building on agent (.+?)(?:, bamboo version: .*)?$`)
+
+// AgentName returns the name of the agent which built the job, or an empty string if the log doesn't mention it
+func (apiRes *ApiBambooJobBuild) AgentName() string {
+	for _, entry := range apiRes.LogEntries.LogEntry {
+		if matches := agentLogPattern.FindStringSubmatch(entry.UnstyledLog); matches != nil {
+			return matches[1]
+		}
+	}
+	return ""
+}
 
 func (apiRes *ApiBambooJobBuild) Convert() *BambooJobBuild {
 	return &BambooJobBuild{
@@ -122,5 +144,6 @@ func (apiRes *ApiBambooJobBuild) Convert() *BambooJobBuild {
 		State:                    apiRes.State,
 		BuildState:               apiRes.BuildState,
 		JobResultKey:             apiRes.PlanResultKey.Key,
+		AgentName:                apiRes.AgentName(),
 	}
 }
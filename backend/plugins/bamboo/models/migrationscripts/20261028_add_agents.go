@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addAgents20261028 struct{}
+
+type bambooAgent20261028 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	AgentId      uint64 `gorm:"primaryKey"`
+	Name         string `gorm:"type:varchar(255)"`
+	Type         string `gorm:"type:varchar(100)"`
+	Active       bool
+	Enabled      bool
+	archived.NoPKModel
+}
+
+func (bambooAgent20261028) TableName() string {
+	return "_tool_bamboo_agents"
+}
+
+type bambooDeployBuild20261028 struct {
+	AgentId uint64 `gorm:"index"`
+}
+
+func (bambooDeployBuild20261028) TableName() string {
+	return "_tool_bamboo_deploy_builds"
+}
+
+func (*addAgents20261028) Up(baseRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(baseRes, &bambooAgent20261028{}, &bambooDeployBuild20261028{})
+}
+
+func (*addAgents20261028) Down(baseRes context.BasicRes) errors.Error {
+	db := baseRes.GetDal()
+	if err := db.DropColumns("_tool_bamboo_deploy_builds", "agent_id"); err != nil {
+		return err
+	}
+	return db.DropTables(&bambooAgent20261028{})
+}
+
+func (*addAgents20261028) Version() uint64 {
+	return 20261028000001
+}
+
+func (*addAgents20261028) Name() string {
+	return "add _tool_bamboo_agents and agent_id to _tool_bamboo_deploy_builds"
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addAgentNameToJobBuilds)(nil)
+
+type bambooJobBuild20261107 struct {
+	AgentName string `gorm:"type:varchar(255)"`
+}
+
+func (bambooJobBuild20261107) TableName() string {
+	return "_tool_bamboo_job_builds"
+}
+
+type addAgentNameToJobBuilds struct{}
+
+func (*addAgentNameToJobBuilds) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&bambooJobBuild20261107{})
+}
+
+func (*addAgentNameToJobBuilds) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(bambooJobBuild20261107{}.TableName(), "agent_name")
+}
+
+func (*addAgentNameToJobBuilds) Version() uint64 {
+	return 20261107000001
+}
+
+func (*addAgentNameToJobBuilds) Name() string {
+	return "add agent_name to _tool_bamboo_job_builds"
+}
@@ -38,5 +38,8 @@ func All() []plugin.MigrationScript {
 		new(addMissingPrimaryKeyForBambooPlanBuildVcsRevision),
 		new(addQueuedFieldsInJobBuild20231128),
 		new(addLinkHrefToBambooPlanBuild),
+		new(addAgents20261028),
+		new(addDeploymentRules),
+		new(addAgentNameToJobBuilds),
 	}
 }
@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
 type BambooScopeConfig struct {
@@ -27,6 +28,9 @@ type BambooScopeConfig struct {
 	DeploymentPattern  string           `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
 	ProductionPattern  string           `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	EnvNamePattern     string           `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
+
+	// DeploymentRules classify the pipelines and tasks ahead of deploymentPattern and productionPattern
+	DeploymentRules []*api.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
 }
 
 func (BambooScopeConfig) TableName() string {
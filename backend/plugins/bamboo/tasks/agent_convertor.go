@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+)
+
+var ConvertAgentsMeta = plugin.SubTaskMeta{
+	Name:             "convertAgents",
+	EntryPoint:       ConvertAgents,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table bamboo_agents into domain layer table cicd_runners",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// ConvertAgents converts the agents which ran deployments or built jobs of the plan. Agents are only
+// collected from deploy builds, so a job built on an agent which never deployed is not linked to a runner.
+func ConvertAgents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOY_BUILD_TABLE)
+	cursor, err := db.Cursor(
+		dal.From(&models.BambooAgent{}),
+		dal.Where(`connection_id = ? and (agent_id in (
+			select agent_id from _tool_bamboo_deploy_builds where connection_id = ? and plan_key = ?
+		) or name in (
+			select agent_name from _tool_bamboo_job_builds where connection_id = ? and plan_key = ?
+		))`, data.Options.ConnectionId, data.Options.ConnectionId, data.Options.PlanKey,
+			data.Options.ConnectionId, data.Options.PlanKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	agentIdGen := didgen.NewDomainIdGenerator(&models.BambooAgent{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BambooAgent{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			agent := inputRow.(*models.BambooAgent)
+			// bamboo is self-managed, elastic agents run in the owner's own cloud account
+			return []interface{}{
+				&devops.CicdRunner{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: agentIdGen.Generate(agent.ConnectionId, agent.AgentId),
+					},
+					Name:         agent.Name,
+					Pool:         agent.Type,
+					Os:           devops.GetRunnerOs(agent.Name),
+					IsSelfHosted: true,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
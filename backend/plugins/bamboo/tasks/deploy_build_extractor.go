@@ -51,6 +51,9 @@ func ExtractDeployBuild(taskCtx plugin.SubTaskContext) errors.Error {
 			for _, build := range builds {
 				result = append(result, build)
 			}
+			if agent := res.Agent.ToBambooAgent(data.Options.ConnectionId); agent != nil {
+				result = append(result, agent)
+			}
 			return result, nil
 		},
 	})
@@ -155,9 +155,9 @@ func ConvertDeployBuildsToDeploymentCommits(taskCtx plugin.SubTaskContext) error
 			if len(parts) > 1 {
 				deploymentCommit.Url = fmt.Sprintf("%s/deploy/viewEnvironment.action?id=%s", baseURL, parts[1])
 			}
-			if data.RegexEnricher.ReturnNameIfMatched(devops.ENV_NAME_PATTERN, input.Environment) != "" {
-				deploymentCommit.Environment = devops.PRODUCTION
-			}
+			deploymentCommit.Environment = classifyDeployBuildEnvironment(
+				data, input.GenerateCICDDeploymentCommitName(), input.Environment, input.PlanBranchName, input.DeploymentState,
+			)
 			if input.FinishedDate != nil && input.ExecutedDate != nil {
 				duration := float64(input.FinishedDate.Sub(*input.ExecutedDate).Milliseconds() / 1e3)
 				deploymentCommit.DurationSec = &duration
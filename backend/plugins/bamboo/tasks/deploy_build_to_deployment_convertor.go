@@ -18,7 +18,6 @@ limitations under the License.
 package tasks
 
 import (
-	"fmt"
 	"reflect"
 	"time"
 
@@ -72,10 +71,7 @@ func ConvertDeployBuildsToDeployments(taskCtx plugin.SubTaskContext) errors.Erro
 			if input.StartedDate != nil {
 				createdDate = *input.StartedDate
 			}
-			name := input.DeploymentVersionName
-			if input.ProjectPlanName != "" {
-				name = fmt.Sprintf("%s/%s", input.ProjectPlanName, input.DeploymentVersionName)
-			}
+			name := deployBuildName(input)
 
 			deployment := &devops.CICDDeployment{
 				DomainEntity: domainlayer.DomainEntity{
@@ -105,9 +101,7 @@ func ConvertDeployBuildsToDeployments(taskCtx plugin.SubTaskContext) errors.Erro
 				},
 				DisplayTitle: name,
 			}
-			if data.RegexEnricher.ReturnNameIfMatched(devops.ENV_NAME_PATTERN, input.Environment) != "" {
-				deployment.Environment = devops.PRODUCTION
-			}
+			deployment.Environment = classifyDeployBuildEnvironment(data, name, input.Environment, input.PlanBranchName, input.DeploymentState)
 			if input.FinishedDate != nil && input.ExecutedDate != nil {
 				duration := float64(input.FinishedDate.Sub(*input.ExecutedDate).Milliseconds() / 1e3)
 				deployment.DurationSec = &duration
@@ -122,3 +116,16 @@ func ConvertDeployBuildsToDeployments(taskCtx plugin.SubTaskContext) errors.Erro
 
 	return converter.Execute()
 }
+
+// classifyDeployBuildEnvironment gives the environment of a deploy build, the deployment rules of the scope config
+// decide ahead of its envNamePattern, which turns the matching environments into production
+func classifyDeployBuildEnvironment(data *BambooOptions, name, environment, branch, state string) string {
+	fallback := environment
+	if data.RegexEnricher.ReturnNameIfMatched(devops.ENV_NAME_PATTERN, environment) != "" {
+		fallback = devops.PRODUCTION
+	}
+	_, environment = data.DeploymentRules.Classify(
+		newDeployBuildDeploymentCandidate(name, environment, branch, state), devops.DEPLOYMENT, fallback,
+	)
+	return environment
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+)
+
+// newPlanBuildDeploymentCandidate describes a plan build to the deployment rules
+func newPlanBuildDeploymentCandidate(planBuild *models.BambooPlanBuild) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, planBuild.BuildState)
+	candidate.PipelineName = planBuild.PlanName
+	return candidate
+}
+
+// newJobBuildDeploymentCandidate describes a job build to the deployment rules
+func newJobBuildDeploymentCandidate(jobBuild *models.BambooJobBuild) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, jobBuild.BuildState)
+	candidate.JobName, candidate.PipelineName = jobBuild.JobName, jobBuild.PlanName
+	return candidate
+}
+
+// newDeployBuildDeploymentCandidate describes a deploy build to the deployment rules by the name of its deployment
+func newDeployBuildDeploymentCandidate(name, environment, branch, state string) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate(branch, false, state)
+	candidate.PipelineName, candidate.Environment = name, environment
+	return candidate
+}
+
+// deployBuildName is the name of the deployment of a deploy build, prefixed by the name of the plan when it is known
+func deployBuildName(deployBuild *bambooDeployBuildEx) string {
+	if deployBuild.ProjectPlanName != "" {
+		return fmt.Sprintf("%s/%s", deployBuild.ProjectPlanName, deployBuild.DeploymentVersionName)
+	}
+	return deployBuild.DeploymentVersionName
+}
+
+// DeploymentCandidates describes the plan builds, the job builds and the deploy builds of the plan started since the
+// time to the deployment rules by the ids of the cicd_pipelines, the cicd_tasks and the cicd_deployments they are
+// converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, planKey string, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var planBuilds []*models.BambooPlanBuild
+	err := db.All(&planBuilds, dal.Where("connection_id = ? AND plan_key = ? AND build_started_time >= ?", connectionId, planKey, since))
+	if err != nil {
+		return nil, err
+	}
+	planBuildIdGen := didgen.NewDomainIdGenerator(&models.BambooPlanBuild{})
+	for _, planBuild := range planBuilds {
+		candidates.Pipelines[planBuildIdGen.Generate(connectionId, planBuild.PlanBuildKey)] = newPlanBuildDeploymentCandidate(planBuild)
+	}
+	var jobBuilds []*models.BambooJobBuild
+	err = db.All(&jobBuilds, dal.Where("connection_id = ? AND plan_key = ? AND build_started_time >= ?", connectionId, planKey, since))
+	if err != nil {
+		return nil, err
+	}
+	jobBuildIdGen := didgen.NewDomainIdGenerator(&models.BambooJobBuild{})
+	for _, jobBuild := range jobBuilds {
+		candidates.Tasks[jobBuildIdGen.Generate(connectionId, jobBuild.JobBuildKey)] = newJobBuildDeploymentCandidate(jobBuild)
+	}
+	var deployBuilds []*bambooDeployBuildEx
+	err = db.All(
+		&deployBuilds,
+		dal.Select("db.*, p.name as project_plan_name, p.project_name"),
+		dal.From("_tool_bamboo_deploy_builds AS db"),
+		dal.Join("LEFT JOIN _tool_bamboo_plans as p ON db.plan_key = p.plan_key"),
+		dal.Where("db.connection_id = ? AND db.plan_key = ? AND db.started_date >= ?", connectionId, planKey, since),
+	)
+	if err != nil {
+		return nil, err
+	}
+	deploymentIdGen := didgen.NewDomainIdGenerator(&bambooDeployBuildEx{})
+	for _, deployBuild := range deployBuilds {
+		candidates.Deployments[deploymentIdGen.Generate(connectionId, deployBuild.DeployBuildId)] = newDeployBuildDeploymentCandidate(
+			deployBuildName(deployBuild), deployBuild.Environment, deployBuild.PlanBranchName, deployBuild.DeploymentState,
+		)
+	}
+	return candidates, nil
+}
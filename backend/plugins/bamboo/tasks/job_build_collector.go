@@ -63,7 +63,7 @@ func CollectJobBuild(taskCtx plugin.SubTaskContext) errors.Error {
 		Input:              iterator,
 		UrlTemplate:        "result/{{ .Input.JobKey }}.json",
 
-		Query:          QueryForResult,
+		Query:          QueryForJobResult,
 		GetTotalPages:  GetTotalPagesFromResult,
 		ResponseParser: GetResultsResult,
 	})
@@ -50,7 +50,13 @@ func ConvertJobBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 	defer cursor.Close()
 
+	agentIds, err := loadAgentIdsByName(db, data.Options.ConnectionId)
+	if err != nil {
+		return err
+	}
+
 	jobBuildIdGen := didgen.NewDomainIdGenerator(&models.BambooJobBuild{})
+	agentIdGen := didgen.NewDomainIdGenerator(&models.BambooAgent{})
 	planBuildIdGen := didgen.NewDomainIdGenerator(&models.BambooPlanBuild{})
 	planIdGen := didgen.NewDomainIdGenerator(&models.BambooPlan{})
 
@@ -95,6 +101,9 @@ func ConvertJobBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				OriginalStatus: line.LifeCycleState,
 			}
 
+			if agentId, ok := agentIds[line.AgentName]; ok {
+				domainJobBuild.RunnerId = agentIdGen.Generate(data.Options.ConnectionId, agentId)
+			}
 			domainJobBuild.Type = line.Type
 			domainJobBuild.Environment = line.Environment
 
@@ -110,3 +119,17 @@ func ConvertJobBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return converter.Execute()
 }
+
+// loadAgentIdsByName maps agent names to ids, job builds only know the name of the agent from the build log
+func loadAgentIdsByName(db dal.Dal, connectionId uint64) (map[string]uint64, errors.Error) {
+	var agents []models.BambooAgent
+	err := db.All(&agents, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return nil, err
+	}
+	agentIds := make(map[string]uint64, len(agents))
+	for _, agent := range agents {
+		agentIds[agent.Name] = agent.AgentId
+	}
+	return agentIds, nil
+}
@@ -54,6 +54,7 @@ func ExtractJobBuild(taskCtx plugin.SubTaskContext) errors.Error {
 			body.PlanBuildKey = fmt.Sprintf("%s-%v", plan.PlanKey, body.Number)
 			body.Type = data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, body.JobName)
 			body.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, body.JobName)
+			body.Type, body.Environment = data.DeploymentRules.Classify(newJobBuildDeploymentCandidate(body), body.Type, body.Environment)
 			results := make([]interface{}, 0)
 			results = append(results, body)
 			return results, nil
@@ -47,6 +47,7 @@ func ExtractPlanBuild(taskCtx plugin.SubTaskContext) errors.Error {
 			body.PlanKey = data.Options.PlanKey
 			body.Type = data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, body.PlanName)
 			body.Environment = data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, body.PlanName)
+			body.Type, body.Environment = data.DeploymentRules.Classify(newPlanBuildDeploymentCandidate(body), body.Type, body.Environment)
 
 			var url string
 			homepage, errGetHomePage := getBambooHomePage(body.LinkHref)
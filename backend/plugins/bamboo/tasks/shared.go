@@ -98,6 +98,16 @@ func QueryForResult(reqData *api.RequestData) (url.Values, errors.Error) {
 	return query, nil
 }
 
+// QueryForJobResult also expands the log entries, the agent which built a job is only mentioned there
+func QueryForJobResult(reqData *api.RequestData) (url.Values, errors.Error) {
+	query, err := QueryForResult(reqData)
+	if err != nil {
+		return nil, err
+	}
+	query.Set("expand", "results.result.vcsRevisions,results.result.logEntries")
+	return query, nil
+}
+
 func GetResultsResult(res *http.Response) ([]json.RawMessage, errors.Error) {
 	var resData struct {
 		Results struct {
@@ -24,9 +24,10 @@ import (
 )
 
 type BambooOptions struct {
-	Options       *models.BambooOptions
-	ApiClient     *helper.ApiAsyncClient
-	RegexEnricher *helper.RegexEnricher
+	Options         *models.BambooOptions
+	ApiClient       *helper.ApiAsyncClient
+	RegexEnricher   *helper.RegexEnricher
+	DeploymentRules *helper.DeploymentRuleEngine
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*models.BambooOptions, errors.Error) {
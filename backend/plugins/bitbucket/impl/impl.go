@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -43,6 +44,7 @@ var _ interface {
 	plugin.CloseablePluginTask
 	plugin.DataSourcePluginBlueprintV200
 	plugin.PluginSource
+	helper.DeploymentCandidateSource
 } = (*Bitbucket)(nil)
 
 type Bitbucket struct{}
@@ -170,10 +172,15 @@ func (p Bitbucket) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ProductionPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
 	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 	taskData := &tasks.BitbucketTaskData{
-		Options:       op,
-		ApiClient:     apiClient,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	return taskData, nil
@@ -303,3 +310,7 @@ func EnrichOptions(taskCtx plugin.TaskContext,
 	}
 	return err
 }
+
+func (p Bitbucket) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	return tasks.DeploymentCandidates(db, connectionId, scopeId, since)
+}
@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"gorm.io/datatypes"
 )
 
@@ -33,6 +34,9 @@ type BitbucketScopeConfig struct {
 	IssueStatusInProgress string `mapstructure:"issueStatusInProgress,omitempty" json:"issueStatusInProgress" gorm:"type:varchar(255)"`
 	IssueStatusDone       string `mapstructure:"issueStatusDone,omitempty" json:"issueStatusDone" gorm:"type:varchar(255)"`
 	IssueStatusOther      string `mapstructure:"issueStatusOther,omitempty" json:"issueStatusOther" gorm:"type:varchar(255)"`
+
+	// DeploymentRules classify the pipelines and tasks ahead of deploymentPattern and productionPattern
+	DeploymentRules []*api.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
 }
 
 func (BitbucketScopeConfig) TableName() string {
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+// newPipelineDeploymentCandidate describes a pipeline to the deployment rules
+func newPipelineDeploymentCandidate(pipeline *models.BitbucketPipeline) *api.DeploymentCandidate {
+	return api.NewDeploymentCandidate(pipeline.RefName, false, pipeline.Result)
+}
+
+// newPipelineStepDeploymentCandidate describes a step of a pipeline to the deployment rules
+func newPipelineStepDeploymentCandidate(step *models.BitbucketPipelineStep) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, step.Result)
+	candidate.JobName, candidate.Trigger = step.Name, step.Trigger
+	return candidate
+}
+
+// DeploymentCandidates describes the pipelines of the repo created since the time and their steps to the deployment
+// rules by the ids of the cicd_pipelines and the cicd_tasks they are converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, fullName string, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var pipelines []*models.BitbucketPipeline
+	err := db.All(&pipelines, dal.Where("connection_id = ? AND repo_id = ? AND bitbucket_created_on >= ?", connectionId, fullName, since))
+	if err != nil {
+		return nil, err
+	}
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.BitbucketPipeline{})
+	for _, pipeline := range pipelines {
+		candidates.Pipelines[pipelineIdGen.Generate(connectionId, pipeline.BitbucketId)] = newPipelineDeploymentCandidate(pipeline)
+	}
+	var steps []*models.BitbucketPipelineStep
+	err = db.All(&steps, dal.Where("connection_id = ? AND repo_id = ? AND started_on >= ?", connectionId, fullName, since))
+	if err != nil {
+		return nil, err
+	}
+	stepIdGen := didgen.NewDomainIdGenerator(&models.BitbucketPipelineStep{})
+	for _, step := range steps {
+		candidates.Tasks[stepIdGen.Generate(connectionId, step.BitbucketId)] = newPipelineStepDeploymentCandidate(step)
+	}
+	return candidates, nil
+}
@@ -111,6 +111,10 @@ func ExtractApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
 			} else if bitbucketApiPipeline.State.Stage != nil {
 				bitbucketPipeline.Result = bitbucketApiPipeline.State.Stage.Name
 			}
+			bitbucketPipeline.Type, bitbucketPipeline.Environment = data.DeploymentRules.Classify(
+				newPipelineDeploymentCandidate(bitbucketPipeline),
+				bitbucketPipeline.Type, bitbucketPipeline.Environment,
+			)
 
 			results := make([]interface{}, 0, 2)
 			results = append(results, bitbucketPipeline)
@@ -96,6 +96,9 @@ func ExtractPipelineSteps(taskCtx plugin.SubTaskContext) errors.Error {
 				Type:              data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, apiPipelineStep.Name),
 				Environment:       data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, apiPipelineStep.Name),
 			}
+			bitbucketStep.Type, bitbucketStep.Environment = data.DeploymentRules.Classify(
+				newPipelineStepDeploymentCandidate(bitbucketStep), bitbucketStep.Type, bitbucketStep.Environment,
+			)
 			return []interface{}{
 				bitbucketStep,
 			}, nil
@@ -32,9 +32,10 @@ type BitbucketOptions struct {
 }
 
 type BitbucketTaskData struct {
-	Options       *BitbucketOptions
-	ApiClient     *api.ApiAsyncClient
-	RegexEnricher *api.RegexEnricher
+	Options         *BitbucketOptions
+	ApiClient       *api.ApiAsyncClient
+	RegexEnricher   *api.RegexEnricher
+	DeploymentRules *api.DeploymentRuleEngine
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*BitbucketOptions, errors.Error) {
@@ -70,6 +70,22 @@ func MakeDataSourcePipelinePlanV200(
 	return plan, scopes, nil
 }
 
+// commitsFromApiSubtaskMetas collect diffstats and refs and convert commits from the rest api, they replace
+// gitextractor if CommitsFromApi is enabled in the scope config. Commits themselves are always collected for
+// the build statuses.
+var commitsFromApiSubtaskMetas = []plugin.SubTaskMeta{
+	tasks.CollectApiCommitDiffsMeta,
+	tasks.ExtractApiCommitDiffsMeta,
+	tasks.CollectApiBranchesMeta,
+	tasks.ExtractApiBranchesMeta,
+	tasks.CollectApiTagsMeta,
+	tasks.ExtractApiTagsMeta,
+	tasks.ConvertCommitsMeta,
+	tasks.ConvertCommitFilesMeta,
+	tasks.ConvertBranchesMeta,
+	tasks.ConvertTagsMeta,
+}
+
 func makeDataSourcePipelinePlanV200(
 	subtaskMetas []plugin.SubTaskMeta,
 	scopeDetails []*srvhelper.ScopeDetail[models.BitbucketServerRepo, models.BitbucketServerScopeConfig],
@@ -114,6 +130,12 @@ func makeDataSourcePipelinePlanV200(
 		if err != nil {
 			return nil, err
 		}
+		collectCode := utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE)
+		if collectCode && scopeConfig.CommitsFromApi {
+			for _, subtaskMeta := range commitsFromApiSubtaskMetas {
+				subtasks = append(subtasks, subtaskMeta.Name)
+			}
+		}
 		stage = append(stage, &coreModels.PipelineTask{
 			Plugin:   "bitbucket_server",
 			Subtasks: subtasks,
@@ -124,7 +146,7 @@ func makeDataSourcePipelinePlanV200(
 		}
 
 		// add gitex stage
-		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) {
+		if collectCode && !scopeConfig.CommitsFromApi {
 			cloneUrl, err := errors.Convert01(url.Parse(repo.CloneUrl))
 			if err != nil {
 				return nil, err
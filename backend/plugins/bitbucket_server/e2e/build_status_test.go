@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/tasks"
+)
+
+func TestBuildStatusDataFlow(t *testing.T) {
+	var plugin impl.BitbucketServer
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket_server", plugin)
+
+	regexEnricher := api.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)deploy")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)prod")
+	taskData := &tasks.BitbucketServerTaskData{
+		Options: &tasks.BitbucketServerOptions{
+			ConnectionId: 3,
+			FullName:     "TP/repos/first-repo",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_build_statuses.csv", "_raw_bitbucket_server_api_build_statuses")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_bitbucket_server_repos.csv", &models.BitbucketServerRepo{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.BitbucketServerBuildStatus{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildStatusesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.BitbucketServerBuildStatus{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_bitbucket_server_build_statuses.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertBuildStatusesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CICDPipeline{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_pipelines.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CiCDPipelineCommit{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_pipeline_commits.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+}
+
+func TestBuildStatusDeploymentRulesDataFlow(t *testing.T) {
+	var plugin impl.BitbucketServer
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket_server", plugin)
+
+	regexEnricher := api.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)deploy")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)prod")
+	deploymentRules, err := api.NewDeploymentRuleEngine([]*api.DeploymentRule{
+		{
+			Name:         "staging deploys",
+			Conditions:   []*api.DeploymentRuleCondition{{Field: api.DEPLOYMENT_RULE_JOB_NAME, Operator: api.DEPLOYMENT_RULE_MATCHES, Values: []string{"-STAGING$"}}},
+			IsDeployment: true,
+			Environment:  devops.STAGING,
+		},
+		{
+			Name:         "production deploys",
+			Conditions:   []*api.DeploymentRuleCondition{{Field: api.DEPLOYMENT_RULE_PIPELINE_NAME, Operator: api.DEPLOYMENT_RULE_MATCHES, Values: []string{"deploy to production"}}},
+			IsDeployment: true,
+			Environment:  devops.PRODUCTION,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskData := &tasks.BitbucketServerTaskData{
+		Options: &tasks.BitbucketServerOptions{
+			ConnectionId: 3,
+			FullName:     "TP/repos/first-repo",
+		},
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
+	}
+
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_build_statuses.csv", "_raw_bitbucket_server_api_build_statuses")
+
+	// the rules classify the build statuses ahead of the patterns
+	dataflowTester.FlushTabler(&models.BitbucketServerBuildStatus{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildStatusesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.BitbucketServerBuildStatus{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_bitbucket_server_build_statuses_with_rules.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+}
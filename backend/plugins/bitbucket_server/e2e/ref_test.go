@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/tasks"
+)
+
+func TestRefDataFlow(t *testing.T) {
+	var plugin impl.BitbucketServer
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket_server", plugin)
+
+	taskData := &tasks.BitbucketServerTaskData{
+		Options: &tasks.BitbucketServerOptions{
+			ConnectionId: 3,
+			FullName:     "TP/repos/first-repo",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_branches.csv", "_raw_bitbucket_server_api_branches")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_tags.csv", "_raw_bitbucket_server_api_tags")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.BitbucketServerRef{})
+	dataflowTester.Subtask(tasks.ExtractApiBranchesMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractApiTagsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.BitbucketServerRef{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_bitbucket_server_refs.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.Ref{})
+	dataflowTester.Subtask(tasks.ConvertBranchesMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertTagsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		code.Ref{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/refs.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+}
@@ -19,11 +19,13 @@ package impl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/bitbucket_server/api"
@@ -42,6 +44,7 @@ var _ interface {
 	plugin.CloseablePluginTask
 	plugin.DataSourcePluginBlueprintV200
 	plugin.PluginSource
+	helper.DeploymentCandidateSource
 } = (*BitbucketServer)(nil)
 
 type BitbucketServer struct{}
@@ -73,6 +76,10 @@ func (p BitbucketServer) GetTablesInfo() []dal.Tabler {
 		&models.BitbucketServerRepo{},
 		&models.BitbucketServerPrCommit{},
 		&models.BitbucketServerScopeConfig{},
+		&models.BitbucketServerCommit{},
+		&models.BitbucketServerCommitFile{},
+		&models.BitbucketServerRef{},
+		&models.BitbucketServerBuildStatus{},
 	}
 }
 
@@ -95,6 +102,19 @@ func (p BitbucketServer) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectApiPrCommitsMeta,
 		tasks.ExtractApiPrCommitsMeta,
 
+		tasks.CollectApiCommitsMeta,
+		tasks.ExtractApiCommitsMeta,
+		tasks.CollectApiCommitDiffsMeta,
+		tasks.ExtractApiCommitDiffsMeta,
+
+		tasks.CollectApiBranchesMeta,
+		tasks.ExtractApiBranchesMeta,
+		tasks.CollectApiTagsMeta,
+		tasks.ExtractApiTagsMeta,
+
+		tasks.CollectApiBuildStatusesMeta,
+		tasks.ExtractApiBuildStatusesMeta,
+
 		tasks.ConvertRepoMeta, // ?
 		tasks.ConvertPullRequestsMeta,
 
@@ -102,6 +122,12 @@ func (p BitbucketServer) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertPrCommitsMeta,
 
 		tasks.ConvertUsersMeta,
+
+		tasks.ConvertCommitsMeta,
+		tasks.ConvertCommitFilesMeta,
+		tasks.ConvertBranchesMeta,
+		tasks.ConvertTagsMeta,
+		tasks.ConvertBuildStatusesMeta,
 	}
 }
 
@@ -133,10 +159,21 @@ func (p BitbucketServer) PrepareTaskData(taskCtx plugin.TaskContext, options map
 	}
 
 	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(devops.DEPLOYMENT, op.DeploymentPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ProductionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
+	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 	taskData := &tasks.BitbucketServerTaskData{
-		Options:       op,
-		ApiClient:     apiClient,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	return taskData, nil
@@ -266,3 +303,7 @@ func EnrichOptions(taskCtx plugin.TaskContext,
 	}
 	return err
 }
+
+func (p BitbucketServer) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	return tasks.DeploymentCandidates(db, connectionId, scopeId, since)
+}
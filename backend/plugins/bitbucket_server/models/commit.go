@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type BitbucketServerCommit struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	RepoId         string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha      string `gorm:"primaryKey;type:varchar(40)"`
+	DisplayId      string `gorm:"type:varchar(40)"`
+	Message        string
+	AuthorName     string `gorm:"type:varchar(255)"`
+	AuthorEmail    string `gorm:"type:varchar(255)"`
+	AuthoredDate   time.Time
+	CommitterName  string    `gorm:"type:varchar(255)"`
+	CommitterEmail string    `gorm:"type:varchar(255)"`
+	CommittedDate  time.Time `gorm:"index"`
+	common.NoPKModel
+}
+
+func (BitbucketServerCommit) TableName() string {
+	return "_tool_bitbucket_server_commits"
+}
+
+// BitbucketServerCommitFile holds the diffstat of a file changed by the commit
+type BitbucketServerCommitFile struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha    string `gorm:"primaryKey;type:varchar(40)"`
+	FilePath     string `gorm:"primaryKey;type:varchar(255)"`
+	Additions    int
+	Deletions    int
+	common.NoPKModel
+}
+
+func (BitbucketServerCommitFile) TableName() string {
+	return "_tool_bitbucket_server_commit_files"
+}
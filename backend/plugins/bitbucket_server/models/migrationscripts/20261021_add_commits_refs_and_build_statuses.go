@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models/migrationscripts/archived"
+)
+
+type scopeConfig20261021 struct {
+	DeploymentPattern string `gorm:"type:varchar(255)"`
+	ProductionPattern string `gorm:"type:varchar(255)"`
+	CommitsFromApi    bool
+}
+
+func (scopeConfig20261021) TableName() string {
+	return "_tool_bitbucket_server_scope_configs"
+}
+
+type addCommitsRefsAndBuildStatuses struct{}
+
+func (script *addCommitsRefsAndBuildStatuses) Up(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().AutoMigrate(&scopeConfig20261021{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.BitbucketServerCommit{},
+		&archived.BitbucketServerCommitFile{},
+		&archived.BitbucketServerRef{},
+		&archived.BitbucketServerBuildStatus{},
+	)
+}
+
+func (script *addCommitsRefsAndBuildStatuses) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_bitbucket_server_scope_configs", "deployment_pattern", "production_pattern", "commits_from_api"); err != nil {
+		return err
+	}
+	return db.DropTables(&archived.BitbucketServerCommit{}, &archived.BitbucketServerCommitFile{}, &archived.BitbucketServerRef{}, &archived.BitbucketServerBuildStatus{})
+}
+
+func (*addCommitsRefsAndBuildStatuses) Version() uint64 {
+	return 20261021000001
+}
+
+func (*addCommitsRefsAndBuildStatuses) Name() string {
+	return "add commits, refs and build statuses for bitbucket server"
+}
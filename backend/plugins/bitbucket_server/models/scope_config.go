@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"gorm.io/datatypes"
 )
 
@@ -29,9 +30,13 @@ type BitbucketServerScopeConfig struct {
 	PrComponent        string `mapstructure:"prComponent,omitempty" json:"prComponent" gorm:"type:varchar(255)"`
 	PrBodyClosePattern string `mapstructure:"prBodyClosePattern,omitempty" json:"prBodyClosePattern" gorm:"type:varchar(255)"`
 
-	// DeploymentPattern  string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
-	// ProductionPattern  string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
-	Refdiff datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	DeploymentPattern string `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
+	ProductionPattern string `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
+	// DeploymentRules classify the build statuses ahead of deploymentPattern and productionPattern
+	DeploymentRules []*api.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
+	// CommitsFromApi collects commits, diffstats, branches and tags from the rest api instead of cloning the repo by gitextractor
+	CommitsFromApi bool              `mapstructure:"commitsFromApi,omitempty" json:"commitsFromApi"`
+	Refdiff        datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
 
 	// a string array, split by `,`.
 }
@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -143,3 +144,27 @@ func GetPullRequestsIterator(taskCtx plugin.SubTaskContext, apiCollector *helper
 
 	return helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(BitbucketServerInput{}))
 }
+
+// GetCommitsIterator iterates the commits of the repo committed after the given time, or all commits if it is nil
+func GetCommitsIterator(taskCtx plugin.SubTaskContext, committedAfter *time.Time) (*helper.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*BitbucketServerTaskData)
+	clauses := []dal.Clause{
+		dal.Select("c.commit_sha"),
+		dal.From("_tool_bitbucket_server_commits c"),
+		dal.Where(
+			`c.repo_id = ? and c.connection_id = ?`,
+			data.Options.FullName, data.Options.ConnectionId,
+		),
+	}
+	if committedAfter != nil {
+		clauses = append(clauses, dal.Where("c.committed_date > ?", *committedAfter))
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(BitbucketServerCommitInput{}))
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+const RAW_BUILD_STATUSES_TABLE = "bitbucket_server_api_build_statuses"
+
+var CollectApiBuildStatusesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBuildStatuses",
+	EntryPoint:       CollectApiBuildStatuses,
+	EnabledByDefault: true,
+	Description:      "Collect the build statuses reported by CI servers like Bamboo or Jenkins for each commit from Bitbucket Server api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiCommitsMeta},
+	ProductTables:    []string{RAW_BUILD_STATUSES_TABLE},
+}
+
+func CollectApiBuildStatuses(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_STATUSES_TABLE)
+	db := taskCtx.GetDal()
+	collector, err := helper.NewStatefulApiCollectorForFinalizableEntity(helper.FinalizableApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		CollectNewRecordsByList: helper.FinalizableApiCollectorListArgs{
+			PageSize:              100,
+			GetNextPageCustomData: GetNextPageCustomData,
+			BuildInputIterator: func(isIncremental bool, createdAfter *time.Time) (helper.Iterator, errors.Error) {
+				if !isIncremental {
+					createdAfter = nil
+				}
+				return GetCommitsIterator(taskCtx, createdAfter)
+			},
+			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
+				UrlTemplate: "rest/build-status/1.0/commits/{{ .Input.CommitSha }}",
+				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+					return GetQueryForNextPage(reqData)
+				},
+				ResponseParser: GetRawMessageFromResponse,
+				AfterResponse:  ignoreHTTPStatus404,
+			},
+		},
+		// builds of older commits might still be running during the last collection
+		CollectUnfinishedDetails: &helper.FinalizableApiCollectorDetailArgs{
+			BuildInputIterator: func() (helper.Iterator, errors.Error) {
+				cursor, err := db.Cursor(
+					dal.Select("DISTINCT commit_sha"),
+					dal.From(&models.BitbucketServerBuildStatus{}),
+					dal.Where(
+						"connection_id = ? AND repo_id = ? AND state = ?",
+						data.Options.ConnectionId, data.Options.FullName, models.BUILD_STATE_INPROGRESS,
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(BitbucketServerCommitInput{}))
+			},
+			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
+				UrlTemplate:    "rest/build-status/1.0/commits/{{ .Input.CommitSha }}",
+				ResponseParser: GetRawMessageFromResponse,
+				AfterResponse:  ignoreHTTPStatus404,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+var ConvertBuildStatusesMeta = plugin.SubTaskMeta{
+	Name:             "convertBuildStatuses",
+	EntryPoint:       ConvertBuildStatuses,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table bitbucket_server_build_statuses into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertBuildStatuses(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_STATUSES_TABLE)
+	db := taskCtx.GetDal()
+
+	repo := &models.BitbucketServerRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND bitbucket_id = ?", data.Options.ConnectionId, data.Options.FullName))
+	if err != nil {
+		return err
+	}
+	domainRepoId := didgen.NewDomainIdGenerator(&models.BitbucketServerRepo{}).Generate(data.Options.ConnectionId, data.Options.FullName)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.BitbucketServerBuildStatus{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	buildStatusIdGen := didgen.NewDomainIdGenerator(&models.BitbucketServerBuildStatus{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BitbucketServerBuildStatus{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			buildStatus := inputRow.(*models.BitbucketServerBuildStatus)
+			pipelineId := buildStatusIdGen.Generate(buildStatus.ConnectionId, buildStatus.RepoId, buildStatus.CommitSha, buildStatus.BuildKey)
+			name := buildStatus.Name
+			if name == "" {
+				name = buildStatus.BuildKey
+			}
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: pipelineId,
+				},
+				Name:         name,
+				DisplayTitle: name,
+				Url:          buildStatus.Url,
+				Result: devops.GetResult(&devops.ResultRule{
+					Success: []string{models.BUILD_STATE_SUCCESSFUL},
+					Failure: []string{models.BUILD_STATE_FAILED},
+					Default: devops.RESULT_DEFAULT,
+				}, buildStatus.State),
+				OriginalResult: buildStatus.State,
+				Status: devops.GetStatus(&devops.StatusRule{
+					Done:       []string{models.BUILD_STATE_SUCCESSFUL, models.BUILD_STATE_FAILED},
+					InProgress: []string{models.BUILD_STATE_INPROGRESS},
+					Default:    devops.STATUS_OTHER,
+				}, buildStatus.State),
+				OriginalStatus: buildStatus.State,
+				Type:           buildStatus.Type,
+				Environment:    buildStatus.Environment,
+				CicdScopeId:    domainRepoId,
+			}
+			// the build-status api only keeps the time of the latest state
+			if buildStatus.DateAdded != nil {
+				domainPipeline.CreatedDate = *buildStatus.DateAdded
+				if domainPipeline.Status == devops.STATUS_DONE {
+					domainPipeline.FinishedDate = buildStatus.DateAdded
+				}
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId:   pipelineId,
+				CommitSha:    buildStatus.CommitSha,
+				DisplayTitle: name,
+				Url:          buildStatus.Url,
+				RepoId:       domainRepoId,
+				RepoUrl:      repo.HTMLUrl,
+			}
+			return []interface{}{
+				domainPipeline,
+				domainPipelineCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
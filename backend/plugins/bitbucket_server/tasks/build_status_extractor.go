@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+var ExtractApiBuildStatusesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBuildStatuses",
+	EntryPoint:       ExtractApiBuildStatuses,
+	EnabledByDefault: true,
+	Description:      "Extract raw build statuses data into tool layer table bitbucket_server_build_statuses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type ApiBuildStatusResponse struct {
+	State       string `json:"state"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Url         string `json:"url"`
+	Description string `json:"description"`
+	DateAdded   int64  `json:"dateAdded"`
+}
+
+func ExtractApiBuildStatuses(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_STATUSES_TABLE)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiBuildStatus := &ApiBuildStatusResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiBuildStatus))
+			if err != nil {
+				return nil, err
+			}
+			input := &BitbucketServerCommitInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			buildStatus := &models.BitbucketServerBuildStatus{
+				ConnectionId: data.Options.ConnectionId,
+				RepoId:       data.Options.FullName,
+				CommitSha:    input.CommitSha,
+				BuildKey:     apiBuildStatus.Key,
+				Name:         apiBuildStatus.Name,
+				State:        apiBuildStatus.State,
+				Url:          apiBuildStatus.Url,
+				Description:  apiBuildStatus.Description,
+			}
+			buildStatus.Type, buildStatus.Environment = data.DeploymentRules.Classify(
+				newBuildStatusDeploymentCandidate(buildStatus),
+				data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, apiBuildStatus.Key, apiBuildStatus.Name),
+				data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, apiBuildStatus.Key, apiBuildStatus.Name),
+			)
+			if apiBuildStatus.DateAdded > 0 {
+				dateAdded := time.UnixMilli(apiBuildStatus.DateAdded)
+				buildStatus.DateAdded = &dateAdded
+			}
+			return []interface{}{buildStatus}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_COMMITS_TABLE = "bitbucket_server_api_commits"
+
+var CollectApiCommitsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiCommits",
+	EntryPoint:       CollectApiCommits,
+	EnabledByDefault: true,
+	Description:      "Collect commits of the default branch from Bitbucket Server api, supports timeFilter but not diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_CICD},
+	ProductTables:    []string{RAW_COMMITS_TABLE},
+}
+
+type SimpleApiCommit struct {
+	CommitterTimestamp int64 `json:"committerTimestamp"`
+}
+
+func CollectApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMITS_TABLE)
+	collector, err := helper.NewStatefulApiCollectorForFinalizableEntity(helper.FinalizableApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		CollectNewRecordsByList: helper.FinalizableApiCollectorListArgs{
+			PageSize:              100,
+			GetNextPageCustomData: GetNextPageCustomData,
+			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
+				UrlTemplate: "rest/api/1.0/projects/{{ .Params.FullName }}/commits",
+				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+					return GetQueryForNextPage(reqData)
+				},
+				ResponseParser: GetRawMessageFromResponse,
+				AfterResponse:  ignoreHTTPStatus404,
+			},
+			// commits are listed in reverse chronological order
+			GetCreated: func(item json.RawMessage) (time.Time, errors.Error) {
+				commit := &SimpleApiCommit{}
+				err := json.Unmarshal(item, commit)
+				if err != nil {
+					return time.Time{}, errors.BadInput.Wrap(err, "failed to unmarshal bitbucket server commit")
+				}
+				return time.UnixMilli(commit.CommitterTimestamp), nil
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
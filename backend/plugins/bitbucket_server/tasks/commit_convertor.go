@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+var ConvertCommitsMeta = plugin.SubTaskMeta{
+	Name:             "convertCommits",
+	EntryPoint:       ConvertCommits,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table bitbucket_server_commits into domain layer table commits and repo_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var ConvertCommitFilesMeta = plugin.SubTaskMeta{
+	Name:             "convertCommitFiles",
+	EntryPoint:       ConvertCommitFiles,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table bitbucket_server_commit_files into domain layer table commit_files",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type commitDiffstat struct {
+	CommitSha string
+	Additions int
+	Deletions int
+}
+
+func ConvertCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMITS_TABLE)
+	db := taskCtx.GetDal()
+
+	// sum up the diffstat of files for each commit
+	var diffstats []commitDiffstat
+	err := db.All(
+		&diffstats,
+		dal.Select("commit_sha, SUM(additions) AS additions, SUM(deletions) AS deletions"),
+		dal.From(&models.BitbucketServerCommitFile{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.FullName),
+		dal.Groupby("commit_sha"),
+	)
+	if err != nil {
+		return err
+	}
+	diffstatMap := make(map[string]commitDiffstat, len(diffstats))
+	for _, diffstat := range diffstats {
+		diffstatMap[diffstat.CommitSha] = diffstat
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.BitbucketServerCommit{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	domainRepoId := didgen.NewDomainIdGenerator(&models.BitbucketServerRepo{}).Generate(data.Options.ConnectionId, data.Options.FullName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BitbucketServerCommit{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commit := inputRow.(*models.BitbucketServerCommit)
+			diffstat := diffstatMap[commit.CommitSha]
+			domainCommit := &code.Commit{
+				Sha:            commit.CommitSha,
+				Additions:      diffstat.Additions,
+				Deletions:      diffstat.Deletions,
+				Message:        commit.Message,
+				AuthorName:     commit.AuthorName,
+				AuthorEmail:    commit.AuthorEmail,
+				AuthoredDate:   commit.AuthoredDate,
+				AuthorId:       commit.AuthorEmail,
+				CommitterName:  commit.CommitterName,
+				CommitterEmail: commit.CommitterEmail,
+				CommittedDate:  commit.CommittedDate,
+				CommitterId:    commit.CommitterEmail,
+			}
+			repoCommit := &code.RepoCommit{
+				RepoId:    domainRepoId,
+				CommitSha: commit.CommitSha,
+			}
+			return []interface{}{
+				domainCommit,
+				repoCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func ConvertCommitFiles(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_DIFFS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.BitbucketServerCommitFile{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BitbucketServerCommitFile{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commitFile := inputRow.(*models.BitbucketServerCommitFile)
+			domainCommitFile := &code.CommitFile{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: genCommitFileId(commitFile.CommitSha, commitFile.FilePath),
+				},
+				CommitSha: commitFile.CommitSha,
+				FilePath:  commitFile.FilePath,
+				Additions: commitFile.Additions,
+				Deletions: commitFile.Deletions,
+			}
+			return []interface{}{domainCommitFile}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// genCommitFileId generates the same id as gitextractor does, so the commit files would not be duplicated
+// if both of them are used for the repo
+func genCommitFileId(commitSha, filePath string) string {
+	shaFilePath := sha256.New()
+	shaFilePath.Write([]byte(filePath))
+	return commitSha + ":" + hex.EncodeToString(shaFilePath.Sum(nil))
+}
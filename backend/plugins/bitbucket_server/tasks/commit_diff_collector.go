@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_COMMIT_DIFFS_TABLE = "bitbucket_server_api_commit_diffs"
+
+var CollectApiCommitDiffsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiCommitDiffs",
+	EntryPoint:       CollectApiCommitDiffs,
+	EnabledByDefault: false,
+	Description:      "Collect the diff of each commit from Bitbucket Server api for diffstat, one request per commit",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiCommitsMeta},
+	ProductTables:    []string{RAW_COMMIT_DIFFS_TABLE},
+}
+
+func CollectApiCommitDiffs(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_DIFFS_TABLE)
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := GetCommitsIterator(taskCtx, collectorWithState.GetSince())
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "rest/api/1.0/projects/{{ .Params.FullName }}/commits/{{ .Input.CommitSha }}/diff",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			// only the changed lines are needed for diffstat
+			query.Set("contextLines", "0")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var body struct {
+				Diffs []json.RawMessage `json:"diffs"`
+			}
+			err := helper.UnmarshalResponse(res, &body)
+			if err != nil {
+				return nil, err
+			}
+			return body.Diffs, nil
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+var ExtractApiCommitDiffsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiCommitDiffs",
+	EntryPoint:       ExtractApiCommitDiffs,
+	EnabledByDefault: false,
+	Description:      "Extract raw commit diffs into tool layer table bitbucket_server_commit_files",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type ApiDiffPath struct {
+	ToString string `json:"toString"`
+}
+
+type ApiDiffResponse struct {
+	Source      *ApiDiffPath `json:"source"`
+	Destination *ApiDiffPath `json:"destination"`
+	Hunks       []struct {
+		Segments []struct {
+			Type  string            `json:"type"`
+			Lines []json.RawMessage `json:"lines"`
+		} `json:"segments"`
+	} `json:"hunks"`
+}
+
+func ExtractApiCommitDiffs(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_DIFFS_TABLE)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiDiff := &ApiDiffResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDiff))
+			if err != nil {
+				return nil, err
+			}
+			input := &BitbucketServerCommitInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			commitFile := &models.BitbucketServerCommitFile{
+				ConnectionId: data.Options.ConnectionId,
+				RepoId:       data.Options.FullName,
+				CommitSha:    input.CommitSha,
+			}
+			// the destination is missing if the file was deleted
+			if apiDiff.Destination != nil {
+				commitFile.FilePath = apiDiff.Destination.ToString
+			} else if apiDiff.Source != nil {
+				commitFile.FilePath = apiDiff.Source.ToString
+			}
+			for _, hunk := range apiDiff.Hunks {
+				for _, segment := range hunk.Segments {
+					switch segment.Type {
+					case "ADDED":
+						commitFile.Additions += len(segment.Lines)
+					case "REMOVED":
+						commitFile.Deletions += len(segment.Lines)
+					}
+				}
+			}
+			return []interface{}{commitFile}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
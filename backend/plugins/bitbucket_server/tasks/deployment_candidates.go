@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+// newBuildStatusDeploymentCandidate describes a build status to the deployment rules, the key of the build is the job
+// name and its name the pipeline name, build statuses tell neither the branch nor the trigger
+func newBuildStatusDeploymentCandidate(buildStatus *models.BitbucketServerBuildStatus) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, buildStatus.State)
+	candidate.JobName, candidate.PipelineName = buildStatus.BuildKey, buildStatus.Name
+	return candidate
+}
+
+// DeploymentCandidates describes the build statuses of the repo added since the time to the deployment rules by the ids
+// of the cicd_pipelines they are converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, fullName string, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var buildStatuses []*models.BitbucketServerBuildStatus
+	err := db.All(&buildStatuses, dal.Where("connection_id = ? AND repo_id = ? AND date_added >= ?", connectionId, fullName, since))
+	if err != nil {
+		return nil, err
+	}
+	buildStatusIdGen := didgen.NewDomainIdGenerator(&models.BitbucketServerBuildStatus{})
+	for _, buildStatus := range buildStatuses {
+		pipelineId := buildStatusIdGen.Generate(buildStatus.ConnectionId, buildStatus.RepoId, buildStatus.CommitSha, buildStatus.BuildKey)
+		candidates.Pipelines[pipelineId] = newBuildStatusDeploymentCandidate(buildStatus)
+	}
+	return candidates, nil
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BRANCHES_TABLE = "bitbucket_server_api_branches"
+const RAW_TAGS_TABLE = "bitbucket_server_api_tags"
+
+var CollectApiBranchesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBranches",
+	EntryPoint:       CollectApiBranches,
+	EnabledByDefault: false,
+	Description:      "Collect branches data from Bitbucket Server api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	ProductTables:    []string{RAW_BRANCHES_TABLE},
+}
+
+var CollectApiTagsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTags",
+	EntryPoint:       CollectApiTags,
+	EnabledByDefault: false,
+	Description:      "Collect tags data from Bitbucket Server api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	ProductTables:    []string{RAW_TAGS_TABLE},
+}
+
+func CollectApiBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	return collectApiRefs(taskCtx, RAW_BRANCHES_TABLE, "rest/api/1.0/projects/{{ .Params.FullName }}/branches")
+}
+
+func CollectApiTags(taskCtx plugin.SubTaskContext) errors.Error {
+	return collectApiRefs(taskCtx, RAW_TAGS_TABLE, "rest/api/1.0/projects/{{ .Params.FullName }}/tags")
+}
+
+// refs might be deleted or moved, so they are always collected in full
+func collectApiRefs(taskCtx plugin.SubTaskContext, table string, urlTemplate string) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, table)
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs:    *rawDataSubTaskArgs,
+		ApiClient:             data.ApiClient,
+		PageSize:              100,
+		GetNextPageCustomData: GetNextPageCustomData,
+		Query:                 GetQueryForNextPage,
+		UrlTemplate:           urlTemplate,
+		ResponseParser:        GetRawMessageFromResponse,
+		AfterResponse:         ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+var ConvertBranchesMeta = plugin.SubTaskMeta{
+	Name:             "convertBranches",
+	EntryPoint:       ConvertBranches,
+	EnabledByDefault: false,
+	Description:      "Convert branches in tool layer table bitbucket_server_refs into domain layer table refs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var ConvertTagsMeta = plugin.SubTaskMeta{
+	Name:             "convertTags",
+	EntryPoint:       ConvertTags,
+	EnabledByDefault: false,
+	Description:      "Convert tags in tool layer table bitbucket_server_refs into domain layer table refs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	return convertRefs(taskCtx, RAW_BRANCHES_TABLE, models.REF_TYPE_BRANCH)
+}
+
+func ConvertTags(taskCtx plugin.SubTaskContext) errors.Error {
+	return convertRefs(taskCtx, RAW_TAGS_TABLE, models.REF_TYPE_TAG)
+}
+
+func convertRefs(taskCtx plugin.SubTaskContext, table string, refType string) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, table)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.BitbucketServerRef{}),
+		dal.Where("connection_id = ? AND repo_id = ? AND ref_type = ?", data.Options.ConnectionId, data.Options.FullName, refType),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	domainRepoId := didgen.NewDomainIdGenerator(&models.BitbucketServerRepo{}).Generate(data.Options.ConnectionId, data.Options.FullName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BitbucketServerRef{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			ref := inputRow.(*models.BitbucketServerRef)
+			// names follow gitextractor, branches are named by the short name while tags by the full name
+			name := ref.RefId
+			if ref.RefType == models.REF_TYPE_BRANCH {
+				name = ref.DisplayId
+			}
+			domainRef := &code.Ref{
+				DomainEntityExtended: domainlayer.DomainEntityExtended{
+					Id: fmt.Sprintf("%s:%s", domainRepoId, name),
+				},
+				RepoId:    domainRepoId,
+				Name:      name,
+				CommitSha: ref.CommitSha,
+				IsDefault: ref.IsDefault,
+				RefType:   ref.RefType,
+			}
+			return []interface{}{domainRef}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
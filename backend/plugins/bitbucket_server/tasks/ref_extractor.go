@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket_server/models"
+)
+
+var ExtractApiBranchesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranches",
+	EntryPoint:       ExtractApiBranches,
+	EnabledByDefault: false,
+	Description:      "Extract raw branches data into tool layer table bitbucket_server_refs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var ExtractApiTagsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTags",
+	EntryPoint:       ExtractApiTags,
+	EnabledByDefault: false,
+	Description:      "Extract raw tags data into tool layer table bitbucket_server_refs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type ApiRefResponse struct {
+	Id           string `json:"id"`
+	DisplayId    string `json:"displayId"`
+	Type         string `json:"type"`
+	LatestCommit string `json:"latestCommit"`
+	IsDefault    bool   `json:"isDefault"`
+}
+
+func ExtractApiBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	return extractApiRefs(taskCtx, RAW_BRANCHES_TABLE, models.REF_TYPE_BRANCH)
+}
+
+func ExtractApiTags(taskCtx plugin.SubTaskContext) errors.Error {
+	return extractApiRefs(taskCtx, RAW_TAGS_TABLE, models.REF_TYPE_TAG)
+}
+
+func extractApiRefs(taskCtx plugin.SubTaskContext, table string, refType string) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, table)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiRef := &ApiRefResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRef))
+			if err != nil {
+				return nil, err
+			}
+			ref := &models.BitbucketServerRef{
+				ConnectionId: data.Options.ConnectionId,
+				RepoId:       data.Options.FullName,
+				RefId:        apiRef.Id,
+				DisplayId:    apiRef.DisplayId,
+				RefType:      refType,
+				CommitSha:    apiRef.LatestCommit,
+				IsDefault:    apiRef.IsDefault,
+			}
+			return []interface{}{ref}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
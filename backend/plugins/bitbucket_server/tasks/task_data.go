@@ -34,9 +34,10 @@ type BitbucketServerOptions struct {
 }
 
 type BitbucketServerTaskData struct {
-	Options       *BitbucketServerOptions
-	ApiClient     *api.ApiAsyncClient
-	RegexEnricher *api.RegexEnricher
+	Options         *BitbucketServerOptions
+	ApiClient       *api.ApiAsyncClient
+	RegexEnricher   *api.RegexEnricher
+	DeploymentRules *api.DeploymentRuleEngine
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*BitbucketServerOptions, errors.Error) {
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -39,6 +40,7 @@ var _ plugin.PluginInit = (*Circleci)(nil)
 var _ plugin.PluginTask = (*Circleci)(nil)
 var _ plugin.PluginApi = (*Circleci)(nil)
 var _ plugin.CloseablePluginTask = (*Circleci)(nil)
+var _ helper.DeploymentCandidateSource = (*Circleci)(nil)
 
 type Circleci struct{}
 
@@ -153,7 +155,12 @@ func (p Circleci) PrepareTaskData(taskCtx plugin.TaskContext, options map[string
 	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ScopeConfig.ProductionPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
 	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.ScopeConfig.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 	taskData.RegexEnricher = regexEnricher
+	taskData.DeploymentRules = deploymentRules
 	return taskData, nil
 }
 
@@ -221,3 +228,8 @@ func (p Circleci) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+// DeploymentCandidates implements helper.DeploymentCandidateSource.
+func (p Circleci) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	return tasks.DeploymentCandidates(db, connectionId, scopeId, since)
+}
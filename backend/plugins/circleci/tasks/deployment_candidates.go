@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/circleci/models"
+)
+
+// newWorkflowDeploymentCandidate describes a workflow to the deployment rules, it runs on the tag or else the branch of
+// its pipeline, which could be missing
+func newWorkflowDeploymentCandidate(workflow *models.CircleciWorkflow, pipeline *models.CircleciPipeline) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, workflow.Status)
+	if pipeline != nil {
+		candidate = api.NewDeploymentCandidate(pipeline.Vcs.Tag, true, workflow.Status)
+		if pipeline.Vcs.Tag == "" {
+			candidate = api.NewDeploymentCandidate(pipeline.Vcs.Branch, false, workflow.Status)
+		}
+	}
+	candidate.PipelineName = workflow.Name
+	return candidate
+}
+
+// newJobDeploymentCandidate describes a job of a workflow to the deployment rules
+func newJobDeploymentCandidate(job *models.CircleciJob) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, job.Status)
+	candidate.JobName = job.Name
+	return candidate
+}
+
+// DeploymentCandidates describes the workflows of the project created since the time and their jobs to the deployment
+// rules by the ids of the cicd_pipelines and the cicd_tasks they are converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, projectSlug string, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var workflows []*models.CircleciWorkflow
+	err := db.All(&workflows, dal.Where("connection_id = ? AND project_slug = ? AND created_at >= ?", connectionId, projectSlug, since))
+	if err != nil {
+		return nil, err
+	}
+	pipelineIds := make([]string, 0, len(workflows))
+	for _, workflow := range workflows {
+		pipelineIds = append(pipelineIds, workflow.PipelineId)
+	}
+	var pipelines []*models.CircleciPipeline
+	if len(pipelineIds) > 0 {
+		err = db.All(&pipelines, dal.Where("connection_id = ? AND id IN ?", connectionId, pipelineIds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	pipelinesById := make(map[string]*models.CircleciPipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		pipelinesById[pipeline.Id] = pipeline
+	}
+	for _, workflow := range workflows {
+		pipelineId := getWorkflowIdGen().Generate(connectionId, workflow.Id)
+		candidates.Pipelines[pipelineId] = newWorkflowDeploymentCandidate(workflow, pipelinesById[workflow.PipelineId])
+	}
+	var jobs []*models.CircleciJob
+	err = db.All(&jobs, dal.Where("connection_id = ? AND project_slug = ? AND created_at >= ?", connectionId, projectSlug, since))
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		candidates.Tasks[getJobIdGen().Generate(connectionId, job.WorkflowId, job.Id)] = newJobDeploymentCandidate(job)
+	}
+	return candidates, nil
+}
@@ -92,6 +92,7 @@ func ConvertJobs(taskCtx plugin.SubTaskContext) errors.Error {
 			if task.DurationSec == 0 {
 				task.DurationSec = userTool.DurationSec
 			}
+			task.Type, task.Environment = data.DeploymentRules.Classify(newJobDeploymentCandidate(userTool), task.Type, task.Environment)
 			return []interface{}{
 				task,
 			}, nil
@@ -32,10 +32,11 @@ type CircleciOptions struct {
 }
 
 type CircleciTaskData struct {
-	Options       *CircleciOptions
-	ApiClient     *helper.ApiAsyncClient
-	RegexEnricher *helper.RegexEnricher
-	Project       *models.CircleciProject
+	Options         *CircleciOptions
+	ApiClient       *helper.ApiAsyncClient
+	RegexEnricher   *helper.RegexEnricher
+	DeploymentRules *helper.DeploymentRuleEngine
+	Project         *models.CircleciProject
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*CircleciOptions, errors.Error) {
@@ -93,12 +93,17 @@ func ConvertWorkflows(taskCtx plugin.SubTaskContext) errors.Error {
 				Environment:  data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, userTool.Name),
 				DisplayTitle: fmt.Sprintf("%s#%d", userTool.Name, userTool.PipelineNumber),
 			}
+			// the pipeline is nil when it is not found
+			p, findErr := findPipelineById(db, userTool.PipelineId)
+			pipeline.Type, pipeline.Environment = data.DeploymentRules.Classify(
+				newWorkflowDeploymentCandidate(userTool, p), pipeline.Type, pipeline.Environment,
+			)
 			result := make([]interface{}, 0, 2)
 			result = append(result, pipeline)
 
 			// CircleCI does not support multiple repositories in one pipeline, so we can get the commit sha from the pipeline
 			// and convert it to a pipeline commit
-			if p, err := findPipelineById(db, userTool.PipelineId); err == nil {
+			if findErr == nil {
 				if p.Vcs.Revision != "" {
 					result = append(result, &devops.CiCDPipelineCommit{
 						PipelineId:   pipeline.Id,
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// ImportTestReports accepts an uploaded test report file or a zip of them, parses and saves them to the database
+// @Summary      Upload the test reports of a CI pipeline
+// @Description  Upload JUnit XML, TRX or Cobertura reports, either as a file or a zip file. 4 tables(qa_projects, qa_test_cases, qa_test_case_executions, qa_test_coverages) would be affected, the reports of the same cicdPipelineId are replaced.
+// @Tags 		 plugins/customize
+// @Accept       multipart/form-data
+// @Param        qaProjectId formData string true "the ID of the QA project, e.g. the id of the cicd_scope running the tests"
+// @Param        qaProjectName formData string false "the name of the QA project, defaults to qaProjectId"
+// @Param        cicdPipelineId formData string true "the ID of the CI pipeline producing the reports"
+// @Param        commitSha formData string false "the commit tested by the pipeline, required by flaky test detection"
+// @Param        startTime formData string false "the start time of the pipeline in RFC3339, for the reports without timestamps"
+// @Param        file formData file true "select a report or a zip file of reports to upload"
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/customize/test-reports [post]
+func (h *Handlers) ImportTestReports(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	if input.Request == nil {
+		return nil, errors.Default.New("request is nil")
+	}
+	if input.Request.MultipartForm == nil {
+		if err := input.Request.ParseMultipartForm(maxMemory); err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse the form")
+		}
+	}
+	source := &helper.TestReportSource{
+		QaProjectId:    strings.TrimSpace(input.Request.FormValue("qaProjectId")),
+		CicdPipelineId: strings.TrimSpace(input.Request.FormValue("cicdPipelineId")),
+		CommitSha:      strings.TrimSpace(input.Request.FormValue("commitSha")),
+	}
+	if source.QaProjectId == "" {
+		return nil, errors.BadInput.New("empty qaProjectId")
+	}
+	if source.CicdPipelineId == "" {
+		return nil, errors.BadInput.New("empty cicdPipelineId")
+	}
+	qaProjectName := strings.TrimSpace(input.Request.FormValue("qaProjectName"))
+	if qaProjectName == "" {
+		qaProjectName = source.QaProjectId
+	}
+	startTime := time.Now()
+	if value := strings.TrimSpace(input.Request.FormValue("startTime")); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid startTime")
+		}
+		startTime = t
+	}
+
+	reports, err := h.extractTestReports(input)
+	if err != nil {
+		return nil, err
+	}
+	return nil, h.svc.ImportTestReports(source, qaProjectName, startTime, reports)
+}
+
+// extractTestReports parses the uploaded file, which is either a report or a zip file of reports
+func (h *Handlers) extractTestReports(input *plugin.ApiResourceInput) ([]*helper.TestReport, errors.Error) {
+	f, fh, err := input.Request.FormFile("file")
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "file is required")
+	}
+	// nolint
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	if strings.EqualFold(filepath.Ext(fh.Filename), ".zip") {
+		return helper.ParseTestReportArchive(content)
+	}
+	report, parseErr := helper.ParseTestReport(fh.Filename, content)
+	if parseErr != nil {
+		return nil, errors.BadInput.Wrap(parseErr, "failed to parse the test report")
+	}
+	if report == nil {
+		return nil, errors.BadInput.New("the file is not a JUnit XML, TRX or Cobertura report")
+	}
+	return []*helper.TestReport{report}, nil
+}
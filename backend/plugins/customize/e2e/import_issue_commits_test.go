@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/customize/impl"
 	"github.com/apache/incubator-devlake/plugins/customize/service"
@@ -31,8 +32,9 @@ func TestImportIssueCommitDataFlow(t *testing.T) {
 	var plugin impl.Customize
 	dataflowTester := e2ehelper.NewDataFlowTester(t, "customize", plugin)
 
-	// create table `issue_commits`
+	// create table `issue_commits` and `board_issues`
 	dataflowTester.FlushTabler(&crossdomain.IssueCommit{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
 	svc := service.NewService(dataflowTester.Dal)
 
 	f, err1 := os.Open("raw_tables/issues_commits.csv")
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/customize/impl"
+	"github.com/apache/incubator-devlake/plugins/customize/service"
+)
+
+func TestImportTestReportsDataFlow(t *testing.T) {
+	var plugin impl.Customize
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "customize", plugin)
+
+	dataflowTester.FlushTabler(&qa.QaProject{})
+	dataflowTester.FlushTabler(&qa.QaTestCase{})
+	dataflowTester.FlushTabler(&qa.QaTestCaseExecution{})
+	dataflowTester.FlushTabler(&qa.QaTestCoverage{})
+
+	svc := service.NewService(dataflowTester.Dal)
+	reports, err := api.ParseTestReportDir("raw_tables/test_reports", dataflowTester.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := &api.TestReportSource{
+		QaProjectId:    "jenkins:JenkinsJob:1:shop",
+		CicdPipelineId: "jenkins:JenkinsBuild:1:shop#42",
+		CommitSha:      "5b2c1f0e9d8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c",
+	}
+	startTime := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	// importing the reports of the same pipeline again replaces them
+	for i := 0; i < 2; i++ {
+		err = svc.ImportTestReports(source, "shop", startTime, reports)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dataflowTester.VerifyTableWithRawData(
+		&qa.QaTestCase{},
+		"snapshot_tables/qa_test_cases_from_test_reports.csv",
+		[]string{"id", "name", "create_time", "type", "qa_project_id"},
+	)
+	dataflowTester.VerifyTableWithRawData(
+		&qa.QaTestCaseExecution{},
+		"snapshot_tables/qa_test_case_executions_from_test_reports.csv",
+		[]string{"id", "qa_project_id", "qa_test_case_id", "start_time", "finish_time", "status", "cicd_pipeline_id", "commit_sha", "duration_sec"},
+	)
+	dataflowTester.VerifyTableWithRawData(
+		&qa.QaTestCoverage{},
+		"snapshot_tables/qa_test_coverages_from_test_reports.csv",
+		[]string{"id", "qa_project_id", "cicd_pipeline_id", "commit_sha", "file_name", "line_rate", "branch_rate", "lines_covered", "lines_valid", "create_time"},
+	)
+}
@@ -117,5 +117,8 @@ func (p Customize) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 		"csvfiles/qa_test_case_executions.csv": {
 			"POST": handlers.ImportQaTestCaseExecutions,
 		},
+		"test-reports": {
+			"POST": handlers.ImportTestReports,
+		},
 	}
 }
@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// testReportBatchSize is the number of records saved by a statement when importing test reports
+const testReportBatchSize = 500
+
+// ImportTestReports saves the JUnit XML, TRX and Cobertura reports of a CI pipeline into the tables `qa_test_cases`,
+// `qa_test_case_executions` and `qa_test_coverages` in a transaction, the reports imported for the same pipeline before are replaced
+func (s *Service) ImportTestReports(source *api.TestReportSource, qaProjectName string, startTime time.Time, reports []*api.TestReport) errors.Error {
+	tx := s.dal.Begin()
+	err := importTestReports(tx, source, qaProjectName, startTime, reports)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Default.Wrap(rollbackErr, fmt.Sprintf("failed to roll back the import of test reports after: %s", err.Error()))
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func importTestReports(tx dal.Transaction, source *api.TestReportSource, qaProjectName string, startTime time.Time, reports []*api.TestReport) errors.Error {
+	err := tx.Delete(&qa.QaTestCaseExecution{}, dal.Where("qa_project_id = ? AND cicd_pipeline_id = ?", source.QaProjectId, source.CicdPipelineId))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to delete old qa_test_case_executions for cicdPipelineId %s", source.CicdPipelineId))
+	}
+	err = tx.Delete(&qa.QaTestCoverage{}, dal.Where("qa_project_id = ? AND cicd_pipeline_id = ?", source.QaProjectId, source.CicdPipelineId))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to delete old qa_test_coverages for cicdPipelineId %s", source.CicdPipelineId))
+	}
+	// create or update qa_projects
+	err = tx.CreateOrUpdate(&qa.QaProject{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{
+			Id: source.QaProjectId,
+		},
+		Name: qaProjectName,
+	})
+	if err != nil {
+		return err
+	}
+
+	idPrefix := fmt.Sprintf("%s:%s", source.QaProjectId, source.CicdPipelineId)
+	var coverages []*qa.QaTestCoverage
+	var executions []*qa.QaTestCaseExecution
+	var testCases []*qa.QaTestCase
+	testCaseById := make(map[string]*qa.QaTestCase)
+	seq := 0
+	for reportSeq, report := range reports {
+		if report.Coverage != nil {
+			coverage := api.ConvertTestCoverage(source, fmt.Sprintf("%s:coverage:%d", idPrefix, reportSeq), report.File, report.Coverage, startTime)
+			coverage.RawDataParams = source.QaProjectId
+			coverages = append(coverages, coverage)
+		}
+		for _, result := range report.TestCases {
+			// reports without timestamps are dated by the start of the pipeline
+			if result.StartTime == nil {
+				result.StartTime = &startTime
+			}
+			testCase, execution := api.ConvertTestCaseResult(source, fmt.Sprintf("%s:%d", idPrefix, seq), result)
+			seq++
+			if execution == nil {
+				continue
+			}
+			execution.RawDataParams = source.QaProjectId
+			executions = append(executions, execution)
+			// a test case run several times is created by its first execution
+			if seen, ok := testCaseById[testCase.Id]; ok {
+				if testCase.CreateTime.Before(seen.CreateTime) {
+					seen.CreateTime = testCase.CreateTime
+				}
+				continue
+			}
+			testCase.RawDataParams = source.QaProjectId
+			testCaseById[testCase.Id] = testCase
+			testCases = append(testCases, testCase)
+		}
+	}
+
+	// keep the creation time of the test cases seen before
+	for i := 0; i < len(testCases); i += testReportBatchSize {
+		end := i + testReportBatchSize
+		if end > len(testCases) {
+			end = len(testCases)
+		}
+		ids := make([]string, 0, end-i)
+		for _, testCase := range testCases[i:end] {
+			ids = append(ids, testCase.Id)
+		}
+		var existingTestCases []*qa.QaTestCase
+		err = tx.All(&existingTestCases, dal.Select("id, create_time"), dal.Where("id IN ?", ids))
+		if err != nil {
+			return err
+		}
+		for _, existing := range existingTestCases {
+			testCase := testCaseById[existing.Id]
+			if !existing.CreateTime.IsZero() && existing.CreateTime.Before(testCase.CreateTime) {
+				testCase.CreateTime = existing.CreateTime
+			}
+		}
+	}
+
+	if err = saveInBatches(tx, testCases); err != nil {
+		return err
+	}
+	if err = saveInBatches(tx, executions); err != nil {
+		return err
+	}
+	return saveInBatches(tx, coverages)
+}
+
+func saveInBatches[T any](tx dal.Transaction, records []T) errors.Error {
+	for i := 0; i < len(records); i += testReportBatchSize {
+		end := i + testReportBatchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[i:end]
+		if err := tx.CreateOrUpdate(&batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
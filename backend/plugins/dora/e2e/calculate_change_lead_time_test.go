@@ -20,6 +20,7 @@ package e2e
 import (
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
@@ -50,6 +51,8 @@ func TestCalculateCLTimeDataFlow(t *testing.T) {
 	dataflowTester.ImportNullableCsvIntoTabler("./change_lead_time/commits_diffs.csv", &code.CommitsDiff{})
 	dataflowTester.ImportCsvIntoTabler("./change_lead_time/pull_request_comments.csv", &code.PullRequestComment{})
 	dataflowTester.ImportCsvIntoTabler("./change_lead_time/pull_request_commits.csv", &code.PullRequestCommit{})
+	dataflowTester.ImportCsvIntoTabler("./change_lead_time/project_calendars.csv", &models.ProjectCalendar{})
+	dataflowTester.ImportCsvIntoTabler("./change_lead_time/project_holidays.csv", &models.ProjectHoliday{})
 
 	// verify converter
 	dataflowTester.FlushTabler(&crossdomain.ProjectPrMetric{})
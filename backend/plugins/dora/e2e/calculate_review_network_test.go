@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+)
+
+func TestCalculateReviewNetworkDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./review_network/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./review_network/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./review_network/pull_request_comments.csv", &code.PullRequestComment{})
+	dataflowTester.ImportCsvIntoTabler("./review_network/pull_request_reviewers.csv", &code.PullRequestReviewer{})
+
+	dataflowTester.FlushTabler(&crossdomain.ProjectReviewEdge{})
+	dataflowTester.FlushTabler(&crossdomain.ProjectReviewerLoad{})
+	dataflowTester.FlushTabler(&crossdomain.ProjectReviewConcentration{})
+	dataflowTester.Subtask(tasks.CalculateReviewNetworkMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectReviewEdge{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./review_network/project_review_edges.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectReviewerLoad{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./review_network/project_reviewer_loads.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectReviewConcentration{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./review_network/project_review_concentrations.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
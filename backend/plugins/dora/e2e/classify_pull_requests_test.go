@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+)
+
+func TestClassifyPullRequestsDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	options, err := tasks.DecodeAndValidateTaskOptions(map[string]interface{}{
+		"projectName": "project1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskData := &tasks.DoraTaskData{
+		Options: options,
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/pull_request_commits.csv", &code.PullRequestCommit{})
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/commit_files.csv", &code.CommitFile{})
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/pull_request_comments.csv", &code.PullRequestComment{})
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/pull_request_reviewers.csv", &code.PullRequestReviewer{})
+	dataflowTester.ImportCsvIntoTabler("./pr_risk/components.csv", &code.Component{})
+	// no calendar, merges are outside business hours out of Monday to Friday 09:00-17:00 UTC
+	dataflowTester.FlushTabler(&models.ProjectCalendar{})
+	dataflowTester.FlushTabler(&models.ProjectHoliday{})
+
+	dataflowTester.FlushTabler(&crossdomain.ProjectPrRisk{})
+	dataflowTester.Subtask(tasks.ClassifyPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectPrRisk{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./pr_risk/project_pr_risks.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+)
+
+func TestDecomposeIssueLeadTimeDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./issue_lead_time/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./issue_lead_time/pull_request_issues.csv", &crossdomain.PullRequestIssue{})
+	dataflowTester.ImportCsvIntoTabler("./issue_lead_time/project_pr_metrics.csv", &crossdomain.ProjectPrMetric{})
+	dataflowTester.ImportCsvIntoTabler("./issue_lead_time/cicd_deployment_commits.csv", &devops.CicdDeploymentCommit{})
+
+	dataflowTester.FlushTabler(&crossdomain.ProjectIssueLeadTime{})
+	dataflowTester.Subtask(tasks.DecomposeIssueLeadTimeMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectIssueLeadTime{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./issue_lead_time/project_issue_lead_times.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+)
+
+func TestGenerateReleaseNotesDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./release_notes/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./release_notes/cicd_deployment_commits.csv", &devops.CicdDeploymentCommit{})
+	dataflowTester.ImportCsvIntoTabler("./release_notes/commits_diffs.csv", &code.CommitsDiff{})
+	dataflowTester.ImportCsvIntoTabler("./release_notes/commits.csv", &code.Commit{})
+	dataflowTester.ImportCsvIntoTabler("./release_notes/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./release_notes/pull_request_issues.csv", &crossdomain.PullRequestIssue{})
+	dataflowTester.ImportCsvIntoTabler("./release_notes/issues.csv", &ticket.Issue{})
+	// no template, the default Markdown one is used
+	dataflowTester.FlushTabler(&models.ProjectReleaseNotesTemplate{})
+
+	dataflowTester.FlushTabler(&models.ProjectReleaseNote{})
+	dataflowTester.Subtask(tasks.GenerateReleaseNotesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.ProjectReleaseNote{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./release_notes/project_release_notes.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
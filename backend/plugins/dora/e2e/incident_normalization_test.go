@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	corePlugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+	jiraImpl "github.com/apache/incubator-devlake/plugins/jira/impl"
+	jiraModels "github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// blueprint20261026 is the part of models.Blueprint read by the normalization, whose plans could not be migrated here
+type blueprint20261026 struct {
+	ID          uint64 `gorm:"primaryKey"`
+	Name        string
+	ProjectName string
+	Mode        string
+}
+
+func (blueprint20261026) TableName() string {
+	return models.Blueprint{}.TableName()
+}
+
+func TestIncidentNormalizationDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+	// the incidentRules are read from the scope configs of the jira boards
+	assert.Nil(t, corePlugin.RegisterPlugin("jira", jiraImpl.Jira{}))
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/boards.csv", &ticket.Board{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/_devlake_blueprints.csv", &blueprint20261026{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/_devlake_blueprint_scopes.csv", &models.BlueprintScope{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/_tool_jira_boards.csv", &jiraModels.JiraBoard{})
+	dataflowTester.ImportCsvIntoTabler("./incident_normalization/raw_tables/_tool_jira_scope_configs.csv", &jiraModels.JiraScopeConfig{})
+
+	// verify the severity mappings and customer impacting pattern of the payment board are applied, and the
+	// platform board without scope config is normalized by the defaults
+	dataflowTester.FlushTabler(&ticket.Incident{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.FlushTabler(&ticket.IncidentAssignee{})
+	dataflowTester.Subtask(tasks.IssuesToIncidentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Incident{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./incident_normalization/snapshot_tables/incidents.csv",
+		TargetFields: []string{"id", "scope_id", "std_severity", "affected_service", "is_customer_impacting"},
+	})
+}
@@ -94,8 +94,16 @@ func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.EnrichPrevSuccessDeploymentCommitMeta,
 		tasks.EnrichTaskEnvMeta,
 		tasks.CalculateChangeLeadTimeMeta,
+		tasks.DecomposeIssueLeadTimeMeta,
+		tasks.ClassifyPullRequestsMeta,
+		tasks.CalculateReviewNetworkMeta,
+		tasks.CalculateReviewQualityMeta,
+		tasks.CalculateRunnerPoolMetricsMeta,
+		tasks.CalculateTestFlakinessMeta,
 		tasks.IssuesToIncidentsMeta,
+		tasks.DeduplicateIncidentsMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.GenerateReleaseNotesMeta,
 	}
 }
 
@@ -127,6 +135,17 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 		}
 	}
 
+	// incidents are deduplicated by the window of the project, pull requests sized by its thresholds
+	metricOptions := map[string]interface{}{
+		"projectName": projectName,
+	}
+	if op.IncidentDeduplicationWindowMinutes > 0 {
+		metricOptions["incidentDeduplicationWindowMinutes"] = op.IncidentDeduplicationWindowMinutes
+	}
+	if op.PrSizeThresholds != nil {
+		metricOptions["prSizeThresholds"] = op.PrSizeThresholds
+	}
+
 	plan := coreModels.PipelinePlan{
 		{
 			{
@@ -154,17 +173,25 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 		},
 		{
 			{
-				Plugin: "dora",
-				Options: map[string]interface{}{
-					"projectName": projectName,
-				},
+				Plugin:  "dora",
+				Options: metricOptions,
 				Subtasks: []string{
 					"calculateChangeLeadTime",
+					tasks.DecomposeIssueLeadTimeMeta.Name,
+					tasks.ClassifyPullRequestsMeta.Name,
+					tasks.CalculateReviewNetworkMeta.Name,
+					tasks.CalculateReviewQualityMeta.Name,
 					tasks.IssuesToIncidentsMeta.Name,
+					tasks.DeduplicateIncidentsMeta.Name,
+					tasks.CalculateRunnerPoolMetricsMeta.Name,
+					tasks.CalculateTestFlakinessMeta.Name,
 					"ConnectIncidentToDeployment",
 				},
 			},
 		},
 	}
+	if op.GenerateReleaseNotes {
+		plan[2][0].Subtasks = append(plan[2][0].Subtasks, tasks.GenerateReleaseNotesMeta.Name)
+	}
 	return plan, nil
 }
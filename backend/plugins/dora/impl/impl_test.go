@@ -61,7 +61,14 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 				Plugin: "dora",
 				Subtasks: []string{
 					"calculateChangeLeadTime",
+					tasks.DecomposeIssueLeadTimeMeta.Name,
+					tasks.ClassifyPullRequestsMeta.Name,
+					tasks.CalculateReviewNetworkMeta.Name,
+					tasks.CalculateReviewQualityMeta.Name,
 					tasks.IssuesToIncidentsMeta.Name,
+					tasks.DeduplicateIncidentsMeta.Name,
+					tasks.CalculateRunnerPoolMetricsMeta.Name,
+					tasks.CalculateTestFlakinessMeta.Name,
 					"ConnectIncidentToDeployment",
 				},
 				Options: map[string]interface{}{"projectName": projectName},
@@ -70,3 +77,45 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 	}
 	assert.Equal(t, doraOutputPlan, plan)
 }
+
+func TestMakeMetricPluginPipelinePlanV200WithIncidentDeduplicationWindow(t *testing.T) {
+	var dora Dora
+	const projectName = "TestMakePlanV200-project"
+	optionJson := []byte(`{"incidentDeduplicationWindowMinutes":30}`)
+	plan, err := dora.MakeMetricPluginPipelinePlanV200(projectName, optionJson)
+	assert.Nil(t, err)
+	options := plan[2][0].Options
+	assert.Equal(t, projectName, options["projectName"])
+	assert.Equal(t, 30, options["incidentDeduplicationWindowMinutes"])
+
+	taskOptions, err := tasks.DecodeAndValidateTaskOptions(options)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, taskOptions.IncidentDeduplicationWindowMinutes)
+	assert.Equal(t, tasks.DefaultPrSizeThresholds, *taskOptions.PrSizeThresholds)
+	taskOptions.PrSizeThresholds.Lines[0] = 1
+	assert.Equal(t, 10, tasks.DefaultPrSizeThresholds.Lines[0])
+}
+
+func TestMakeMetricPluginPipelinePlanV200WithPrSizeThresholds(t *testing.T) {
+	var dora Dora
+	optionJson := []byte(`{"prSizeThresholds":{"lines":[20,200,800,2000],"files":[2,8,20,50]}}`)
+	plan, err := dora.MakeMetricPluginPipelinePlanV200("TestMakePlanV200-project", optionJson)
+	assert.Nil(t, err)
+	taskOptions, err := tasks.DecodeAndValidateTaskOptions(plan[2][0].Options)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{20, 200, 800, 2000}, taskOptions.PrSizeThresholds.Lines)
+	assert.Equal(t, []int{2, 8, 20, 50}, taskOptions.PrSizeThresholds.Files)
+
+	_, err = tasks.DecodeAndValidateTaskOptions(map[string]interface{}{
+		"prSizeThresholds": map[string]interface{}{"lines": []int{100, 10}, "files": []int{1, 5, 15, 30}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestMakeMetricPluginPipelinePlanV200WithReleaseNotes(t *testing.T) {
+	var dora Dora
+	plan, err := dora.MakeMetricPluginPipelinePlanV200("TestMakePlanV200-project", []byte(`{"generateReleaseNotes":true}`))
+	assert.Nil(t, err)
+	subtasks := plan[2][0].Subtasks
+	assert.Equal(t, tasks.GenerateReleaseNotesMeta.Name, subtasks[len(subtasks)-1])
+}
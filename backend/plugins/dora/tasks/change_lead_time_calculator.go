@@ -51,6 +51,11 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 	if err != nil {
 		return errors.Default.Wrap(err, "error deleting previous project_pr_metrics")
 	}
+	// The business-time variants only count the working hours of the project calendar
+	calendar, err := api.LoadWorkingCalendar(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
 
 	// Get pull requests by repo project_name
 	var clauses = []dal.Clause{
@@ -92,6 +97,7 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 			// Calculate PR coding time
 			if firstCommit != nil {
 				projectPrMetric.PrCodingTime = computeTimeSpan(&firstCommit.CommitAuthoredDate, &pr.CreatedDate)
+				projectPrMetric.PrCodingBusinessTime = calendar.BusinessMinutes(&firstCommit.CommitAuthoredDate, &pr.CreatedDate)
 				projectPrMetric.FirstCommitSha = firstCommit.CommitSha
 				projectPrMetric.FirstCommitAuthoredDate = &firstCommit.CommitAuthoredDate
 			}
@@ -103,9 +109,12 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 			// Calculate PR pickup time and PR review time
 			prDuring := computeTimeSpan(&pr.CreatedDate, pr.MergedDate)
+			prBusinessDuring := calendar.BusinessMinutes(&pr.CreatedDate, pr.MergedDate)
 			if firstReview != nil {
 				projectPrMetric.PrPickupTime = computeTimeSpan(&pr.CreatedDate, &firstReview.CreatedDate)
 				projectPrMetric.PrReviewTime = computeTimeSpan(&firstReview.CreatedDate, pr.MergedDate)
+				projectPrMetric.PrPickupBusinessTime = calendar.BusinessMinutes(&pr.CreatedDate, &firstReview.CreatedDate)
+				projectPrMetric.PrReviewBusinessTime = calendar.BusinessMinutes(&firstReview.CreatedDate, pr.MergedDate)
 				projectPrMetric.FirstReviewId = firstReview.Id
 				projectPrMetric.FirstCommentDate = &firstReview.CreatedDate
 			}
@@ -122,6 +131,7 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 			// Calculate PR deploy time
 			if deployment != nil && deployment.FinishedDate != nil {
 				projectPrMetric.PrDeployTime = computeTimeSpan(pr.MergedDate, deployment.FinishedDate)
+				projectPrMetric.PrDeployBusinessTime = calendar.BusinessMinutes(pr.MergedDate, deployment.FinishedDate)
 				projectPrMetric.DeploymentCommitId = deployment.Id
 				projectPrMetric.PrDeployedDate = deployment.FinishedDate
 			} else {
@@ -140,6 +150,7 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 				cycleTime += *projectPrMetric.PrDeployTime
 			}
 			projectPrMetric.PrCycleTime = &cycleTime
+			projectPrMetric.PrCycleBusinessTime = sumTimeSpans(projectPrMetric.PrCodingBusinessTime, prBusinessDuring, projectPrMetric.PrDeployBusinessTime)
 
 			// Return the projectPrMetric
 			return []interface{}{projectPrMetric}, nil
@@ -244,3 +255,14 @@ func computeTimeSpan(start, end *time.Time) *int64 {
 	}
 	return &minutes
 }
+
+// sumTimeSpans adds up the spans which are not nil
+func sumTimeSpans(spans ...*int64) *int64 {
+	var sum int64
+	for _, span := range spans {
+		if span != nil {
+			sum += *span
+		}
+	}
+	return &sum
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var DeduplicateIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "DeduplicateIncidents",
+	EntryPoint:       DeduplicateIncidents,
+	EnabledByDefault: true,
+	Description:      "Mark incidents of the same affected service reported through different tools within a time window as duplicates",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type simpleIncident struct {
+	Id                string
+	ScopeId           string
+	AffectedService   string
+	PrimaryIncidentId string
+	CreatedDate       *time.Time
+}
+
+// outage is a group of incidents reported by different tools, the first one is the primary incident
+type outage struct {
+	primaryId string
+	service   string
+	startedAt time.Time
+	scopes    map[string]bool
+}
+
+// DeduplicateIncidents sets primary_incident_id of the duplicated incidents of the project
+func DeduplicateIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	logger := taskCtx.GetLogger()
+	var incidents []simpleIncident
+	err := db.All(&incidents,
+		dal.Select("i.id, i.scope_id, i.affected_service, i.primary_incident_id, i.created_date"),
+		dal.From(`incidents i`),
+		dal.Join(`left join project_mapping pm on pm.row_id = i.scope_id and pm.table = i.table`),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+		dal.Orderby("i.created_date, i.id"),
+	)
+	if err != nil {
+		return err
+	}
+	window := time.Duration(data.Options.IncidentDeduplicationWindowMinutes) * time.Minute
+	var outages []*outage
+	duplicates := 0
+	for _, incident := range incidents {
+		primaryId := ""
+		service := strings.ToLower(strings.TrimSpace(incident.AffectedService))
+		if window > 0 && incident.CreatedDate != nil && service != "" {
+			// drop the outages out of the window
+			for len(outages) > 0 && incident.CreatedDate.Sub(outages[0].startedAt) > window {
+				outages = outages[1:]
+			}
+			var matched *outage
+			for _, o := range outages {
+				if o.service == service && !o.scopes[incident.ScopeId] {
+					matched = o
+					break
+				}
+			}
+			if matched == nil {
+				outages = append(outages, &outage{
+					primaryId: incident.Id,
+					service:   service,
+					startedAt: *incident.CreatedDate,
+					scopes:    map[string]bool{incident.ScopeId: true},
+				})
+			} else {
+				matched.scopes[incident.ScopeId] = true
+				primaryId = matched.primaryId
+				duplicates++
+			}
+		}
+		if primaryId == incident.PrimaryIncidentId {
+			continue
+		}
+		err = db.UpdateColumn(&ticket.Incident{}, "primary_incident_id", primaryId, dal.Where("id = ?", incident.Id))
+		if err != nil {
+			return errors.Default.Wrap(err, "error updating primary_incident_id of incidents")
+		}
+	}
+	logger.Info("%d of %d incidents are marked as duplicates", duplicates, len(incidents))
+	return nil
+}
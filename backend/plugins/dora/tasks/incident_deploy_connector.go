@@ -59,7 +59,8 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 	clauses := []dal.Clause{
 		dal.From(`incidents i`),
 		dal.Join(`left join project_mapping pm on pm.row_id = i.scope_id and pm.table = i.table`),
-		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+		// duplicated reports of the same outage would be counted by their primary incident
+		dal.Where("pm.project_name = ? AND (i.primary_incident_id IS NULL OR i.primary_incident_id = '')", data.Options.ProjectName),
 	}
 
 	//count, err := db.Count(
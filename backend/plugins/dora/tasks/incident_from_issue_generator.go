@@ -39,7 +39,8 @@ var IssuesToIncidentsMeta = plugin.SubTaskMeta{
 
 type issueWithBoardId struct {
 	ticket.Issue
-	BoardId string
+	BoardId   string
+	BoardName string
 }
 
 func ConvertIssuesToIncidents(taskCtx plugin.SubTaskContext) errors.Error {
@@ -81,11 +82,22 @@ func ConvertIssuesToIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 		return errors.Default.Wrap(err, "error deleting previous incident_assignees")
 	}
 
+	// incidents are normalized by the incidentRules in the scope configs of their boards
+	normalizers, err := loadIncidentNormalizers(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	defaultNormalizer, err := api.NewIncidentNormalizer(nil)
+	if err != nil {
+		return err
+	}
+
 	// select all issues belongs to the board
 	clauses := []dal.Clause{
-		dal.Select("i.*, bi.board_id as board_id"),
+		dal.Select("i.*, bi.board_id as board_id, b.name as board_name"),
 		dal.From(`issues i`),
 		dal.Join(`left join board_issues bi on bi.issue_id = i.id`),
+		dal.Join(`left join boards b on b.id = bi.board_id`),
 		dal.Join(`left join project_mapping pm on pm.row_id = bi.board_id`),
 		dal.Where(
 			"i.type = ? and pm.project_name = ? and pm.table = ?",
@@ -114,6 +126,12 @@ func ConvertIssuesToIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, errors.Convert(err)
 			}
+			normalizer := normalizers[issueWithBoardId.BoardId]
+			if normalizer == nil {
+				normalizer = defaultNormalizer
+			}
+			// boards of incident tools like PagerDuty and Opsgenie are the services
+			normalizer.Normalize(incident, issueWithBoardId.BoardName)
 			incidentAssignees, err := generateIncidentAssigneeFromIssue(db, taskCtx.GetLogger(), &issueWithBoardId.Issue)
 			if err != nil {
 				return nil, errors.Convert(err)
@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// loadIncidentNormalizers returns the normalizers by the ids of the boards in the project, built from the incidentRules
+// of the scope configs of the boards. Boards without incidentRules are normalized by the default normalizer.
+func loadIncidentNormalizers(db dal.Dal, projectName string) (map[string]*helper.IncidentNormalizer, errors.Error) {
+	var bpScopes []models.BlueprintScope
+	err := db.All(&bpScopes,
+		dal.Select("bs.*"),
+		dal.From("_devlake_blueprint_scopes bs"),
+		dal.Join("JOIN _devlake_blueprints b ON b.id = bs.blueprint_id"),
+		dal.Where("b.project_name = ?", projectName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	normalizers := make(map[string]*helper.IncidentNormalizer)
+	for _, bpScope := range bpScopes {
+		boardId, rules, err := loadScopeIncidentRules(db, bpScope)
+		if err != nil {
+			return nil, err
+		}
+		if rules == nil {
+			continue
+		}
+		normalizer, err := helper.NewIncidentNormalizer(rules)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `incidentRules` of "+boardId)
+		}
+		normalizers[boardId] = normalizer
+	}
+	return normalizers, nil
+}
+
+// loadScopeIncidentRules returns the domain id of the scope and the incidentRules of its scope config, nil if the
+// plugin has no such rules or the scope has no scope config
+func loadScopeIncidentRules(db dal.Dal, bpScope models.BlueprintScope) (string, *helper.IncidentNormalizationRules, errors.Error) {
+	pluginMeta, err := plugin.GetPlugin(bpScope.PluginName)
+	if err != nil {
+		// the plugin is not loaded
+		return "", nil, nil
+	}
+	source, ok := pluginMeta.(plugin.PluginSource)
+	if !ok {
+		return "", nil, nil
+	}
+	scopeConfigModel := source.ScopeConfig()
+	if _, ok := scopeConfigModel.(helper.IncidentRulesScopeConfig); !ok {
+		return "", nil, nil
+	}
+
+	// the scopes are keyed by the connection and the scope id
+	pkColumns, err := dal.GetPrimarykeyColumns(db, source.Scope())
+	if err != nil {
+		return "", nil, err
+	}
+	where := "connection_id = ?"
+	for _, column := range pkColumns {
+		if column.Name() != "connection_id" {
+			where += fmt.Sprintf(" AND %s = ?", column.Name())
+		}
+	}
+	scopeType := reflect.TypeOf(source.Scope()).Elem()
+	scope := reflect.New(scopeType).Interface().(plugin.ToolLayerScope)
+	err = db.First(scope, dal.From(source.Scope().TableName()), dal.Where(where, bpScope.ConnectionId, bpScope.ScopeId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	if scope.ScopeScopeConfigId() == 0 {
+		return "", nil, nil
+	}
+
+	scopeConfig := reflect.New(reflect.TypeOf(scopeConfigModel).Elem()).Interface()
+	err = db.First(scopeConfig, dal.From(scopeConfigModel.TableName()), dal.Where("id = ?", scope.ScopeScopeConfigId()))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	rules := scopeConfig.(helper.IncidentRulesScopeConfig).GetIncidentRules()
+	if rules == nil {
+		return "", nil, nil
+	}
+
+	// boards share the ids of their scopes
+	var pkValues []interface{}
+	for _, field := range db.GetPrimaryKeyFields(scopeType) {
+		pkValues = append(pkValues, reflect.ValueOf(scope).Elem().FieldByName(field.Name).Interface())
+	}
+	return didgen.NewDomainIdGenerator(scope).Generate(pkValues...), rules, nil
+}
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var DecomposeIssueLeadTimeMeta = plugin.SubTaskMeta{
+	Name:             "decomposeIssueLeadTime",
+	EntryPoint:       DecomposeIssueLeadTime,
+	EnabledByDefault: true,
+	Description:      "Break the lead time of issues down into backlog, development, review, waiting to deploy and time to production",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET},
+}
+
+type issuePr struct {
+	IssueId                 string
+	IssueCreatedDate        *time.Time
+	FirstCommitAuthoredDate *time.Time
+	PrCreatedDate           *time.Time
+	PrMergedDate            *time.Time
+	DeploymentCommitId      string
+	DeployedDate            *time.Time
+}
+
+// DecomposeIssueLeadTime walks the merged pull requests of the project linked to each issue, their project_pr_metrics
+// must be calculated beforehand. The issue only reaches production once all of them are deployed.
+func DecomposeIssueLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	err := db.Delete(&crossdomain.ProjectIssueLeadTime{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous project_issue_lead_times")
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select(`pri.issue_id, i.created_date AS issue_created_date, m.first_commit_authored_date, m.pr_created_date,
+			m.pr_merged_date, dc.id AS deployment_commit_id, dc.finished_date AS deployed_date`),
+		dal.From("pull_request_issues pri"),
+		dal.Join("JOIN issues i ON i.id = pri.issue_id"),
+		dal.Join("JOIN project_pr_metrics m ON m.id = pri.pull_request_id"),
+		dal.Join("LEFT JOIN cicd_deployment_commits dc ON dc.id = m.deployment_commit_id"),
+		dal.Where("m.project_name = ?", data.Options.ProjectName),
+		dal.Orderby("pri.issue_id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectIssueLeadTime{}), 500)
+	if err != nil {
+		return err
+	}
+	var leadTime *crossdomain.ProjectIssueLeadTime
+	undeployed := false
+	count := 0
+	flush := func() errors.Error {
+		if leadTime == nil {
+			return nil
+		}
+		if undeployed {
+			leadTime.DeployedDate = nil
+			leadTime.DeploymentCommitId = ""
+		}
+		leadTime.BacklogTime = computeTimeSpan(leadTime.IssueCreatedDate, leadTime.DevelopmentStartedDate)
+		leadTime.DevelopmentTime = computeTimeSpan(leadTime.DevelopmentStartedDate, leadTime.ReviewStartedDate)
+		leadTime.ReviewTime = computeTimeSpan(leadTime.ReviewStartedDate, leadTime.LastMergedDate)
+		leadTime.WaitingToDeployTime = computeTimeSpan(leadTime.LastMergedDate, leadTime.DeployedDate)
+		leadTime.TimeToProduction = computeTimeSpan(leadTime.IssueCreatedDate, leadTime.DeployedDate)
+		count++
+		return batch.Add(leadTime)
+	}
+	for cursor.Next() {
+		pr := &issuePr{}
+		err = db.Fetch(cursor, pr)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching pull requests of issues")
+		}
+		if leadTime == nil || leadTime.Id != pr.IssueId {
+			err = flush()
+			if err != nil {
+				return err
+			}
+			leadTime = &crossdomain.ProjectIssueLeadTime{
+				DomainEntity:     domainlayer.DomainEntity{Id: pr.IssueId},
+				ProjectName:      data.Options.ProjectName,
+				IssueCreatedDate: pr.IssueCreatedDate,
+			}
+			undeployed = false
+		}
+		leadTime.PrCount++
+		developmentStarted := pr.FirstCommitAuthoredDate
+		if developmentStarted == nil {
+			developmentStarted = pr.PrCreatedDate
+		}
+		leadTime.DevelopmentStartedDate = earliest(leadTime.DevelopmentStartedDate, developmentStarted)
+		leadTime.ReviewStartedDate = earliest(leadTime.ReviewStartedDate, pr.PrCreatedDate)
+		if pr.PrMergedDate != nil && (leadTime.LastMergedDate == nil || pr.PrMergedDate.After(*leadTime.LastMergedDate)) {
+			leadTime.LastMergedDate = pr.PrMergedDate
+		}
+		if pr.DeployedDate == nil {
+			undeployed = true
+		} else if leadTime.DeployedDate == nil || pr.DeployedDate.After(*leadTime.DeployedDate) {
+			leadTime.DeployedDate = pr.DeployedDate
+			leadTime.DeploymentCommitId = pr.DeploymentCommitId
+		}
+	}
+	err = flush()
+	if err != nil {
+		return err
+	}
+	logger.Info("decomposed the lead time of %d issues", count)
+	return batch.Close()
+}
+
+// earliest returns the earlier of both times, ignoring nil
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
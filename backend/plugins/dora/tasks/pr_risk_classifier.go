@@ -0,0 +1,272 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var ClassifyPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "classifyPullRequests",
+	EntryPoint:       ClassifyPullRequests,
+	EnabledByDefault: true,
+	Description:      "Classify the size of pull requests and flag the risky ones",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var prSizes = []string{
+	crossdomain.PR_SIZE_XS,
+	crossdomain.PR_SIZE_S,
+	crossdomain.PR_SIZE_M,
+	crossdomain.PR_SIZE_L,
+	crossdomain.PR_SIZE_XL,
+}
+
+type prToClassify struct {
+	Id         string
+	BaseRepoId string
+	AuthorId   string
+	MergedById string
+	MergedDate *time.Time
+	Additions  int
+	Deletions  int
+}
+
+type sensitiveComponent struct {
+	name string
+	path *regexp.Regexp
+}
+
+// ClassifyPullRequests sizes the pull requests of the project by their changed lines and files, the larger
+// of both wins, and flags the ones touching the components of their repo, self-merged without review, merged
+// outside the working hours of the project calendar, or large without review. A pull request is reviewed
+// when someone other than its author commented, submitted a review or is one of its reviewers.
+func ClassifyPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	err := db.Delete(&crossdomain.ProjectPrRisk{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous project_pr_risks")
+	}
+	calendar, err := api.LoadWorkingCalendar(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	thresholds := data.Options.PrSizeThresholds
+	if thresholds == nil {
+		thresholds = &DefaultPrSizeThresholds
+	}
+
+	// the path of components are the sensitive paths of their repo
+	var components []code.Component
+	err = db.All(&components)
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting components")
+	}
+	repoComponents := make(map[string][]*sensitiveComponent)
+	for _, component := range components {
+		path, err := regexp.Compile(component.PathRegex)
+		if err != nil {
+			logger.Warn(err, "invalid path regex of component %s", component.Name)
+			continue
+		}
+		repoComponents[component.RepoId] = append(repoComponents[component.RepoId], &sensitiveComponent{name: component.Name, path: path})
+	}
+
+	filesByPr, commentCounts, reviewersByPr, err := loadPrReviewData(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("pr.id, pr.base_repo_id, pr.author_id, pr.merged_by_id, pr.merged_date, pr.additions, pr.deletions"),
+		dal.From("pull_requests pr"),
+		dal.Join(`LEFT JOIN project_mapping pm ON (pm.row_id = pr.base_repo_id)`),
+		dal.Where("pm.project_name = ? AND pm.table = 'repos'", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectPrRisk{}), 500)
+	if err != nil {
+		return err
+	}
+	for cursor.Next() {
+		pr := &prToClassify{}
+		err = db.Fetch(cursor, pr)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching pull requests")
+		}
+		files := filesByPr[pr.Id]
+		reviewComments := commentCounts[pr.Id]
+		isReviewed := reviewComments > 0 || len(reviewersByPr[pr.Id]) > 0
+
+		risk := &crossdomain.ProjectPrRisk{
+			DomainEntity:       domainlayer.DomainEntity{Id: pr.Id},
+			ProjectName:        data.Options.ProjectName,
+			ChangedLines:       pr.Additions + pr.Deletions,
+			ChangedFiles:       len(files),
+			ReviewCommentCount: reviewComments,
+		}
+		size := sizeIndex(risk.ChangedLines, thresholds.Lines)
+		if filesSize := sizeIndex(risk.ChangedFiles, thresholds.Files); filesSize > size {
+			size = filesSize
+		}
+		risk.Size = prSizes[size]
+
+		var sensitive []string
+		for _, component := range repoComponents[pr.BaseRepoId] {
+			for _, file := range files {
+				if component.path.MatchString(file) {
+					sensitive = append(sensitive, component.name)
+					break
+				}
+			}
+		}
+		sort.Strings(sensitive)
+		risk.SensitiveComponents = strings.Join(sensitive, ",")
+		risk.IsSensitive = len(sensitive) > 0
+		if pr.MergedDate != nil {
+			risk.IsSelfMergedWithoutReview = pr.MergedById != "" && pr.MergedById == pr.AuthorId && !isReviewed
+			risk.IsMergedOutsideBusinessHours = !calendar.IsWorkingTime(*pr.MergedDate)
+		}
+		isLarge := risk.Size == crossdomain.PR_SIZE_L || risk.Size == crossdomain.PR_SIZE_XL
+		risk.IsLargeWithoutReview = isLarge && !isReviewed
+		for _, flag := range []bool{risk.IsSensitive, risk.IsSelfMergedWithoutReview, risk.IsMergedOutsideBusinessHours, risk.IsLargeWithoutReview} {
+			if flag {
+				risk.RiskScore++
+			}
+		}
+		risk.IsRisky = risk.RiskScore > 0
+		err = batch.Add(risk)
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Close()
+}
+
+// loadPrReviewData returns the changed files, the number of comments by others than the author and the ids
+// of the other reviewers, either listed as reviewers or having submitted a review, by pull request id
+func loadPrReviewData(db dal.Dal, projectName string) (
+	map[string][]string,
+	map[string]int,
+	map[string]map[string]bool,
+	errors.Error,
+) {
+	projectPrs := []dal.Clause{
+		dal.Join("INNER JOIN pull_requests pr ON pr.id = t.pull_request_id"),
+		dal.Join("INNER JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+	}
+
+	var prFiles []struct {
+		PullRequestId string
+		FilePath      string
+	}
+	err := db.All(&prFiles, append([]dal.Clause{
+		dal.Select("t.pull_request_id, cf.file_path"),
+		dal.From("pull_request_commits t"),
+		dal.Join("INNER JOIN commit_files cf ON cf.commit_sha = t.commit_sha"),
+		dal.Where("pm.project_name = ?", projectName),
+		dal.Groupby("t.pull_request_id, cf.file_path"),
+	}, projectPrs...)...)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "error getting the changed files of pull requests")
+	}
+	filesByPr := make(map[string][]string)
+	for _, prFile := range prFiles {
+		filesByPr[prFile.PullRequestId] = append(filesByPr[prFile.PullRequestId], prFile.FilePath)
+	}
+
+	var prComments []struct {
+		PullRequestId string
+		Count         int
+	}
+	err = db.All(&prComments, append([]dal.Clause{
+		dal.Select("t.pull_request_id, COUNT(*) AS count"),
+		dal.From("pull_request_comments t"),
+		dal.Where("pm.project_name = ? AND t.account_id != pr.author_id", projectName),
+		dal.Groupby("t.pull_request_id"),
+	}, projectPrs...)...)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "error counting the review comments of pull requests")
+	}
+	commentCounts := make(map[string]int)
+	for _, prComment := range prComments {
+		commentCounts[prComment.PullRequestId] = prComment.Count
+	}
+
+	var prReviewers []struct {
+		PullRequestId string
+		ReviewerId    string
+	}
+	err = db.All(&prReviewers, append([]dal.Clause{
+		dal.Select("t.pull_request_id, t.reviewer_id"),
+		dal.From("pull_request_reviewers t"),
+		dal.Where("pm.project_name = ? AND t.reviewer_id != pr.author_id", projectName),
+	}, projectPrs...)...)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "error getting the reviewers of pull requests")
+	}
+	var prReviews []struct {
+		PullRequestId string
+		ReviewerId    string
+	}
+	err = db.All(&prReviews, append([]dal.Clause{
+		dal.Select("t.pull_request_id, t.account_id AS reviewer_id"),
+		dal.From("pull_request_comments t"),
+		dal.Where("pm.project_name = ? AND t.type = ? AND t.account_id != pr.author_id", projectName, code.REVIEW),
+		dal.Groupby("t.pull_request_id, t.account_id"),
+	}, projectPrs...)...)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "error getting the reviews of pull requests")
+	}
+	reviewersByPr := make(map[string]map[string]bool)
+	for _, prReviewer := range append(prReviewers, prReviews...) {
+		if reviewersByPr[prReviewer.PullRequestId] == nil {
+			reviewersByPr[prReviewer.PullRequestId] = make(map[string]bool)
+		}
+		reviewersByPr[prReviewer.PullRequestId][prReviewer.ReviewerId] = true
+	}
+	return filesByPr, commentCounts, reviewersByPr, nil
+}
+
+// sizeIndex is the index of the first threshold the value fits in, or the number of thresholds
+func sizeIndex(value int, thresholds []int) int {
+	for i, threshold := range thresholds {
+		if value <= threshold {
+			return i
+		}
+	}
+	return len(thresholds)
+}
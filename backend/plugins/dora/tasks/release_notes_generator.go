@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var GenerateReleaseNotesMeta = plugin.SubTaskMeta{
+	Name:             "generateReleaseNotes",
+	EntryPoint:       GenerateReleaseNotes,
+	EnabledByDefault: false,
+	Description:      "Render the release notes of new production deployments with the release notes template of the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE},
+}
+
+// GenerateReleaseNotes renders the release notes of the successful production deployment commits of the project
+// which have a previous successful deployment commit to diff with. Deployments rendered before are left untouched.
+func GenerateReleaseNotes(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	text, err := api.LoadReleaseNotesTemplate(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	var deploymentCommitIds []string
+	err = db.Pluck(
+		"dc.id", &deploymentCommitIds,
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = dc.cicd_scope_id AND pm.table = 'cicd_scopes'"),
+		dal.Join("LEFT JOIN project_release_notes rn ON rn.deployment_commit_id = dc.id AND rn.project_name = pm.project_name"),
+		dal.Where(
+			"pm.project_name = ? AND dc.result = ? AND dc.environment = ? AND dc.prev_success_deployment_commit_id <> '' AND rn.deployment_commit_id IS NULL",
+			data.Options.ProjectName, devops.RESULT_SUCCESS, devops.PRODUCTION,
+		),
+		dal.Orderby("dc.finished_date, dc.id"),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting deployment commits without release notes")
+	}
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.ProjectReleaseNote{}), 100)
+	if err != nil {
+		return err
+	}
+	for _, deploymentCommitId := range deploymentCommitIds {
+		notes, err := api.BuildReleaseNotes(db, deploymentCommitId)
+		if err != nil {
+			return err
+		}
+		content, err := api.RenderReleaseNotes(notes, text)
+		if err != nil {
+			return err
+		}
+		err = batch.Add(&models.ProjectReleaseNote{
+			ProjectName:        data.Options.ProjectName,
+			DeploymentCommitId: deploymentCommitId,
+			Content:            content,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	logger.Info("generated the release notes of %d deployment commits", len(deploymentCommitIds))
+	return batch.Close()
+}
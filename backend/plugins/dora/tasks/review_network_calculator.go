@@ -0,0 +1,295 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateReviewNetworkMeta = plugin.SubTaskMeta{
+	Name:             "calculateReviewNetwork",
+	EntryPoint:       CalculateReviewNetwork,
+	EnabledByDefault: true,
+	Description:      "Calculate the reviewer-author network, the open review load of reviewers and the concentration of reviews",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type reviewComment struct {
+	PullRequestId string
+	AuthorId      string
+	PrCreatedDate time.Time
+	AccountId     string
+	CreatedDate   time.Time
+}
+
+type prReview struct {
+	reviewerId    string
+	authorId      string
+	prCreatedDate time.Time
+	// the first comment, or the merge when the reviewer approved without commenting
+	firstReview time.Time
+	comments    int
+}
+
+type mergedReview struct {
+	PullRequestId string
+	AuthorId      string
+	PrCreatedDate time.Time
+	ReviewerId    string
+	MergedDate    time.Time
+}
+
+type reviewEdge struct {
+	period     time.Time
+	reviewerId string
+	authorId   string
+	prs        int
+	comments   int
+	responses  []float64
+}
+
+type openReview struct {
+	ReviewerId    string
+	PullRequestId string
+	CreatedDate   time.Time
+}
+
+// CalculateReviewNetwork treats the comments on a pull request by anyone but its author, submitted reviews and
+// approvals included, as a review. The reviewers of a merged pull request who didn't comment approved it, their
+// review is dated by the merge and has no response time. The reviews are grouped into reviewer-author edges by the
+// (UTC) month of the first review of the reviewer, and the edges of a month give the concentration of the reviews.
+// The load of a reviewer is the open pull requests they are requested on, the pending ones being those they have
+// not commented on yet.
+func CalculateReviewNetwork(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	for _, table := range []interface{}{&crossdomain.ProjectReviewEdge{}, &crossdomain.ProjectReviewerLoad{}, &crossdomain.ProjectReviewConcentration{}} {
+		err := db.Delete(table, dal.Where("project_name = ?", data.Options.ProjectName))
+		if err != nil {
+			return errors.Default.Wrap(err, "error deleting previous review network")
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("c.pull_request_id, pr.author_id, pr.created_date AS pr_created_date, c.account_id, c.created_date"),
+		dal.From("pull_request_comments c"),
+		dal.Join("JOIN pull_requests pr ON pr.id = c.pull_request_id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+		dal.Where("pm.project_name = ? AND c.account_id != '' AND c.account_id != pr.author_id", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	reviews := make(map[string]*prReview)
+	for cursor.Next() {
+		comment := &reviewComment{}
+		err = db.Fetch(cursor, comment)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching pull_request_comments")
+		}
+		key := comment.PullRequestId + "\x00" + comment.AccountId
+		review, ok := reviews[key]
+		if !ok {
+			review = &prReview{reviewerId: comment.AccountId, authorId: comment.AuthorId, prCreatedDate: comment.PrCreatedDate, firstReview: comment.CreatedDate}
+			reviews[key] = review
+		}
+		review.comments++
+		if comment.CreatedDate.Before(review.firstReview) {
+			review.firstReview = comment.CreatedDate
+		}
+	}
+
+	var mergedReviews []*mergedReview
+	err = db.All(
+		&mergedReviews,
+		dal.Select("prr.pull_request_id, pr.author_id, pr.created_date AS pr_created_date, prr.reviewer_id, pr.merged_date"),
+		dal.From("pull_request_reviewers prr"),
+		dal.Join("JOIN pull_requests pr ON pr.id = prr.pull_request_id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+		dal.Where("pm.project_name = ? AND pr.merged_date IS NOT NULL AND prr.reviewer_id != '' AND prr.reviewer_id != pr.author_id", data.Options.ProjectName),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting the reviewers of merged pull requests")
+	}
+	for _, merged := range mergedReviews {
+		key := merged.PullRequestId + "\x00" + merged.ReviewerId
+		if _, ok := reviews[key]; !ok {
+			reviews[key] = &prReview{reviewerId: merged.ReviewerId, authorId: merged.AuthorId, prCreatedDate: merged.PrCreatedDate, firstReview: merged.MergedDate}
+		}
+	}
+
+	edges := make(map[string]*reviewEdge)
+	for _, review := range reviews {
+		first := review.firstReview.UTC()
+		period := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+		edgeKey := period.Format(time.DateOnly) + "\x00" + review.reviewerId + "\x00" + review.authorId
+		edge, ok := edges[edgeKey]
+		if !ok {
+			edge = &reviewEdge{period: period, reviewerId: review.reviewerId, authorId: review.authorId}
+			edges[edgeKey] = edge
+		}
+		edge.prs++
+		edge.comments += review.comments
+		if response := review.firstReview.Sub(review.prCreatedDate); review.comments > 0 && response >= 0 {
+			edge.responses = append(edge.responses, response.Minutes())
+		}
+	}
+
+	edgeBatch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectReviewEdge{}), 500)
+	if err != nil {
+		return err
+	}
+	periods := make(map[time.Time][]*reviewEdge)
+	for _, edge := range edges {
+		sort.Float64s(edge.responses)
+		err = edgeBatch.Add(&crossdomain.ProjectReviewEdge{
+			ProjectName:           data.Options.ProjectName,
+			Period:                edge.period,
+			ReviewerId:            edge.reviewerId,
+			AuthorId:              edge.authorId,
+			PrCount:               edge.prs,
+			CommentCount:          edge.comments,
+			MedianResponseMinutes: api.NearestRank(edge.responses, 50),
+		})
+		if err != nil {
+			return err
+		}
+		periods[edge.period] = append(periods[edge.period], edge)
+	}
+	err = edgeBatch.Close()
+	if err != nil {
+		return err
+	}
+
+	concentrationBatch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectReviewConcentration{}), 500)
+	if err != nil {
+		return err
+	}
+	for period, periodEdges := range periods {
+		err = concentrationBatch.Add(reviewConcentration(data.Options.ProjectName, period, periodEdges))
+		if err != nil {
+			return err
+		}
+	}
+	err = concentrationBatch.Close()
+	if err != nil {
+		return err
+	}
+
+	loads, err := reviewerLoads(db, data.Options.ProjectName, reviews)
+	if err != nil {
+		return err
+	}
+	loadBatch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectReviewerLoad{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, load := range loads {
+		err = loadBatch.Add(load)
+		if err != nil {
+			return err
+		}
+	}
+	logger.Info("calculated %d review edges over %d months and the load of %d reviewers", len(edges), len(periods), len(loads))
+	return loadBatch.Close()
+}
+
+// reviewConcentration spreads the reviews of a month, counted as reviewed pull requests, across the reviewers
+func reviewConcentration(projectName string, period time.Time, edges []*reviewEdge) *crossdomain.ProjectReviewConcentration {
+	reviewerReviews := make(map[string]int)
+	authors := make(map[string]bool)
+	pairs := make(map[string]bool)
+	total := 0
+	for _, edge := range edges {
+		reviewerReviews[edge.reviewerId] += edge.prs
+		authors[edge.authorId] = true
+		pairs[edge.reviewerId+"\x00"+edge.authorId] = true
+		total += edge.prs
+	}
+	concentration := &crossdomain.ProjectReviewConcentration{
+		ProjectName:   projectName,
+		Period:        period,
+		ReviewerCount: len(reviewerReviews),
+		AuthorCount:   len(authors),
+		ReviewCount:   total,
+	}
+	if total == 0 {
+		return concentration
+	}
+	for _, count := range reviewerReviews {
+		share := float64(count) / float64(total)
+		concentration.Hhi += share * share
+		if share > concentration.TopReviewerShare {
+			concentration.TopReviewerShare = share
+		}
+	}
+	reciprocal := 0
+	for _, edge := range edges {
+		if pairs[edge.authorId+"\x00"+edge.reviewerId] {
+			reciprocal += edge.prs
+		}
+	}
+	concentration.ReciprocalShare = float64(reciprocal) / float64(total)
+	return concentration
+}
+
+// reviewerLoads counts the open pull requests of the project each reviewer is requested on
+func reviewerLoads(db dal.Dal, projectName string, reviews map[string]*prReview) (map[string]*crossdomain.ProjectReviewerLoad, errors.Error) {
+	var openReviews []*openReview
+	err := db.All(
+		&openReviews,
+		dal.Select("prr.reviewer_id, pr.id AS pull_request_id, pr.created_date"),
+		dal.From("pull_request_reviewers prr"),
+		dal.Join("JOIN pull_requests pr ON pr.id = prr.pull_request_id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+		dal.Where("pm.project_name = ? AND pr.status = ? AND prr.reviewer_id != pr.author_id", projectName, code.OPEN),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting open reviews")
+	}
+	loads := make(map[string]*crossdomain.ProjectReviewerLoad)
+	for _, open := range openReviews {
+		load, ok := loads[open.ReviewerId]
+		if !ok {
+			load = &crossdomain.ProjectReviewerLoad{ProjectName: projectName, ReviewerId: open.ReviewerId}
+			loads[open.ReviewerId] = load
+		}
+		load.OpenReviewCount++
+		if _, reviewed := reviews[open.PullRequestId+"\x00"+open.ReviewerId]; reviewed {
+			continue
+		}
+		load.PendingReviewCount++
+		if load.OldestPendingCreatedDate == nil || open.CreatedDate.Before(*load.OldestPendingCreatedDate) {
+			createdDate := open.CreatedDate
+			load.OldestPendingCreatedDate = &createdDate
+		}
+	}
+	return loads, nil
+}
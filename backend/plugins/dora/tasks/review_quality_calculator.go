@@ -0,0 +1,327 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateReviewQualityMeta = plugin.SubTaskMeta{
+	Name:             "calculateReviewQuality",
+	EntryPoint:       CalculateReviewQuality,
+	EnabledByDefault: true,
+	Description:      "Calculate the review depth of pull requests and its monthly aggregates per repo",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+// the states of review records, e.g. github reviews or gitlab approval notes
+const (
+	reviewApproved         = "APPROVED"
+	reviewChangesRequested = "CHANGES_REQUESTED"
+)
+
+type prToReview struct {
+	Id         string
+	BaseRepoId string
+	AuthorId   string
+	MergedDate *time.Time
+	Additions  int
+	Deletions  int
+}
+
+type repoReviewMonth struct {
+	repoId                  string
+	period                  time.Time
+	prs                     int
+	commentDensities        []float64
+	rounds                  int
+	unreviewed              int
+	approvedWithoutComments int
+	reReviews               []float64
+}
+
+// CalculateReviewQuality measures the review of every pull request of the project, and aggregates the merged ones
+// by their repo and the (UTC) month they were merged in. The comments and commits of the pull requests are streamed
+// alongside them.
+func CalculateReviewQuality(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	for _, table := range []interface{}{&crossdomain.ProjectPrReviewMetric{}, &crossdomain.ProjectRepoReviewMetric{}} {
+		err := db.Delete(table, dal.Where("project_name = ?", data.Options.ProjectName))
+		if err != nil {
+			return errors.Default.Wrap(err, "error deleting previous review metrics")
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("pr.id, pr.base_repo_id, pr.author_id, pr.merged_date, pr.additions, pr.deletions"),
+		dal.From("pull_requests pr"),
+		dal.Join(`LEFT JOIN project_mapping pm ON (pm.row_id = pr.base_repo_id)`),
+		dal.Where("pm.project_name = ? AND pm.table = 'repos'", data.Options.ProjectName),
+		dal.Orderby("pr.id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	activities, pushes, err := openReviewActivities(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	defer activities.cursor.Close()
+	defer pushes.cursor.Close()
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectPrReviewMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	months := make(map[string]*repoReviewMonth)
+	for cursor.Next() {
+		pr := &prToReview{}
+		err = db.Fetch(cursor, pr)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching pull requests")
+		}
+		prActivities, err := activities.take(pr.Id)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching the comments of pull requests")
+		}
+		prCommits, err := pushes.take(pr.Id)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching the commits of pull requests")
+		}
+		prPushes := make([]time.Time, 0, len(prCommits))
+		for _, commit := range prCommits {
+			prPushes = append(prPushes, commit.CommitAuthoredDate)
+		}
+		metric := measureReview(prActivities, prPushes)
+		metric.DomainEntity = domainlayer.DomainEntity{Id: pr.Id}
+		metric.ProjectName = data.Options.ProjectName
+		metric.BaseRepoId = pr.BaseRepoId
+		metric.ChangedLines = pr.Additions + pr.Deletions
+		metric.MergedDate = pr.MergedDate
+		if metric.ChangedLines > 0 {
+			density := float64(metric.ReviewCommentCount) * 100 / float64(metric.ChangedLines)
+			metric.CommentsPer100Lines = &density
+		}
+		err = batch.Add(metric)
+		if err != nil {
+			return err
+		}
+
+		if pr.MergedDate == nil {
+			continue
+		}
+		merged := pr.MergedDate.UTC()
+		period := time.Date(merged.Year(), merged.Month(), 1, 0, 0, 0, 0, time.UTC)
+		key := pr.BaseRepoId + "\x00" + period.Format(time.DateOnly)
+		month, ok := months[key]
+		if !ok {
+			month = &repoReviewMonth{repoId: pr.BaseRepoId, period: period}
+			months[key] = month
+		}
+		month.prs++
+		if metric.CommentsPer100Lines != nil {
+			month.commentDensities = append(month.commentDensities, *metric.CommentsPer100Lines)
+		}
+		month.rounds += metric.ReviewRounds
+		if metric.ReviewRounds == 0 {
+			month.unreviewed++
+		}
+		if metric.IsApprovedWithoutComments {
+			month.approvedWithoutComments++
+		}
+		if metric.ReReviewMinutes != nil {
+			month.reReviews = append(month.reReviews, *metric.ReReviewMinutes)
+		}
+	}
+	err = batch.Close()
+	if err != nil {
+		return err
+	}
+
+	repoBatch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectRepoReviewMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, month := range months {
+		err = repoBatch.Add(&crossdomain.ProjectRepoReviewMetric{
+			ProjectName:                 data.Options.ProjectName,
+			RepoId:                      month.repoId,
+			Period:                      month.period,
+			PrCount:                     month.prs,
+			AvgCommentsPer100Lines:      average(month.commentDensities),
+			AvgReviewRounds:             float64(month.rounds) / float64(month.prs),
+			UnreviewedRate:              float64(month.unreviewed) / float64(month.prs),
+			ApprovedWithoutCommentsRate: float64(month.approvedWithoutComments) / float64(month.prs),
+			AvgReReviewMinutes:          average(month.reReviews),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	logger.Info("aggregated the review metrics of %d repo months", len(months))
+	return repoBatch.Close()
+}
+
+// reviewActivity is a comment of a reviewer, only whether its body is blank is loaded
+type reviewActivity struct {
+	PullRequestId string
+	AccountId     string
+	CreatedDate   time.Time
+	Type          string
+	Status        string
+	HasBody       bool
+}
+
+// pullRequestRows reads the rows of a cursor ordered by pull_request_id one pull request at a time, the pull requests
+// must be taken in the same order
+type pullRequestRows[T any] struct {
+	db            dal.Dal
+	cursor        dal.Rows
+	pullRequestId func(row *T) string
+	next          *T
+}
+
+// take returns the rows of the pull request, none if the next row belongs to a later one
+func (r *pullRequestRows[T]) take(pullRequestId string) ([]*T, errors.Error) {
+	var rows []*T
+	for {
+		if r.next == nil {
+			if !r.cursor.Next() {
+				return rows, nil
+			}
+			r.next = new(T)
+			if err := r.db.Fetch(r.cursor, r.next); err != nil {
+				return nil, err
+			}
+		}
+		if r.pullRequestId(r.next) != pullRequestId {
+			return rows, nil
+		}
+		rows = append(rows, r.next)
+		r.next = nil
+	}
+}
+
+// openReviewActivities streams the comments by others than the author, sorted by date, and the sorted commits of
+// the pull requests of the project, both ordered by pull request id like the pull requests
+func openReviewActivities(db dal.Dal, projectName string) (*pullRequestRows[reviewActivity], *pullRequestRows[code.PullRequestCommit], errors.Error) {
+	commentCursor, err := db.Cursor(
+		dal.Select("c.pull_request_id, c.account_id, c.created_date, c.type, c.status, COALESCE(LENGTH(TRIM(c.body)), 0) > 0 AS has_body"),
+		dal.From("pull_request_comments c"),
+		dal.Join("JOIN pull_requests pr ON pr.id = c.pull_request_id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+		dal.Where("pm.project_name = ? AND c.account_id != pr.author_id", projectName),
+		dal.Orderby("c.pull_request_id, c.created_date, c.id"),
+	)
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, "error getting the comments of pull requests")
+	}
+	commitCursor, err := db.Cursor(
+		dal.Select("prc.pull_request_id, prc.commit_authored_date"),
+		dal.From("pull_request_commits prc"),
+		dal.Join("JOIN pull_requests pr ON pr.id = prc.pull_request_id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+		dal.Where("pm.project_name = ?", projectName),
+		dal.Orderby("prc.pull_request_id, prc.commit_authored_date"),
+	)
+	if err != nil {
+		commentCursor.Close()
+		return nil, nil, errors.Default.Wrap(err, "error getting the commits of pull requests")
+	}
+	activities := &pullRequestRows[reviewActivity]{
+		db:            db,
+		cursor:        commentCursor,
+		pullRequestId: func(row *reviewActivity) string { return row.PullRequestId },
+	}
+	pushes := &pullRequestRows[code.PullRequestCommit]{
+		db:            db,
+		cursor:        commitCursor,
+		pullRequestId: func(row *code.PullRequestCommit) string { return row.PullRequestId },
+	}
+	return activities, pushes, nil
+}
+
+// isReviewRecord tells the submitted reviews and approval notes apart from the plain comments of the reviewers
+func isReviewRecord(activity *reviewActivity) bool {
+	return activity.Type == code.REVIEW || activity.Status == reviewApproved || activity.Status == reviewChangesRequested
+}
+
+// measureReview counts the review comments of the reviewers' activity, sorted by date, and the rounds of their review
+// records in between the sorted pushes of the author. A pull request is approved when the latest review record of one
+// of its reviewers approves it, i.e. the approval was neither revoked nor followed by requested changes.
+func measureReview(activities []*reviewActivity, pushes []time.Time) *crossdomain.ProjectPrReviewMetric {
+	metric := &crossdomain.ProjectPrReviewMetric{}
+	var reReviews []float64
+	latestReviews := make(map[string]string)
+	lastRound := -1
+	for _, activity := range activities {
+		if activity.Status != reviewApproved && activity.HasBody {
+			metric.ReviewCommentCount++
+		}
+		if !isReviewRecord(activity) {
+			continue
+		}
+		latestReviews[activity.AccountId] = activity.Status
+		// the round is the number of pushes before the review
+		round := sort.Search(len(pushes), func(i int) bool {
+			return !pushes[i].Before(activity.CreatedDate)
+		})
+		if round == lastRound {
+			continue
+		}
+		if lastRound >= 0 && round > 0 {
+			reReviews = append(reReviews, activity.CreatedDate.Sub(pushes[round-1]).Minutes())
+		}
+		metric.ReviewRounds++
+		lastRound = round
+	}
+	for _, status := range latestReviews {
+		if status == reviewApproved {
+			metric.IsApproved = true
+		}
+	}
+	metric.IsApprovedWithoutComments = metric.IsApproved && metric.ReviewCommentCount == 0
+	metric.ReReviewMinutes = average(reReviews)
+	return metric
+}
+
+// average returns the mean of the values, or nil if there are none
+func average(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	return &mean
+}
@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateRunnerPoolMetricsMeta = plugin.SubTaskMeta{
+	Name:             "calculateRunnerPoolMetrics",
+	EntryPoint:       CalculateRunnerPoolMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate daily utilisation and queue time percentiles of the runner pools used by the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type runnerTask struct {
+	RunnerId          string
+	Pool              string
+	DurationSec       float64
+	QueuedDurationSec *float64
+	StartedDate       *time.Time
+}
+
+type runnerPoolDay struct {
+	pool    string
+	date    time.Time
+	tasks   int
+	busySec float64
+	runners map[string]bool
+	queued  []float64
+}
+
+// CalculateRunnerPoolMetrics aggregates the cicd_tasks of the project by the pool of their runner and
+// the (UTC) day they started. Utilisation is the busy time over 24 hours of every runner seen that day,
+// queue time percentiles use the nearest-rank method over the tasks reporting a queued duration.
+func CalculateRunnerPoolMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	err := db.Delete(&devops.ProjectRunnerPoolMetric{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous project_runner_pool_metrics")
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("t.runner_id, r.pool, t.duration_sec, t.queued_duration_sec, t.started_date"),
+		dal.From("cicd_tasks t"),
+		dal.Join("join cicd_runners r on r.id = t.runner_id"),
+		dal.Join("join project_mapping pm on pm.row_id = t.cicd_scope_id and pm.table = 'cicd_scopes'"),
+		dal.Where("pm.project_name = ? and t.started_date is not null", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	days := make(map[string]*runnerPoolDay)
+	for cursor.Next() {
+		task := &runnerTask{}
+		err = db.Fetch(cursor, task)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching cicd_tasks")
+		}
+		date := task.StartedDate.UTC().Truncate(24 * time.Hour)
+		key := task.Pool + "\x00" + date.Format(time.DateOnly)
+		day, ok := days[key]
+		if !ok {
+			day = &runnerPoolDay{pool: task.Pool, date: date, runners: make(map[string]bool)}
+			days[key] = day
+		}
+		day.tasks++
+		day.busySec += task.DurationSec
+		day.runners[task.RunnerId] = true
+		if task.QueuedDurationSec != nil {
+			day.queued = append(day.queued, *task.QueuedDurationSec)
+		}
+	}
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&devops.ProjectRunnerPoolMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, day := range days {
+		sort.Float64s(day.queued)
+		err = batch.Add(&devops.ProjectRunnerPoolMetric{
+			ProjectName:  data.Options.ProjectName,
+			Pool:         day.pool,
+			Date:         day.date,
+			TaskCount:    day.tasks,
+			RunnerCount:  len(day.runners),
+			BusySec:      day.busySec,
+			Utilisation:  day.busySec / (float64(len(day.runners)) * 24 * 3600),
+			QueuedP50Sec: api.NearestRank(day.queued, 50),
+			QueuedP90Sec: api.NearestRank(day.queued, 90),
+			QueuedP95Sec: api.NearestRank(day.queued, 95),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	logger.Info("calculated %d daily runner pool metrics", len(days))
+	return batch.Close()
+}
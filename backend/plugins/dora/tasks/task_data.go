@@ -18,6 +18,8 @@ limitations under the License.
 package tasks
 
 import (
+	"sort"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
@@ -31,6 +33,24 @@ type DoraOptions struct {
 	Since       string
 	ProjectName string  `json:"projectName"`
 	ScopeId     *string `json:"scopeId,omitempty"`
+	// IncidentDeduplicationWindowMinutes merges incidents of the same affected service reported through
+	// different tools within the window, 0 means disabled
+	IncidentDeduplicationWindowMinutes int `json:"incidentDeduplicationWindowMinutes,omitempty" mapstructure:"incidentDeduplicationWindowMinutes,omitempty"`
+	// PrSizeThresholds overrides the DefaultPrSizeThresholds
+	PrSizeThresholds *PrSizeThresholds `json:"prSizeThresholds,omitempty" mapstructure:"prSizeThresholds,omitempty"`
+	// GenerateReleaseNotes renders the release notes of new production deployments at the end of the pipeline
+	GenerateReleaseNotes bool `json:"generateReleaseNotes,omitempty" mapstructure:"generateReleaseNotes,omitempty"`
+}
+
+// PrSizeThresholds are the largest changed lines and files of the XS, S, M and L pull requests, larger ones are XL
+type PrSizeThresholds struct {
+	Lines []int `json:"lines" mapstructure:"lines"`
+	Files []int `json:"files" mapstructure:"files"`
+}
+
+var DefaultPrSizeThresholds = PrSizeThresholds{
+	Lines: []int{10, 100, 500, 1000},
+	Files: []int{1, 5, 15, 30},
 }
 
 type DoraTaskData struct {
@@ -44,6 +64,18 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*DoraOptions,
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error decoding DORA task options")
 	}
+	if op.PrSizeThresholds == nil {
+		// a copy, so the options of a task cannot alter the defaults
+		op.PrSizeThresholds = &PrSizeThresholds{
+			Lines: append([]int(nil), DefaultPrSizeThresholds.Lines...),
+			Files: append([]int(nil), DefaultPrSizeThresholds.Files...),
+		}
+	}
+	for _, thresholds := range [][]int{op.PrSizeThresholds.Lines, op.PrSizeThresholds.Files} {
+		if len(thresholds) != 4 || !sort.IntsAreSorted(thresholds) {
+			return nil, errors.BadInput.New("prSizeThresholds expects 4 ascending lines and files thresholds for XS, S, M and L")
+		}
+	}
 
 	return &op, nil
 }
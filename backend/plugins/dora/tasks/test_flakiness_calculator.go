@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateTestFlakinessMeta = plugin.SubTaskMeta{
+	Name:             "calculateTestFlakiness",
+	EntryPoint:       CalculateTestFlakiness,
+	EnabledByDefault: true,
+	Description:      "Flag the test cases which both passed and failed on the same commit across CI runs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+type testExecutionToCheck struct {
+	QaTestCaseId string
+	QaProjectId  string
+	TestName     string
+	CommitSha    string
+	Status       string
+	StartTime    time.Time
+}
+
+// CalculateTestFlakiness walks through the executions of the test cases of the project, sorted by test case, commit
+// and start time. The test is flaky on a commit when an execution contradicts an earlier one on the same commit,
+// e.g. a retry passing after a failure, or a rerun of the pipeline failing after a success.
+func CalculateTestFlakiness(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*DoraTaskData)
+	err := db.Delete(&crossdomain.ProjectFlakyTest{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous flaky tests")
+	}
+
+	// the test cases of the CI runs belong to the cicd scope running them, the imported ones to their qa project
+	cursor, err := db.Cursor(
+		dal.Select("e.qa_test_case_id, e.qa_project_id, tc.name AS test_name, e.commit_sha, e.status, e.start_time"),
+		dal.From("qa_test_case_executions e"),
+		dal.Join(`JOIN project_mapping pm ON (pm.row_id = e.qa_project_id)`),
+		dal.Join(`LEFT JOIN qa_test_cases tc ON (tc.id = e.qa_test_case_id)`),
+		dal.Where(
+			"pm.project_name = ? AND pm.table IN ('cicd_scopes', 'qa_projects') AND e.commit_sha != '' AND e.status IN ?",
+			data.Options.ProjectName, []string{api.TEST_RESULT_SUCCESS, api.TEST_RESULT_FAILED},
+		),
+		dal.Orderby("e.qa_test_case_id, e.commit_sha, e.start_time, e.id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectFlakyTest{}), 500)
+	if err != nil {
+		return err
+	}
+	var current *crossdomain.ProjectFlakyTest
+	var currentCommit string
+	var commitStatus string
+	var commitFlaky bool
+	flakyCount := 0
+	save := func() errors.Error {
+		if current == nil {
+			return nil
+		}
+		current.FlakeRate = float64(current.FlakyCommitCount) / float64(current.CommitCount)
+		current.IsFlaky = current.FlakyCommitCount > 0
+		if current.IsFlaky {
+			flakyCount++
+		}
+		return batch.Add(current)
+	}
+	for cursor.Next() {
+		execution := &testExecutionToCheck{}
+		err = db.Fetch(cursor, execution)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching test case executions")
+		}
+		if current == nil || current.QaTestCaseId != execution.QaTestCaseId {
+			if err = save(); err != nil {
+				return err
+			}
+			current = &crossdomain.ProjectFlakyTest{
+				ProjectName:  data.Options.ProjectName,
+				QaTestCaseId: execution.QaTestCaseId,
+				QaProjectId:  execution.QaProjectId,
+				TestName:     execution.TestName,
+			}
+			currentCommit = ""
+		}
+		current.ExecutionCount++
+		if execution.Status == api.TEST_RESULT_FAILED {
+			current.FailureCount++
+		}
+		if currentCommit != execution.CommitSha {
+			currentCommit = execution.CommitSha
+			commitStatus = execution.Status
+			commitFlaky = false
+			current.CommitCount++
+			continue
+		}
+		if commitFlaky || execution.Status == commitStatus {
+			continue
+		}
+		commitFlaky = true
+		current.FlakyCommitCount++
+		flakyDate := execution.StartTime
+		if current.FirstFlakyDate == nil || flakyDate.Before(*current.FirstFlakyDate) {
+			current.FirstFlakyDate = &flakyDate
+		}
+		if current.LastFlakyDate == nil || flakyDate.After(*current.LastFlakyDate) {
+			current.LastFlakyDate = &flakyDate
+		}
+	}
+	if err = save(); err != nil {
+		return err
+	}
+	logger.Info("found %d flaky tests", flakyCount)
+	return batch.Close()
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/plugins/export/impl"
+	"github.com/spf13/cobra"
+)
+
+var PluginEntry impl.Export
+
+func main() {
+	cmd := &cobra.Command{Use: "export"}
+	destination := cmd.Flags().StringP("destination", "t", "parquet", "Destination, one of clickhouse, parquet and csv")
+	directory := cmd.Flags().StringP("directory", "d", "", "Directory of parquet or csv files and the manifest")
+	endpoint := cmd.Flags().StringP("clickhouse_endpoint", "e", "", "HTTP endpoint of ClickHouse, i.e. http://localhost:8123")
+	user := cmd.Flags().StringP("clickhouse_user", "u", "", "ClickHouse user")
+	password := cmd.Flags().StringP("clickhouse_password", "P", "", "ClickHouse password")
+	database := cmd.Flags().StringP("clickhouse_database", "D", "", "ClickHouse database")
+	domainLayers := cmd.Flags().StringArrayP("domain_layer", "l", []string{}, "Domain layers to export, i.e. code, ticket")
+	tables := cmd.Flags().StringArrayP("table", "T", []string{}, "Regular expressions of tables to export")
+	propagateDeletes := cmd.Flags().Bool("propagate_deletes", false, "Delete rows from the destination if they were deleted from DevLake")
+	timeAfter := cmd.Flags().StringP("time_after", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
+			"destination": *destination,
+			"directory":   *directory,
+			"clickhouse": map[string]interface{}{
+				"endpoint": *endpoint,
+				"user":     *user,
+				"password": *password,
+				"database": *database,
+			},
+			"domain_layers":     *domainLayers,
+			"tables":            *tables,
+			"propagate_deletes": *propagateDeletes,
+		}, *timeAfter)
+	}
+	runner.RunCmd(cmd)
+}
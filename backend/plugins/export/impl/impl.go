@@ -0,0 +1,166 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/export/tasks"
+)
+
+type Export struct{}
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginTask
+	plugin.PluginModel
+	plugin.CloseablePluginTask
+} = (*Export)(nil)
+
+func (p Export) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.ExportTablesMeta,
+		tasks.WriteManifestMeta,
+	}
+}
+
+func (p Export) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	var op tasks.ExportOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := selectTables(&op)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := newExporter(&op)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.ExportTaskData{
+		Options:  &op,
+		Tables:   tables,
+		Exporter: exporter,
+		Results:  make(map[string]*exporthelper.TableExportResult),
+	}, nil
+}
+
+// selectTables returns the domain layer tables of the DomainLayers matching the Tables
+func selectTables(op *tasks.ExportOptions) ([]string, errors.Error) {
+	var tablers []dal.Tabler
+	if len(op.DomainLayers) == 0 {
+		tablers = domaininfo.GetDomainTablesInfo()
+	}
+	for _, layer := range op.DomainLayers {
+		layerTables := domaininfo.GetDomainTablesInfoByLayer(layer)
+		if len(layerTables) == 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown domain layer %s, should be one of %v", layer, domaininfo.GetDomainLayers()))
+		}
+		tablers = append(tablers, layerTables...)
+	}
+	patterns := make([]*regexp.Regexp, len(op.Tables))
+	for i, table := range op.Tables {
+		pattern, err := regexp.Compile(table)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid table pattern %s", table))
+		}
+		patterns[i] = pattern
+	}
+	var tables []string
+	for _, tabler := range tablers {
+		name := tabler.TableName()
+		matched := len(patterns) == 0
+		for _, pattern := range patterns {
+			if pattern.MatchString(name) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			tables = append(tables, name)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, errors.BadInput.New("no table is selected to export")
+	}
+	return tables, nil
+}
+
+func newExporter(op *tasks.ExportOptions) (exporthelper.Exporter, errors.Error) {
+	switch op.Destination {
+	case tasks.DestinationClickHouse:
+		if op.ClickHouse == nil {
+			return nil, errors.BadInput.New("clickhouse is required for the clickhouse destination")
+		}
+		return exporthelper.NewClickHouseExporter(op.ClickHouse)
+	case tasks.DestinationParquet, tasks.DestinationCsv:
+		if op.Directory == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("directory is required for the %s destination", op.Destination))
+		}
+		var exporter *exporthelper.FileExporter
+		var err errors.Error
+		if op.Destination == tasks.DestinationParquet {
+			exporter, err = exporthelper.NewParquetExporter(op.Directory)
+		} else {
+			exporter, err = exporthelper.NewCsvExporter(op.Directory)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for table, tableConfig := range op.TableConfigs {
+			if tableConfig.PartitionBy != "" {
+				exporter.SetPartitionColumn(table, tableConfig.PartitionBy)
+			}
+		}
+		return exporter, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unknown destination %s, should be one of clickhouse, parquet and csv", op.Destination))
+}
+
+func (p Export) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.ExportTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	return data.Exporter.Close()
+}
+
+func (p Export) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p Export) Description() string {
+	return "Export domain layer data to ClickHouse, parquet or csv files"
+}
+
+func (p Export) Name() string {
+	return "export"
+}
+
+func (p Export) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/export"
+}
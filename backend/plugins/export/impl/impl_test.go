@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/export/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectTables(t *testing.T) {
+	tables, err := selectTables(&tasks.ExportOptions{DomainLayers: []string{"codequality"}, Tables: []string{"^cq_issue"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cq_issue_code_blocks", "cq_issues", "cq_issue_impacts"}, tables)
+
+	tables, err = selectTables(&tasks.ExportOptions{Tables: []string{"^cicd_deployments$"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cicd_deployments"}, tables)
+
+	_, err = selectTables(&tasks.ExportOptions{DomainLayers: []string{"unknown"}})
+	assert.NotNil(t, err)
+	_, err = selectTables(&tasks.ExportOptions{Tables: []string{"^unknown$"}})
+	assert.NotNil(t, err)
+}
+
+func TestNewExporter(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := newExporter(&tasks.ExportOptions{Destination: tasks.DestinationCsv, Directory: dir})
+	assert.Nil(t, err)
+	assert.Equal(t, "csv:"+dir, exporter.Name())
+
+	_, err = newExporter(&tasks.ExportOptions{Destination: tasks.DestinationParquet})
+	assert.NotNil(t, err)
+	_, err = newExporter(&tasks.ExportOptions{Destination: tasks.DestinationClickHouse})
+	assert.NotNil(t, err)
+	_, err = newExporter(&tasks.ExportOptions{Destination: "bigquery"})
+	assert.NotNil(t, err)
+}
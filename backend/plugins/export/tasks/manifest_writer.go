@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+)
+
+var WriteManifestMeta = plugin.SubTaskMeta{
+	Name:             "WriteManifest",
+	EntryPoint:       WriteManifest,
+	EnabledByDefault: true,
+	Description:      "Write the manifest describing schema and row counts of the exported tables",
+	Dependencies:     []*plugin.SubTaskMeta{&ExportTablesMeta},
+}
+
+type ManifestColumn struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Kind       exporthelper.Kind `json:"kind"`
+	PrimaryKey bool              `json:"primaryKey"`
+}
+
+type ManifestTable struct {
+	Name        string            `json:"name"`
+	Columns     []*ManifestColumn `json:"columns"`
+	PartitionBy string            `json:"partitionBy,omitempty"`
+	// Rows is the number of rows in the source table matching the Where filter when the manifest is written
+	Rows         int64      `json:"rows"`
+	ExportedRows int        `json:"exportedRows"`
+	DeletedRows  int        `json:"deletedRows"`
+	Incremental  bool       `json:"incremental"`
+	UpdatedUntil *time.Time `json:"updatedUntil"`
+}
+
+type Manifest struct {
+	Destination string           `json:"destination"`
+	ExportedAt  time.Time        `json:"exportedAt"`
+	Tables      []*ManifestTable `json:"tables"`
+}
+
+// WriteManifest writes manifest.json to the directory, it would be skipped if no directory is configured
+func WriteManifest(c plugin.SubTaskContext) errors.Error {
+	data := c.GetData().(*ExportTaskData)
+	if data.Options.Directory == "" {
+		c.GetLogger().Info("no directory configured, skip writing the manifest")
+		return nil
+	}
+	db := c.GetDal()
+	manifest := &Manifest{
+		Destination: data.Exporter.Name(),
+		ExportedAt:  time.Now().UTC(),
+	}
+	for _, table := range data.Tables {
+		result, ok := data.Results[table]
+		if !ok {
+			continue
+		}
+		tableConfig := data.Options.TableConfigs[table]
+		clauses := []dal.Clause{dal.From(table)}
+		if tableConfig.Where != "" {
+			clauses = append(clauses, dal.Where(tableConfig.Where))
+		}
+		rows, err := db.Count(clauses...)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to count rows of %s", table))
+		}
+		manifestTable := &ManifestTable{
+			Name:         table,
+			PartitionBy:  tableConfig.PartitionBy,
+			Rows:         rows,
+			ExportedRows: result.ExportedRows,
+			DeletedRows:  result.DeletedRows,
+			Incremental:  result.Incremental,
+			UpdatedUntil: result.UpdatedUntil,
+		}
+		for _, column := range result.Schema.Columns {
+			manifestTable.Columns = append(manifestTable.Columns, &ManifestColumn{
+				Name:       column.Name,
+				Type:       column.Type,
+				Kind:       column.Kind,
+				PrimaryKey: column.PrimaryKey,
+			})
+		}
+		manifest.Tables = append(manifest.Tables, manifestTable)
+	}
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Convert(err)
+	}
+	path := filepath.Join(data.Options.Directory, ManifestFileName)
+	err = os.WriteFile(path, b, 0644)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to write %s", path))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+)
+
+var ExportTablesMeta = plugin.SubTaskMeta{
+	Name:             "ExportTables",
+	EntryPoint:       ExportTables,
+	EnabledByDefault: true,
+	Description:      "Export domain layer tables to the destination",
+}
+
+// ExportTables exports the selected tables incrementally, the whole tables would be exported on full sync
+func ExportTables(c plugin.SubTaskContext) errors.Error {
+	data := c.GetData().(*ExportTaskData)
+	options := data.Options
+	fullSync := false
+	if syncPolicy := c.TaskContext().SyncPolicy(); syncPolicy != nil {
+		fullSync = syncPolicy.FullSync
+	}
+	c.SetProgress(0, len(data.Tables))
+	for _, table := range data.Tables {
+		tableConfig := options.TableConfigs[table]
+		result, err := exporthelper.ExportTable(c, c.GetDal(), data.Exporter, table, &exporthelper.TableExportOptions{
+			UpdateColumn:     options.UpdateColumn,
+			IncludedColumns:  tableConfig.IncludedColumns,
+			ExcludedColumns:  tableConfig.ExcludedColumns,
+			Where:            tableConfig.Where,
+			BatchSize:        options.BatchSize,
+			PropagateDeletes: options.PropagateDeletes,
+			FullSync:         fullSync,
+		})
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to export %s to %s", table, data.Exporter.Name()))
+		}
+		data.Results[table] = result
+		c.IncProgress(1)
+	}
+	return nil
+}
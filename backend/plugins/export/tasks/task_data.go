@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+)
+
+const (
+	DestinationClickHouse = "clickhouse"
+	DestinationParquet    = "parquet"
+	DestinationCsv        = "csv"
+)
+
+const ManifestFileName = "manifest.json"
+
+type TableConfig struct {
+	IncludedColumns []string `mapstructure:"included_columns" json:"included_columns"`
+	ExcludedColumns []string `mapstructure:"excluded_columns" json:"excluded_columns"`
+	Where           string   `mapstructure:"where" json:"where"`
+	// PartitionBy partitions the parquet or csv files of the table by the column, by month for time columns
+	PartitionBy string `mapstructure:"partition_by" json:"partition_by"`
+}
+
+type ExportOptions struct {
+	// Destination is one of clickhouse, parquet and csv
+	Destination string `mapstructure:"destination" json:"destination"`
+	// Directory to write the parquet or csv files, the manifest would be written to it for all destinations
+	Directory  string                         `mapstructure:"directory" json:"directory"`
+	ClickHouse *exporthelper.ClickHouseConfig `mapstructure:"clickhouse" json:"clickhouse"`
+	// DomainLayers selects the tables of the domain layers, i.e. code, ticket, all domain layer tables by default
+	DomainLayers []string `mapstructure:"domain_layers" json:"domain_layers"`
+	// Tables are regular expressions to filter the selected tables
+	Tables           []string               `mapstructure:"tables" json:"tables"`
+	TableConfigs     map[string]TableConfig `mapstructure:"table_configs" json:"table_configs"`
+	UpdateColumn     string                 `mapstructure:"update_column" json:"update_column"`
+	BatchSize        int                    `mapstructure:"batch_size" json:"batch_size"`
+	PropagateDeletes bool                   `mapstructure:"propagate_deletes" json:"propagate_deletes"`
+}
+
+type ExportTaskData struct {
+	Options  *ExportOptions
+	Tables   []string
+	Exporter exporthelper.Exporter
+	Results  map[string]*exporthelper.TableExportResult
+}
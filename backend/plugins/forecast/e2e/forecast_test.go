@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/forecast/impl"
+	"github.com/apache/incubator-devlake/plugins/forecast/models"
+	"github.com/apache/incubator-devlake/plugins/forecast/tasks"
+)
+
+func TestRunMonteCarloForecast(t *testing.T) {
+	var plugin impl.Forecast
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "forecast", plugin)
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/sprints.csv", &ticket.Sprint{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_sprints.csv", &ticket.BoardSprint{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/sprint_issues.csv", &ticket.SprintIssue{})
+
+	asOf, _ := time.Parse(time.DateOnly, "2024-03-15")
+	options, err := tasks.DecodeAndValidateTaskOptions(map[string]interface{}{
+		"projectName":  "project1",
+		"historyDays":  14,
+		"forecastDays": 7,
+		"trials":       1000,
+		"asOf":         "2024-03-15",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskData := &tasks.ForecastTaskData{
+		Options:      options,
+		BoardIds:     []string{"jira:JiraBoard:1:1", "jira:JiraBoard:1:2"},
+		AsOf:         asOf,
+		ForecastedAt: asOf,
+	}
+	dataflowTester.FlushTabler(&models.BoardForecast{})
+	dataflowTester.Subtask(tasks.RunMonteCarloForecastMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.BoardForecast{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_forecasts_issue.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// story points, with the remaining items given
+	options.Unit = models.UNIT_STORY_POINT
+	remaining := float64(20)
+	options.RemainingItems = &remaining
+	dataflowTester.FlushTabler(&models.BoardForecast{})
+	dataflowTester.Subtask(tasks.RunMonteCarloForecastMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.BoardForecast{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_forecasts_story_point.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/forecast/models"
+	"github.com/apache/incubator-devlake/plugins/forecast/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/forecast/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginTask
+	plugin.PluginModel
+	plugin.PluginMetric
+	plugin.PluginMigration
+	plugin.MetricPluginBlueprintV200
+} = (*Forecast)(nil)
+
+type Forecast struct{}
+
+func (p Forecast) Description() string {
+	return "Forecast the throughput and completion dates of boards with Monte Carlo simulations"
+}
+
+// RequiredDataEntities hasn't been used so far
+func (p Forecast) RequiredDataEntities() (data []map[string]interface{}, err errors.Error) {
+	return []map[string]interface{}{}, nil
+}
+
+func (p Forecast) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.BoardForecast{},
+	}
+}
+
+func (p Forecast) Name() string {
+	return "forecast"
+}
+
+func (p Forecast) IsProjectMetric() bool {
+	return true
+}
+
+func (p Forecast) RunAfter() ([]string, errors.Error) {
+	return []string{}, nil
+}
+
+func (p Forecast) Settings() interface{} {
+	return nil
+}
+
+func (p Forecast) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.RunMonteCarloForecastMeta,
+	}
+}
+
+func (p Forecast) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	taskData := &tasks.ForecastTaskData{
+		Options:      op,
+		ForecastedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if op.AsOf != "" {
+		taskData.ForecastedAt, _ = time.Parse(time.DateOnly, op.AsOf)
+	}
+	taskData.AsOf = taskData.ForecastedAt.Truncate(24 * time.Hour)
+
+	var boards []crossdomain.ProjectMapping
+	err = taskCtx.GetDal().All(
+		&boards,
+		dal.From("project_mapping pm"),
+		dal.Where("pm.project_name = ? AND pm.table = ?", op.ProjectName, "boards"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting the boards of the project")
+	}
+	for _, board := range boards {
+		taskData.BoardIds = append(taskData.BoardIds, board.RowId)
+	}
+	return taskData, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p Forecast) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/forecast"
+}
+
+func (p Forecast) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Forecast) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	op := &tasks.ForecastOptions{}
+	if options != nil && string(options) != "\"\"" {
+		err := json.Unmarshal(options, op)
+		if err != nil {
+			return nil, errors.Default.WrapRaw(err)
+		}
+	}
+	plan := coreModels.PipelinePlan{
+		{
+			{
+				Plugin: "forecast",
+				Options: map[string]interface{}{
+					"projectName":    projectName,
+					"unit":           op.Unit,
+					"historyDays":    op.HistoryDays,
+					"forecastDays":   op.ForecastDays,
+					"trials":         op.Trials,
+					"remainingItems": op.RemainingItems,
+				},
+				Subtasks: []string{
+					"RunMonteCarloForecast",
+				},
+			},
+		},
+	}
+	return plan, nil
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	UNIT_ISSUE       = "ISSUE"
+	UNIT_STORY_POINT = "STORY_POINT"
+)
+
+// BoardForecast is the result of a Monte Carlo forecast of a board, every run is kept to chart the forecast drift.
+// The simulation resamples the daily throughput of the HistoryDays before the as-of date, day by day from the as-of date.
+type BoardForecast struct {
+	common.NoPKModel
+	BoardId      string    `gorm:"primaryKey;type:varchar(255)"`
+	ForecastedAt time.Time `gorm:"primaryKey"`
+	ProjectName  string    `gorm:"index;type:varchar(255)"`
+	// Unit is ISSUE or STORY_POINT
+	Unit               string `gorm:"type:varchar(20)"`
+	HistoryDays        int
+	Trials             int
+	AvgDailyThroughput float64
+	// With 50, 85 and 95% confidence at least ItemsPxx are done by the end of TargetDate
+	TargetDate time.Time
+	ItemsP50   float64
+	ItemsP85   float64
+	ItemsP95   float64
+	// RemainingItems are the undone items of the active sprint SprintId, or of the board without one,
+	// unless given by the options
+	SprintId       string `gorm:"type:varchar(255)"`
+	RemainingItems float64
+	// With 50, 85 and 95% confidence the remaining items are done by the end of CompletionDatePxx,
+	// nil if the throughput history cannot finish them within 10 years
+	CompletionDateP50 *time.Time
+	CompletionDateP85 *time.Time
+	CompletionDateP95 *time.Time
+}
+
+func (BoardForecast) TableName() string {
+	return "board_forecasts"
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type boardForecast20261031 struct {
+	archived.NoPKModel
+	BoardId            string    `gorm:"primaryKey;type:varchar(255)"`
+	ForecastedAt       time.Time `gorm:"primaryKey"`
+	ProjectName        string    `gorm:"index;type:varchar(255)"`
+	Unit               string    `gorm:"type:varchar(20)"`
+	HistoryDays        int
+	Trials             int
+	AvgDailyThroughput float64
+	TargetDate         time.Time
+	ItemsP50           float64
+	ItemsP85           float64
+	ItemsP95           float64
+	SprintId           string `gorm:"type:varchar(255)"`
+	RemainingItems     float64
+	CompletionDateP50  *time.Time
+	CompletionDateP85  *time.Time
+	CompletionDateP95  *time.Time
+}
+
+func (boardForecast20261031) TableName() string {
+	return "board_forecasts"
+}
+
+type addInitTables20261031 struct{}
+
+func (*addInitTables20261031) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &boardForecast20261031{})
+}
+
+func (*addInitTables20261031) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&boardForecast20261031{})
+}
+
+func (*addInitTables20261031) Version() uint64 {
+	return 20261031000001
+}
+
+func (*addInitTables20261031) Name() string {
+	return "forecast init schemas"
+}
@@ -0,0 +1,200 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"hash/fnv"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/forecast/models"
+)
+
+// forecasts stop after 10 years, e.g. when the board has no throughput at all
+const maxForecastDays = 3650
+
+var RunMonteCarloForecastMeta = plugin.SubTaskMeta{
+	Name:             "RunMonteCarloForecast",
+	EntryPoint:       RunMonteCarloForecast,
+	EnabledByDefault: true,
+	Description:      "Forecast how many items the boards of the project get done and when the remaining ones are done with Monte Carlo simulations",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type forecastIssue struct {
+	Id             string
+	Status         string
+	StoryPoint     *float64
+	CreatedDate    *time.Time
+	ResolutionDate *time.Time
+}
+
+// RunMonteCarloForecast forecasts every board of the project into board_forecasts. Results are keyed by the time
+// of the run so that every run is kept, or by the asOf date when given so that backtesting reruns replace them.
+func RunMonteCarloForecast(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*ForecastTaskData)
+
+	batch, err := helper.NewBatchSave(taskCtx, reflect.TypeOf(&models.BoardForecast{}), 100)
+	if err != nil {
+		return err
+	}
+	for _, boardId := range data.BoardIds {
+		forecast, err := forecastBoard(db, data, boardId)
+		if err != nil {
+			return err
+		}
+		err = batch.Add(forecast)
+		if err != nil {
+			return err
+		}
+	}
+	logger.Info("forecasted %d boards", len(data.BoardIds))
+	return batch.Close()
+}
+
+func forecastBoard(db dal.Dal, data *ForecastTaskData, boardId string) (*models.BoardForecast, errors.Error) {
+	op := data.Options
+	forecast := &models.BoardForecast{
+		BoardId:      boardId,
+		ForecastedAt: data.ForecastedAt,
+		ProjectName:  op.ProjectName,
+		Unit:         op.Unit,
+		HistoryDays:  op.HistoryDays,
+		Trials:       op.Trials,
+		TargetDate:   data.AsOf.AddDate(0, 0, op.ForecastDays-1),
+	}
+
+	// the undone issues of the latest active sprint are the remaining items, if there is one
+	var sprints []ticket.Sprint
+	err := db.All(
+		&sprints,
+		dal.Select("sprints.id"),
+		dal.From("sprints"),
+		dal.Join("INNER JOIN board_sprints ON board_sprints.sprint_id = sprints.id"),
+		dal.Where("board_sprints.board_id = ? AND sprints.status = ?", boardId, "ACTIVE"),
+		dal.Orderby("sprints.started_date DESC"),
+		dal.Limit(1),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting active sprint")
+	}
+	var sprintIssueIds map[string]bool
+	if len(sprints) > 0 {
+		forecast.SprintId = sprints[0].Id
+		var issueIds []string
+		err = db.Pluck("issue_id", &issueIds, dal.From(&ticket.SprintIssue{}), dal.Where("sprint_id = ?", forecast.SprintId))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error getting sprint issues")
+		}
+		sprintIssueIds = make(map[string]bool, len(issueIds))
+		for _, issueId := range issueIds {
+			sprintIssueIds[issueId] = true
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("issues.id, issues.status, issues.story_point, issues.created_date, issues.resolution_date"),
+		dal.From("issues"),
+		dal.Join("INNER JOIN board_issues ON board_issues.issue_id = issues.id"),
+		dal.Where("board_issues.board_id = ?", boardId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	// the history starts HistoryDays before the as-of date, or when the first issue of the board was created
+	historyStart := data.AsOf.AddDate(0, 0, -op.HistoryDays)
+	firstCreated := data.AsOf
+	throughput := make([]float64, op.HistoryDays)
+	var remaining float64
+	for cursor.Next() {
+		issue := &forecastIssue{}
+		err = db.Fetch(cursor, issue)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error fetching issues")
+		}
+		size := float64(1)
+		if op.Unit == models.UNIT_STORY_POINT {
+			size = 0
+			if issue.StoryPoint != nil {
+				size = *issue.StoryPoint
+			}
+		}
+		if issue.CreatedDate != nil && issue.CreatedDate.Before(firstCreated) {
+			firstCreated = *issue.CreatedDate
+		}
+		if issue.Status == ticket.DONE {
+			if issue.ResolutionDate != nil && !issue.ResolutionDate.Before(historyStart) && issue.ResolutionDate.Before(data.AsOf) {
+				throughput[int(issue.ResolutionDate.Sub(historyStart)/(24*time.Hour))] += size
+			}
+		} else if sprintIssueIds == nil || sprintIssueIds[issue.Id] {
+			remaining += size
+		}
+	}
+	if firstCreated.After(historyStart) {
+		skippedDays := int(firstCreated.UTC().Truncate(24*time.Hour).Sub(historyStart) / (24 * time.Hour))
+		throughput = throughput[skippedDays:]
+	}
+	if op.RemainingItems != nil {
+		forecast.SprintId = ""
+		remaining = *op.RemainingItems
+	}
+	forecast.RemainingItems = remaining
+
+	var total float64
+	for _, items := range throughput {
+		total += items
+	}
+	if len(throughput) > 0 {
+		forecast.AvgDailyThroughput = total / float64(len(throughput))
+	}
+
+	// the same board and date always get the same forecast
+	seed := fnv.New64a()
+	_, _ = seed.Write([]byte(boardId + data.AsOf.Format(time.DateOnly)))
+	simulation := NewMonteCarlo(throughput, op.Trials, int64(seed.Sum64()))
+	totals := simulation.HowMany(op.ForecastDays)
+	forecast.ItemsP50 = AtLeast(totals, 50)
+	forecast.ItemsP85 = AtLeast(totals, 85)
+	forecast.ItemsP95 = AtLeast(totals, 95)
+	days := simulation.When(remaining, maxForecastDays)
+	forecast.CompletionDateP50 = completionDate(data.AsOf, Within(days, 50))
+	forecast.CompletionDateP85 = completionDate(data.AsOf, Within(days, 85))
+	forecast.CompletionDateP95 = completionDate(data.AsOf, Within(days, 95))
+	return forecast, nil
+}
+
+// completionDate is the date of the last simulated day, the as-of date being the first one
+func completionDate(asOf time.Time, days int) *time.Time {
+	if days > maxForecastDays {
+		return nil
+	}
+	if days < 1 {
+		return &asOf
+	}
+	date := asOf.AddDate(0, 0, days-1)
+	return &date
+}
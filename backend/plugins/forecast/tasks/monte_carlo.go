@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"math/rand"
+	"sort"
+
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// MonteCarlo simulates future days by drawing daily throughputs from the history with replacement
+type MonteCarlo struct {
+	history []float64
+	trials  int
+	rand    *rand.Rand
+}
+
+func NewMonteCarlo(history []float64, trials int, seed int64) *MonteCarlo {
+	return &MonteCarlo{history: history, trials: trials, rand: rand.New(rand.NewSource(seed))}
+}
+
+// HowMany returns the sorted totals done within the days of every trial
+func (m *MonteCarlo) HowMany(days int) []float64 {
+	totals := make([]float64, m.trials)
+	if len(m.history) == 0 {
+		return totals
+	}
+	for trial := range totals {
+		for day := 0; day < days; day++ {
+			totals[trial] += m.history[m.rand.Intn(len(m.history))]
+		}
+	}
+	sort.Float64s(totals)
+	return totals
+}
+
+// When returns the sorted number of days every trial took to get the remaining items done,
+// trials not done within maxDays are counted as maxDays + 1
+func (m *MonteCarlo) When(remaining float64, maxDays int) []int {
+	days := make([]int, m.trials)
+	for trial := range days {
+		if remaining <= 0 {
+			continue
+		}
+		var done float64
+		day := 0
+		for done < remaining && day <= maxDays {
+			day++
+			if len(m.history) > 0 {
+				done += m.history[m.rand.Intn(len(m.history))]
+			}
+		}
+		days[trial] = day
+	}
+	sort.Ints(days)
+	return days
+}
+
+// AtLeast returns the total reached by the given percentage of the trials
+func AtLeast(sortedTotals []float64, confidence float64) float64 {
+	if len(sortedTotals) == 0 {
+		return 0
+	}
+	return *helper.NearestRank(sortedTotals, 100-confidence)
+}
+
+// Within returns the number of days within which the given percentage of the trials were done
+func Within(sortedDays []int, confidence float64) int {
+	if len(sortedDays) == 0 {
+		return 0
+	}
+	return *helper.NearestRank(sortedDays, confidence)
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonteCarlo(t *testing.T) {
+	constant := NewMonteCarlo([]float64{2}, 100, 1)
+	assert.Equal(t, float64(10), AtLeast(constant.HowMany(5), 95))
+	assert.Equal(t, 4, Within(constant.When(7, 365), 95))
+	assert.Equal(t, 0, Within(constant.When(0, 365), 95))
+
+	idle := NewMonteCarlo([]float64{0, 0}, 100, 1)
+	assert.Equal(t, float64(0), AtLeast(idle.HowMany(5), 50))
+	assert.Equal(t, 366, Within(idle.When(1, 365), 50))
+
+	// half of the days get one item done: more confidence means fewer items and later dates
+	coin := NewMonteCarlo([]float64{0, 1}, 10000, 1)
+	totals := coin.HowMany(20)
+	assert.InDelta(t, 10, AtLeast(totals, 50), 1)
+	assert.Less(t, AtLeast(totals, 95), AtLeast(totals, 50))
+	days := coin.When(10, 365)
+	assert.InDelta(t, 20, Within(days, 50), 2)
+	assert.Greater(t, Within(days, 95), Within(days, 50))
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/forecast/models"
+)
+
+type ForecastOptions struct {
+	ProjectName string `json:"projectName"`
+	// Unit is ISSUE (default) or STORY_POINT
+	Unit string `json:"unit"`
+	// HistoryDays is the number of days of throughput the simulation resamples, 90 by default
+	HistoryDays int `json:"historyDays"`
+	// ForecastDays is the number of days of the "how many items" forecast, 14 by default
+	ForecastDays int `json:"forecastDays"`
+	// Trials is the number of simulations per forecast, 10000 by default
+	Trials int `json:"trials"`
+	// RemainingItems overrides the items of the "when will they be done" forecast
+	RemainingItems *float64 `json:"remainingItems"`
+	// AsOf is the date (YYYY-MM-DD) to forecast from, today by default, past dates allow backtesting
+	AsOf string `json:"asOf"`
+}
+
+type ForecastTaskData struct {
+	Options      *ForecastOptions
+	BoardIds     []string
+	AsOf         time.Time
+	ForecastedAt time.Time
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*ForecastOptions, errors.Error) {
+	var op ForecastOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding forecast task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required")
+	}
+	if op.Unit == "" {
+		op.Unit = models.UNIT_ISSUE
+	}
+	if op.Unit != models.UNIT_ISSUE && op.Unit != models.UNIT_STORY_POINT {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid unit %s, expecting %s or %s", op.Unit, models.UNIT_ISSUE, models.UNIT_STORY_POINT))
+	}
+	if op.HistoryDays <= 0 {
+		op.HistoryDays = 90
+	}
+	if op.ForecastDays <= 0 {
+		op.ForecastDays = 14
+	}
+	if op.Trials <= 0 {
+		op.Trials = 10000
+	}
+	if op.AsOf != "" {
+		if _, err := time.Parse(time.DateOnly, op.AsOf); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid asOf %s, expecting YYYY-MM-DD", op.AsOf))
+		}
+	}
+	return &op, nil
+}
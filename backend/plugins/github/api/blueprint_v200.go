@@ -30,6 +30,7 @@ import (
 	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
@@ -190,6 +191,16 @@ func makeScopesV200(
 			}
 			scopes = append(scopes, scopeTicket)
 		}
+		// add cq_project to scopes for security alerts
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE_QUALITY) {
+			scopeCqProject := &codequality.CqProject{
+				DomainEntityExtended: domainlayer.DomainEntityExtended{
+					Id: didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
+				},
+				Name: githubRepo.FullName,
+			}
+			scopes = append(scopes, scopeCqProject)
+		}
 	}
 	return scopes, nil
 }
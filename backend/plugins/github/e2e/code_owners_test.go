@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestCodeOwnersDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_code_owners.csv", "_raw_"+tasks.RAW_CODE_OWNERS_TABLE)
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubCodeOwnerRule{})
+	dataflowTester.Subtask(tasks.ExtractCodeOwnersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubCodeOwnerRule{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_code_owner_rules.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_pull_requests.csv", &models.GithubPullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_accounts.csv", &models.GithubAccount{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_request_commits.csv", &code.PullRequestCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/commit_files.csv", &code.CommitFile{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_request_reviewers.csv", &code.PullRequestReviewer{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_request_comments.csv", &code.PullRequestComment{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/teams.csv", &crossdomain.Team{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/team_users.csv", &crossdomain.TeamUser{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/user_accounts.csv", &crossdomain.UserAccount{})
+	dataflowTester.FlushTabler(&code.PullRequestCodeOwner{})
+	dataflowTester.Subtask(tasks.ConvertCodeOwnersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestCodeOwner{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_code_owners.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
@@ -52,6 +53,7 @@ func TestRepoDataFlow(t *testing.T) {
 	dataflowTester.FlushTabler(&ticket.Board{})
 	dataflowTester.FlushTabler(&devops.CicdScope{})
 	dataflowTester.FlushTabler(&crossdomain.BoardRepo{})
+	dataflowTester.FlushTabler(&codequality.CqProject{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_repos.csv", &models.GithubRepo{})
 	dataflowTester.Subtask(tasks.ConvertRepoMeta, taskData)
 	dataflowTester.VerifyTable(
@@ -107,4 +109,9 @@ func TestRepoDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/cicd_scopes.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	dataflowTester.VerifyTableWithOptions(&codequality.CqProject{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cq_projects.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
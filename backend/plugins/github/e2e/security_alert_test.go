@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestSecurityAlertDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_dependabot_alerts.csv", "_raw_"+tasks.RAW_DEPENDABOT_ALERTS_TABLE)
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_code_scanning_alerts.csv", "_raw_"+tasks.RAW_CODE_SCANNING_ALERTS_TABLE)
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_secret_scanning_alerts.csv", "_raw_"+tasks.RAW_SECRET_SCANNING_ALERTS_TABLE)
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubSecurityAlert{})
+	dataflowTester.Subtask(tasks.ExtractSecurityAlertsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubSecurityAlert{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_security_alerts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&codequality.CqIssue{})
+	dataflowTester.FlushTabler(&codequality.CqIssueCodeBlock{})
+	dataflowTester.Subtask(tasks.ConvertSecurityAlertsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&codequality.CqIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cq_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&codequality.CqIssueCodeBlock{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cq_issue_code_blocks.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// the cq_issue of an alert no longer collected is deleted
+	errors.Must(dataflowTester.Dal.Delete(
+		&models.GithubSecurityAlert{},
+		dal.Where("alert_type = ? AND number = ?", models.ALERT_TYPE_SECRET_SCANNING, 2),
+	))
+	dataflowTester.Subtask(tasks.ConvertSecurityAlertsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&codequality.CqIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cq_issues_after_alert_removed.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestGithubTestReportDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(helper.TEST_REPORT_PATTERN, "^test-results$")
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+			ScopeConfig: &models.GithubScopeConfig{
+				TestReportPattern: "^test-results$",
+			},
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// verify artifact extraction
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_run_artifacts.csv", "_raw_github_api_run_artifacts")
+	dataflowTester.FlushTabler(&models.GithubRunArtifact{})
+	dataflowTester.Subtask(tasks.ExtractRunArtifactsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubRunArtifact{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_run_artifacts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify test report extraction
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_test_reports.csv", "_raw_github_api_test_reports")
+	dataflowTester.FlushTabler(&models.GithubTestResult{})
+	dataflowTester.FlushTabler(&models.GithubTestCoverage{})
+	dataflowTester.Subtask(tasks.ExtractTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubTestResult{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_test_results.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubTestCoverage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_test_coverages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&qa.QaProject{})
+	dataflowTester.FlushTabler(&qa.QaTestCase{})
+	dataflowTester.FlushTabler(&qa.QaTestCaseExecution{})
+	dataflowTester.Subtask(tasks.ConvertTestResultsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&qa.QaProject{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_projects.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_test_cases.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCaseExecution{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_test_case_executions.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&qa.QaTestCoverage{})
+	dataflowTester.Subtask(tasks.ConvertTestCoveragesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCoverage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_test_coverages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -19,7 +19,9 @@ package impl
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/subtaskmeta/sorter"
 
@@ -45,6 +47,7 @@ var _ interface {
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
 	plugin.CloseablePluginTask
+	helper.DeploymentCandidateSource
 } = (*Github)(nil)
 
 var sortedSubtaskMetas []plugin.SubTaskMeta
@@ -102,10 +105,21 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubRepoCommit{},
 		&models.GithubReviewer{},
 		&models.GithubRun{},
+		&models.GithubRunner{},
 		&models.GithubIssueAssignee{},
 		&models.GithubScopeConfig{},
 		&models.GithubDeployment{},
 		&models.GithubRelease{},
+		&models.GithubProject{},
+		&models.GithubProjectItem{},
+		&models.GithubProjectIteration{},
+		&models.GithubProjectItemFieldValue{},
+		&models.GithubIssueType{},
+		&models.GithubSecurityAlert{},
+		&models.GithubCodeOwnerRule{},
+		&models.GithubRunArtifact{},
+		&models.GithubTestResult{},
+		&models.GithubTestCoverage{},
 	}
 }
 
@@ -157,11 +171,19 @@ func (p Github) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err = regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, op.ScopeConfig.EnvNamePattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
 	}
+	if err = regexEnricher.TryAdd(helper.TEST_REPORT_PATTERN, op.ScopeConfig.TestReportPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `testReportPattern`")
+	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.ScopeConfig.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 
 	taskData := &tasks.GithubTaskData{
-		Options:       op,
-		ApiClient:     apiClient,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	return taskData, nil
@@ -275,6 +297,14 @@ func (p Github) GetDynamicGitUrl(taskCtx plugin.TaskContext, connectionId uint64
 	return newUrl, nil
 }
 
+func (p Github) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	githubId, err := strconv.Atoi(scopeId)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid github repo id")
+	}
+	return tasks.DeploymentCandidates(db, connectionId, githubId, since)
+}
+
 func EnrichOptions(taskCtx plugin.TaskContext,
 	op *tasks.GithubOptions,
 	apiClient *helper.ApiClient,
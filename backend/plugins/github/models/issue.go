@@ -46,6 +46,10 @@ type GithubIssue struct {
 	GithubUpdatedAt time.Time `gorm:"index"`
 	Severity        string    `gorm:"type:varchar(255)"`
 	Component       string    `gorm:"type:text"`
+	IssueType       string    `gorm:"type:varchar(255)"`
+	ProjectStatus   string    `gorm:"type:varchar(255)"`
+	StdStatus       string    `gorm:"type:varchar(100)"`
+	StoryPoint      *float64
 	common.NoPKModel
 }
 
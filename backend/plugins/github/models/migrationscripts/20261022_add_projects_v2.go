@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/github/models/migrationscripts/archived"
+)
+
+var _ plugin.MigrationScript = (*addProjectsV2)(nil)
+
+type issue20261022 struct {
+	IssueType     string `gorm:"type:varchar(255)"`
+	ProjectStatus string `gorm:"type:varchar(255)"`
+	StdStatus     string `gorm:"type:varchar(100)"`
+	StoryPoint    *float64
+}
+
+func (issue20261022) TableName() string {
+	return "_tool_github_issues"
+}
+
+type scopeConfig20261022 struct {
+	ProjectStatusField    string            `gorm:"type:varchar(255)"`
+	ProjectStatusMappings map[string]string `gorm:"serializer:json"`
+	ProjectIterationField string            `gorm:"type:varchar(255)"`
+	ProjectEstimateField  string            `gorm:"type:varchar(255)"`
+}
+
+func (scopeConfig20261022) TableName() string {
+	return "_tool_github_scope_configs"
+}
+
+type addProjectsV2 struct{}
+
+func (*addProjectsV2) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.AutoMigrate(&issue20261022{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&scopeConfig20261022{}); err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.GithubProject{},
+		&archived.GithubProjectItem{},
+		&archived.GithubProjectIteration{},
+		&archived.GithubProjectItemFieldValue{},
+		&archived.GithubIssueType{},
+	)
+}
+
+func (*addProjectsV2) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_github_issues", "issue_type", "project_status", "std_status", "story_point"); err != nil {
+		return err
+	}
+	if err := db.DropColumns("_tool_github_scope_configs", "project_status_field", "project_status_mappings", "project_iteration_field", "project_estimate_field"); err != nil {
+		return err
+	}
+	return db.DropTables(&archived.GithubProject{}, &archived.GithubProjectItem{}, &archived.GithubProjectIteration{}, &archived.GithubProjectItemFieldValue{}, &archived.GithubIssueType{})
+}
+
+func (*addProjectsV2) Version() uint64 {
+	return 20261022000001
+}
+
+func (*addProjectsV2) Name() string {
+	return "add projects v2 tables and issue type, status and story point to _tool_github_issues"
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/github/models/migrationscripts/archived"
+)
+
+var _ plugin.MigrationScript = (*addTestReports)(nil)
+
+type githubScopeConfig20261106 struct {
+	TestReportPattern string `gorm:"type:varchar(255)"`
+}
+
+func (githubScopeConfig20261106) TableName() string {
+	return "_tool_github_scope_configs"
+}
+
+type addTestReports struct{}
+
+func (*addTestReports) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubScopeConfig20261106{},
+		&archived.GithubRunArtifact{},
+		&archived.GithubTestResult{},
+		&archived.GithubTestCoverage{},
+	)
+}
+
+func (*addTestReports) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_github_scope_configs", "test_report_pattern"); err != nil {
+		return err
+	}
+	return db.DropTables(&archived.GithubRunArtifact{}, &archived.GithubTestResult{}, &archived.GithubTestCoverage{})
+}
+
+func (*addTestReports) Version() uint64 {
+	return 20261106000001
+}
+
+func (*addTestReports) Name() string {
+	return "add test_report_pattern to _tool_github_scope_configs and the tables of test reports"
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type GithubSecurityAlert struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	RepoId          int    `gorm:"primaryKey;autoIncrement:false"`
+	AlertType       string `gorm:"primaryKey;type:varchar(50)"`
+	Number          int    `gorm:"primaryKey;autoIncrement:false"`
+	State           string `gorm:"type:varchar(50)"`
+	Severity        string `gorm:"type:varchar(50)"`
+	RuleId          string `gorm:"type:varchar(255)"`
+	RuleName        string `gorm:"type:varchar(255)"`
+	Description     string
+	Tool            string `gorm:"type:varchar(100)"`
+	Tags            string
+	PackageName     string `gorm:"type:varchar(255)"`
+	Ecosystem       string `gorm:"type:varchar(100)"`
+	GhsaId          string `gorm:"type:varchar(100)"`
+	CveId           string `gorm:"type:varchar(100)"`
+	Path            string `gorm:"type:varchar(500)"`
+	StartLine       int
+	EndLine         int
+	StartColumn     int
+	EndColumn       int
+	CommitSha       string `gorm:"type:varchar(40)"`
+	Url             string `gorm:"type:varchar(255)"`
+	GithubCreatedAt time.Time
+	GithubUpdatedAt *time.Time
+	FixedAt         *time.Time
+	DismissedAt     *time.Time
+	DismissedReason string `gorm:"type:varchar(100)"`
+	DismissedBy     string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (GithubSecurityAlert) TableName() string {
+	return "_tool_github_security_alerts"
+}
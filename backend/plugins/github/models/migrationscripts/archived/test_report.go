@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type GithubRunArtifact struct {
+	archived.NoPKModel
+	ConnectionId    uint64     `gorm:"primaryKey"`
+	ID              int64      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	RepoId          int        `gorm:"index"`
+	RunId           int        `gorm:"index"`
+	HeadSha         string     `gorm:"type:varchar(255)"`
+	Name            string     `json:"name" gorm:"type:varchar(255)"`
+	SizeInBytes     int64      `json:"size_in_bytes"`
+	Expired         bool       `json:"expired"`
+	GithubCreatedAt *time.Time `json:"created_at"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+}
+
+func (GithubRunArtifact) TableName() string {
+	return "_tool_github_run_artifacts"
+}
+
+type GithubTestResult struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ArtifactId   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Seq          int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId       int    `gorm:"index"`
+	RunId        int    `gorm:"index"`
+	HeadSha      string `gorm:"type:varchar(255)"`
+	ReportFile   string `gorm:"type:varchar(255)"`
+	Format       string `gorm:"type:varchar(20)"`
+	Suite        string `gorm:"type:varchar(255)"`
+	TestName     string `gorm:"type:text"`
+	Status       string `gorm:"type:varchar(20)"`
+	DurationSec  float64
+	StartedAt    *time.Time
+	Message      string `gorm:"type:text"`
+}
+
+func (GithubTestResult) TableName() string {
+	return "_tool_github_test_results"
+}
+
+type GithubTestCoverage struct {
+	archived.NoPKModel
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ArtifactId      int64  `gorm:"primaryKey;autoIncrement:false"`
+	Seq             int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId          int    `gorm:"index"`
+	RunId           int    `gorm:"index"`
+	HeadSha         string `gorm:"type:varchar(255)"`
+	ReportFile      string `gorm:"type:varchar(255)"`
+	LineRate        float64
+	BranchRate      float64
+	LinesCovered    int
+	LinesValid      int
+	BranchesCovered int
+	BranchesValid   int
+	GithubCreatedAt *time.Time
+}
+
+func (GithubTestCoverage) TableName() string {
+	return "_tool_github_test_coverages"
+}
@@ -55,5 +55,12 @@ func All() []plugin.MigrationScript {
 		new(addIsDraftToPr),
 		new(changeIssueComponentType),
 		new(addIndexToGithubJobs),
+		new(addProjectsV2),
+		new(addSecurityAlerts),
+		new(addCodeOwnerRules),
+		new(addRunners),
+		new(addDeploymentRules),
+		new(addTestReports),
+		new(addIncidentRules),
 	}
 }
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GithubProject is a GitHub Projects (v2) project which contains issues of the repo
+type GithubProject struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectId       string `gorm:"primaryKey;type:varchar(100)"`
+	Number          int
+	Title           string `gorm:"type:varchar(255)"`
+	Url             string `gorm:"type:varchar(255)"`
+	Closed          bool
+	GithubCreatedAt time.Time
+	GithubUpdatedAt time.Time
+	common.NoPKModel
+}
+
+func (GithubProject) TableName() string {
+	return "_tool_github_projects"
+}
+
+// GithubProjectItem is an issue added to the project, with the values of the fields configured in the scope config
+type GithubProjectItem struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ItemId       string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId    string `gorm:"index;type:varchar(100)"`
+	RepoId       int    `gorm:"index"`
+	IssueId      int    `gorm:"index"`
+	IsArchived   bool
+	Status       string `gorm:"type:varchar(255)"`
+	IterationId  string `gorm:"type:varchar(100)"`
+	Estimate     *float64
+	common.NoPKModel
+}
+
+func (GithubProjectItem) TableName() string {
+	return "_tool_github_project_items"
+}
+
+type GithubProjectIteration struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ProjectId    string `gorm:"primaryKey;type:varchar(100)"`
+	IterationId  string `gorm:"primaryKey;type:varchar(100)"`
+	FieldName    string `gorm:"type:varchar(255)"`
+	Title        string `gorm:"type:varchar(255)"`
+	StartDate    *time.Time
+	// Duration is the number of days of the iteration
+	Duration int
+	common.NoPKModel
+}
+
+func (GithubProjectIteration) TableName() string {
+	return "_tool_github_project_iterations"
+}
+
+// GithubProjectItemFieldValue holds the value of every field of the project item as string
+type GithubProjectItemFieldValue struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ItemId       string `gorm:"primaryKey;type:varchar(100)"`
+	FieldName    string `gorm:"primaryKey;type:varchar(255)"`
+	FieldType    string `gorm:"type:varchar(100)"`
+	Value        string
+	common.NoPKModel
+}
+
+func (GithubProjectItemFieldValue) TableName() string {
+	return "_tool_github_project_item_field_values"
+}
+
+// GithubIssueType is the issue type assigned to the issue, which is configured by the organization
+type GithubIssueType struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	IssueId      int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId       int    `gorm:"index"`
+	Name         string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (GithubIssueType) TableName() string {
+	return "_tool_github_issue_types"
+}
@@ -20,6 +20,7 @@ package models
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"gorm.io/datatypes"
 )
 
@@ -40,6 +41,27 @@ type GithubScopeConfig struct {
 	ProductionPattern    string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	EnvNamePattern       string            `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
 	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	// ProjectStatusField is the single select field of Projects (v2) used as the status of issues, defaults to Status
+	ProjectStatusField string `mapstructure:"projectStatusField,omitempty" json:"projectStatusField" gorm:"type:varchar(255)"`
+	// ProjectStatusMappings maps the options of the status field to TODO, IN_PROGRESS or DONE
+	ProjectStatusMappings map[string]string `mapstructure:"projectStatusMappings,omitempty" json:"projectStatusMappings" gorm:"serializer:json"`
+	// ProjectIterationField is the iteration field of Projects (v2) used as sprints, defaults to Iteration
+	ProjectIterationField string `mapstructure:"projectIterationField,omitempty" json:"projectIterationField" gorm:"type:varchar(255)"`
+	// ProjectEstimateField is the field of Projects (v2) used as story points, defaults to Estimate
+	ProjectEstimateField string `mapstructure:"projectEstimateField,omitempty" json:"projectEstimateField" gorm:"type:varchar(255)"`
+
+	// DeploymentRules classify the pipelines and tasks ahead of deploymentPattern and productionPattern
+	DeploymentRules []*helper.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
+	// TestReportPattern matches the names of the run artifacts carrying JUnit XML, TRX or Cobertura reports
+	TestReportPattern string `mapstructure:"testReportPattern,omitempty" json:"testReportPattern" gorm:"type:varchar(255)"`
+	// IncidentRules normalizes severity, affected service and customer impact of the issues matching issueTypeIncident
+	IncidentRules *helper.IncidentNormalizationRules `mapstructure:"incidentRules,omitempty" json:"incidentRules" gorm:"type:json;serializer:json"`
+}
+
+var _ helper.IncidentRulesScopeConfig = (*GithubScopeConfig)(nil)
+
+func (sc GithubScopeConfig) GetIncidentRules() *helper.IncidentNormalizationRules {
+	return sc.IncidentRules
 }
 
 // GetConnectionId implements plugin.ToolLayerScopeConfig.
@@ -72,6 +72,7 @@ func ConvertJobs(taskCtx plugin.SubTaskContext) (err errors.Error) {
 	jobIdGen := didgen.NewDomainIdGenerator(&models.GithubJob{})
 	runIdGen := didgen.NewDomainIdGenerator(&models.GithubRun{})
 	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	runnerIdGen := didgen.NewDomainIdGenerator(&models.GithubRunner{})
 	converter, err := api.NewDataConverter(api.DataConverterArgs{
 		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
 			Ctx: taskCtx,
@@ -117,6 +118,9 @@ func ConvertJobs(taskCtx plugin.SubTaskContext) (err errors.Error) {
 				}, line.Status),
 				OriginalStatus: line.Status,
 			}
+			if line.RunnerName != "" {
+				domainJob.RunnerId = runnerIdGen.Generate(data.Options.ConnectionId, line.RunnerName)
+			}
 			if line.CompletedAt != nil && line.StartedAt != nil {
 				domainJob.DurationSec = float64(line.CompletedAt.Sub(*line.StartedAt).Milliseconds() / 1e3)
 			}
@@ -39,7 +39,7 @@ var ExtractJobsMeta = plugin.SubTaskMeta{
 	Description:      "Extract raw run data into tool layer table github_jobs",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 	DependencyTables: []string{RAW_JOB_TABLE},
-	ProductTables:    []string{models.GithubJob{}.TableName()},
+	ProductTables:    []string{models.GithubJob{}.TableName(), models.GithubRunner{}.TableName()},
 }
 
 func ExtractJobs(taskCtx plugin.SubTaskContext) errors.Error {
@@ -62,32 +62,42 @@ func ExtractJobs(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, err
 			}
 
+			jobType, jobEnvironment := data.DeploymentRules.Classify(
+				NewJobDeploymentCandidate(githubJob),
+				data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, githubJob.Name),
+				data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, githubJob.Name),
+			)
+
 			results := make([]interface{}, 0, 1)
 			githubJobResult := &models.GithubJob{
-				ConnectionId:  data.Options.ConnectionId,
-				RepoId:        repoId,
-				ID:            githubJob.ID,
-				RunID:         githubJob.RunID,
-				RunURL:        githubJob.RunURL,
-				NodeID:        githubJob.NodeID,
-				HeadSha:       githubJob.HeadSha,
-				URL:           githubJob.URL,
-				HTMLURL:       githubJob.HTMLURL,
-				Status:        strings.ToUpper(githubJob.Status),
-				Conclusion:    strings.ToUpper(githubJob.Conclusion),
-				StartedAt:     githubJob.StartedAt,
-				CompletedAt:   githubJob.CompletedAt,
-				Name:          githubJob.Name,
-				Steps:         githubJob.Steps,
-				CheckRunURL:   githubJob.CheckRunURL,
-				Labels:        githubJob.Labels,
-				RunnerID:      githubJob.RunID,
-				RunnerName:    githubJob.RunnerName,
-				RunnerGroupID: githubJob.RunnerGroupID,
-				Type:          data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, githubJob.Name),
-				Environment:   data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, githubJob.Name),
+				ConnectionId:    data.Options.ConnectionId,
+				RepoId:          repoId,
+				ID:              githubJob.ID,
+				RunID:           githubJob.RunID,
+				RunURL:          githubJob.RunURL,
+				NodeID:          githubJob.NodeID,
+				HeadSha:         githubJob.HeadSha,
+				URL:             githubJob.URL,
+				HTMLURL:         githubJob.HTMLURL,
+				Status:          strings.ToUpper(githubJob.Status),
+				Conclusion:      strings.ToUpper(githubJob.Conclusion),
+				StartedAt:       githubJob.StartedAt,
+				CompletedAt:     githubJob.CompletedAt,
+				Name:            githubJob.Name,
+				Steps:           githubJob.Steps,
+				CheckRunURL:     githubJob.CheckRunURL,
+				Labels:          githubJob.Labels,
+				RunnerID:        githubJob.RunnerID,
+				RunnerName:      githubJob.RunnerName,
+				RunnerGroupID:   githubJob.RunnerGroupID,
+				RunnerGroupName: githubJob.RunnerGroupName,
+				Type:            jobType,
+				Environment:     jobEnvironment,
 			}
 			results = append(results, githubJobResult)
+			if githubJob.RunnerName != "" {
+				results = append(results, ExtractRunner(data.Options.ConnectionId, githubJob))
+			}
 			return results, nil
 		},
 	})
@@ -98,3 +108,25 @@ func ExtractJobs(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return extractor.Execute()
 }
+
+// ExtractRunner records the runner of a job, the labels of a job are the ones its runner had to match
+func ExtractRunner(connectionId uint64, githubJob *models.GithubJob) *models.GithubRunner {
+	var labels []string
+	_ = json.Unmarshal(githubJob.Labels, &labels)
+	isSelfHosted := false
+	for _, label := range labels {
+		if strings.EqualFold(label, "self-hosted") {
+			isSelfHosted = true
+		}
+	}
+	return &models.GithubRunner{
+		ConnectionId:    connectionId,
+		Name:            githubJob.RunnerName,
+		RunnerId:        githubJob.RunnerID,
+		RunnerGroupId:   githubJob.RunnerGroupID,
+		RunnerGroupName: githubJob.RunnerGroupName,
+		Labels:          strings.Join(labels, ","),
+		Os:              devops.GetRunnerOs(labels...),
+		IsSelfHosted:    isSelfHosted,
+	}
+}
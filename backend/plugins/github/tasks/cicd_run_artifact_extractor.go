@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractRunArtifactsMeta)
+}
+
+var ExtractRunArtifactsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Run Artifacts",
+	EntryPoint:       ExtractRunArtifacts,
+	EnabledByDefault: true,
+	Description:      "Extract raw artifact data into tool layer table _tool_github_run_artifacts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{RAW_RUN_ARTIFACT_TABLE},
+	ProductTables:    []string{models.GithubRunArtifact{}.TableName()},
+}
+
+type GithubApiRunArtifact struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	SizeInBytes int64      `json:"size_in_bytes"`
+	Expired     bool       `json:"expired"`
+	CreatedAt   *time.Time `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	WorkflowRun struct {
+		ID      int    `json:"id"`
+		HeadSha string `json:"head_sha"`
+	} `json:"workflow_run"`
+}
+
+func ExtractRunArtifacts(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RUN_ARTIFACT_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiArtifact := &GithubApiRunArtifact{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiArtifact))
+			if err != nil {
+				return nil, err
+			}
+			if apiArtifact.WorkflowRun.ID == 0 {
+				input := &SimpleGithubRun{}
+				if err = errors.Convert(json.Unmarshal(row.Input, input)); err != nil {
+					return nil, err
+				}
+				apiArtifact.WorkflowRun.ID = int(input.ID)
+			}
+			return []interface{}{
+				&models.GithubRunArtifact{
+					ConnectionId:    data.Options.ConnectionId,
+					ID:              apiArtifact.ID,
+					RepoId:          data.Options.GithubId,
+					RunId:           apiArtifact.WorkflowRun.ID,
+					HeadSha:         apiArtifact.WorkflowRun.HeadSha,
+					Name:            apiArtifact.Name,
+					SizeInBytes:     apiArtifact.SizeInBytes,
+					Expired:         apiArtifact.Expired,
+					GithubCreatedAt: apiArtifact.CreatedAt,
+					ExpiresAt:       apiArtifact.ExpiresAt,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -62,8 +62,11 @@ func ExtractRuns(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 			githubRun.RepoId = repoId
 			githubRun.ConnectionId = data.Options.ConnectionId
-			githubRun.Type = data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, githubRun.Name)
-			githubRun.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, githubRun.Name, githubRun.HeadBranch)
+			githubRun.Type, githubRun.Environment = data.DeploymentRules.Classify(
+				NewRunDeploymentCandidate(githubRun),
+				data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, githubRun.Name),
+				data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, githubRun.Name, githubRun.HeadBranch),
+			)
 			return []interface{}{githubRun}, nil
 		},
 	})
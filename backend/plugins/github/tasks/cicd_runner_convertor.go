@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertRunnersMeta)
+}
+
+var ConvertRunnersMeta = plugin.SubTaskMeta{
+	Name:             "Convert Runners",
+	EntryPoint:       ConvertRunners,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_runners into domain layer table cicd_runners",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	DependencyTables: []string{
+		RAW_JOB_TABLE,
+		models.GithubRunner{}.TableName(),
+		models.GithubJob{}.TableName(),
+	},
+	ProductTables: []string{devops.CicdRunner{}.TableName()},
+}
+
+func ConvertRunners(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	// runners are shared by repos, only the ones which ran jobs of the repo are converted
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubRunner{}),
+		dal.Where(`connection_id = ? and name in (
+			select runner_name from _tool_github_jobs where connection_id = ? and repo_id = ?
+		)`, data.Options.ConnectionId, data.Options.ConnectionId, data.Options.GithubId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	runnerIdGen := didgen.NewDomainIdGenerator(&models.GithubRunner{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_JOB_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubRunner{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			runner := inputRow.(*models.GithubRunner)
+			pool := runner.RunnerGroupName
+			if pool == "" {
+				pool = runner.Labels
+			}
+			return []interface{}{
+				&devops.CicdRunner{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: runnerIdGen.Generate(runner.ConnectionId, runner.Name),
+					},
+					Name:         runner.Name,
+					Pool:         pool,
+					Labels:       runner.Labels,
+					Os:           runner.Os,
+					IsSelfHosted: runner.IsSelfHosted,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectCodeOwnersMeta)
+}
+
+const RAW_CODE_OWNERS_TABLE = "github_api_code_owners"
+
+// CODE_OWNERS_PATHS are the locations Github looks for the CODEOWNERS file, ordered by precedence
+var CODE_OWNERS_PATHS = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+var CollectCodeOwnersMeta = plugin.SubTaskMeta{
+	Name:             "Collect Code Owners",
+	EntryPoint:       CollectCodeOwners,
+	EnabledByDefault: true,
+	Description:      "Collect CODEOWNERS files of the base refs of pull requests from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	DependencyTables: []string{models.GithubPullRequest{}.TableName()},
+	ProductTables:    []string{RAW_CODE_OWNERS_TABLE},
+}
+
+type SimpleCodeOwnersFile struct {
+	Ref  string
+	Path string
+}
+
+func CollectCodeOwners(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	var refs []string
+	err := db.Pluck("DISTINCT base_ref", &refs,
+		dal.From(&models.GithubPullRequest{}),
+		dal.Where("repo_id = ? and connection_id = ? and base_ref != ''", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	for _, ref := range refs {
+		for _, path := range CODE_OWNERS_PATHS {
+			iterator.Push(&SimpleCodeOwnersFile{Ref: ref, Path: path})
+		}
+	}
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_CODE_OWNERS_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		Input:       iterator,
+		UrlTemplate: "repos/{{ .Params.Name }}/contents/{{ .Input.Path }}",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("ref", reqData.Input.(*SimpleCodeOwnersFile).Ref)
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				return nil, errors.Convert(err)
+			}
+			res.Body.Close()
+			return []json.RawMessage{body}, nil
+		},
+		AfterResponse: ignoreHTTPStatus403And404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
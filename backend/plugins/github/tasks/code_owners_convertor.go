@@ -0,0 +1,344 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertCodeOwnersMeta)
+}
+
+var ConvertCodeOwnersMeta = plugin.SubTaskMeta{
+	Name:             "Convert Code Owners",
+	EntryPoint:       ConvertCodeOwners,
+	EnabledByDefault: true,
+	Description:      "Match the files changed by pull requests against CODEOWNERS of their base refs, and record the required owners along with their reviews into domain layer table pull_request_code_owners",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	DependencyTables: []string{
+		models.GithubCodeOwnerRule{}.TableName(), // rules
+		models.GithubPullRequest{}.TableName(),   // cursor and id generator
+		models.GithubAccount{}.TableName(),       // owner resolving and id generator
+		code.PullRequest{}.TableName(),           // pull requests of the repo
+		code.PullRequestCommit{}.TableName(),     // changed files
+		code.PullRequestReviewer{}.TableName(),   // reviewers
+		code.PullRequestComment{}.TableName(),    // review dates
+	},
+	ProductTables: []string{code.PullRequestCodeOwner{}.TableName()},
+}
+
+type prReview struct {
+	PullRequestId string
+	AccountId     string
+	CreatedDate   time.Time
+}
+
+func ConvertCodeOwners(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	connectionId := data.Options.ConnectionId
+
+	rulesByRef, err := loadCodeOwnerRules(db, connectionId, data.Options.GithubId)
+	if err != nil {
+		return err
+	}
+	if len(rulesByRef) == 0 {
+		taskCtx.GetLogger().Info("no CODEOWNERS found for repo %s", data.Options.Name)
+	}
+
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GithubAccount{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.GithubPullRequest{})
+
+	// resolve `@login` and emails to accounts
+	var githubAccounts []models.GithubAccount
+	err = db.All(&githubAccounts, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return err
+	}
+	accountIds := make(map[string]string)
+	for _, account := range githubAccounts {
+		accountId := accountIdGen.Generate(connectionId, account.Id)
+		if account.Email != "" {
+			accountIds[strings.ToLower(account.Email)] = accountId
+		}
+		accountIds["@"+strings.ToLower(account.Login)] = accountId
+	}
+
+	// resolve `@org/team` to teams by name or alias, and load the accounts of their members
+	var teams []crossdomain.Team
+	err = db.All(&teams)
+	if err != nil {
+		return err
+	}
+	teamIds := make(map[string]string)
+	for _, team := range teams {
+		for _, name := range []string{team.Name, team.Alias} {
+			if name != "" {
+				teamIds[strings.ToLower(name)] = team.Id
+			}
+		}
+	}
+	var teamAccounts []struct {
+		TeamId    string
+		AccountId string
+	}
+	err = db.All(&teamAccounts,
+		dal.Select("team_users.team_id, user_accounts.account_id"),
+		dal.From(&crossdomain.TeamUser{}),
+		dal.Join("JOIN user_accounts ON user_accounts.user_id = team_users.user_id"),
+	)
+	if err != nil {
+		return err
+	}
+	teamMembers := make(map[string]map[string]bool)
+	for _, teamAccount := range teamAccounts {
+		if teamMembers[teamAccount.TeamId] == nil {
+			teamMembers[teamAccount.TeamId] = make(map[string]bool)
+		}
+		teamMembers[teamAccount.TeamId][teamAccount.AccountId] = true
+	}
+
+	// load the changed files, reviewers and reviews of all pull requests in the repo at once
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connectionId, data.Options.GithubId)
+	filesByPr, reviewerIdsByPr, reviewsByPr, err := loadCodeOwnersReviewData(db, repoId)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubPullRequest{}),
+		dal.Where("repo_id = ? and connection_id = ?", data.Options.GithubId, connectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.GithubPullRequest{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: connectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_PULL_REQUEST_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pr := inputRow.(*models.GithubPullRequest)
+			rules := rulesByRef[pr.BaseRef]
+			if len(rules) == 0 {
+				return nil, nil
+			}
+			prId := prIdGen.Generate(connectionId, pr.GithubId)
+
+			var ownerNames []string
+			ownedFileCounts := make(map[string]int)
+			for _, file := range filesByPr[prId] {
+				rule := api.FindCodeOwnersRule(rules, file)
+				if rule == nil {
+					continue
+				}
+				for _, owner := range rule.Owners {
+					if ownedFileCounts[owner] == 0 {
+						ownerNames = append(ownerNames, owner)
+					}
+					ownedFileCounts[owner]++
+				}
+			}
+			if len(ownerNames) == 0 {
+				return nil, nil
+			}
+
+			reviews := reviewsByPr[prId]
+			reviewerIds := reviewerIdsByPr[prId]
+
+			results := make([]interface{}, 0, len(ownerNames))
+			for _, ownerName := range ownerNames {
+				prCodeOwner := &code.PullRequestCodeOwner{
+					PullRequestId:  prId,
+					OwnerId:        ownerName,
+					OwnerName:      ownerName,
+					OwnerType:      code.CODE_OWNER_TYPE_UNRESOLVED,
+					OwnedFileCount: ownedFileCounts[ownerName],
+				}
+				members := make(map[string]bool)
+				if accountId, ok := accountIds[strings.ToLower(ownerName)]; ok {
+					prCodeOwner.OwnerId = accountId
+					prCodeOwner.OwnerType = code.CODE_OWNER_TYPE_ACCOUNT
+					members[accountId] = true
+				} else if teamId := resolveCodeOwnerTeam(teamIds, ownerName); teamId != "" {
+					prCodeOwner.OwnerId = teamId
+					prCodeOwner.OwnerType = code.CODE_OWNER_TYPE_TEAM
+					members = teamMembers[teamId]
+				}
+				for _, review := range reviews {
+					if members[review.AccountId] {
+						prCodeOwner.IsReviewed = true
+						prCodeOwner.ReviewerId = review.AccountId
+						reviewDate := review.CreatedDate
+						prCodeOwner.FirstReviewDate = &reviewDate
+						break
+					}
+				}
+				if !prCodeOwner.IsReviewed {
+					for _, reviewerId := range reviewerIds {
+						if members[reviewerId] {
+							prCodeOwner.IsReviewed = true
+							prCodeOwner.ReviewerId = reviewerId
+							break
+						}
+					}
+				}
+				results = append(results, prCodeOwner)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// loadCodeOwnersReviewData returns the changed files, reviewer ids and reviews (ordered by date) by pull request id
+func loadCodeOwnersReviewData(db dal.Dal, repoId string) (
+	map[string][]string,
+	map[string][]string,
+	map[string][]prReview,
+	errors.Error,
+) {
+	var prFiles []struct {
+		PullRequestId string
+		FilePath      string
+	}
+	err := db.All(&prFiles,
+		dal.Select("DISTINCT pull_request_commits.pull_request_id, commit_files.file_path"),
+		dal.From(&code.PullRequestCommit{}),
+		dal.Join("JOIN pull_requests ON pull_requests.id = pull_request_commits.pull_request_id"),
+		dal.Join("JOIN commit_files ON commit_files.commit_sha = pull_request_commits.commit_sha"),
+		dal.Where("pull_requests.base_repo_id = ?", repoId),
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	filesByPr := make(map[string][]string)
+	for _, prFile := range prFiles {
+		filesByPr[prFile.PullRequestId] = append(filesByPr[prFile.PullRequestId], prFile.FilePath)
+	}
+
+	var prReviewers []struct {
+		PullRequestId string
+		ReviewerId    string
+	}
+	err = db.All(&prReviewers,
+		dal.Select("pull_request_reviewers.pull_request_id, pull_request_reviewers.reviewer_id"),
+		dal.From(&code.PullRequestReviewer{}),
+		dal.Join("JOIN pull_requests ON pull_requests.id = pull_request_reviewers.pull_request_id"),
+		dal.Where("pull_requests.base_repo_id = ?", repoId),
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	reviewerIdsByPr := make(map[string][]string)
+	for _, prReviewer := range prReviewers {
+		reviewerIdsByPr[prReviewer.PullRequestId] = append(reviewerIdsByPr[prReviewer.PullRequestId], prReviewer.ReviewerId)
+	}
+
+	var reviews []prReview
+	err = db.All(&reviews,
+		dal.Select("pull_request_comments.pull_request_id, pull_request_comments.account_id, pull_request_comments.created_date"),
+		dal.From(&code.PullRequestComment{}),
+		dal.Join("JOIN pull_requests ON pull_requests.id = pull_request_comments.pull_request_id"),
+		dal.Where("pull_requests.base_repo_id = ? AND pull_request_comments.type = ?", repoId, "REVIEW"),
+		dal.Orderby("pull_request_comments.created_date"),
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	reviewsByPr := make(map[string][]prReview)
+	for _, review := range reviews {
+		reviewsByPr[review.PullRequestId] = append(reviewsByPr[review.PullRequestId], review)
+	}
+	return filesByPr, reviewerIdsByPr, reviewsByPr, nil
+}
+
+// loadCodeOwnerRules returns the rules by ref, only the CODEOWNERS file with the highest precedence in a ref is effective
+func loadCodeOwnerRules(db dal.Dal, connectionId uint64, repoId int) (map[string][]*api.CodeOwnersRule, errors.Error) {
+	var githubRules []models.GithubCodeOwnerRule
+	err := db.All(&githubRules,
+		dal.Where("connection_id = ? and repo_id = ?", connectionId, repoId),
+		dal.Orderby("ref, line_number"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	filePaths := make(map[string]string)
+	for _, githubRule := range githubRules {
+		if current, ok := filePaths[githubRule.Ref]; !ok || codeOwnersPathPrecedence(githubRule.FilePath) < codeOwnersPathPrecedence(current) {
+			filePaths[githubRule.Ref] = githubRule.FilePath
+		}
+	}
+	rulesByRef := make(map[string][]*api.CodeOwnersRule)
+	for _, githubRule := range githubRules {
+		if filePaths[githubRule.Ref] != githubRule.FilePath {
+			continue
+		}
+		rule, err := api.NewCodeOwnersRule(githubRule.LineNumber, githubRule.Pattern, strings.Fields(githubRule.Owners))
+		if err != nil {
+			return nil, err
+		}
+		rulesByRef[githubRule.Ref] = append(rulesByRef[githubRule.Ref], rule)
+	}
+	return rulesByRef, nil
+}
+
+func codeOwnersPathPrecedence(path string) int {
+	for i, p := range CODE_OWNERS_PATHS {
+		if p == path {
+			return i
+		}
+	}
+	return len(CODE_OWNERS_PATHS)
+}
+
+// resolveCodeOwnerTeam looks up `@org/team-slug` by the full name first and then by the slug
+func resolveCodeOwnerTeam(teamIds map[string]string, ownerName string) string {
+	if !strings.HasPrefix(ownerName, "@") || !strings.Contains(ownerName, "/") {
+		return ""
+	}
+	fullName := strings.ToLower(strings.TrimPrefix(ownerName, "@"))
+	if teamId, ok := teamIds[fullName]; ok {
+		return teamId
+	}
+	return teamIds[fullName[strings.Index(fullName, "/")+1:]]
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractCodeOwnersMeta)
+}
+
+var ExtractCodeOwnersMeta = plugin.SubTaskMeta{
+	Name:             "Extract Code Owners",
+	EntryPoint:       ExtractCodeOwners,
+	EnabledByDefault: true,
+	Description:      "Extract raw CODEOWNERS files into tool layer table github_code_owner_rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	DependencyTables: []string{RAW_CODE_OWNERS_TABLE},
+	ProductTables:    []string{models.GithubCodeOwnerRule{}.TableName()},
+}
+
+type GithubApiContent struct {
+	Type     string
+	Path     string
+	Encoding string
+	Content  string
+}
+
+func ExtractCodeOwners(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_CODE_OWNERS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &SimpleCodeOwnersFile{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			content := &GithubApiContent{}
+			err = errors.Convert(json.Unmarshal(row.Data, content))
+			if err != nil {
+				return nil, err
+			}
+			if content.Type != "file" || content.Encoding != "base64" {
+				return nil, nil
+			}
+			// the content is wrapped every 60 characters
+			decoded, err := errors.Convert01(base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", "")))
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "failed to decode CODEOWNERS content")
+			}
+			results := make([]interface{}, 0)
+			for _, rule := range api.ParseCodeOwners(string(decoded)) {
+				results = append(results, &models.GithubCodeOwnerRule{
+					ConnectionId: data.Options.ConnectionId,
+					RepoId:       data.Options.GithubId,
+					Ref:          input.Ref,
+					FilePath:     input.Path,
+					LineNumber:   rule.LineNumber,
+					Pattern:      rule.Pattern,
+					Owners:       strings.Join(rule.Owners, " "),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
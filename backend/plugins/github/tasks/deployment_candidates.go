@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+// NewRunDeploymentCandidate describes a workflow run to the deployment rules
+func NewRunDeploymentCandidate(run *models.GithubRun) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate(run.HeadBranch, false, run.Conclusion)
+	candidate.PipelineName, candidate.Trigger = run.Name, run.Event
+	return candidate
+}
+
+// NewJobDeploymentCandidate describes a job of a workflow run to the deployment rules
+func NewJobDeploymentCandidate(job *models.GithubJob) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, job.Conclusion)
+	candidate.JobName = job.Name
+	return candidate
+}
+
+// DeploymentCandidates describes the workflow runs of the repo created since the time and their jobs to the deployment
+// rules by the ids of the cicd_pipelines and the cicd_tasks they are converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, githubId int, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var runs []*models.GithubRun
+	err := db.All(&runs, dal.Where("connection_id = ? AND repo_id = ? AND github_created_at >= ?", connectionId, githubId, since))
+	if err != nil {
+		return nil, err
+	}
+	runIdGen := didgen.NewDomainIdGenerator(&models.GithubRun{})
+	for _, run := range runs {
+		candidates.Pipelines[runIdGen.Generate(connectionId, run.RepoId, run.ID)] = NewRunDeploymentCandidate(run)
+	}
+	var jobs []*models.GithubJob
+	err = db.All(&jobs, dal.Where("connection_id = ? AND repo_id = ? AND started_at >= ?", connectionId, githubId, since))
+	if err != nil {
+		return nil, err
+	}
+	jobIdGen := didgen.NewDomainIdGenerator(&models.GithubJob{})
+	for _, job := range jobs {
+		candidates.Tasks[jobIdGen.Generate(connectionId, job.RunID, job.ID)] = NewJobDeploymentCandidate(job)
+	}
+	return candidates, nil
+}
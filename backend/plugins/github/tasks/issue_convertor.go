@@ -102,6 +102,10 @@ func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 				ResolutionDate:  issue.ClosedAt,
 				Severity:        issue.Severity,
 				Component:       issue.Component,
+				StoryPoint:      issue.StoryPoint,
+			}
+			if issue.ProjectStatus != "" {
+				domainIssue.OriginalStatus = issue.ProjectStatus
 			}
 			if issue.AssigneeId != 0 {
 				domainIssue.AssigneeId = accountIdGen.Generate(data.Options.ConnectionId, issue.AssigneeId)
@@ -111,6 +115,8 @@ func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 			if strings.ToUpper(issue.State) == "CLOSED" {
 				domainIssue.Status = ticket.DONE
+			} else if issue.StdStatus != "" {
+				domainIssue.Status = issue.StdStatus
 			} else {
 				domainIssue.Status = ticket.TODO
 			}
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
@@ -63,7 +64,8 @@ var ConvertRepoMeta = plugin.SubTaskMeta{
 		plugin.DOMAIN_TYPE_TICKET,
 		plugin.DOMAIN_TYPE_CICD,
 		plugin.DOMAIN_TYPE_CODE_REVIEW,
-		plugin.DOMAIN_TYPE_CROSS},
+		plugin.DOMAIN_TYPE_CROSS,
+		plugin.DOMAIN_TYPE_CODE_QUALITY},
 	DependencyTables: []string{
 		//models.GithubRepo{}.TableName(), // config will not regard as dependency
 		//RAW_REPOSITORIES_TABLE,
@@ -72,7 +74,8 @@ var ConvertRepoMeta = plugin.SubTaskMeta{
 		code.Repo{}.TableName(),
 		ticket.Board{}.TableName(),
 		crossdomain.BoardRepo{}.TableName(),
-		devops.CicdScope{}.TableName()},
+		devops.CicdScope{}.TableName(),
+		codequality.CqProject{}.TableName()},
 }
 
 func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
@@ -142,11 +145,19 @@ func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
 				UpdatedDate: repository.UpdatedDate,
 			}
 
+			domainCqProject := &codequality.CqProject{
+				DomainEntityExtended: domainlayer.DomainEntityExtended{
+					Id: repoIdGen.Generate(data.Options.ConnectionId, repository.GithubId),
+				},
+				Name: repository.FullName,
+			}
+
 			return []interface{}{
 				domainRepository,
 				domainBoard,
 				domainBoardRepo,
 				domainCicdScope,
+				domainCqProject,
 			}, nil
 		},
 	})
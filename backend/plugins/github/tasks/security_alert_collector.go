@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	githubUtils "github.com/apache/incubator-devlake/plugins/github/utils"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectSecurityAlertsMeta)
+}
+
+const (
+	RAW_DEPENDABOT_ALERTS_TABLE      = "github_api_dependabot_alerts"
+	RAW_CODE_SCANNING_ALERTS_TABLE   = "github_api_code_scanning_alerts"
+	RAW_SECRET_SCANNING_ALERTS_TABLE = "github_api_secret_scanning_alerts"
+)
+
+var CollectSecurityAlertsMeta = plugin.SubTaskMeta{
+	Name:             "Collect Security Alerts",
+	EntryPoint:       CollectSecurityAlerts,
+	EnabledByDefault: true,
+	Description:      "Collect Dependabot, code scanning and secret scanning alerts from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{},
+	ProductTables: []string{
+		RAW_DEPENDABOT_ALERTS_TABLE,
+		RAW_CODE_SCANNING_ALERTS_TABLE,
+		RAW_SECRET_SCANNING_ALERTS_TABLE},
+}
+
+// CollectSecurityAlerts collects all kinds of alerts, the alerts would be skipped if the feature is disabled
+// for the repo or the token doesn't have the permission to read them
+func CollectSecurityAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	alerts := []struct {
+		table       string
+		urlTemplate string
+	}{
+		{RAW_DEPENDABOT_ALERTS_TABLE, "repos/{{ .Params.Name }}/dependabot/alerts"},
+		{RAW_CODE_SCANNING_ALERTS_TABLE, "repos/{{ .Params.Name }}/code-scanning/alerts"},
+		{RAW_SECRET_SCANNING_ALERTS_TABLE, "repos/{{ .Params.Name }}/secret-scanning/alerts"},
+	}
+	for _, alert := range alerts {
+		collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+			RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+				Ctx: taskCtx,
+				Params: GithubApiParams{
+					ConnectionId: data.Options.ConnectionId,
+					Name:         data.Options.Name,
+				},
+				Table: alert.table,
+			},
+			ApiClient:   data.ApiClient,
+			PageSize:    100,
+			Incremental: false,
+			UrlTemplate: alert.urlTemplate,
+			Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+				query := url.Values{}
+				query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+				if cursor, ok := reqData.CustomData.(string); ok && cursor != "" {
+					query.Set("after", cursor)
+				}
+				return query, nil
+			},
+			GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+				cursor := githubUtils.GetNextCursorFromLinkHeader(prevPageResponse.Header.Get("link"))
+				if cursor == "" {
+					return nil, api.ErrFinishCollect
+				}
+				return cursor, nil
+			},
+			ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+				var items []json.RawMessage
+				err := api.UnmarshalResponse(res, &items)
+				if err != nil {
+					return nil, err
+				}
+				return items, nil
+			},
+			AfterResponse: ignoreHTTPStatus403And404,
+		})
+		if err != nil {
+			return err
+		}
+		err = collector.Execute()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertSecurityAlertsMeta)
+}
+
+var ConvertSecurityAlertsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Security Alerts",
+	EntryPoint:       ConvertSecurityAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_security_alerts into domain layer table cq_issues and cq_issue_code_blocks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{models.GithubSecurityAlert{}.TableName()},
+	ProductTables: []string{
+		codequality.CqIssue{}.TableName(),
+		codequality.CqIssueCodeBlock{}.TableName()},
+}
+
+// the severities of alerts are mapped to the severities used by SonarQube
+var securityAlertSeverities = map[string]string{
+	"critical": "BLOCKER",
+	"high":     "CRITICAL",
+	"error":    "CRITICAL",
+	"medium":   "MAJOR",
+	"moderate": "MAJOR",
+	"warning":  "MAJOR",
+	"low":      "MINOR",
+	"note":     "INFO",
+}
+
+// ConvertSecurityAlerts converts the alerts of each type separately with the raw table they were collected to, so
+// the cq_issues of alerts no longer returned by the api would be deleted along with the raw data
+func ConvertSecurityAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	for _, alert := range []struct {
+		table     string
+		alertType string
+	}{
+		{RAW_DEPENDABOT_ALERTS_TABLE, models.ALERT_TYPE_DEPENDABOT},
+		{RAW_CODE_SCANNING_ALERTS_TABLE, models.ALERT_TYPE_CODE_SCANNING},
+		{RAW_SECRET_SCANNING_ALERTS_TABLE, models.ALERT_TYPE_SECRET_SCANNING},
+	} {
+		err := convertSecurityAlerts(taskCtx, data, alert.table, alert.alertType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertSecurityAlerts(taskCtx plugin.SubTaskContext, data *GithubTaskData, table, alertType string) errors.Error {
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubSecurityAlert{}),
+		dal.Where(
+			"repo_id = ? and connection_id = ? and alert_type = ?",
+			data.Options.GithubId, data.Options.ConnectionId, alertType,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	alertIdGen := didgen.NewDomainIdGenerator(&models.GithubSecurityAlert{})
+	projectKey := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: table,
+		},
+		InputRowType: reflect.TypeOf(models.GithubSecurityAlert{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alert := inputRow.(*models.GithubSecurityAlert)
+			issueId := alertIdGen.Generate(alert.ConnectionId, alert.RepoId, alert.AlertType, alert.Number)
+			cqIssue := &codequality.CqIssue{
+				DomainEntity: domainlayer.DomainEntity{Id: issueId},
+				Rule:         alert.RuleId,
+				Severity:     securityAlertSeverities[strings.ToLower(alert.Severity)],
+				Component:    alert.Path,
+				ProjectKey:   projectKey,
+				Line:         alert.StartLine,
+				Status:       "OPEN",
+				Message:      alert.RuleName,
+				Tags:         alert.Tags,
+				Type:         "VULNERABILITY",
+				Scope:        alert.AlertType,
+				StartLine:    alert.StartLine,
+				EndLine:      alert.EndLine,
+				StartOffset:  alert.StartColumn,
+				EndOffset:    alert.EndColumn,
+				CreatedDate:  iso8601Time(&alert.GithubCreatedAt),
+				UpdatedDate:  iso8601Time(alert.GithubUpdatedAt),
+			}
+			if alert.Severity == "" && alert.AlertType == models.ALERT_TYPE_SECRET_SCANNING {
+				cqIssue.Severity = "BLOCKER"
+			}
+			if alert.AlertType == models.ALERT_TYPE_CODE_SCANNING && !strings.Contains(alert.Tags, "security") {
+				cqIssue.Type = "CODE_SMELL"
+			}
+			if alert.AlertType == models.ALERT_TYPE_DEPENDABOT {
+				cqIssue.SecurityCategory = alert.Ecosystem
+			}
+			// the updated date of closed alerts would be the time they were closed for calculating the MTTR
+			if alert.FixedAt != nil {
+				cqIssue.Status = "CLOSED"
+				cqIssue.UpdatedDate = iso8601Time(alert.FixedAt)
+			} else if alert.DismissedAt != nil {
+				cqIssue.Status = "RESOLVED"
+				cqIssue.UpdatedDate = iso8601Time(alert.DismissedAt)
+			} else if alert.State != "open" {
+				cqIssue.Status = "CLOSED"
+			}
+			results := []interface{}{cqIssue}
+			if alert.Path != "" {
+				results = append(results, &codequality.CqIssueCodeBlock{
+					DomainEntity: domainlayer.DomainEntity{Id: issueId},
+					IssueKey:     issueId,
+					Component:    alert.Path,
+					StartLine:    alert.StartLine,
+					EndLine:      alert.EndLine,
+					StartOffset:  alert.StartColumn,
+					EndOffset:    alert.EndColumn,
+					Msg:          alert.Description,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func iso8601Time(t *time.Time) *common.Iso8601Time {
+	if t == nil {
+		return nil
+	}
+	return &common.Iso8601Time{Time: *t}
+}
@@ -0,0 +1,285 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractSecurityAlertsMeta)
+}
+
+var ExtractSecurityAlertsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Security Alerts",
+	EntryPoint:       ExtractSecurityAlerts,
+	EnabledByDefault: true,
+	Description:      "Extract raw Dependabot, code scanning and secret scanning alerts into tool layer table github_security_alerts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{
+		RAW_DEPENDABOT_ALERTS_TABLE,
+		RAW_CODE_SCANNING_ALERTS_TABLE,
+		RAW_SECRET_SCANNING_ALERTS_TABLE},
+	ProductTables: []string{models.GithubSecurityAlert{}.TableName()},
+}
+
+type githubAlertUser struct {
+	Login string
+}
+
+type GithubApiDependabotAlert struct {
+	Number     int
+	State      string
+	Dependency struct {
+		Package struct {
+			Ecosystem string
+			Name      string
+		}
+		ManifestPath string `json:"manifest_path"`
+	}
+	SecurityAdvisory struct {
+		GhsaId      string `json:"ghsa_id"`
+		CveId       string `json:"cve_id"`
+		Summary     string
+		Description string
+		Severity    string
+		Cwes        []struct {
+			CweId string `json:"cwe_id"`
+		}
+	} `json:"security_advisory"`
+	HtmlUrl         string              `json:"html_url"`
+	CreatedAt       common.Iso8601Time  `json:"created_at"`
+	UpdatedAt       *common.Iso8601Time `json:"updated_at"`
+	FixedAt         *common.Iso8601Time `json:"fixed_at"`
+	DismissedAt     *common.Iso8601Time `json:"dismissed_at"`
+	DismissedBy     *githubAlertUser    `json:"dismissed_by"`
+	DismissedReason string              `json:"dismissed_reason"`
+	AutoDismissedAt *common.Iso8601Time `json:"auto_dismissed_at"`
+}
+
+type GithubApiCodeScanningAlert struct {
+	Number int
+	State  string
+	Rule   struct {
+		Id                    string
+		Name                  string
+		Severity              string
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string
+		Tags                  []string
+	}
+	Tool struct {
+		Name string
+	}
+	MostRecentInstance struct {
+		CommitSha string `json:"commit_sha"`
+		Message   struct {
+			Text string
+		}
+		Location struct {
+			Path        string
+			StartLine   int `json:"start_line"`
+			EndLine     int `json:"end_line"`
+			StartColumn int `json:"start_column"`
+			EndColumn   int `json:"end_column"`
+		}
+	} `json:"most_recent_instance"`
+	HtmlUrl         string              `json:"html_url"`
+	CreatedAt       common.Iso8601Time  `json:"created_at"`
+	UpdatedAt       *common.Iso8601Time `json:"updated_at"`
+	FixedAt         *common.Iso8601Time `json:"fixed_at"`
+	DismissedAt     *common.Iso8601Time `json:"dismissed_at"`
+	DismissedBy     *githubAlertUser    `json:"dismissed_by"`
+	DismissedReason string              `json:"dismissed_reason"`
+}
+
+type GithubApiSecretScanningAlert struct {
+	Number                int
+	State                 string
+	Resolution            string
+	SecretType            string              `json:"secret_type"`
+	SecretTypeDisplayName string              `json:"secret_type_display_name"`
+	HtmlUrl               string              `json:"html_url"`
+	CreatedAt             common.Iso8601Time  `json:"created_at"`
+	UpdatedAt             *common.Iso8601Time `json:"updated_at"`
+	ResolvedAt            *common.Iso8601Time `json:"resolved_at"`
+	ResolvedBy            *githubAlertUser    `json:"resolved_by"`
+}
+
+func ExtractSecurityAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractors := map[string]func(row *api.RawData) (*models.GithubSecurityAlert, errors.Error){
+		RAW_DEPENDABOT_ALERTS_TABLE:      extractDependabotAlert,
+		RAW_CODE_SCANNING_ALERTS_TABLE:   extractCodeScanningAlert,
+		RAW_SECRET_SCANNING_ALERTS_TABLE: extractSecretScanningAlert,
+	}
+	for _, table := range []string{RAW_DEPENDABOT_ALERTS_TABLE, RAW_CODE_SCANNING_ALERTS_TABLE, RAW_SECRET_SCANNING_ALERTS_TABLE} {
+		extract := extractors[table]
+		extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+			RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+				Ctx: taskCtx,
+				Params: GithubApiParams{
+					ConnectionId: data.Options.ConnectionId,
+					Name:         data.Options.Name,
+				},
+				Table: table,
+			},
+			Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+				alert, err := extract(row)
+				if err != nil {
+					return nil, err
+				}
+				alert.ConnectionId = data.Options.ConnectionId
+				alert.RepoId = data.Options.GithubId
+				return []interface{}{alert}, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		err = extractor.Execute()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractDependabotAlert(row *api.RawData) (*models.GithubSecurityAlert, errors.Error) {
+	apiAlert := &GithubApiDependabotAlert{}
+	err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+	if err != nil {
+		return nil, err
+	}
+	var cwes []string
+	for _, cwe := range apiAlert.SecurityAdvisory.Cwes {
+		cwes = append(cwes, cwe.CweId)
+	}
+	alert := &models.GithubSecurityAlert{
+		AlertType:       models.ALERT_TYPE_DEPENDABOT,
+		Number:          apiAlert.Number,
+		State:           apiAlert.State,
+		Severity:        apiAlert.SecurityAdvisory.Severity,
+		RuleId:          apiAlert.SecurityAdvisory.GhsaId,
+		RuleName:        apiAlert.SecurityAdvisory.Summary,
+		Description:     apiAlert.SecurityAdvisory.Description,
+		Tool:            "dependabot",
+		Tags:            strings.Join(cwes, ","),
+		PackageName:     apiAlert.Dependency.Package.Name,
+		Ecosystem:       apiAlert.Dependency.Package.Ecosystem,
+		GhsaId:          apiAlert.SecurityAdvisory.GhsaId,
+		CveId:           apiAlert.SecurityAdvisory.CveId,
+		Path:            apiAlert.Dependency.ManifestPath,
+		Url:             apiAlert.HtmlUrl,
+		GithubCreatedAt: apiAlert.CreatedAt.ToTime(),
+		GithubUpdatedAt: common.Iso8601TimeToTime(apiAlert.UpdatedAt),
+		FixedAt:         common.Iso8601TimeToTime(apiAlert.FixedAt),
+		DismissedAt:     common.Iso8601TimeToTime(apiAlert.DismissedAt),
+		DismissedReason: apiAlert.DismissedReason,
+	}
+	if apiAlert.DismissedBy != nil {
+		alert.DismissedBy = apiAlert.DismissedBy.Login
+	}
+	if apiAlert.AutoDismissedAt != nil {
+		alert.DismissedAt = common.Iso8601TimeToTime(apiAlert.AutoDismissedAt)
+		alert.DismissedReason = "auto_dismissed"
+	}
+	return alert, nil
+}
+
+func extractCodeScanningAlert(row *api.RawData) (*models.GithubSecurityAlert, errors.Error) {
+	apiAlert := &GithubApiCodeScanningAlert{}
+	err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+	if err != nil {
+		return nil, err
+	}
+	location := apiAlert.MostRecentInstance.Location
+	alert := &models.GithubSecurityAlert{
+		AlertType:       models.ALERT_TYPE_CODE_SCANNING,
+		Number:          apiAlert.Number,
+		State:           apiAlert.State,
+		Severity:        apiAlert.Rule.SecuritySeverityLevel,
+		RuleId:          apiAlert.Rule.Id,
+		RuleName:        apiAlert.Rule.Name,
+		Description:     apiAlert.MostRecentInstance.Message.Text,
+		Tool:            apiAlert.Tool.Name,
+		Tags:            strings.Join(apiAlert.Rule.Tags, ","),
+		Path:            location.Path,
+		StartLine:       location.StartLine,
+		EndLine:         location.EndLine,
+		StartColumn:     location.StartColumn,
+		EndColumn:       location.EndColumn,
+		CommitSha:       apiAlert.MostRecentInstance.CommitSha,
+		Url:             apiAlert.HtmlUrl,
+		GithubCreatedAt: apiAlert.CreatedAt.ToTime(),
+		GithubUpdatedAt: common.Iso8601TimeToTime(apiAlert.UpdatedAt),
+		FixedAt:         common.Iso8601TimeToTime(apiAlert.FixedAt),
+		DismissedAt:     common.Iso8601TimeToTime(apiAlert.DismissedAt),
+		DismissedReason: apiAlert.DismissedReason,
+	}
+	// rules which are not security related only have the severity of error, warning or note
+	if alert.Severity == "" {
+		alert.Severity = apiAlert.Rule.Severity
+	}
+	if alert.Description == "" {
+		alert.Description = apiAlert.Rule.Description
+	}
+	if apiAlert.DismissedBy != nil {
+		alert.DismissedBy = apiAlert.DismissedBy.Login
+	}
+	return alert, nil
+}
+
+func extractSecretScanningAlert(row *api.RawData) (*models.GithubSecurityAlert, errors.Error) {
+	apiAlert := &GithubApiSecretScanningAlert{}
+	err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+	if err != nil {
+		return nil, err
+	}
+	alert := &models.GithubSecurityAlert{
+		AlertType:       models.ALERT_TYPE_SECRET_SCANNING,
+		Number:          apiAlert.Number,
+		State:           apiAlert.State,
+		RuleId:          apiAlert.SecretType,
+		RuleName:        apiAlert.SecretTypeDisplayName,
+		Tool:            "secret-scanning",
+		Url:             apiAlert.HtmlUrl,
+		GithubCreatedAt: apiAlert.CreatedAt.ToTime(),
+		GithubUpdatedAt: common.Iso8601TimeToTime(apiAlert.UpdatedAt),
+	}
+	// revoked secrets are regarded as fixed, the other resolutions are regarded as dismissed
+	if apiAlert.State == "resolved" {
+		if apiAlert.Resolution == "revoked" {
+			alert.FixedAt = common.Iso8601TimeToTime(apiAlert.ResolvedAt)
+		} else {
+			alert.DismissedAt = common.Iso8601TimeToTime(apiAlert.ResolvedAt)
+			alert.DismissedReason = apiAlert.Resolution
+			if apiAlert.ResolvedBy != nil {
+				alert.DismissedBy = apiAlert.ResolvedBy.Login
+			}
+		}
+	}
+	return alert, nil
+}
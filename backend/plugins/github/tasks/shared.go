@@ -18,7 +18,10 @@ limitations under the License.
 package tasks
 
 import (
+	"bytes"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -73,6 +76,66 @@ func ignoreHTTPStatus404(res *http.Response) errors.Error {
 	return nil
 }
 
+// ignoreHTTPStatus403And404 skips the resources which are disabled for the repo or not accessible by the token,
+// any other 403, i.e. the primary or secondary rate limit being exceeded, would be retried by the api client
+func ignoreHTTPStatus403And404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	if res.StatusCode == http.StatusForbidden && isFeatureDisabledOrForbidden(res) {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
+
+// the messages of 403 responses returned when the feature is disabled or the token lacks the permission, e.g.
+// "Dependabot alerts are disabled for this repository." or "Resource not accessible by personal access token"
+var featureDisabledOrForbiddenHints = []string{
+	"disabled",
+	"not enabled",
+	"must be enabled",
+	"not accessible",
+	"must have admin",
+	"permission",
+}
+
+func isFeatureDisabledOrForbidden(res *http.Response) bool {
+	if res.Header.Get("X-RateLimit-Remaining") == "0" || res.Header.Get("Retry-After") != "" {
+		return false
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	// the body would be read again if the request is retried
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	message := strings.ToLower(string(body))
+	if strings.Contains(message, "rate limit") {
+		return false
+	}
+	for _, hint := range featureDisabledOrForbiddenHints {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoreHTTPStatus404And410 skips the artifacts which are deleted or expired since they were listed
+func ignoreHTTPStatus404And410(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
+	}
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
+
 func ignoreHTTPStatus422(res *http.Response) errors.Error {
 	if res.StatusCode == http.StatusUnprocessableEntity {
 		return api.ErrIgnoreAndContinue
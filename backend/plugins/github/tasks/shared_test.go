@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreHTTPStatus403And404(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		status  int
+		header  http.Header
+		body    string
+		ignored bool
+	}{
+		{name: "ok", status: http.StatusOK, body: "[]"},
+		{name: "not found", status: http.StatusNotFound, body: `{"message":"Not Found"}`, ignored: true},
+		{name: "feature disabled", status: http.StatusForbidden, body: `{"message":"Dependabot alerts are disabled for this repository."}`, ignored: true},
+		{name: "no permission", status: http.StatusForbidden, body: `{"message":"Resource not accessible by personal access token"}`, ignored: true},
+		{name: "rate limit", status: http.StatusForbidden, header: http.Header{"X-Ratelimit-Remaining": {"0"}}, body: `{"message":"API rate limit exceeded"}`},
+		{name: "secondary rate limit", status: http.StatusForbidden, header: http.Header{"Retry-After": {"60"}}, body: `{"message":"You have exceeded a secondary rate limit."}`},
+		{name: "secondary rate limit without header", status: http.StatusForbidden, body: `{"message":"You have exceeded a secondary rate limit."}`},
+		{name: "unknown", status: http.StatusForbidden, body: `{"message":"Forbidden"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := &http.Response{StatusCode: tc.status, Header: tc.header, Body: io.NopCloser(strings.NewReader(tc.body))}
+			if res.Header == nil {
+				res.Header = http.Header{}
+			}
+			err := ignoreHTTPStatus403And404(res)
+			if tc.ignored {
+				assert.Equal(t, api.ErrIgnoreAndContinue, err)
+				return
+			}
+			assert.Nil(t, err)
+			// the response would be handled as usual, e.g. retried for the rate limit
+			body, e := io.ReadAll(res.Body)
+			assert.Nil(t, e)
+			assert.Equal(t, tc.body, string(body))
+		})
+	}
+}
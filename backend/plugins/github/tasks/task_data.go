@@ -38,10 +38,11 @@ type GithubOptions struct {
 }
 
 type GithubTaskData struct {
-	Options       *GithubOptions
-	ApiClient     *helper.ApiAsyncClient
-	GraphqlClient *helper.GraphqlAsyncClient
-	RegexEnricher *helper.RegexEnricher
+	Options         *GithubOptions
+	ApiClient       *helper.ApiAsyncClient
+	GraphqlClient   *helper.GraphqlAsyncClient
+	RegexEnricher   *helper.RegexEnricher
+	DeploymentRules *helper.DeploymentRuleEngine
 }
 
 // TODO: avoid touching too many files, should be removed in the future
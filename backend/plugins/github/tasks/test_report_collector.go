@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectTestReportsMeta)
+}
+
+const RAW_TEST_REPORT_TABLE = "github_api_test_reports"
+
+var CollectTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "Collect Test Reports",
+	EntryPoint:       CollectTestReports,
+	EnabledByDefault: true,
+	Description:      "Download the run artifacts matching testReportPattern and parse the JUnit XML, TRX and Cobertura reports inside, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{models.GithubRunArtifact{}.TableName()},
+	ProductTables:    []string{RAW_TEST_REPORT_TABLE},
+}
+
+// SimpleGithubArtifact is the input of a test report request, the run and the commit are kept for the extractor
+type SimpleGithubArtifact struct {
+	ID              int64
+	RunId           int
+	HeadSha         string
+	GithubCreatedAt *time.Time
+}
+
+func CollectTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*GithubTaskData)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		logger.Info("testReportPattern is not set, skip collecting test reports")
+		return nil
+	}
+
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Name:         data.Options.Name,
+		},
+		Table: RAW_TEST_REPORT_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the artifacts are immutable, only the ones uploaded since the last collection are downloaded
+	clauses := []dal.Clause{
+		dal.Select("id, run_id, head_sha, name, github_created_at"),
+		dal.From(&models.GithubRunArtifact{}),
+		dal.Where(
+			"repo_id = ? AND connection_id = ? AND expired = ?",
+			data.Options.GithubId, data.Options.ConnectionId, false,
+		),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("github_created_at > ?", apiCollector.GetSince()))
+	}
+	var artifacts []models.GithubRunArtifact
+	err = db.All(&artifacts, clauses...)
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	for _, artifact := range artifacts {
+		if data.RegexEnricher.ReturnNameIfMatched(api.TEST_REPORT_PATTERN, artifact.Name) == "" {
+			continue
+		}
+		iterator.Push(&SimpleGithubArtifact{
+			ID:              artifact.ID,
+			RunId:           artifact.RunId,
+			HeadSha:         artifact.HeadSha,
+			GithubCreatedAt: artifact.GithubCreatedAt,
+		})
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		ApiClient:      data.ApiClient,
+		Input:          iterator,
+		UrlTemplate:    "repos/{{ .Params.Name }}/actions/artifacts/{{ .Input.ID }}/zip",
+		ResponseParser: api.NewTestReportArchiveParser(logger),
+		AfterResponse:  ignoreHTTPStatus404And410,
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertTestResultsMeta)
+	RegisterSubtaskMeta(&ConvertTestCoveragesMeta)
+}
+
+var ConvertTestResultsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Test Results",
+	EntryPoint:       ConvertTestResults,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_github_test_results into domain layer tables qa_projects, qa_test_cases and qa_test_case_executions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{
+		RAW_TEST_REPORT_TABLE,
+		models.GithubTestResult{}.TableName(),
+	},
+	ProductTables: []string{
+		qa.QaProject{}.TableName(),
+		(&qa.QaTestCase{}).TableName(),
+		qa.QaTestCaseExecution{}.TableName(),
+	},
+}
+
+var ConvertTestCoveragesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Test Coverages",
+	EntryPoint:       ConvertTestCoverages,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_github_test_coverages into domain layer table qa_test_coverages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{
+		RAW_TEST_REPORT_TABLE,
+		models.GithubTestCoverage{}.TableName(),
+	},
+	ProductTables: []string{qa.QaTestCoverage{}.TableName()},
+}
+
+// ConvertTestResults converts the test results of the repo, the repo is the qa project of its test cases so the
+// executions are scoped to the projects the repo belongs to
+func ConvertTestResults(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		return nil
+	}
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	err := db.CreateOrUpdate(&qa.QaProject{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{Id: repoId},
+		Name:                 data.Options.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the latest executions come first, so the test cases end up created at their first execution
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubTestResult{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+		dal.Orderby("started_at DESC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	resultIdGen := didgen.NewDomainIdGenerator(&models.GithubTestResult{})
+	runIdGen := didgen.NewDomainIdGenerator(&models.GithubRun{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubTestResult{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			result := inputRow.(*models.GithubTestResult)
+			testCase, execution := api.ConvertTestCaseResult(
+				&api.TestReportSource{
+					QaProjectId:    repoId,
+					CicdPipelineId: runIdGen.Generate(data.Options.ConnectionId, result.RepoId, result.RunId),
+					CommitSha:      result.HeadSha,
+				},
+				resultIdGen.Generate(data.Options.ConnectionId, result.ArtifactId, result.Seq),
+				&api.TestCaseResult{
+					Suite:       result.Suite,
+					Name:        result.TestName,
+					Status:      result.Status,
+					DurationSec: result.DurationSec,
+					StartTime:   result.StartedAt,
+				},
+			)
+			if execution == nil {
+				return nil, nil
+			}
+			return []interface{}{testCase, execution}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func ConvertTestCoverages(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		return nil
+	}
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubTestCoverage{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	coverageIdGen := didgen.NewDomainIdGenerator(&models.GithubTestCoverage{})
+	runIdGen := didgen.NewDomainIdGenerator(&models.GithubRun{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubTestCoverage{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			coverage := inputRow.(*models.GithubTestCoverage)
+			var createTime time.Time
+			if coverage.GithubCreatedAt != nil {
+				createTime = *coverage.GithubCreatedAt
+			}
+			domainCoverage := api.ConvertTestCoverage(
+				&api.TestReportSource{
+					QaProjectId:    repoId,
+					CicdPipelineId: runIdGen.Generate(data.Options.ConnectionId, coverage.RepoId, coverage.RunId),
+					CommitSha:      coverage.HeadSha,
+				},
+				coverageIdGen.Generate(data.Options.ConnectionId, coverage.ArtifactId, coverage.Seq),
+				coverage.ReportFile,
+				&api.TestCoverage{
+					LineRate:        coverage.LineRate,
+					BranchRate:      coverage.BranchRate,
+					LinesCovered:    coverage.LinesCovered,
+					LinesValid:      coverage.LinesValid,
+					BranchesCovered: coverage.BranchesCovered,
+					BranchesValid:   coverage.BranchesValid,
+				},
+				createTime,
+			)
+			return []interface{}{domainCoverage}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
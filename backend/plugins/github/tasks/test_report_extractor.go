@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractTestReportsMeta)
+}
+
+var ExtractTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Test Reports",
+	EntryPoint:       ExtractTestReports,
+	EnabledByDefault: true,
+	Description:      "Extract raw test reports into tool layer tables _tool_github_test_results and _tool_github_test_coverages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DependencyTables: []string{RAW_TEST_REPORT_TABLE},
+	ProductTables:    []string{models.GithubTestResult{}.TableName(), models.GithubTestCoverage{}.TableName()},
+}
+
+func ExtractTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			artifact := &SimpleGithubArtifact{}
+			err := errors.Convert(json.Unmarshal(row.Input, artifact))
+			if err != nil {
+				return nil, err
+			}
+			var reports []*api.TestReport
+			err = errors.Convert(json.Unmarshal(row.Data, &reports))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0)
+			seq := 0
+			for reportSeq, report := range reports {
+				if report.Coverage != nil {
+					results = append(results, &models.GithubTestCoverage{
+						ConnectionId:    data.Options.ConnectionId,
+						ArtifactId:      artifact.ID,
+						Seq:             reportSeq,
+						RepoId:          data.Options.GithubId,
+						RunId:           artifact.RunId,
+						HeadSha:         artifact.HeadSha,
+						ReportFile:      report.File,
+						LineRate:        report.Coverage.LineRate,
+						BranchRate:      report.Coverage.BranchRate,
+						LinesCovered:    report.Coverage.LinesCovered,
+						LinesValid:      report.Coverage.LinesValid,
+						BranchesCovered: report.Coverage.BranchesCovered,
+						BranchesValid:   report.Coverage.BranchesValid,
+						GithubCreatedAt: artifact.GithubCreatedAt,
+					})
+				}
+				for _, testCase := range report.TestCases {
+					// reports without timestamps are dated by the upload of the artifact
+					startedAt := testCase.StartTime
+					if startedAt == nil {
+						startedAt = artifact.GithubCreatedAt
+					}
+					results = append(results, &models.GithubTestResult{
+						ConnectionId: data.Options.ConnectionId,
+						ArtifactId:   artifact.ID,
+						Seq:          seq,
+						RepoId:       data.Options.GithubId,
+						RunId:        artifact.RunId,
+						HeadSha:      artifact.HeadSha,
+						ReportFile:   report.File,
+						Format:       report.Format,
+						Suite:        testCase.Suite,
+						TestName:     testCase.FullName(),
+						Status:       testCase.Status,
+						DurationSec:  testCase.DurationSec,
+						StartedAt:    startedAt,
+						Message:      testCase.Message,
+					})
+					seq++
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -127,6 +128,23 @@ func GetPagingFromLinkHeader(link string) (PagingInfo, errors.Error) {
 	return result, nil
 }
 
+// GetNextCursorFromLinkHeader returns the `after` cursor of the next page for the APIs using cursor based
+// pagination, an empty string is returned if there is no next page
+func GetNextCursorFromLinkHeader(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 || !strings.Contains(segments[1], `rel="next"`) {
+			continue
+		}
+		nextUrl, err := url.Parse(strings.Trim(strings.TrimSpace(segments[0]), "<>"))
+		if err != nil {
+			return ""
+		}
+		return nextUrl.Query().Get("after")
+	}
+	return ""
+}
+
 func GetIssueIdByIssueUrl(s string) (int, errors.Error) {
 	regex := regexp.MustCompile(`.*/issues/(\d+)`)
 	groups := regex.FindStringSubmatch(s)
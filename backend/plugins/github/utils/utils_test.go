@@ -61,6 +61,15 @@ func TestParseLinkHeaderEmptyString(t *testing.T) {
 	assert.Equal(t, paginationInfo, pagingExpected)
 }
 
+func TestGetNextCursorFromLinkHeader(t *testing.T) {
+	link := `<https://api.github.com/repositories/1/dependabot/alerts?per_page=100&after=Y3Vyc29yOnYyOpK0>; rel="next",
+  <https://api.github.com/repositories/1/dependabot/alerts?per_page=100&before=Y3Vyc29yOnYyOpK1>; rel="prev"`
+	assert.Equal(t, GetNextCursorFromLinkHeader(link), "Y3Vyc29yOnYyOpK0")
+	link = `<https://api.github.com/repositories/1/dependabot/alerts?per_page=100&before=Y3Vyc29yOnYyOpK1>; rel="prev"`
+	assert.Equal(t, GetNextCursorFromLinkHeader(link), "")
+	assert.Equal(t, GetNextCursorFromLinkHeader(""), "")
+}
+
 // This test is incomplete.
 func TestGetRateLimitPerSecond(t *testing.T) {
 	date := "Mon, 20 Sep 2021 18:08:38 GMT"
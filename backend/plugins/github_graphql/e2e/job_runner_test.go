@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+	githubGraphQLTasks "github.com/apache/incubator-devlake/plugins/github_graphql/tasks"
+)
+
+func TestGithubGraphqlJobRunnerDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+		RegexEnricher: helper.NewRegexEnricher(),
+	}
+
+	// jobs extracted from graphql check runs, which don't expose the runner
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_jobs_without_runners.csv", &models.GithubJob{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_graphql_job_runners.csv", "_raw_github_graphql_job_runners")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubRunner{})
+	dataflowTester.Subtask(githubGraphQLTasks.ExtractJobRunnersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubRunner{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_runners.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubJob{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_github_jobs_with_runners.csv",
+		TargetFields: []string{
+			"connection_id",
+			"repo_id",
+			"id",
+			"name",
+			"runner_id",
+			"runner_name",
+			"runner_group_id",
+			"runner_group_name",
+			"_raw_data_table",
+			"_raw_data_id",
+		},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdRunner{})
+	dataflowTester.Subtask(tasks.ConvertRunnersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CicdRunner{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cicd_runners.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertJobsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CICDTask{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/cicd_tasks_with_runners.csv",
+		TargetFields: []string{"id", "name", "runner_id"},
+	})
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+	githubGraphQLTasks "github.com/apache/incubator-devlake/plugins/github_graphql/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithubGraphqlJobDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+
+	regexEnricher := helper.NewRegexEnricher()
+	assert.Nil(t, regexEnricher.TryAdd(devops.DEPLOYMENT, "deploy"))
+	assert.Nil(t, regexEnricher.TryAdd(devops.PRODUCTION, "prod"))
+	deploymentRules, err := helper.NewDeploymentRuleEngine([]*helper.DeploymentRule{
+		{
+			Name: "skip dry runs",
+			Conditions: []*helper.DeploymentRuleCondition{
+				{Field: helper.DEPLOYMENT_RULE_JOB_NAME, Operator: helper.DEPLOYMENT_RULE_MATCHES, Values: []string{"dry-run"}},
+			},
+		},
+		{
+			Name: "successful releases",
+			Conditions: []*helper.DeploymentRuleCondition{
+				{Field: helper.DEPLOYMENT_RULE_JOB_NAME, Operator: helper.DEPLOYMENT_RULE_EQUALS, Values: []string{"release"}},
+				{Field: helper.DEPLOYMENT_RULE_RESULT, Operator: helper.DEPLOYMENT_RULE_EQUALS, Values: []string{"success"}},
+			},
+			IsDeployment: true,
+			Environment:  devops.PRODUCTION,
+		},
+	})
+	assert.Nil(t, err)
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
+	}
+
+	// verify extraction
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_graphql_jobs.csv", "_raw_github_graphql_jobs")
+	dataflowTester.FlushTabler(&models.GithubJob{})
+	dataflowTester.Subtask(githubGraphQLTasks.ExtractJobsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubJob{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_github_jobs.csv",
+		TargetFields: []string{
+			"connection_id",
+			"repo_id",
+			"id",
+			"run_id",
+			"name",
+			"status",
+			"conclusion",
+			"type",
+			"environment",
+			"_raw_data_table",
+			"_raw_data_id",
+		},
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+	githubGraphQLTasks "github.com/apache/incubator-devlake/plugins/github_graphql/tasks"
+)
+
+func TestGithubProjectDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "facebook/OpenBIC",
+			GithubId:     335709078,
+			ScopeConfig: &models.GithubScopeConfig{
+				ProjectStatusMappings: map[string]string{
+					"Todo":        ticket.TODO,
+					"In Progress": ticket.IN_PROGRESS,
+					"Done":        ticket.DONE,
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_graphql_project_items.csv", "_raw_github_graphql_project_items")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubProject{})
+	dataflowTester.FlushTabler(&models.GithubProjectItem{})
+	dataflowTester.FlushTabler(&models.GithubProjectIteration{})
+	dataflowTester.FlushTabler(&models.GithubProjectItemFieldValue{})
+	dataflowTester.FlushTabler(&models.GithubIssueType{})
+	dataflowTester.Subtask(githubGraphQLTasks.ExtractProjectItemsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubProject{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_projects.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubProjectItem{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_project_items.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubProjectIteration{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_project_iterations.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubProjectItemFieldValue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_project_item_field_values.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubIssueType{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_issue_types.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify enrichment
+	dataflowTester.FlushTabler(&models.GithubIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_issues.csv", &models.GithubIssue{})
+	dataflowTester.Subtask(githubGraphQLTasks.EnrichIssuesWithProjectItemsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubIssue{},
+		"./snapshot_tables/_tool_github_issues.csv",
+		[]string{
+			"connection_id",
+			"github_id",
+			"number",
+			"state",
+			"type",
+			"std_type",
+			"issue_type",
+			"project_status",
+			"std_status",
+			"story_point",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIssuesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"issue_key",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"story_point",
+		},
+	)
+
+	// the triage project is shared with another repo of the Platform project
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	errors.Must(dataflowTester.Dal.CreateOrUpdate(&models.GithubProjectItem{
+		ConnectionId: 1,
+		ItemId:       "PVTI_5",
+		ProjectId:    "PVT_kwDOAbc2",
+		RepoId:       335709079,
+		IssueId:      201,
+	}))
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&ticket.Sprint{})
+	dataflowTester.FlushTabler(&ticket.BoardSprint{})
+	dataflowTester.Subtask(githubGraphQLTasks.ConvertProjectsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectMapping{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/project_mapping.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.Board{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/boards.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.Sprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardSprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// the shared project is converted only by the repo which extracted it most recently
+	errors.Must(dataflowTester.Dal.UpdateColumn(
+		&models.GithubProject{},
+		"_raw_data_params",
+		`{"ConnectionId":1,"Name":"facebook/OpenBIC-platform"}`,
+		dal.Where("project_id = ?", "PVT_kwDOAbc2"),
+	))
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(githubGraphQLTasks.ConvertProjectsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Board{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/boards_of_shared_projects.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.SprintIssue{})
+	dataflowTester.Subtask(githubGraphQLTasks.ConvertProjectItemsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprint_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
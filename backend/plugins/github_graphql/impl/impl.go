@@ -93,11 +93,21 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectPrsMeta,
 		tasks.ExtractPrsMeta,
 
+		// collect issue types and projects (v2), deps on issue
+		tasks.CollectProjectItemsMeta,
+		tasks.ExtractProjectItemsMeta,
+
 		// collect workflow run & job
 		githubTasks.CollectRunsMeta,
 		githubTasks.ExtractRunsMeta,
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
+		tasks.CollectJobRunnersMeta,
+		tasks.ExtractJobRunnersMeta,
+		githubTasks.CollectRunArtifactsMeta,
+		githubTasks.ExtractRunArtifactsMeta,
+		githubTasks.CollectTestReportsMeta,
+		githubTasks.ExtractTestReportsMeta,
 
 		// collect others
 		githubTasks.CollectApiCommentsMeta,
@@ -106,6 +116,10 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ExtractApiEventsMeta,
 		githubTasks.CollectApiPrReviewCommentsMeta,
 		githubTasks.ExtractApiPrReviewCommentsMeta,
+		githubTasks.CollectSecurityAlertsMeta,
+		githubTasks.ExtractSecurityAlertsMeta,
+		githubTasks.CollectCodeOwnersMeta,
+		githubTasks.ExtractCodeOwnersMeta,
 
 		// collect account, deps on all before
 		tasks.CollectAccountMeta,
@@ -114,8 +128,12 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		// convert to domain layer
 		githubTasks.ConvertRunsMeta,
 		githubTasks.ConvertJobsMeta,
+		githubTasks.ConvertRunnersMeta,
+		githubTasks.ConvertTestResultsMeta,
+		githubTasks.ConvertTestCoveragesMeta,
 		githubTasks.EnrichPullRequestIssuesMeta,
 		githubTasks.ConvertRepoMeta,
+		tasks.EnrichIssuesWithProjectItemsMeta,
 		githubTasks.ConvertIssuesMeta,
 		githubTasks.ConvertCommitsMeta,
 		githubTasks.ConvertIssueLabelsMeta,
@@ -128,7 +146,11 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ConvertIssueCommentsMeta,
 		githubTasks.ConvertPullRequestCommentsMeta,
 		githubTasks.ConvertReviewsMeta,
+		githubTasks.ConvertCodeOwnersMeta,
 		githubTasks.ConvertMilestonesMeta,
+		githubTasks.ConvertSecurityAlertsMeta,
+		tasks.ConvertProjectsMeta,
+		tasks.ConvertProjectItemsMeta,
 		githubTasks.ConvertAccountsMeta,
 
 		// deployment
@@ -256,12 +278,20 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 	if err = regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, op.ScopeConfig.EnvNamePattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
 	}
+	if err = regexEnricher.TryAdd(helper.TEST_REPORT_PATTERN, op.ScopeConfig.TestReportPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `testReportPattern`")
+	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.ScopeConfig.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 
 	taskData := &githubTasks.GithubTaskData{
-		Options:       &op,
-		ApiClient:     apiClient,
-		GraphqlClient: graphqlClient,
-		RegexEnricher: regexEnricher,
+		Options:         &op,
+		ApiClient:       apiClient,
+		GraphqlClient:   graphqlClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	return taskData, nil
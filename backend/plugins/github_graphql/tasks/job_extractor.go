@@ -76,9 +76,12 @@ func ExtractJobs(taskCtx plugin.SubTaskContext) errors.Error {
 				CompletedAt:  checkRun.CompletedAt,
 				Name:         checkRun.Name,
 				Steps:        paramsBytes,
-				Type:         data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, checkRun.Name),
-				Environment:  data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, checkRun.Name),
 			}
+			githubJob.Type, githubJob.Environment = data.DeploymentRules.Classify(
+				githubTasks.NewJobDeploymentCandidate(githubJob),
+				data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, checkRun.Name),
+				data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, checkRun.Name),
+			)
 			results = append(results, githubJob)
 
 			return results, nil
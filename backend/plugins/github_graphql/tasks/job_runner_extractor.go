@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+var ExtractJobRunnersMeta = plugin.SubTaskMeta{
+	Name:             "Extract Job Runners",
+	EntryPoint:       ExtractJobRunners,
+	EnabledByDefault: true,
+	Description:      "Extract raw job data into tool layer table github_runners and the runner fields of github_jobs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	DependencyTables: []string{RAW_GRAPHQL_JOB_RUNNERS_TABLE, models.GithubJob{}.TableName()},
+	ProductTables:    []string{models.GithubRunner{}.TableName(), models.GithubJob{}.TableName()},
+}
+
+var _ plugin.SubTaskEntryPoint = ExtractJobRunners
+
+// ExtractJobRunners must run after ExtractJobs, the jobs extracted from graphql are updated in place
+// so they keep the raw data origin of the graphql check runs.
+func ExtractJobRunners(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_GRAPHQL_JOB_RUNNERS_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			githubJob := &models.GithubJob{}
+			err := errors.Convert(json.Unmarshal(row.Data, githubJob))
+			if err != nil {
+				return nil, err
+			}
+			if githubJob.RunnerName == "" {
+				return nil, nil
+			}
+			err = db.UpdateColumns(
+				&models.GithubJob{},
+				[]dal.DalSet{
+					{ColumnName: "runner_id", Value: githubJob.RunnerID},
+					{ColumnName: "runner_name", Value: githubJob.RunnerName},
+					{ColumnName: "runner_group_id", Value: githubJob.RunnerGroupID},
+					{ColumnName: "runner_group_name", Value: githubJob.RunnerGroupName},
+					{ColumnName: "labels", Value: githubJob.Labels},
+				},
+				dal.Where("connection_id = ? and id = ?", data.Options.ConnectionId, githubJob.ID),
+			)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{githubTasks.ExtractRunner(data.Options.ConnectionId, githubJob)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
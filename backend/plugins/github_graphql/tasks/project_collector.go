@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_PROJECT_ITEMS_TABLE = "github_graphql_project_items"
+
+type GraphqlQueryProjectItemWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		IssueList struct {
+			TotalCount graphql.Int
+			Issues     []GraphqlQueryIssueProjectItems `graphql:"nodes"`
+			PageInfo   *helper.GraphqlQueryPageInfo
+		} `graphql:"issues(first: $pageSize, after: $skipCursor, orderBy: {field: CREATED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// GraphqlQueryIssueProjectItems holds the issue type and the Projects (v2) items of an issue
+type GraphqlQueryIssueProjectItems struct {
+	DatabaseId int
+	Number     int
+	IssueType  *struct {
+		Name string
+	}
+	ProjectItems struct {
+		Nodes []GraphqlQueryProjectItem
+	} `graphql:"projectItems(first: 10, includeArchived: true)"`
+}
+
+type GraphqlQueryProjectItem struct {
+	Id         string
+	IsArchived bool
+	Project    struct {
+		Id        string
+		Number    int
+		Title     string
+		Url       string
+		Closed    bool
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	FieldValues struct {
+		Nodes []GraphqlQueryProjectFieldValue
+	} `graphql:"fieldValues(first: 20)"`
+}
+
+type GraphqlQueryProjectField struct {
+	Common struct {
+		Name string
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// GraphqlQueryProjectFieldValue is one of the ProjectV2ItemFieldValue types distinguished by Typename
+type GraphqlQueryProjectFieldValue struct {
+	Typename     string `graphql:"__typename"`
+	SingleSelect struct {
+		Name  string
+		Field GraphqlQueryProjectField
+	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Iteration struct {
+		IterationId string
+		Title       string
+		StartDate   string
+		Duration    int
+		Field       GraphqlQueryProjectField
+	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+	Number struct {
+		Number *float64
+		Field  GraphqlQueryProjectField
+	} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+	Text struct {
+		Text  string
+		Field GraphqlQueryProjectField
+	} `graphql:"... on ProjectV2ItemFieldTextValue"`
+	Date struct {
+		Date  string
+		Field GraphqlQueryProjectField
+	} `graphql:"... on ProjectV2ItemFieldDateValue"`
+}
+
+var CollectProjectItemsMeta = plugin.SubTaskMeta{
+	Name:             "Collect Project Items",
+	EntryPoint:       CollectProjectItems,
+	EnabledByDefault: true,
+	Description:      "Collect issue types and Projects (v2) items of issues from GithubGraphql api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+var _ plugin.SubTaskEntryPoint = CollectProjectItems
+
+// CollectProjectItems collects the issues which belong to any project or have an issue type. Errors of the query are
+// ignored since the token might not have the read:project scope or the server might not support issue types.
+func CollectProjectItems(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+	collector, err := helper.NewGraphqlCollector(helper.GraphqlCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: githubTasks.GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_PROJECT_ITEMS_TABLE,
+		},
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      50,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			query := &GraphqlQueryProjectItemWrapper{}
+			if reqData == nil {
+				return query, map[string]interface{}{}, nil
+			}
+			ownerName := strings.Split(data.Options.Name, "/")
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(ownerName[0]),
+				"name":       graphql.String(ownerName[1]),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryProjectItemWrapper)
+			if query.Repository.IssueList.PageInfo == nil {
+				// the query failed as a whole
+				return &helper.GraphqlQueryPageInfo{}, nil
+			}
+			return query.Repository.IssueList.PageInfo, nil
+		},
+		ResponseParser: func(queryWrapper any) (messages []json.RawMessage, err errors.Error) {
+			query := queryWrapper.(*GraphqlQueryProjectItemWrapper)
+			for _, issue := range query.Repository.IssueList.Issues {
+				if issue.IssueType == nil && len(issue.ProjectItems.Nodes) == 0 {
+					continue
+				}
+				messages = append(messages, errors.Must1(json.Marshal(issue)))
+			}
+			return
+		},
+		IgnoreQueryErrors: true,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
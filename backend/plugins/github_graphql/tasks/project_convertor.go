@@ -0,0 +1,282 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"golang.org/x/exp/slices"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertProjects
+
+var ConvertProjectsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Projects",
+	EntryPoint:       ConvertProjects,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_projects and github_project_iterations into domain layer table boards, sprints and board_sprints, and map the boards to the projects of the repos",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.GithubProject{}.TableName(),
+		models.GithubProjectIteration{}.TableName(),
+		RAW_PROJECT_ITEMS_TABLE},
+	ProductTables: []string{
+		ticket.Board{}.TableName(),
+		ticket.Sprint{}.TableName(),
+		ticket.BoardSprint{}.TableName(),
+		crossdomain.ProjectMapping{}.TableName()},
+}
+
+var _ plugin.SubTaskEntryPoint = ConvertProjectItems
+
+var ConvertProjectItemsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Project Items",
+	EntryPoint:       ConvertProjectItems,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_project_items into domain layer table board_issues and sprint_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.GithubProjectItem{}.TableName(),
+		RAW_PROJECT_ITEMS_TABLE},
+	ProductTables: []string{
+		ticket.BoardIssue{}.TableName(),
+		ticket.SprintIssue{}.TableName()},
+}
+
+// ConvertProjects converts the projects containing issues of the repo to boards, and iterations to sprints of them.
+// A project shared by repos of the organization is converted only once by the repo which extracted it most recently,
+// that is, the one sharing the raw data params with it, and the boards are mapped to the projects of all these repos.
+func ConvertProjects(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+	connectionId := data.Options.ConnectionId
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: connectionId,
+			Name:         data.Options.Name,
+		},
+		Table: RAW_PROJECT_ITEMS_TABLE,
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	projectsClause := dal.Where(`connection_id = ? AND _raw_data_params = ? AND project_id IN (
+		SELECT project_id FROM _tool_github_project_items WHERE repo_id = ? AND connection_id = ?
+	)`, connectionId, rawDataSubTask.GetParams(), data.Options.GithubId, connectionId)
+
+	var iterations []models.GithubProjectIteration
+	err = db.All(
+		&iterations,
+		dal.Where(`connection_id = ? AND project_id IN (
+			SELECT project_id FROM _tool_github_projects WHERE connection_id = ? AND _raw_data_params = ?
+		) AND project_id IN (
+			SELECT project_id FROM _tool_github_project_items WHERE repo_id = ? AND connection_id = ?
+		)`, connectionId, connectionId, rawDataSubTask.GetParams(), data.Options.GithubId, connectionId),
+		dal.Orderby("project_id, start_date"),
+	)
+	if err != nil {
+		return err
+	}
+	iterationMap := make(map[string][]models.GithubProjectIteration)
+	for _, iteration := range iterations {
+		iterationMap[iteration.ProjectId] = append(iterationMap[iteration.ProjectId], iteration)
+	}
+	projectNames, err := loadProjectNamesOfProjects(db, connectionId, projectsClause)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(dal.From(&models.GithubProject{}), projectsClause)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardIdGen := didgen.NewDomainIdGenerator(&models.GithubProject{})
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.GithubProjectIteration{})
+	now := time.Now()
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GithubProject{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			project := inputRow.(*models.GithubProject)
+			boardId := boardIdGen.Generate(connectionId, project.ProjectId)
+			board := &ticket.Board{
+				DomainEntity: domainlayer.DomainEntity{Id: boardId},
+				Name:         project.Title,
+				Url:          project.Url,
+				CreatedDate:  &project.GithubCreatedAt,
+				Type:         "kanban",
+			}
+			results := []interface{}{board}
+			for _, projectName := range projectNames[project.ProjectId] {
+				results = append(results, &crossdomain.ProjectMapping{
+					ProjectName: projectName,
+					Table:       board.TableName(),
+					RowId:       boardId,
+				})
+			}
+			for _, iteration := range iterationMap[project.ProjectId] {
+				board.Type = "scrum"
+				sprint := &ticket.Sprint{
+					DomainEntity:    domainlayer.DomainEntity{Id: sprintIdGen.Generate(connectionId, iteration.ProjectId, iteration.IterationId)},
+					Name:            iteration.Title,
+					StartedDate:     iteration.StartDate,
+					OriginalBoardID: boardId,
+				}
+				if iteration.StartDate != nil {
+					endedDate := iteration.StartDate.AddDate(0, 0, iteration.Duration)
+					sprint.EndedDate = &endedDate
+					switch {
+					case endedDate.Before(now):
+						sprint.Status = "CLOSED"
+						sprint.CompletedDate = &endedDate
+					case iteration.StartDate.After(now):
+						sprint.Status = "FUTURE"
+					default:
+						sprint.Status = "ACTIVE"
+					}
+				}
+				results = append(results, sprint, &ticket.BoardSprint{
+					BoardId:  boardId,
+					SprintId: sprint.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// loadProjectNamesOfProjects returns the names of the DevLake projects containing any repo with items in the
+// GitHub projects matching the clause, keyed by the id of the GitHub project
+func loadProjectNamesOfProjects(db dal.Dal, connectionId uint64, projectsClause dal.Clause) (map[string][]string, errors.Error) {
+	var projectIds []string
+	err := db.Pluck("project_id", &projectIds, dal.From(&models.GithubProject{}), projectsClause)
+	if err != nil || len(projectIds) == 0 {
+		return nil, err
+	}
+	var projectRepos []struct {
+		ProjectId string
+		RepoId    int
+	}
+	err = db.All(
+		&projectRepos,
+		dal.Select("DISTINCT project_id, repo_id"),
+		dal.From(&models.GithubProjectItem{}),
+		dal.Where("connection_id = ? AND project_id IN ?", connectionId, projectIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	repoIds := make([]string, 0, len(projectRepos))
+	for _, projectRepo := range projectRepos {
+		repoIds = append(repoIds, repoIdGen.Generate(connectionId, projectRepo.RepoId))
+	}
+	var mappings []crossdomain.ProjectMapping
+	err = db.All(
+		&mappings,
+		dal.From("project_mapping pm"),
+		dal.Where("pm.table = ? AND pm.row_id IN ?", "repos", repoIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	repoProjectNames := make(map[string][]string)
+	for _, mapping := range mappings {
+		repoProjectNames[mapping.RowId] = append(repoProjectNames[mapping.RowId], mapping.ProjectName)
+	}
+	projectNames := make(map[string][]string)
+	for _, projectRepo := range projectRepos {
+		for _, projectName := range repoProjectNames[repoIdGen.Generate(connectionId, projectRepo.RepoId)] {
+			if !slices.Contains(projectNames[projectRepo.ProjectId], projectName) {
+				projectNames[projectRepo.ProjectId] = append(projectNames[projectRepo.ProjectId], projectName)
+			}
+		}
+	}
+	return projectNames, nil
+}
+
+// ConvertProjectItems links the issues of the repo to the boards of projects and the sprints of iterations
+func ConvertProjectItems(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+	connectionId := data.Options.ConnectionId
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubProjectItem{}),
+		dal.Where("repo_id = ? AND connection_id = ? AND is_archived = ?", data.Options.GithubId, connectionId, false),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardIdGen := didgen.NewDomainIdGenerator(&models.GithubProject{})
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.GithubProjectIteration{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GithubIssue{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: githubTasks.GithubApiParams{
+				ConnectionId: connectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_PROJECT_ITEMS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubProjectItem{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			item := inputRow.(*models.GithubProjectItem)
+			issueId := issueIdGen.Generate(connectionId, item.IssueId)
+			results := []interface{}{&ticket.BoardIssue{
+				BoardId: boardIdGen.Generate(connectionId, item.ProjectId),
+				IssueId: issueId,
+			}}
+			if item.IterationId != "" {
+				results = append(results, &ticket.SprintIssue{
+					SprintId: sprintIdGen.Generate(connectionId, item.ProjectId, item.IterationId),
+					IssueId:  issueId,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+var _ plugin.SubTaskEntryPoint = EnrichIssuesWithProjectItems
+
+var EnrichIssuesWithProjectItemsMeta = plugin.SubTaskMeta{
+	Name:             "Enrich Issues With Project Items",
+	EntryPoint:       EnrichIssuesWithProjectItems,
+	EnabledByDefault: true,
+	Description:      "Set issue type, status and story point of github_issues by issue types and Projects (v2) items",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// EnrichIssuesWithProjectItems fills the IssueType, ProjectStatus, StdStatus and StoryPoint of issues. Issues might
+// belong to multiple projects, the first item which is not archived would be used.
+func EnrichIssuesWithProjectItems(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+	config := data.Options.ScopeConfig
+	issueRegexes, err := githubTasks.NewIssueRegexes(config)
+	if err != nil {
+		return err
+	}
+	var statusMappings map[string]string
+	if config != nil {
+		statusMappings = config.ProjectStatusMappings
+	}
+
+	var issueTypes []models.GithubIssueType
+	err = db.All(&issueTypes, dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	issueTypeMap := make(map[int]string, len(issueTypes))
+	for _, issueType := range issueTypes {
+		issueTypeMap[issueType.IssueId] = issueType.Name
+	}
+	var items []models.GithubProjectItem
+	err = db.All(
+		&items,
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+		dal.Orderby("is_archived, project_id, item_id"),
+	)
+	if err != nil {
+		return err
+	}
+	itemMap := make(map[int]*models.GithubProjectItem, len(items))
+	for i := range items {
+		if _, ok := itemMap[items[i].IssueId]; !ok {
+			itemMap[items[i].IssueId] = &items[i]
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubIssue{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	enricher, err := api.NewDataEnricher(api.DataEnricherArgs[models.GithubIssue]{
+		Ctx:   taskCtx,
+		Name:  "github_project_items",
+		Input: cursor,
+		Enrich: func(issue *models.GithubIssue) ([]interface{}, errors.Error) {
+			issue.IssueType = issueTypeMap[issue.GithubId]
+			if issue.IssueType != "" && issue.StdType == "" {
+				issue.StdType = getStdTypeFromIssueType(issueRegexes, issue.IssueType)
+			}
+			issue.ProjectStatus = ""
+			issue.StdStatus = ""
+			issue.StoryPoint = nil
+			if item, ok := itemMap[issue.GithubId]; ok {
+				issue.ProjectStatus = item.Status
+				issue.StdStatus = statusMappings[item.Status]
+				issue.StoryPoint = item.Estimate
+			}
+			return []interface{}{issue}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return enricher.Execute()
+}
+
+// getStdTypeFromIssueType matches the issue type by the regexes of scope config first, the default issue types of
+// GitHub would be used otherwise
+func getStdTypeFromIssueType(issueRegexes *githubTasks.IssueRegexes, issueType string) string {
+	switch {
+	case issueRegexes.TypeRequirementRegex != nil && issueRegexes.TypeRequirementRegex.MatchString(issueType):
+		return ticket.REQUIREMENT
+	case issueRegexes.TypeBugRegex != nil && issueRegexes.TypeBugRegex.MatchString(issueType):
+		return ticket.BUG
+	case issueRegexes.TypeIncidentRegex != nil && issueRegexes.TypeIncidentRegex.MatchString(issueType):
+		return ticket.INCIDENT
+	}
+	switch strings.ToLower(issueType) {
+	case "bug":
+		return ticket.BUG
+	case "feature":
+		return ticket.REQUIREMENT
+	case "task":
+		return ticket.TASK
+	}
+	return ""
+}
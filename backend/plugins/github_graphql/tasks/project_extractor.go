@@ -0,0 +1,181 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractProjectItems
+
+var ExtractProjectItemsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Project Items",
+	EntryPoint:       ExtractProjectItems,
+	EnabledByDefault: true,
+	Description:      "Extract raw project items data into tool layer table github_projects, github_project_items and github_issue_types",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+const (
+	DEFAULT_PROJECT_STATUS_FIELD    = "Status"
+	DEFAULT_PROJECT_ITERATION_FIELD = "Iteration"
+	DEFAULT_PROJECT_ESTIMATE_FIELD  = "Estimate"
+)
+
+// projectFieldNames returns the names of fields used as status, iteration and estimate
+func projectFieldNames(config *models.GithubScopeConfig) (status, iteration, estimate string) {
+	status, iteration, estimate = DEFAULT_PROJECT_STATUS_FIELD, DEFAULT_PROJECT_ITERATION_FIELD, DEFAULT_PROJECT_ESTIMATE_FIELD
+	if config == nil {
+		return
+	}
+	if config.ProjectStatusField != "" {
+		status = config.ProjectStatusField
+	}
+	if config.ProjectIterationField != "" {
+		iteration = config.ProjectIterationField
+	}
+	if config.ProjectEstimateField != "" {
+		estimate = config.ProjectEstimateField
+	}
+	return
+}
+
+func ExtractProjectItems(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+	statusField, iterationField, estimateField := projectFieldNames(data.Options.ScopeConfig)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: githubTasks.GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_PROJECT_ITEMS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			issue := &GraphqlQueryIssueProjectItems{}
+			err := errors.Convert(json.Unmarshal(row.Data, issue))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, 1+len(issue.ProjectItems.Nodes)*3)
+			if issue.IssueType != nil {
+				results = append(results, &models.GithubIssueType{
+					ConnectionId: data.Options.ConnectionId,
+					IssueId:      issue.DatabaseId,
+					RepoId:       data.Options.GithubId,
+					Name:         issue.IssueType.Name,
+				})
+			}
+			for _, item := range issue.ProjectItems.Nodes {
+				results = append(results, &models.GithubProject{
+					ConnectionId:    data.Options.ConnectionId,
+					ProjectId:       item.Project.Id,
+					Number:          item.Project.Number,
+					Title:           item.Project.Title,
+					Url:             item.Project.Url,
+					Closed:          item.Project.Closed,
+					GithubCreatedAt: item.Project.CreatedAt,
+					GithubUpdatedAt: item.Project.UpdatedAt,
+				})
+				githubItem := &models.GithubProjectItem{
+					ConnectionId: data.Options.ConnectionId,
+					ItemId:       item.Id,
+					ProjectId:    item.Project.Id,
+					RepoId:       data.Options.GithubId,
+					IssueId:      issue.DatabaseId,
+					IsArchived:   item.IsArchived,
+				}
+				for _, fieldValue := range item.FieldValues.Nodes {
+					name, fieldType, value := projectFieldValue(&fieldValue)
+					if name == "" {
+						continue
+					}
+					results = append(results, &models.GithubProjectItemFieldValue{
+						ConnectionId: data.Options.ConnectionId,
+						ItemId:       item.Id,
+						FieldName:    name,
+						FieldType:    fieldType,
+						Value:        value,
+					})
+					switch name {
+					case statusField:
+						githubItem.Status = value
+					case estimateField:
+						if estimate, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+							githubItem.Estimate = &estimate
+						}
+					case iterationField:
+						if fieldType != "ITERATION" {
+							continue
+						}
+						iteration := &models.GithubProjectIteration{
+							ConnectionId: data.Options.ConnectionId,
+							ProjectId:    item.Project.Id,
+							IterationId:  fieldValue.Iteration.IterationId,
+							FieldName:    name,
+							Title:        fieldValue.Iteration.Title,
+							Duration:     fieldValue.Iteration.Duration,
+						}
+						if startDate, err := time.Parse("2006-01-02", fieldValue.Iteration.StartDate); err == nil {
+							iteration.StartDate = &startDate
+						}
+						githubItem.IterationId = iteration.IterationId
+						results = append(results, iteration)
+					}
+				}
+				results = append(results, githubItem)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// projectFieldValue returns the name and type of the field and the value as string
+func projectFieldValue(v *GraphqlQueryProjectFieldValue) (name string, fieldType string, value string) {
+	switch v.Typename {
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return v.SingleSelect.Field.Common.Name, "SINGLE_SELECT", v.SingleSelect.Name
+	case "ProjectV2ItemFieldIterationValue":
+		return v.Iteration.Field.Common.Name, "ITERATION", v.Iteration.Title
+	case "ProjectV2ItemFieldNumberValue":
+		if v.Number.Number != nil {
+			value = strconv.FormatFloat(*v.Number.Number, 'f', -1, 64)
+		}
+		return v.Number.Field.Common.Name, "NUMBER", value
+	case "ProjectV2ItemFieldTextValue":
+		return v.Text.Field.Common.Name, "TEXT", v.Text.Text
+	case "ProjectV2ItemFieldDateValue":
+		return v.Date.Field.Common.Name, "DATE", v.Date.Date
+	}
+	return "", "", ""
+}
@@ -18,6 +18,9 @@ limitations under the License.
 package tasks
 
 import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/github/models"
@@ -36,3 +39,13 @@ func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*hel
 	}
 	return RawDataSubTaskArgs, data
 }
+
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return helper.ErrIgnoreAndContinue
+	}
+	return nil
+}
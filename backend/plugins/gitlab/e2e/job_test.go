@@ -48,6 +48,7 @@ func TestGitlabJobDataFlow(t *testing.T) {
 
 	// verify extraction
 	dataflowTester.FlushTabler(&models.GitlabJob{})
+	dataflowTester.FlushTabler(&models.GitlabRunner{})
 	dataflowTester.Subtask(tasks.ExtractApiJobsMeta, taskData)
 	dataflowTester.VerifyTable(
 		models.GitlabJob{},
@@ -68,8 +69,13 @@ func TestGitlabJobDataFlow(t *testing.T) {
 			"gitlab_created_at",
 			"started_at",
 			"finished_at",
+			"runner_id",
 		),
 	)
+	dataflowTester.VerifyTableWithOptions(&models.GitlabRunner{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitlab_runners.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 
 	// verifi when production regex is omitted
 	dataflowTester.FlushTabler(&devops.CICDTask{})
@@ -87,4 +93,12 @@ func TestGitlabJobDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/cicd_tasks.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	// verify runner conversion
+	dataflowTester.FlushTabler(&devops.CicdRunner{})
+	dataflowTester.Subtask(tasks.ConvertRunnersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CicdRunner{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cicd_runners.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
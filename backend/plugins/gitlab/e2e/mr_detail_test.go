@@ -61,6 +61,7 @@ func TestGitlabMrDetailDataFlow(t *testing.T) {
 
 	// verify conversion
 	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.FlushTabler(&code.PullRequestLabel{})
 	dataflowTester.Subtask(tasks.ConvertApiMergeRequestsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&code.PullRequest{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/pull_requests.csv",
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabTestReportDataFlow(t *testing.T) {
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+	regexEnricher := api.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(api.TEST_REPORT_PATTERN, "unit-test")
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    44,
+			FullName:     "nddtf/gitlab-example-cli",
+			ScopeConfig: &models.GitlabScopeConfig{
+				TestReportPattern: "unit-test",
+			},
+		},
+		RegexEnricher: regexEnricher,
+	}
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_pipeline_test_reports.csv", "_raw_gitlab_api_pipeline_test_reports")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabTestResult{})
+	dataflowTester.FlushTabler(&models.GitlabTestCoverage{})
+	dataflowTester.Subtask(tasks.ExtractTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GitlabTestResult{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitlab_test_results.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GitlabTestCoverage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitlab_test_coverages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&qa.QaProject{})
+	dataflowTester.FlushTabler(&qa.QaTestCase{})
+	dataflowTester.FlushTabler(&qa.QaTestCaseExecution{})
+	dataflowTester.FlushTabler(&qa.QaTestCoverage{})
+	dataflowTester.Subtask(tasks.ConvertTestResultsMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertTestCoveragesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&qa.QaProject{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_projects.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_test_cases.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCaseExecution{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_test_case_executions.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCoverage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_test_coverages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
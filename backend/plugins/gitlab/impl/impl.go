@@ -19,6 +19,8 @@ package impl
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/subtaskmeta/sorter"
 
@@ -44,6 +46,7 @@ var _ interface {
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
 	plugin.CloseablePluginTask
+	helper.DeploymentCandidateSource
 } = (*Gitlab)(nil)
 
 type Gitlab struct{}
@@ -98,11 +101,14 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabProject{},
 		&models.GitlabProjectCommit{},
 		&models.GitlabReviewer{},
+		&models.GitlabRunner{},
 		&models.GitlabAssignee{},
 		&models.GitlabTag{},
 		&models.GitlabIssueAssignee{},
 		&models.GitlabScopeConfig{},
 		&models.GitlabDeployment{},
+		&models.GitlabTestResult{},
+		&models.GitlabTestCoverage{},
 	}
 }
 
@@ -207,11 +213,19 @@ func (p Gitlab) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err := regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, op.ScopeConfig.EnvNamePattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
 	}
+	if err := regexEnricher.TryAdd(helper.TEST_REPORT_PATTERN, op.ScopeConfig.TestReportPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `testReportPattern`")
+	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.ScopeConfig.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 
 	taskData := tasks.GitlabTaskData{
-		Options:       op,
-		ApiClient:     apiClient,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	return &taskData, nil
@@ -286,3 +300,11 @@ func (p Gitlab) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+func (p Gitlab) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	projectId, err := strconv.Atoi(scopeId)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid gitlab project id")
+	}
+	return tasks.DeploymentCandidates(db, connectionId, projectId, since)
+}
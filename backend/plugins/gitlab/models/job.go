@@ -37,6 +37,7 @@ type GitlabJob struct {
 	Duration       float64 `gorm:"type:float8"`
 	WebUrl         string  `gorm:"type:varchar(255)"`
 	QueuedDuration float64
+	RunnerId       int
 
 	GitlabCreatedAt *time.Time
 	StartedAt       *time.Time
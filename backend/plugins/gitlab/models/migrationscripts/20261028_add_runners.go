@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type gitlabRunner20261028 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	GitlabId     int    `gorm:"primaryKey"`
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string `gorm:"type:varchar(255)"`
+	RunnerType   string `gorm:"type:varchar(100)"`
+	IsShared     bool
+	Tags         string `gorm:"type:varchar(500)"`
+	Os           string `gorm:"type:varchar(100)"`
+	IsSelfHosted bool
+
+	archived.NoPKModel
+}
+
+func (gitlabRunner20261028) TableName() string {
+	return "_tool_gitlab_runners"
+}
+
+type gitlabJob20261028 struct {
+	RunnerId int
+}
+
+func (gitlabJob20261028) TableName() string {
+	return "_tool_gitlab_jobs"
+}
+
+type addRunners20261028 struct{}
+
+func (*addRunners20261028) Up(baseRes context.BasicRes) errors.Error {
+	db := baseRes.GetDal()
+	if err := db.AutoMigrate(&gitlabRunner20261028{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&gitlabJob20261028{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (*addRunners20261028) Down(baseRes context.BasicRes) errors.Error {
+	db := baseRes.GetDal()
+	if err := db.DropColumns("_tool_gitlab_jobs", "runner_id"); err != nil {
+		return err
+	}
+	return db.DropTables(&gitlabRunner20261028{})
+}
+
+func (*addRunners20261028) Version() uint64 {
+	return 20261028000001
+}
+
+func (*addRunners20261028) Name() string {
+	return "add table _tool_gitlab_runners and runner_id to _tool_gitlab_jobs"
+}
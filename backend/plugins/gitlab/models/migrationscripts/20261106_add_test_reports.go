@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addTestReports)(nil)
+
+type scopeConfig20261106 struct {
+	TestReportPattern string `gorm:"type:varchar(255)"`
+}
+
+func (scopeConfig20261106) TableName() string {
+	return "_tool_gitlab_scope_configs"
+}
+
+type gitlabTestResult20261106 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	JobId        int    `gorm:"primaryKey;autoIncrement:false"`
+	Seq          int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    int    `gorm:"index"`
+	PipelineId   int    `gorm:"index"`
+	Sha          string `gorm:"type:varchar(255)"`
+	ReportFile   string `gorm:"type:varchar(255)"`
+	Format       string `gorm:"type:varchar(20)"`
+	Suite        string `gorm:"type:varchar(255)"`
+	TestName     string `gorm:"type:text"`
+	Status       string `gorm:"type:varchar(20)"`
+	DurationSec  float64
+	StartedAt    *time.Time
+	Message      string `gorm:"type:text"`
+
+	archived.NoPKModel
+}
+
+func (gitlabTestResult20261106) TableName() string {
+	return "_tool_gitlab_test_results"
+}
+
+type gitlabTestCoverage20261106 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	JobId           int    `gorm:"primaryKey;autoIncrement:false"`
+	Seq             int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId       int    `gorm:"index"`
+	PipelineId      int    `gorm:"index"`
+	Sha             string `gorm:"type:varchar(255)"`
+	ReportFile      string `gorm:"type:varchar(255)"`
+	LineRate        float64
+	BranchRate      float64
+	LinesCovered    int
+	LinesValid      int
+	BranchesCovered int
+	BranchesValid   int
+	FinishedAt      *time.Time
+
+	archived.NoPKModel
+}
+
+func (gitlabTestCoverage20261106) TableName() string {
+	return "_tool_gitlab_test_coverages"
+}
+
+type addTestReports struct{}
+
+func (*addTestReports) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&scopeConfig20261106{},
+		&gitlabTestResult20261106{},
+		&gitlabTestCoverage20261106{},
+	)
+}
+
+func (*addTestReports) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_gitlab_scope_configs", "test_report_pattern"); err != nil {
+		return err
+	}
+	return db.DropTables(&gitlabTestResult20261106{}, &gitlabTestCoverage20261106{})
+}
+
+func (*addTestReports) Version() uint64 {
+	return 20261106000001
+}
+
+func (*addTestReports) Name() string {
+	return "add test_report_pattern to _tool_gitlab_scope_configs and the tables of test reports"
+}
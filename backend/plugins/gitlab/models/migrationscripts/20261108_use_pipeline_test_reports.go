@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*usePipelineTestReports)(nil)
+
+type gitlabTestResult20261108 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PipelineId   int    `gorm:"primaryKey;autoIncrement:false"`
+	Seq          int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    int    `gorm:"index"`
+	Sha          string `gorm:"type:varchar(255)"`
+	Suite        string `gorm:"type:varchar(255)"`
+	TestName     string `gorm:"type:text"`
+	Status       string `gorm:"type:varchar(20)"`
+	DurationSec  float64
+	StartedAt    *time.Time
+	Message      string `gorm:"type:text"`
+
+	archived.NoPKModel
+}
+
+func (gitlabTestResult20261108) TableName() string {
+	return "_tool_gitlab_test_results"
+}
+
+type gitlabTestCoverage20261108 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PipelineId   int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    int    `gorm:"index"`
+	Sha          string `gorm:"type:varchar(255)"`
+	LineRate     float64
+	FinishedAt   *time.Time
+
+	archived.NoPKModel
+}
+
+func (gitlabTestCoverage20261108) TableName() string {
+	return "_tool_gitlab_test_coverages"
+}
+
+// usePipelineTestReports keys the test results and coverages by pipeline, as they are collected from the test
+// reports of the pipelines instead of the artifacts of the jobs, the tables are recreated since the collection starts over
+type usePipelineTestReports struct{}
+
+func (*usePipelineTestReports) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropTables(&gitlabTestResult20261106{}, &gitlabTestCoverage20261106{}, "_raw_gitlab_api_test_reports"); err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &gitlabTestResult20261108{}, &gitlabTestCoverage20261108{})
+}
+
+func (*usePipelineTestReports) Down(basicRes context.BasicRes) errors.Error {
+	if err := basicRes.GetDal().DropTables(&gitlabTestResult20261108{}, &gitlabTestCoverage20261108{}); err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &gitlabTestResult20261106{}, &gitlabTestCoverage20261106{})
+}
+
+func (*usePipelineTestReports) Version() uint64 {
+	return 20261108000001
+}
+
+func (*usePipelineTestReports) Name() string {
+	return "key _tool_gitlab_test_results and _tool_gitlab_test_coverages by pipeline"
+}
@@ -52,5 +52,11 @@ func All() []plugin.MigrationScript {
 		new(addGitlabAssigneeAndReviewerPrimaryKey),
 		new(changeIssueComponentType),
 		new(addIsChildToPipelines240906),
+		new(addRunners20261028),
+		new(addDeploymentRules),
+		new(addTestReports),
+		new(usePipelineTestReports),
+		new(addIncidentRules),
+		new(addTagToPipelines),
 	}
 }
@@ -34,6 +34,7 @@ type GitlabPipeline struct {
 	ProjectId      int    `gorm:"index"`
 	Status         string `gorm:"type:varchar(100)"`
 	Ref            string `gorm:"type:varchar(255)"`
+	Tag            bool
 	Sha            string `gorm:"type:varchar(255)"`
 	WebUrl         string `gorm:"type:varchar(255)"`
 	Duration       int
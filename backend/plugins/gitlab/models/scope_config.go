@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"gorm.io/datatypes"
 )
 
@@ -37,6 +38,19 @@ type GitlabScopeConfig struct {
 	ProductionPattern    string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	EnvNamePattern       string            `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
 	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+
+	// DeploymentRules classify the pipelines and tasks ahead of deploymentPattern and productionPattern
+	DeploymentRules []*api.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
+	// TestReportPattern matches the names of the jobs whose artifacts carry JUnit XML, TRX or Cobertura reports
+	TestReportPattern string `mapstructure:"testReportPattern,omitempty" json:"testReportPattern" gorm:"type:varchar(255)"`
+	// IncidentRules normalizes severity, affected service and customer impact of the issues matching issueTypeIncident
+	IncidentRules *api.IncidentNormalizationRules `mapstructure:"incidentRules,omitempty" json:"incidentRules" gorm:"type:json;serializer:json"`
+}
+
+var _ api.IncidentRulesScopeConfig = (*GitlabScopeConfig)(nil)
+
+func (t GitlabScopeConfig) GetIncidentRules() *api.IncidentNormalizationRules {
+	return t.IncidentRules
 }
 
 func (t GitlabScopeConfig) TableName() string {
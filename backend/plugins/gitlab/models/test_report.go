@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GitlabTestResult is a test case result of the test report of a pipeline, Seq numbers the results across the test
+// suites of the pipeline, which are named after its jobs
+type GitlabTestResult struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PipelineId   int    `gorm:"primaryKey;autoIncrement:false"`
+	Seq          int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    int    `gorm:"index"`
+	Sha          string `gorm:"type:varchar(255)"`
+	Suite        string `gorm:"type:varchar(255)"`
+	TestName     string `gorm:"type:text"`
+	Status       string `gorm:"type:varchar(20)"`
+	DurationSec  float64
+	StartedAt    *time.Time
+	Message      string `gorm:"type:text"`
+
+	common.NoPKModel
+}
+
+func (GitlabTestResult) TableName() string {
+	return "_tool_gitlab_test_results"
+}
+
+// GitlabTestCoverage is the line coverage gitlab parses from the job logs of a pipeline with a test report
+type GitlabTestCoverage struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PipelineId   int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    int    `gorm:"index"`
+	Sha          string `gorm:"type:varchar(255)"`
+	LineRate     float64
+	FinishedAt   *time.Time
+
+	common.NoPKModel
+}
+
+func (GitlabTestCoverage) TableName() string {
+	return "_tool_gitlab_test_coverages"
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+// newPipelineDeploymentCandidate describes a pipeline to the deployment rules
+func newPipelineDeploymentCandidate(pipeline *models.GitlabPipeline) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate(pipeline.Ref, pipeline.Tag, pipeline.Status)
+	candidate.Trigger = pipeline.Source
+	return candidate
+}
+
+// newJobDeploymentCandidate describes a job to the deployment rules
+func newJobDeploymentCandidate(job *models.GitlabJob) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate(job.Ref, job.Tag, job.Status)
+	candidate.JobName = job.Name
+	return candidate
+}
+
+// DeploymentCandidates describes the pipelines and the jobs of the project created since the time to the deployment
+// rules by the ids of the cicd_pipelines and the cicd_tasks they are converted to
+func DeploymentCandidates(db dal.Dal, connectionId uint64, projectId int, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	candidates := api.NewDeploymentCandidates()
+	var pipelines []*models.GitlabPipeline
+	err := db.All(&pipelines, dal.Where("connection_id = ? AND project_id = ? AND gitlab_created_at >= ?", connectionId, projectId, since))
+	if err != nil {
+		return nil, err
+	}
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	for _, pipeline := range pipelines {
+		candidates.Pipelines[pipelineIdGen.Generate(connectionId, pipeline.GitlabId)] = newPipelineDeploymentCandidate(pipeline)
+	}
+	var jobs []*models.GitlabJob
+	err = db.All(&jobs, dal.Where("connection_id = ? AND project_id = ? AND gitlab_created_at >= ?", connectionId, projectId, since))
+	if err != nil {
+		return nil, err
+	}
+	jobIdGen := didgen.NewDomainIdGenerator(&models.GitlabJob{})
+	for _, job := range jobs {
+		candidates.Tasks[jobIdGen.Generate(connectionId, job.GitlabId)] = newJobDeploymentCandidate(job)
+	}
+	return candidates, nil
+}
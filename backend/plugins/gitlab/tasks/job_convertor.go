@@ -52,6 +52,7 @@ func ConvertJobs(subtaskCtx plugin.SubTaskContext) (err errors.Error) {
 	jobIdGen := didgen.NewDomainIdGenerator(&models.GitlabJob{})
 	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
 	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	runnerIdGen := didgen.NewDomainIdGenerator(&models.GitlabRunner{})
 	converter, err := api.NewStatefulDataConverter(&api.StatefulDataConverterArgs[models.GitlabJob]{
 		SubtaskCommonArgs: subtaskCommonArgs,
 		Input: func(stateManager *api.SubtaskStateManager) (dal.Rows, errors.Error) {
@@ -98,8 +99,14 @@ func ConvertJobs(subtaskCtx plugin.SubTaskContext) (err errors.Error) {
 				},
 				CicdScopeId: projectIdGen.Generate(data.Options.ConnectionId, gitlabJob.ProjectId),
 			}
-			domainJob.Type = regexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, gitlabJob.Name)
-			domainJob.Environment = regexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, gitlabJob.Name)
+			if gitlabJob.RunnerId != 0 {
+				domainJob.RunnerId = runnerIdGen.Generate(data.Options.ConnectionId, gitlabJob.RunnerId)
+			}
+			domainJob.Type, domainJob.Environment = data.DeploymentRules.Classify(
+				newJobDeploymentCandidate(gitlabJob),
+				regexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, gitlabJob.Name),
+				regexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, gitlabJob.Name),
+			)
 
 			return []interface{}{
 				domainJob,
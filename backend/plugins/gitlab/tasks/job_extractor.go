@@ -18,8 +18,11 @@ limitations under the License.
 package tasks
 
 import (
+	"strings"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/gitlab/models"
@@ -42,6 +45,14 @@ type ApiJob struct {
 	Pipeline     struct {
 		Id int
 	}
+	Runner *struct {
+		Id          int
+		Name        string
+		Description string
+		RunnerType  string `json:"runner_type"`
+		IsShared    bool   `json:"is_shared"`
+	}
+	TagList        []string            `json:"tag_list"`
 	QueuedDuration float64             `json:"queued_duration"`
 	CreatedAt      *common.Iso8601Time `json:"created_at"`
 	StartedAt      *common.Iso8601Time `json:"started_at"`
@@ -73,6 +84,9 @@ func ExtractApiJobs(subtaskCtx plugin.SubTaskContext) errors.Error {
 			gitlabPipeline.ConnectionId = data.Options.ConnectionId
 			results := make([]interface{}, 0, 1)
 			results = append(results, gitlabPipeline)
+			if gitlabApiJob.Runner != nil {
+				results = append(results, extractRunner(data.Options.ConnectionId, gitlabApiJob))
+			}
 
 			return results, nil
 		},
@@ -86,6 +100,10 @@ func ExtractApiJobs(subtaskCtx plugin.SubTaskContext) errors.Error {
 }
 
 func convertJob(job *ApiJob, projectId int) (*models.GitlabJob, errors.Error) {
+	runnerId := 0
+	if job.Runner != nil {
+		runnerId = job.Runner.Id
+	}
 	return &models.GitlabJob{
 		GitlabId:       job.Id,
 		ProjectId:      projectId,
@@ -99,9 +117,27 @@ func convertJob(job *ApiJob, projectId int) (*models.GitlabJob, errors.Error) {
 		WebUrl:         job.WebUrl,
 		PipelineId:     job.Pipeline.Id,
 		QueuedDuration: job.QueuedDuration,
+		RunnerId:       runnerId,
 
 		GitlabCreatedAt: common.Iso8601TimeToTime(job.CreatedAt),
 		StartedAt:       common.Iso8601TimeToTime(job.StartedAt),
 		FinishedAt:      common.Iso8601TimeToTime(job.FinishedAt),
 	}, nil
 }
+
+// extractRunner records the runner of a job, the tags of a job are the ones its runner had to match.
+// Shared runners of gitlab.com describe themselves with their manager host, all the others are self-hosted.
+func extractRunner(connectionId uint64, job *ApiJob) *models.GitlabRunner {
+	isSaas := job.Runner.IsShared && strings.Contains(job.Runner.Description, "gitlab.com")
+	return &models.GitlabRunner{
+		ConnectionId: connectionId,
+		GitlabId:     job.Runner.Id,
+		Name:         job.Runner.Name,
+		Description:  job.Runner.Description,
+		RunnerType:   job.Runner.RunnerType,
+		IsShared:     job.Runner.IsShared,
+		Tags:         strings.Join(job.TagList, ","),
+		Os:           devops.GetRunnerOs(append([]string{job.Runner.Description}, job.TagList...)...),
+		IsSelfHosted: !isSaas,
+	}
+}
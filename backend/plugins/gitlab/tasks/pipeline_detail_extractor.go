@@ -49,6 +49,7 @@ func ExtractApiPipelineDetails(subtaskCtx plugin.SubTaskContext) errors.Error {
 				GitlabId:        gitlabApiPipeline.Id,
 				ProjectId:       data.Options.ProjectId,
 				Ref:             gitlabApiPipeline.Ref,
+				Tag:             gitlabApiPipeline.Tag,
 				Sha:             gitlabApiPipeline.Sha,
 				WebUrl:          gitlabApiPipeline.WebUrl,
 				Status:          gitlabApiPipeline.Status,
@@ -59,10 +60,13 @@ func ExtractApiPipelineDetails(subtaskCtx plugin.SubTaskContext) errors.Error {
 				Duration:        gitlabApiPipeline.Duration,
 				QueuedDuration:  gitlabApiPipeline.QueuedDuration,
 				ConnectionId:    data.Options.ConnectionId,
-				Type:            data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, gitlabApiPipeline.Ref),
-				Environment:     data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, gitlabApiPipeline.Ref),
 				Source:          gitlabApiPipeline.Source,
 			}
+			gitlabPipeline.Type, gitlabPipeline.Environment = data.DeploymentRules.Classify(
+				newPipelineDeploymentCandidate(gitlabPipeline),
+				data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, gitlabApiPipeline.Ref),
+				data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, gitlabApiPipeline.Ref),
+			)
 
 			return []interface{}{gitlabPipeline}, nil
 		},
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertRunnersMeta)
+}
+
+var ConvertRunnersMeta = plugin.SubTaskMeta{
+	Name:             "Convert Runners",
+	EntryPoint:       ConvertRunners,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_runners into domain layer table cicd_runners",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertJobMeta},
+}
+
+func ConvertRunners(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_JOB_TABLE)
+	db := subtaskCtx.GetDal()
+
+	runnerIdGen := didgen.NewDomainIdGenerator(&models.GitlabRunner{})
+	converter, err := api.NewStatefulDataConverter(&api.StatefulDataConverterArgs[models.GitlabRunner]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Input: func(stateManager *api.SubtaskStateManager) (dal.Rows, errors.Error) {
+			// runners are shared by projects, only the ones which ran jobs of the project are converted
+			clauses := []dal.Clause{
+				dal.From(&models.GitlabRunner{}),
+				dal.Where(`connection_id = ? and gitlab_id in (
+					select runner_id from _tool_gitlab_jobs where connection_id = ? and project_id = ?
+				)`, data.Options.ConnectionId, data.Options.ConnectionId, data.Options.ProjectId),
+			}
+			if stateManager.IsIncremental() {
+				since := stateManager.GetSince()
+				if since != nil {
+					clauses = append(clauses, dal.Where("updated_at >= ? ", since))
+				}
+			}
+			return db.Cursor(clauses...)
+		},
+		Convert: func(runner *models.GitlabRunner) ([]interface{}, errors.Error) {
+			pool := runner.Tags
+			if pool == "" {
+				pool = runner.RunnerType
+			}
+			return []interface{}{
+				&devops.CicdRunner{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: runnerIdGen.Generate(runner.ConnectionId, runner.GitlabId),
+					},
+					Name:         runner.Description,
+					Pool:         pool,
+					Labels:       runner.Tags,
+					Os:           runner.Os,
+					IsSelfHosted: runner.IsSelfHosted,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -32,10 +32,11 @@ type GitlabOptions struct {
 }
 
 type GitlabTaskData struct {
-	Options       *GitlabOptions
-	ApiClient     *helper.ApiAsyncClient
-	ProjectCommit *models.GitlabProjectCommit
-	RegexEnricher *helper.RegexEnricher
+	Options         *GitlabOptions
+	ApiClient       *helper.ApiAsyncClient
+	ProjectCommit   *models.GitlabProjectCommit
+	RegexEnricher   *helper.RegexEnricher
+	DeploymentRules *helper.DeploymentRuleEngine
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*GitlabOptions, errors.Error) {
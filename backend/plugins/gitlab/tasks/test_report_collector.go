@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectTestReportsMeta)
+}
+
+const RAW_TEST_REPORT_TABLE = "gitlab_api_pipeline_test_reports"
+
+var CollectTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "Collect Test Reports",
+	EntryPoint:       CollectTestReports,
+	EnabledByDefault: true,
+	Description:      "Collect the test reports of the pipelines running jobs matching testReportPattern, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiPipelineDetailsMeta, &ExtractApiJobsMeta},
+}
+
+// SimpleGitlabTestReportPipeline is the input of a test report request, the commit, the coverage and the dates of the
+// pipeline are kept for the extractor
+type SimpleGitlabTestReportPipeline struct {
+	GitlabId        int
+	Sha             string
+	Coverage        string
+	GitlabCreatedAt *time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+}
+
+type simpleGitlabTestReportJob struct {
+	SimpleGitlabTestReportPipeline
+	JobName string
+}
+
+func CollectTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_REPORT_TABLE)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		logger.Info("testReportPattern is not set, skip collecting test reports")
+		return nil
+	}
+
+	apiCollector, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	// the test reports of finished pipelines don't change, only the ones of the pipelines created since the last
+	// collection are requested
+	clauses := []dal.Clause{
+		dal.Select("p.gitlab_id, p.sha, p.coverage, p.gitlab_created_at, p.started_at, p.finished_at, j.name AS job_name"),
+		dal.From("_tool_gitlab_pipelines p"),
+		dal.Join("JOIN _tool_gitlab_jobs j ON j.connection_id = p.connection_id AND j.pipeline_id = p.gitlab_id"),
+		dal.Where("p.project_id = ? AND p.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+		dal.Orderby("p.gitlab_id"),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("p.gitlab_created_at > ?", apiCollector.GetSince()))
+	}
+	var jobs []simpleGitlabTestReportJob
+	err = db.All(&jobs, clauses...)
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	pushed := make(map[int]bool)
+	for i := range jobs {
+		if pushed[jobs[i].GitlabId] || data.RegexEnricher.ReturnNameIfMatched(api.TEST_REPORT_PATTERN, jobs[i].JobName) == "" {
+			continue
+		}
+		pushed[jobs[i].GitlabId] = true
+		iterator.Push(&jobs[i].SimpleGitlabTestReportPipeline)
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/pipelines/{{ .Input.GitlabId }}/test_report",
+		// the test report of a pipeline is kept as one record, so the results could be numbered across its suites
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			body, err := api.ReadTestReportResponse(res)
+			if err != nil {
+				return nil, err
+			}
+			if body == nil {
+				logger.Warn(nil, "skip the oversized test report of %s", res.Request.URL.Path)
+				return nil, nil
+			}
+			report := &GitlabApiTestReport{}
+			err = errors.Convert(json.Unmarshal(body, report))
+			if err != nil {
+				return nil, err
+			}
+			if report.TotalCount == 0 {
+				return nil, nil
+			}
+			return []json.RawMessage{body}, nil
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
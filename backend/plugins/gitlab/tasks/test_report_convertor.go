@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertTestResultsMeta)
+	RegisterSubtaskMeta(&ConvertTestCoveragesMeta)
+}
+
+var ConvertTestResultsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Test Results",
+	EntryPoint:       ConvertTestResults,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_gitlab_test_results into domain layer tables qa_projects, qa_test_cases and qa_test_case_executions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertJobMeta, &ExtractTestReportsMeta},
+}
+
+var ConvertTestCoveragesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Test Coverages",
+	EntryPoint:       ConvertTestCoverages,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_gitlab_test_coverages into domain layer table qa_test_coverages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertTestResultsMeta},
+}
+
+// ConvertTestResults converts the test results of the project, the gitlab project is the qa project of its test
+// cases so the executions are scoped to the devlake projects it belongs to
+func ConvertTestResults(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_REPORT_TABLE)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		return nil
+	}
+	projectId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	err := db.CreateOrUpdate(&qa.QaProject{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{Id: projectId},
+		Name:                 data.Options.FullName,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the latest executions come first, so the test cases end up created at their first execution
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabTestResult{}),
+		dal.Where("project_id = ? AND connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+		dal.Orderby("started_at DESC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	resultIdGen := didgen.NewDomainIdGenerator(&models.GitlabTestResult{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GitlabTestResult{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			result := inputRow.(*models.GitlabTestResult)
+			testCase, execution := api.ConvertTestCaseResult(
+				&api.TestReportSource{
+					QaProjectId:    projectId,
+					CicdPipelineId: pipelineIdGen.Generate(data.Options.ConnectionId, result.PipelineId),
+					CommitSha:      result.Sha,
+				},
+				resultIdGen.Generate(data.Options.ConnectionId, result.PipelineId, result.Seq),
+				&api.TestCaseResult{
+					Suite:       result.Suite,
+					Name:        result.TestName,
+					Status:      result.Status,
+					DurationSec: result.DurationSec,
+					StartTime:   result.StartedAt,
+				},
+			)
+			if execution == nil {
+				return nil, nil
+			}
+			return []interface{}{testCase, execution}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func ConvertTestCoverages(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_REPORT_TABLE)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		return nil
+	}
+	projectId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabTestCoverage{}),
+		dal.Where("project_id = ? AND connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	coverageIdGen := didgen.NewDomainIdGenerator(&models.GitlabTestCoverage{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GitlabTestCoverage{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			coverage := inputRow.(*models.GitlabTestCoverage)
+			var createTime time.Time
+			if coverage.FinishedAt != nil {
+				createTime = *coverage.FinishedAt
+			}
+			domainCoverage := api.ConvertTestCoverage(
+				&api.TestReportSource{
+					QaProjectId:    projectId,
+					CicdPipelineId: pipelineIdGen.Generate(data.Options.ConnectionId, coverage.PipelineId),
+					CommitSha:      coverage.Sha,
+				},
+				coverageIdGen.Generate(data.Options.ConnectionId, coverage.PipelineId),
+				"",
+				&api.TestCoverage{LineRate: coverage.LineRate},
+				createTime,
+			)
+			return []interface{}{domainCoverage}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractTestReportsMeta)
+}
+
+var ExtractTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Test Reports",
+	EntryPoint:       ExtractTestReports,
+	EnabledByDefault: true,
+	Description:      "Extract raw test reports into tool layer tables _tool_gitlab_test_results and _tool_gitlab_test_coverages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE_QUALITY},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectTestReportsMeta},
+}
+
+// GitlabApiTestReport is the test report of a pipeline, each job uploading JUnit reports adds a suite named after it
+type GitlabApiTestReport struct {
+	TotalCount int `json:"total_count"`
+	TestSuites []struct {
+		Name      string `json:"name"`
+		TestCases []struct {
+			Status        string  `json:"status"`
+			Name          string  `json:"name"`
+			Classname     string  `json:"classname"`
+			ExecutionTime float64 `json:"execution_time"`
+			SystemOutput  string  `json:"system_output"`
+			StackTrace    string  `json:"stack_trace"`
+		} `json:"test_cases"`
+	} `json:"test_suites"`
+}
+
+func ExtractTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_REPORT_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			pipeline := &SimpleGitlabTestReportPipeline{}
+			err := errors.Convert(json.Unmarshal(row.Input, pipeline))
+			if err != nil {
+				return nil, err
+			}
+			report := &GitlabApiTestReport{}
+			err = errors.Convert(json.Unmarshal(row.Data, report))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0)
+			if coverage, parseErr := strconv.ParseFloat(pipeline.Coverage, 64); parseErr == nil {
+				results = append(results, &models.GitlabTestCoverage{
+					ConnectionId: data.Options.ConnectionId,
+					PipelineId:   pipeline.GitlabId,
+					ProjectId:    data.Options.ProjectId,
+					Sha:          pipeline.Sha,
+					LineRate:     coverage / 100,
+					FinishedAt:   pipeline.FinishedAt,
+				})
+			}
+			// the test report carries no timestamps, the results are dated by the start of the pipeline
+			startedAt := pipeline.StartedAt
+			if startedAt == nil {
+				startedAt = pipeline.GitlabCreatedAt
+			}
+			seq := 0
+			for _, suite := range report.TestSuites {
+				// only the suites of the jobs matching testReportPattern are taken
+				if data.RegexEnricher.ReturnNameIfMatched(api.TEST_REPORT_PATTERN, suite.Name) == "" {
+					continue
+				}
+				for _, testCase := range suite.TestCases {
+					result := &api.TestCaseResult{ClassName: testCase.Classname, Name: testCase.Name}
+					message := testCase.StackTrace
+					if message == "" {
+						message = testCase.SystemOutput
+					}
+					results = append(results, &models.GitlabTestResult{
+						ConnectionId: data.Options.ConnectionId,
+						PipelineId:   pipeline.GitlabId,
+						Seq:          seq,
+						ProjectId:    data.Options.ProjectId,
+						Sha:          pipeline.Sha,
+						Suite:        suite.Name,
+						TestName:     result.FullName(),
+						Status:       gitlabTestCaseStatus(testCase.Status),
+						DurationSec:  testCase.ExecutionTime,
+						StartedAt:    startedAt,
+						Message:      message,
+					})
+					seq++
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// gitlabTestCaseStatus maps the statuses of the test cases in gitlab test reports, errors count as failures
+func gitlabTestCaseStatus(status string) string {
+	switch status {
+	case "success":
+		return api.TEST_RESULT_SUCCESS
+	case "failed", "error":
+		return api.TEST_RESULT_FAILED
+	default:
+		return api.TEST_RESULT_SKIPPED
+	}
+}
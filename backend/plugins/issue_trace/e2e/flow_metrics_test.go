@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/impl"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/models"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/tasks"
+)
+
+func TestCalculateFlowMetrics(t *testing.T) {
+	var plugin impl.IssueTrace
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "issue_trace", plugin)
+	dataflowTester.ImportCsvIntoTabler("./flow_metrics/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./flow_metrics/issue_status_history.csv", &models.IssueStatusHistory{})
+
+	taskData := &tasks.TaskData{
+		Options: tasks.Options{
+			Plugin:   "jira",
+			ScopeIds: []string{"jira:JiraBoard:2:9"},
+			FlowSettings: map[string]*tasks.FlowSettings{
+				"jira:JiraBoard:2:9": {
+					StatusMappings:  map[string]string{"Ready for Review": ticket.IN_PROGRESS},
+					WaitingStatuses: []string{"Ready for Review"},
+				},
+			},
+		},
+		ScopeIds: []string{"jira:JiraBoard:2:9"},
+	}
+	dataflowTester.FlushTabler(&ticket.BoardFlowStatus{})
+	dataflowTester.FlushTabler(&ticket.BoardFlowMetric{})
+	dataflowTester.Subtask(tasks.CalculateFlowMetricsMeta, taskData)
+
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardFlowStatus{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./flow_metrics/board_flow_statuses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardFlowMetric{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./flow_metrics/board_flow_metrics.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
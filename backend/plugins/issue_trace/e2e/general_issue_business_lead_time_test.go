@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/impl"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/tasks"
+)
+
+func TestCalculateIssueBusinessLeadTime(t *testing.T) {
+	var plugin impl.IssueTrace
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "issue_trace", plugin)
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
+	// no calendar, the default Monday to Friday 09:00-17:00 UTC applies
+	dataflowTester.FlushTabler(&coreModels.ProjectCalendar{})
+	dataflowTester.FlushTabler(&coreModels.ProjectHoliday{})
+
+	dataflowTester.FlushTabler(&crossdomain.ProjectIssueMetric{})
+
+	taskData := &tasks.TaskData{
+		Options:     TaskData.Options,
+		ScopeIds:    TaskData.ScopeIds,
+		ProjectName: "project1",
+	}
+	dataflowTester.Subtask(tasks.CalculateIssueBusinessLeadTimeMeta, taskData)
+
+	dataflowTester.VerifyTableWithOptions(crossdomain.ProjectIssueMetric{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/project_issue_metrics.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -20,6 +20,7 @@ package e2e
 import (
 	"testing"
 
+	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/issue_trace/impl"
@@ -34,6 +35,8 @@ func TestConvertIssueStatusHistory(t *testing.T) {
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/issue_changelogs.csv", &ticket.IssueChangelogs{})
 
+	dataflowTester.FlushTabler(&coreModels.ProjectCalendar{})
+	dataflowTester.FlushTabler(&coreModels.ProjectHoliday{})
 	dataflowTester.FlushTabler(models.IssueStatusHistory{})
 
 	dataflowTester.Subtask(tasks.ConvertIssueStatusHistoryMeta, TaskData)
@@ -90,6 +90,10 @@ func (p IssueTrace) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertIssueStatusHistoryMeta,
 		// issue_assignee_history
 		tasks.ConvertIssueAssigneeHistoryMeta,
+		// project_issue_metrics
+		tasks.CalculateIssueBusinessLeadTimeMeta,
+		// board_flow_statuses and board_flow_metrics
+		tasks.CalculateFlowMetricsMeta,
 	}
 }
 
@@ -135,9 +139,7 @@ func (p IssueTrace) RootPkgPath() string {
 }
 
 func (p IssueTrace) MigrationScripts() []plugin.MigrationScript {
-	return []plugin.MigrationScript{
-		&migrationscripts.NewIssueTable{},
-	}
+	return migrationscripts.All()
 }
 
 func (p IssueTrace) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
@@ -165,12 +167,15 @@ func (p IssueTrace) MakeMetricPluginPipelinePlanV200(projectName string, options
 			{
 				Plugin: "issue_trace",
 				Options: map[string]interface{}{
-					"projectName": projectName,
-					"scopeIds":    op.ScopeIds,
+					"projectName":  projectName,
+					"scopeIds":     op.ScopeIds,
+					"flowSettings": op.FlowSettings,
 				},
 				Subtasks: []string{
 					"ConvertIssueStatusHistory",
 					"ConvertIssueAssigneeHistory",
+					"CalculateIssueBusinessLeadTime",
+					"CalculateFlowMetrics",
 				},
 			},
 		},
@@ -36,6 +36,8 @@ type IssueStatusHistory struct {
 	IsCurrentStatus   bool       `gorm:"type:boolean"`
 	IsFirstStatus     bool       `gorm:"type:boolean"`
 	StatusTimeMinutes int32      `gorm:"type:integer"`
+	// StatusBusinessTimeMinutes only counts the working hours of the project calendar
+	StatusBusinessTimeMinutes int32 `gorm:"type:integer"`
 }
 
 func (IssueStatusHistory) TableName() string {
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type issueStatusHistory20261029 struct {
+	StatusBusinessTimeMinutes int32 `gorm:"type:integer"`
+}
+
+func (issueStatusHistory20261029) TableName() string {
+	return "issue_status_history"
+}
+
+type addStatusBusinessTime struct{}
+
+func (*addStatusBusinessTime) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&issueStatusHistory20261029{})
+}
+
+func (*addStatusBusinessTime) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns("issue_status_history", "status_business_time_minutes")
+}
+
+func (*addStatusBusinessTime) Version() uint64 {
+	return 20261029000001
+}
+
+func (*addStatusBusinessTime) Name() string {
+	return "add status_business_time_minutes to issue_status_history"
+}
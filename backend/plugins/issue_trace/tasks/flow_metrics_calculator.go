@@ -0,0 +1,303 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/utils"
+)
+
+// the cycle time percentiles and flow efficiency of a day cover the issues done within this window
+const flowMetricWindowDays = 30
+
+var CalculateFlowMetricsMeta = plugin.SubTaskMeta{
+	Name:             "CalculateFlowMetrics",
+	EntryPoint:       CalculateFlowMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate the daily cumulative flow, WIP, throughput, cycle time, flow efficiency and work item age of boards from issue status history",
+}
+
+type flowStatusHistory struct {
+	BoardId         string
+	IssueId         string
+	Status          string
+	OriginalStatus  string
+	StartDate       time.Time
+	EndDate         *time.Time
+	IsCurrentStatus bool
+}
+
+type flowCompletion struct {
+	day          int
+	cycleMinutes *int64
+	active       time.Duration
+	waiting      time.Duration
+}
+
+type boardFlow struct {
+	settings *FlowSettings
+	waiting  map[string]bool
+	issues   map[string][]*flowStatusHistory
+	firstDay time.Time
+	asOf     time.Time
+}
+
+// CalculateFlowMetrics replays the status history of the issues of every board day by day (UTC).
+// The standard status of the history, or the StatusMappings of the board, tells whether an issue
+// is TODO, IN_PROGRESS or DONE, and the metrics are computed as of the latest end date of the history.
+func CalculateFlowMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	options := taskCtx.GetData().(*TaskData)
+	db := taskCtx.GetDal()
+
+	err := db.Delete(&ticket.BoardFlowStatus{}, dal.Where("board_id in ?", options.ScopeIds))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous board_flow_statuses")
+	}
+	err = db.Delete(&ticket.BoardFlowMetric{}, dal.Where("board_id in ?", options.ScopeIds))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous board_flow_metrics")
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("board_issues.board_id, h.issue_id, h.status, h.original_status, h.start_date, h.end_date, h.is_current_status"),
+		dal.From("issue_status_history h"),
+		dal.Join("INNER JOIN board_issues ON board_issues.issue_id = h.issue_id"),
+		dal.Where("board_issues.board_id in ?", options.ScopeIds),
+		dal.Orderby("board_issues.board_id, h.issue_id, h.start_date"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boards := make(map[string]*boardFlow)
+	for cursor.Next() {
+		row := &flowStatusHistory{}
+		err = db.Fetch(cursor, row)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching issue_status_history")
+		}
+		board, ok := boards[row.BoardId]
+		if !ok {
+			board = newBoardFlow(options.Options.FlowSettings[row.BoardId])
+			board.firstDay = truncateDay(row.StartDate)
+			boards[row.BoardId] = board
+		}
+		board.issues[row.IssueId] = append(board.issues[row.IssueId], row)
+		if day := truncateDay(row.StartDate); day.Before(board.firstDay) {
+			board.firstDay = day
+		}
+		if row.EndDate != nil && row.EndDate.After(board.asOf) {
+			board.asOf = *row.EndDate
+		}
+		if row.StartDate.After(board.asOf) {
+			board.asOf = row.StartDate
+		}
+	}
+
+	statusBatch, err := helper.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.BoardFlowStatus{}), utils.BATCH_SIZE)
+	if err != nil {
+		return err
+	}
+	metricBatch, err := helper.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.BoardFlowMetric{}), utils.BATCH_SIZE)
+	if err != nil {
+		return err
+	}
+	for boardId, board := range boards {
+		if ctxErr := utils.CheckCancel(taskCtx); ctxErr != nil {
+			return ctxErr
+		}
+		statuses, metrics := board.calculate(boardId)
+		for _, status := range statuses {
+			err = statusBatch.Add(status)
+			if err != nil {
+				return err
+			}
+		}
+		for _, metric := range metrics {
+			err = metricBatch.Add(metric)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	logger.Info("calculated flow metrics of %d boards", len(boards))
+	err = statusBatch.Close()
+	if err != nil {
+		return err
+	}
+	return metricBatch.Close()
+}
+
+func newBoardFlow(settings *FlowSettings) *boardFlow {
+	if settings == nil {
+		settings = &FlowSettings{}
+	}
+	waiting := make(map[string]bool, len(settings.WaitingStatuses))
+	for _, status := range settings.WaitingStatuses {
+		waiting[status] = true
+	}
+	return &boardFlow{settings: settings, waiting: waiting, issues: make(map[string][]*flowStatusHistory)}
+}
+
+func (b *boardFlow) category(row *flowStatusHistory) string {
+	if status, ok := b.settings.StatusMappings[row.OriginalStatus]; ok {
+		return status
+	}
+	return row.Status
+}
+
+// snapshot is the end of the day, or asOf for the last day
+func (b *boardFlow) snapshot(day int) time.Time {
+	t := b.firstDay.AddDate(0, 0, day+1)
+	if t.After(b.asOf) {
+		return b.asOf
+	}
+	return t
+}
+
+func (b *boardFlow) dayOf(t time.Time) int {
+	return int(truncateDay(t).Sub(b.firstDay) / (24 * time.Hour))
+}
+
+func (b *boardFlow) calculate(boardId string) ([]*ticket.BoardFlowStatus, []*ticket.BoardFlowMetric) {
+	days := b.dayOf(b.asOf) + 1
+	statusCounts := make([]map[string]int, days)
+	categories := make(map[string]string)
+	wipAges := make([][]int64, days)
+	throughput := make([]int, days)
+	var completions []*flowCompletion
+
+	for _, rows := range b.issues {
+		var cycleStart *time.Time
+		var active, waiting time.Duration
+		for i, row := range rows {
+			category := b.category(row)
+			categories[row.OriginalStatus] = category
+			end := b.asOf
+			if !row.IsCurrentStatus && row.EndDate != nil && row.EndDate.Before(end) {
+				end = *row.EndDate
+			}
+			if category == ticket.IN_PROGRESS && cycleStart == nil {
+				cycleStart = &row.StartDate
+			}
+			if category == ticket.DONE && i > 0 && b.category(rows[i-1]) != ticket.DONE {
+				throughput[b.dayOf(row.StartDate)]++
+				completion := &flowCompletion{day: b.dayOf(row.StartDate), active: active, waiting: waiting}
+				if cycleStart != nil {
+					minutes := int64(row.StartDate.Sub(*cycleStart) / time.Minute)
+					completion.cycleMinutes = &minutes
+				}
+				completions = append(completions, completion)
+				cycleStart, active, waiting = nil, 0, 0
+			}
+			if cycleStart != nil && category != ticket.DONE && end.After(row.StartDate) {
+				if category == ticket.IN_PROGRESS && !b.waiting[row.OriginalStatus] {
+					active += end.Sub(row.StartDate)
+				} else {
+					waiting += end.Sub(row.StartDate)
+				}
+			}
+			// count the issue on the days whose snapshot falls within the status, the current status includes asOf
+			for day := b.dayOf(row.StartDate); day < days; day++ {
+				t := b.snapshot(day)
+				if t.Before(row.StartDate) {
+					continue
+				}
+				if !row.IsCurrentStatus && !t.Before(end) {
+					break
+				}
+				if statusCounts[day] == nil {
+					statusCounts[day] = make(map[string]int)
+				}
+				statusCounts[day][row.OriginalStatus]++
+				if category == ticket.IN_PROGRESS {
+					wipAges[day] = append(wipAges[day], int64(t.Sub(*cycleStart)/time.Minute))
+				}
+			}
+		}
+	}
+
+	var statuses []*ticket.BoardFlowStatus
+	metrics := make([]*ticket.BoardFlowMetric, 0, days)
+	for day := 0; day < days; day++ {
+		date := b.firstDay.AddDate(0, 0, day)
+		for originalStatus, count := range statusCounts[day] {
+			statuses = append(statuses, &ticket.BoardFlowStatus{
+				BoardId:        boardId,
+				Date:           date,
+				OriginalStatus: originalStatus,
+				Status:         categories[originalStatus],
+				IssueCount:     count,
+			})
+		}
+		metric := &ticket.BoardFlowMetric{
+			BoardId:    boardId,
+			Date:       date,
+			Wip:        len(wipAges[day]),
+			Throughput: throughput[day],
+		}
+		if len(wipAges[day]) > 0 {
+			var sum, max int64
+			for _, age := range wipAges[day] {
+				sum += age
+				if age > max {
+					max = age
+				}
+			}
+			avg := sum / int64(len(wipAges[day]))
+			metric.AvgWipAgeMinutes = &avg
+			metric.MaxWipAgeMinutes = &max
+		}
+		var cycleTimes []int64
+		var active, total time.Duration
+		for _, completion := range completions {
+			if completion.day > day || completion.day <= day-flowMetricWindowDays {
+				continue
+			}
+			if completion.cycleMinutes != nil {
+				cycleTimes = append(cycleTimes, *completion.cycleMinutes)
+			}
+			active += completion.active
+			total += completion.active + completion.waiting
+		}
+		sort.Slice(cycleTimes, func(i, j int) bool { return cycleTimes[i] < cycleTimes[j] })
+		metric.CycleTimeP50Minutes = helper.NearestRank(cycleTimes, 50)
+		metric.CycleTimeP85Minutes = helper.NearestRank(cycleTimes, 85)
+		metric.CycleTimeP95Minutes = helper.NearestRank(cycleTimes, 95)
+		if len(b.waiting) > 0 && total > 0 {
+			efficiency := float64(active) / float64(total)
+			metric.FlowEfficiency = &efficiency
+		}
+		metrics = append(metrics, metric)
+	}
+	return statuses, metrics
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
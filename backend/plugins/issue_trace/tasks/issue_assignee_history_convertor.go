@@ -35,7 +35,6 @@ type AssigneeHistory struct {
 	UserId    string
 	IssueId   string
 	StartDate time.Time
-	EndDate   time.Time
 }
 
 // AssigneeChangelog is the changelog of issue assignee
@@ -76,8 +75,7 @@ func ConvertIssueAssigneeHistory(taskCtx plugin.SubTaskContext) errors.Error {
 		issues._raw_data_id as _raw_data_id,
 		issues.id as issue_id,
 		issues.created_date as start_date,
-		issues.assignee_id as user_id,
-		now() as end_date
+		issues.assignee_id as user_id
 	from
 		issues
 		join board_issues on board_issues.issue_id = issues.id
@@ -106,6 +104,7 @@ func ConvertIssueAssigneeHistory(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, ctxErr
 			}
 			row := inputRow.(*AssigneeHistory)
+			now := time.Now()
 			err := batchInsertor.Add(&models.IssueAssigneeHistory{
 				NoPKModel: common.NoPKModel{
 					RawDataOrigin: common.RawDataOrigin{
@@ -120,7 +119,7 @@ func ConvertIssueAssigneeHistory(taskCtx plugin.SubTaskContext) errors.Error {
 				IssueId:   row.IssueId,
 				Assignee:  row.UserId,
 				StartDate: row.StartDate,
-				EndDate:   &row.EndDate,
+				EndDate:   &now,
 			})
 			if err != nil {
 				logger.Error(err, "Failed to create convertor")
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/utils"
+)
+
+var CalculateIssueBusinessLeadTimeMeta = plugin.SubTaskMeta{
+	Name:             "CalculateIssueBusinessLeadTime",
+	EntryPoint:       CalculateIssueBusinessLeadTime,
+	EnabledByDefault: true,
+	Description:      "Calculate the business-time lead time of resolved issues with the project calendar into project_issue_metrics",
+}
+
+type issueToMeasure struct {
+	Id             string
+	CreatedDate    *time.Time
+	ResolutionDate *time.Time
+}
+
+// CalculateIssueBusinessLeadTime saves the business lead time of the resolved issues on the boards of the project in
+// project_issue_metrics, it is counted with the calendar of the project
+func CalculateIssueBusinessLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	options := taskCtx.GetData().(*TaskData)
+	db := taskCtx.GetDal()
+	if options.ProjectName == "" {
+		logger.Info("no project, skip calculating the business lead time of issues")
+		return nil
+	}
+	calendar, err := helper.LoadWorkingCalendar(db, options.ProjectName)
+	if err != nil {
+		return err
+	}
+	err = db.Delete(
+		&crossdomain.ProjectIssueMetric{},
+		dal.Where("project_name = ? AND issue_id IN (SELECT issue_id FROM board_issues WHERE board_id IN ?)", options.ProjectName, options.ScopeIds),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous project_issue_metrics")
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("issues.id, issues.created_date, issues.resolution_date"),
+		dal.From(&ticket.Issue{}),
+		dal.Where(`issues.id IN (SELECT issue_id FROM board_issues WHERE board_id in ?)
+			AND issues.lead_time_minutes IS NOT NULL AND issues.resolution_date IS NOT NULL`, options.ScopeIds),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	batch, err := helper.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectIssueMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for cursor.Next() {
+		if ctxErr := utils.CheckCancel(taskCtx); ctxErr != nil {
+			return ctxErr
+		}
+		issue := &issueToMeasure{}
+		err = db.Fetch(cursor, issue)
+		if err != nil {
+			return err
+		}
+		minutes := calendar.BusinessMinutes(issue.CreatedDate, issue.ResolutionDate)
+		if minutes == nil {
+			continue
+		}
+		leadTime := uint(*minutes)
+		err = batch.Add(&crossdomain.ProjectIssueMetric{
+			ProjectName:             options.ProjectName,
+			IssueId:                 issue.Id,
+			LeadTimeBusinessMinutes: &leadTime,
+		})
+		if err != nil {
+			return errors.Default.Wrap(err, "error saving issue business lead time")
+		}
+		count++
+	}
+	err = batch.Close()
+	if err != nil {
+		return errors.Default.Wrap(err, "error saving issue business lead time")
+	}
+	logger.Info("business lead time calculated for %d issues", count)
+	return nil
+}
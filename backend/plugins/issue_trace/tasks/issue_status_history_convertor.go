@@ -62,6 +62,10 @@ func ConvertIssueStatusHistory(taskCtx plugin.SubTaskContext) errors.Error {
 	scopeIds := options.ScopeIds
 
 	db := taskCtx.GetDal()
+	calendar, err := helper.LoadWorkingCalendar(db, options.ProjectName)
+	if err != nil {
+		return err
+	}
 	inserter := helper.NewBatchSaveDivider(taskCtx, utils.BATCH_SIZE, "", "")
 	defer inserter.Close()
 	batchInserter, err := inserter.ForType(reflect.TypeOf(&models.IssueStatusHistory{}))
@@ -112,13 +116,14 @@ func ConvertIssueStatusHistory(taskCtx plugin.SubTaskContext) errors.Error {
 						RawDataId:     issue.RawDataId,
 					},
 				},
-				IssueId:           issue.IssueId,
-				Status:            issue.Status,
-				OriginalStatus:    issue.OriginalStatus,
-				StartDate:         issue.CreatedDate,
-				EndDate:           &now,
-				StatusTimeMinutes: int32(statusSeconds / 60),
-				IsFirstStatus:     true,
+				IssueId:                   issue.IssueId,
+				Status:                    issue.Status,
+				OriginalStatus:            issue.OriginalStatus,
+				StartDate:                 issue.CreatedDate,
+				EndDate:                   &now,
+				StatusTimeMinutes:         int32(statusSeconds / 60),
+				StatusBusinessTimeMinutes: int32(calendar.BusinessDuration(issue.CreatedDate, now) / time.Minute),
+				IsFirstStatus:             true,
 			})
 			return nil, err
 		},
@@ -173,13 +178,7 @@ func ConvertIssueStatusHistory(taskCtx plugin.SubTaskContext) errors.Error {
 				if len(currentLogs) > 0 {
 					historyRows := buildStatusHistoryRecords(currentLogs)
 					for _, r := range historyRows {
-						if r.EndDate != nil {
-							var seconds int64 = 0
-							if r.EndDate.After(r.StartDate) {
-								seconds = r.EndDate.Unix() - r.StartDate.Unix()
-							}
-							r.StatusTimeMinutes = int32(seconds / 60)
-						}
+						setStatusTime(r, calendar)
 						err = batchInserter.Add(r)
 						if err != nil {
 							return nil, err
@@ -206,13 +205,7 @@ func ConvertIssueStatusHistory(taskCtx plugin.SubTaskContext) errors.Error {
 	if len(currentLogs) > 0 {
 		historyRows := buildStatusHistoryRecords(currentLogs)
 		for _, r := range historyRows {
-			if r.EndDate != nil {
-				var seconds int64 = 0
-				if r.EndDate.After(r.StartDate) {
-					seconds = r.EndDate.Unix() - r.StartDate.Unix()
-				}
-				r.StatusTimeMinutes = int32(seconds / 60)
-			}
+			setStatusTime(r, calendar)
 			err = batchInserter.Add(r)
 			if err != nil {
 				return err
@@ -223,6 +216,19 @@ func ConvertIssueStatusHistory(taskCtx plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
+// setStatusTime fills the wall-clock and business-time minutes spent in the status
+func setStatusTime(r *models.IssueStatusHistory, calendar *helper.WorkingCalendar) {
+	if r.EndDate == nil {
+		return
+	}
+	var seconds int64 = 0
+	if r.EndDate.After(r.StartDate) {
+		seconds = r.EndDate.Unix() - r.StartDate.Unix()
+	}
+	r.StatusTimeMinutes = int32(seconds / 60)
+	r.StatusBusinessTimeMinutes = int32(calendar.BusinessDuration(r.StartDate, *r.EndDate) / time.Minute)
+}
+
 func buildStatusHistoryRecords(logs []*StatusChangeLogResult) []*models.IssueStatusHistory {
 	if len(logs) == 0 {
 		return make([]*models.IssueStatusHistory, 0)
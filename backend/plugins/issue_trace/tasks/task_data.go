@@ -22,6 +22,16 @@ type Options struct {
 	Plugin      string   `json:"plugin"`   // jira
 	ScopeIds    []string `json:"scopeIds"` // 68
 	ProjectName string   `json:"projectName"`
+	// FlowSettings configures the flow metrics of the boards, keyed by board id
+	FlowSettings map[string]*FlowSettings `json:"flowSettings,omitempty"`
+}
+
+// FlowSettings configures the flow metrics of a board
+type FlowSettings struct {
+	// StatusMappings maps original statuses to TODO, IN_PROGRESS or DONE, overriding the standard status of the history
+	StatusMappings map[string]string `json:"statusMappings"`
+	// WaitingStatuses are the original statuses in which nobody works on an issue in progress, e.g. Ready for Review
+	WaitingStatuses []string `json:"waitingStatuses"`
 }
 
 // TaskData converted parameter
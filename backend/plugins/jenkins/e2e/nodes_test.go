@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	api "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsNodesDataFlow(t *testing.T) {
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `devlake`,
+			JobFullName:  `Test-jenkins-dir/test-jenkins-sub-dir/test-sub-sub-dir/devlake`,
+			JobPath:      `job/Test-jenkins-dir/job/test-jenkins-sub-dir/job/test-sub-sub-dir/`,
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+	}
+
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_nodes.csv", "_raw_jenkins_api_nodes")
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_jenkins_builds_for_stages.csv", models.JenkinsBuild{})
+	dataflowTester.FlushTabler(&models.JenkinsStage{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_jenkins_stages_for_nodes.csv", models.JenkinsStage{})
+	dataflowTester.FlushTabler(&models.JenkinsPipelineNode{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.JenkinsNode{})
+	dataflowTester.Subtask(tasks.ExtractApiNodesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsNode{},
+		"./snapshot_tables/_tool_jenkins_nodes.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"name",
+			"labels",
+			"num_executors",
+			"os",
+			"offline",
+		),
+	)
+
+	// only the nodes which executed stages of the job are converted
+	dataflowTester.FlushTabler(&devops.CicdRunner{})
+	dataflowTester.Subtask(tasks.ConvertNodesMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdRunner{},
+		"./snapshot_tables/cicd_runners.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"name",
+			"pool",
+			"labels",
+			"os",
+			"is_self_hosted",
+		),
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertStagesMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks_with_runners.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"runner_id",
+		},
+	)
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	api "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsPipelineNodesDataFlow(t *testing.T) {
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `devlake-jenkins`,
+			JobFullName:  `github_org/devlake-jenkins`,
+			JobPath:      ``,
+			Class:        `org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject`,
+			ScopeConfig:  new(models.JenkinsScopeConfig),
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+	}
+
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_jenkins_builds_for_pipeline_nodes.csv", models.JenkinsBuild{})
+
+	// verify extraction
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_pipeline_nodes.csv", "_raw_jenkins_api_pipeline_nodes")
+	dataflowTester.FlushTabler(&models.JenkinsPipelineNode{})
+	dataflowTester.Subtask(tasks.ExtractApiPipelineNodesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsPipelineNode{},
+		"./snapshot_tables/_tool_jenkins_pipeline_nodes.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"build_name",
+			"node_id",
+			"display_name",
+			"type",
+			"state",
+			"result",
+			"start_time",
+			"duration_millis",
+			"first_parent_node_id",
+			"edge_node_ids",
+		),
+	)
+
+	dataflowTester.FlushRawTable("_raw_jenkins_api_stages")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_stages_pipeline_nodes.csv", "_raw_jenkins_api_stages")
+	dataflowTester.FlushTabler(&models.JenkinsStage{})
+	dataflowTester.Subtask(tasks.ExtractApiStagesMeta, taskData)
+
+	// verify stages and parallel branches are nested, and paused time is reported as queued
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertStagesMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertPipelineNodesMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks_pipeline_nodes.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"name",
+			"pipeline_id",
+			"parent_task_id",
+			"result",
+			"status",
+			"original_result",
+			"original_status",
+			"duration_sec",
+			"queued_duration_sec",
+			"queued_date",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+}
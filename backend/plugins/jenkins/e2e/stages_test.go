@@ -52,6 +52,7 @@ func TestJenkinsStagesDataFlow(t *testing.T) {
 
 	// verify extraction
 	dataflowTester.FlushTabler(&models.JenkinsStage{})
+	dataflowTester.FlushTabler(&models.JenkinsPipelineNode{})
 	dataflowTester.Subtask(tasks.ExtractApiStagesMeta, taskData)
 	dataflowTester.VerifyTable(
 		models.JenkinsStage{},
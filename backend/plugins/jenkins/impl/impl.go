@@ -20,6 +20,7 @@ package impl
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	coreModels "github.com/apache/incubator-devlake/core/models"
 
@@ -46,6 +47,7 @@ var _ interface {
 	plugin.CloseablePluginTask
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
+	helper.DeploymentCandidateSource
 } = (*Jenkins)(nil)
 
 type Jenkins struct{}
@@ -75,6 +77,8 @@ func (p Jenkins) GetTablesInfo() []dal.Tabler {
 		&models.JenkinsConnection{},
 		&models.JenkinsJob{},
 		&models.JenkinsJobDag{},
+		&models.JenkinsNode{},
+		&models.JenkinsPipelineNode{},
 		&models.JenkinsStage{},
 		&models.JenkinsScopeConfig{},
 	}
@@ -97,9 +101,15 @@ func (p Jenkins) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiBuildsMeta,
 		tasks.CollectApiStagesMeta,
 		tasks.ExtractApiStagesMeta,
+		tasks.CollectApiPipelineNodesMeta,
+		tasks.ExtractApiPipelineNodesMeta,
+		tasks.CollectApiNodesMeta,
+		tasks.ExtractApiNodesMeta,
 		tasks.EnrichApiBuildWithStagesMeta,
 		tasks.ConvertBuildsToCicdTasksMeta,
 		tasks.ConvertStagesMeta,
+		tasks.ConvertPipelineNodesMeta,
+		tasks.ConvertNodesMeta,
 		tasks.ConvertBuildReposMeta,
 	}
 }
@@ -145,11 +155,16 @@ func (p Jenkins) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]
 	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ScopeConfig.ProductionPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
 	}
+	deploymentRules, err := helper.NewDeploymentRuleEngine(op.ScopeConfig.DeploymentRules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentRules`")
+	}
 	taskData := &tasks.JenkinsTaskData{
-		Options:       op,
-		ApiClient:     apiClient,
-		Connection:    connection,
-		RegexEnricher: regexEnricher,
+		Options:         op,
+		ApiClient:       apiClient,
+		Connection:      connection,
+		RegexEnricher:   regexEnricher,
+		DeploymentRules: deploymentRules,
 	}
 
 	return taskData, nil
@@ -293,3 +308,7 @@ func EnrichOptions(taskCtx plugin.TaskContext,
 
 	return nil
 }
+
+func (p Jenkins) DeploymentCandidates(db dal.Dal, connectionId uint64, scopeId string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	return tasks.DeploymentCandidates(db, connectionId, scopeId, since)
+}
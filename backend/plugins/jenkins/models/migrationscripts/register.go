@@ -36,5 +36,8 @@ func All() []plugin.MigrationScript {
 		new(renameTr2ScopeConfig),
 		new(addRawParamTableForScope),
 		new(addNumberToJenkinsBuildCommit),
+		new(addPipelineNodes),
+		new(addNodes),
+		new(addDeploymentRules),
 	}
 }
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// JenkinsNode is an agent (or the built-in node) which executes builds, Name is
+// what the stages api reports as execNode, Labels excludes the node's own name.
+type JenkinsNode struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Name         string `gorm:"primaryKey;type:varchar(255)"`
+	Labels       string `gorm:"type:varchar(500)"`
+	NumExecutors int
+	Os           string `gorm:"type:varchar(100)"`
+	Offline      bool
+}
+
+func (JenkinsNode) TableName() string {
+	return "_tool_jenkins_nodes"
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// JenkinsPipelineNode is a node of the Blue Ocean pipeline graph of a build,
+// either a stage or a branch of a parallel stage. FirstParentNodeId is the
+// enclosing stage for parallel branches and the preceding node otherwise,
+// EdgeNodeIds holds the comma separated ids of the following nodes.
+type JenkinsPipelineNode struct {
+	common.NoPKModel
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	BuildName         string `gorm:"primaryKey;type:varchar(255)"`
+	NodeId            string `gorm:"primaryKey;type:varchar(255)"`
+	DisplayName       string `gorm:"type:varchar(255)"`
+	Type              string `gorm:"type:varchar(100)"`
+	State             string `gorm:"type:varchar(100)"`
+	Result            string `gorm:"type:varchar(100)"`
+	StartTime         *time.Time
+	DurationMillis    int64
+	FirstParentNodeId string `gorm:"type:varchar(255)"`
+	EdgeNodeIds       string `gorm:"type:varchar(255)"`
+}
+
+func (JenkinsPipelineNode) TableName() string {
+	return "_tool_jenkins_pipeline_nodes"
+}
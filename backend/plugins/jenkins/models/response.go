@@ -19,6 +19,8 @@ package models
 
 import (
 	"strings"
+
+	"github.com/apache/incubator-devlake/core/models/common"
 )
 
 type Job struct {
@@ -159,3 +161,32 @@ type Cause struct {
 	UpstreamProject  string `json:"upstreamProject"`
 	UpstreamURL      string `json:"upstreamUrl"`
 }
+
+type PipelineNode struct {
+	ID               string              `json:"id"`
+	DisplayName      string              `json:"displayName"`
+	Type             string              `json:"type"`
+	State            string              `json:"state"`
+	Result           string              `json:"result"`
+	StartTime        *common.Iso8601Time `json:"startTime"`
+	DurationInMillis int64               `json:"durationInMillis"`
+	FirstParent      string              `json:"firstParent"`
+	Edges            []struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"edges"`
+}
+
+// Computer is a node of the jenkins controller as listed by the computer api
+type Computer struct {
+	Class          string `json:"_class"`
+	DisplayName    string `json:"displayName"`
+	NumExecutors   int    `json:"numExecutors"`
+	Offline        bool   `json:"offline"`
+	AssignedLabels []struct {
+		Name string `json:"name"`
+	} `json:"assignedLabels"`
+	MonitorData struct {
+		Architecture string `json:"hudson.node_monitors.ArchitectureMonitor"`
+	} `json:"monitorData"`
+}
@@ -19,12 +19,16 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
 type JenkinsScopeConfig struct {
 	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
 	DeploymentPattern  string `gorm:"type:varchar(255)" mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern"`
 	ProductionPattern  string `gorm:"type:varchar(255)" mapstructure:"productionPattern,omitempty" json:"productionPattern"`
+
+	// DeploymentRules classify the pipelines and tasks ahead of deploymentPattern and productionPattern
+	DeploymentRules []*helper.DeploymentRule `mapstructure:"deploymentRules,omitempty" json:"deploymentRules" gorm:"type:json;serializer:json"`
 }
 
 func (t JenkinsScopeConfig) TableName() string {
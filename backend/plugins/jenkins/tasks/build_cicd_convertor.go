@@ -131,6 +131,9 @@ func ConvertBuildsToCicdTasks(taskCtx plugin.SubTaskContext) (err errors.Error)
 				Type:        data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, jenkinsBuild.FullName),
 				Environment: data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, jenkinsBuild.FullName),
 			}
+			jenkinsPipeline.Type, jenkinsPipeline.Environment = data.DeploymentRules.Classify(
+				newBuildDeploymentCandidate(jenkinsBuild, data.Options.JobFullName), jenkinsPipeline.Type, jenkinsPipeline.Environment,
+			)
 			jenkinsPipeline.RawDataOrigin = jenkinsBuild.RawDataOrigin
 			results = append(results, jenkinsPipeline)
 
@@ -151,8 +154,8 @@ func ConvertBuildsToCicdTasks(taskCtx plugin.SubTaskContext) (err errors.Error)
 						FinishedDate: jenkinsPipelineFinishedDate,
 					},
 					CicdScopeId: jobIdGen.Generate(jenkinsBuild.ConnectionId, data.Options.JobFullName),
-					Type:        data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, jenkinsBuild.FullName),
-					Environment: data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, jenkinsBuild.FullName),
+					Type:        jenkinsPipeline.Type,
+					Environment: jenkinsPipeline.Environment,
 					PipelineId:  buildIdGen.Generate(jenkinsBuild.ConnectionId, jenkinsBuild.FullName),
 				}
 				// if the task is not executed, set the result to default, so that it will not be calculated in the dora
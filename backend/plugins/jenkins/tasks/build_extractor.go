@@ -20,6 +20,8 @@ package tasks
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -105,6 +107,10 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				if len(a.LastBuiltRevision.Branches) > 0 {
 					branch = a.LastBuiltRevision.Branches[0].Name
 				}
+				if err1 == nil && input.Class == WORKFLOW_JOB {
+					// the branch jobs of a multi-branch workflow are named after the branch or change request they build
+					branch = multiBranchJobRef(input.Name)
+				}
 				for _, url := range a.RemoteUrls {
 					if url != "" {
 						buildCommitRemoteUrl := models.JenkinsBuildCommit{
@@ -142,3 +148,21 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return extractor.Execute()
 }
+
+var changeRequestJobPattern = regexp.MustCompile(`^(PR|MR)-(\d+)$`)
+
+// multiBranchJobRef maps the name of a branch job of a multi-branch workflow to the ref it builds. Branch jobs map to
+// the short (url decoded) branch name like the branches of the other CI/CD plugins, only pull requests (PR-1) and merge
+// requests (MR-1) map to the full head refs, as they have no branch of their own.
+func multiBranchJobRef(jobName string) string {
+	if matches := changeRequestJobPattern.FindStringSubmatch(jobName); matches != nil {
+		if matches[1] == "MR" {
+			return fmt.Sprintf("refs/merge-requests/%s/head", matches[2])
+		}
+		return fmt.Sprintf("refs/pull/%s/head", matches[2])
+	}
+	if branch, err := url.PathUnescape(jobName); err == nil {
+		return branch
+	}
+	return jobName
+}
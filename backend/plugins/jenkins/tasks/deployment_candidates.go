@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+// newBuildDeploymentCandidate describes a build to the deployment rules, the job it runs as is the job name
+func newBuildDeploymentCandidate(build *models.JenkinsBuild, jobFullName string) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, build.Result)
+	candidate.JobName, candidate.PipelineName = jobFullName, build.FullName
+	return candidate
+}
+
+// newStageDeploymentCandidate describes a stage or a branch of parallel stages to the deployment rules, the job its
+// build runs as is the pipeline name
+func newStageDeploymentCandidate(name, result, jobFullName string) *api.DeploymentCandidate {
+	candidate := api.NewDeploymentCandidate("", false, result)
+	candidate.JobName, candidate.PipelineName = name, jobFullName
+	return candidate
+}
+
+// DeploymentCandidates describes the builds of the job started since the time, their stages and the branches of their
+// parallel stages to the deployment rules by the ids of the cicd_pipelines and the cicd_tasks they are converted to,
+// builds without stages are converted to a task as well
+func DeploymentCandidates(db dal.Dal, connectionId uint64, jobFullName string, since time.Time) (*api.DeploymentCandidates, errors.Error) {
+	job := &models.JenkinsJob{}
+	err := db.First(job, dal.Where("connection_id = ? AND full_name = ?", connectionId, jobFullName))
+	if db.IsErrorNotFound(err) {
+		return api.NewDeploymentCandidates(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	buildNamePattern := pipelineNodeBuildNamePattern(&JenkinsOptions{JobFullName: job.FullName, Class: job.Class})
+
+	candidates := api.NewDeploymentCandidates()
+	var builds []*models.JenkinsBuild
+	err = db.All(&builds, dal.Where("connection_id = ? AND full_name LIKE ? AND start_time >= ?", connectionId, buildNamePattern, since))
+	if err != nil {
+		return nil, err
+	}
+	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
+	for _, build := range builds {
+		buildId := buildIdGen.Generate(connectionId, build.FullName)
+		candidates.Pipelines[buildId] = newBuildDeploymentCandidate(build, jobFullName)
+		if !build.HasStages {
+			candidates.Tasks[buildId] = candidates.Pipelines[buildId]
+		}
+	}
+
+	// stages take the result of their builds
+	var stages []*JenkinsBuildWithRepoStage
+	err = db.All(
+		&stages,
+		dal.Select("tjs.build_name, tjs.id, tjs.name, tjb.result"),
+		dal.From("_tool_jenkins_stages tjs"),
+		dal.Join("JOIN _tool_jenkins_builds tjb ON tjb.connection_id = tjs.connection_id AND tjb.full_name = tjs.build_name"),
+		dal.Where("tjb.connection_id = ? AND tjb.full_name LIKE ? AND tjb.start_time >= ?", connectionId, buildNamePattern, since),
+	)
+	if err != nil {
+		return nil, err
+	}
+	stageIdGen := didgen.NewDomainIdGenerator(&models.JenkinsStage{})
+	for _, stage := range stages {
+		if stage.Name == "" {
+			continue
+		}
+		candidates.Tasks[stageIdGen.Generate(connectionId, stage.BuildName, stage.ID)] = newStageDeploymentCandidate(stage.Name, stage.Result, jobFullName)
+	}
+	var nodes []*models.JenkinsPipelineNode
+	err = db.All(
+		&nodes,
+		dal.Select("tjn.*"),
+		dal.From("_tool_jenkins_pipeline_nodes tjn"),
+		dal.Join(`LEFT JOIN _tool_jenkins_stages tjs ON tjs.connection_id = tjn.connection_id
+			AND tjs.build_name = tjn.build_name AND tjs.id = tjn.node_id`),
+		dal.Where("tjn.connection_id = ? AND tjn.build_name LIKE ? AND tjn.type = ? AND tjs.id IS NULL AND tjn.start_time >= ?",
+			connectionId, buildNamePattern, PIPELINE_NODE_PARALLEL, since),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		taskId := stageIdGen.Generate(connectionId, node.BuildName, node.NodeId)
+		candidates.Tasks[taskId] = newStageDeploymentCandidate(node.DisplayName, node.Result, jobFullName)
+	}
+	return candidates, nil
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_NODE_TABLE = "jenkins_api_nodes"
+
+var CollectApiNodesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiNodes",
+	EntryPoint:       CollectApiNodes,
+	EnabledByDefault: true,
+	Description:      "Collect the nodes (agents) of the jenkins controller, supports neither timeFilter nor diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiNodes(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_NODE_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		UrlTemplate: "computer/api/json",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("tree", "computer[_class,displayName,numExecutors,offline,assignedLabels[name],monitorData[hudson.node_monitors.ArchitectureMonitor]]")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var body struct {
+				Computer []json.RawMessage `json:"computer"`
+			}
+			err := api.UnmarshalResponse(res, &body)
+			if err != nil {
+				return nil, err
+			}
+			return body.Computer, nil
+		},
+		// reading nodes requires the Overall/Read permission only, but keep going if it is denied
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ConvertNodesMeta = plugin.SubTaskMeta{
+	Name:             "convertNodes",
+	EntryPoint:       ConvertNodes,
+	EnabledByDefault: true,
+	Description:      "convert jenkins_nodes which executed stages of the job into cicd_runners",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertNodes(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+
+	// nodes are shared by all jobs of the controller, only the ones which executed stages of the job are converted
+	stageClause := `select tjs.exec_node from _tool_jenkins_stages tjs
+		join _tool_jenkins_builds tjb on tjs.connection_id = tjb.connection_id and tjs.build_name = tjb.full_name`
+	var cursorClauses []dal.Clause
+	if data.Options.Class == WORKFLOW_MULTI_BRANCH_PROJECT {
+		cursorClauses = []dal.Clause{
+			dal.From(&models.JenkinsNode{}),
+			dal.Where(fmt.Sprintf(`connection_id = ? and name in (%s where tjb.connection_id = ? and tjb.full_name like ?)`, stageClause),
+				data.Options.ConnectionId, data.Options.ConnectionId, fmt.Sprintf("%s%%", data.Options.JobFullName)),
+		}
+	} else {
+		cursorClauses = []dal.Clause{
+			dal.From(&models.JenkinsNode{}),
+			dal.Where(fmt.Sprintf(`connection_id = ? and name in (%s where tjb.connection_id = ? and tjb.job_path = ? and tjb.job_name = ?)`, stageClause),
+				data.Options.ConnectionId, data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+		}
+	}
+	cursor, err := db.Cursor(cursorClauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	nodeIdGen := didgen.NewDomainIdGenerator(&models.JenkinsNode{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.JenkinsNode{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_NODE_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			node := inputRow.(*models.JenkinsNode)
+			// the first label is usually the one pipelines ask for, e.g. `agent { label 'linux' }`
+			pool := strings.SplitN(node.Labels, ",", 2)[0]
+			if pool == "" {
+				pool = node.Name
+			}
+			return []interface{}{
+				&devops.CicdRunner{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: nodeIdGen.Generate(node.ConnectionId, node.Name),
+					},
+					Name:         node.Name,
+					Pool:         pool,
+					Labels:       node.Labels,
+					Os:           node.Os,
+					IsSelfHosted: true,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ExtractApiNodesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiNodes",
+	EntryPoint:       ExtractApiNodes,
+	EnabledByDefault: true,
+	Description:      "Extract raw nodes into tool layer table jenkins_nodes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiNodes(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_NODE_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &models.Computer{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			// every node carries its own name as a label, which says nothing about its pool
+			labels := make([]string, 0, len(body.AssignedLabels))
+			for _, label := range body.AssignedLabels {
+				if label.Name != body.DisplayName {
+					labels = append(labels, label.Name)
+				}
+			}
+			node := &models.JenkinsNode{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         body.DisplayName,
+				Labels:       strings.Join(labels, ","),
+				NumExecutors: body.NumExecutors,
+				Os:           devops.GetRunnerOs(append([]string{body.MonitorData.Architecture}, labels...)...),
+				Offline:      body.Offline,
+			}
+			return []interface{}{node}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
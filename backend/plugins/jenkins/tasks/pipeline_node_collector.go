@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PIPELINE_NODE_TABLE = "jenkins_api_pipeline_nodes"
+
+var CollectApiPipelineNodesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPipelineNodes",
+	EntryPoint:       CollectApiPipelineNodes,
+	EnabledByDefault: true,
+	Description:      "Collect pipeline graph nodes from the jenkins blue ocean api, supports timeFilter but not diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// PipelineNodesInput identifies a build on the blue ocean api
+type PipelineNodesInput struct {
+	FullName string
+	Number   string
+	// blue ocean path of the pipeline, e.g. pipelines/folder/pipelines/job/branches/main/
+	Path string
+}
+
+func CollectApiPipelineNodes(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Params: JenkinsApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			FullName:     data.Options.JobFullName,
+		},
+		Ctx:   taskCtx,
+		Table: RAW_PIPELINE_NODE_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("tjb.number,tjb.full_name"),
+		dal.From("_tool_jenkins_builds as tjb"),
+	}
+	if data.Options.Class == WORKFLOW_MULTI_BRANCH_PROJECT {
+		clauses = append(clauses, dal.Where(`tjb.connection_id = ? and tjb.full_name like ? and tjb.class = ?`,
+			data.Options.ConnectionId, fmt.Sprintf("%s/%%", data.Options.JobFullName), "WorkflowRun"))
+	} else {
+		clauses = append(clauses, dal.Where(`tjb.connection_id = ? and tjb.job_path = ? and tjb.job_name = ? and tjb.class = ?`,
+			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName, "WorkflowRun"))
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where(`tjb.start_time >= ?`, apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	iterator := api.NewQueueIterator()
+	for cursor.Next() {
+		build := &SimpleBuild{}
+		err = db.Fetch(cursor, build)
+		if err != nil {
+			return err
+		}
+		iterator.Push(&PipelineNodesInput{
+			FullName: build.FullName,
+			Number:   build.Number,
+			Path:     blueOceanPipelinePath(data.Options, build.FullName),
+		})
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Input:       iterator,
+		UrlTemplate: "blue/rest/organizations/jenkins/{{ .Input.Path }}runs/{{ .Input.Number }}/nodes/",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var nodes []json.RawMessage
+			err := api.UnmarshalResponse(res, &nodes)
+			if err != nil {
+				return nil, err
+			}
+			return nodes, nil
+		},
+		// blue ocean is an optional plugin, builds are still converted from stages without it
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
+
+// blueOceanPipelinePath maps a build to the blue ocean path of its pipeline,
+// branches of multibranch projects are addressed by their (escaped) branch name
+func blueOceanPipelinePath(options *JenkinsOptions, buildFullName string) string {
+	jobFullName := strings.SplitN(buildFullName, "#", 2)[0]
+	var branch string
+	if options.Class == WORKFLOW_MULTI_BRANCH_PROJECT {
+		branch = strings.TrimPrefix(jobFullName, options.JobFullName+"/")
+		jobFullName = options.JobFullName
+	}
+	path := ""
+	for _, name := range strings.Split(jobFullName, "/") {
+		path += fmt.Sprintf("pipelines/%s/", url.PathEscape(name))
+	}
+	if branch != "" {
+		path += fmt.Sprintf("branches/%s/", url.PathEscape(branch))
+	}
+	return path
+}
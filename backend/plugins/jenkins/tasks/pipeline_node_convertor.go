@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ConvertPipelineNodesMeta = plugin.SubTaskMeta{
+	Name:             "convertPipelineNodes",
+	EntryPoint:       ConvertPipelineNodes,
+	EnabledByDefault: true,
+	Description:      "convert branches of parallel stages in jenkins_pipeline_nodes into cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// ConvertPipelineNodes converts the branches of parallel stages, which have no
+// counterpart in jenkins_stages, into cicd_tasks nested under their stage
+func ConvertPipelineNodes(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+
+	clauses := []dal.Clause{
+		dal.Select("tjn.*"),
+		dal.From("_tool_jenkins_pipeline_nodes tjn"),
+		dal.Join(`left join _tool_jenkins_stages tjs on tjs.connection_id = tjn.connection_id
+			and tjs.build_name = tjn.build_name and tjs.id = tjn.node_id`),
+		dal.Where("tjn.connection_id = ? and tjn.build_name like ? and tjn.type = ? and tjs.id is null",
+			data.Options.ConnectionId, pipelineNodeBuildNamePattern(data.Options), PIPELINE_NODE_PARALLEL),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	stageIdGen := didgen.NewDomainIdGenerator(&models.JenkinsStage{})
+	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
+	jobIdGen := didgen.NewDomainIdGenerator(&models.JenkinsJob{})
+
+	convertor, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.JenkinsPipelineNode{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_PIPELINE_NODE_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			node := inputRow.(*models.JenkinsPipelineNode)
+			task := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: stageIdGen.Generate(node.ConnectionId, node.BuildName, node.NodeId),
+				},
+				Name:       node.DisplayName,
+				PipelineId: buildIdGen.Generate(node.ConnectionId, node.BuildName),
+				Result: devops.GetResult(&devops.ResultRule{
+					Success: []string{SUCCESS},
+					Failure: []string{FAILED, FAILURE, ABORTED},
+					Default: devops.RESULT_DEFAULT,
+				}, node.Result),
+				Status: devops.GetStatus(&devops.StatusRule{
+					Done:       []string{NODE_FINISHED},
+					InProgress: []string{NODE_RUNNING, NODE_QUEUED, NODE_PAUSED},
+					Default:    devops.STATUS_OTHER,
+				}, node.State),
+				OriginalResult: node.Result,
+				OriginalStatus: node.State,
+				DurationSec:    float64(node.DurationMillis / 1e3),
+				CicdScopeId:    jobIdGen.Generate(node.ConnectionId, data.Options.JobFullName),
+				Type:           data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, node.DisplayName),
+				Environment:    data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, node.DisplayName),
+			}
+			task.Type, task.Environment = data.DeploymentRules.Classify(
+				newStageDeploymentCandidate(node.DisplayName, node.Result, data.Options.JobFullName), task.Type, task.Environment,
+			)
+			if node.FirstParentNodeId != "" {
+				task.ParentTaskId = stageIdGen.Generate(node.ConnectionId, node.BuildName, node.FirstParentNodeId)
+			}
+			if node.StartTime != nil {
+				finishedDate := node.StartTime.Add(time.Duration(node.DurationMillis) * time.Millisecond)
+				task.CreatedDate = *node.StartTime
+				task.StartedDate = node.StartTime
+				task.FinishedDate = &finishedDate
+			}
+			return []interface{}{task}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return convertor.Execute()
+}
+
+// pipelineNodeBuildNamePattern matches the names of the builds of the scope
+func pipelineNodeBuildNamePattern(options *JenkinsOptions) string {
+	if options.Class == WORKFLOW_MULTI_BRANCH_PROJECT {
+		return fmt.Sprintf("%s/%%", options.JobFullName)
+	}
+	return fmt.Sprintf("%s#%%", options.JobFullName)
+}
+
+// loadPipelineNodes loads the pipeline graph nodes of the scope, indexed by build name and node id
+func loadPipelineNodes(db dal.Dal, options *JenkinsOptions) (map[string]map[string]*models.JenkinsPipelineNode, errors.Error) {
+	var nodes []*models.JenkinsPipelineNode
+	err := db.All(&nodes, dal.Where("connection_id = ? and build_name like ?",
+		options.ConnectionId, pipelineNodeBuildNamePattern(options)))
+	if err != nil {
+		return nil, err
+	}
+	graphs := make(map[string]map[string]*models.JenkinsPipelineNode)
+	for _, node := range nodes {
+		if graphs[node.BuildName] == nil {
+			graphs[node.BuildName] = make(map[string]*models.JenkinsPipelineNode)
+		}
+		graphs[node.BuildName][node.NodeId] = node
+	}
+	return graphs, nil
+}
+
+// findParentNodeId returns the id of the parallel branch enclosing a stage, or the
+// enclosing stage of a parallel branch. Top level stages have no parent, their
+// first parent is merely the preceding stage.
+func findParentNodeId(graph map[string]*models.JenkinsPipelineNode, nodeId string) string {
+	node := graph[nodeId]
+	if node == nil {
+		return ""
+	}
+	if node.Type == PIPELINE_NODE_PARALLEL {
+		return node.FirstParentNodeId
+	}
+	// walk back the preceding stages, bounded by the graph size in case of cycles
+	current := graph[node.FirstParentNodeId]
+	for i := 0; current != nil && i < len(graph); i++ {
+		if current.Type == PIPELINE_NODE_PARALLEL {
+			return current.NodeId
+		}
+		current = graph[current.FirstParentNodeId]
+	}
+	return ""
+}
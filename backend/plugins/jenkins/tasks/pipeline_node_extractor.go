@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ExtractApiPipelineNodesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPipelineNodes",
+	EntryPoint:       ExtractApiPipelineNodes,
+	EnabledByDefault: true,
+	Description:      "Extract raw pipeline graph nodes into tool layer table jenkins_pipeline_nodes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiPipelineNodes(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_PIPELINE_NODE_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &models.PipelineNode{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			input := &PipelineNodesInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+
+			edgeNodeIds := make([]string, 0, len(body.Edges))
+			for _, edge := range body.Edges {
+				edgeNodeIds = append(edgeNodeIds, edge.ID)
+			}
+			node := &models.JenkinsPipelineNode{
+				ConnectionId:      data.Options.ConnectionId,
+				BuildName:         input.FullName,
+				NodeId:            body.ID,
+				DisplayName:       body.DisplayName,
+				Type:              body.Type,
+				State:             body.State,
+				Result:            body.Result,
+				StartTime:         body.StartTime.ToNullableTime(),
+				DurationMillis:    body.DurationInMillis,
+				FirstParentNodeId: body.FirstParent,
+				EdgeNodeIds:       strings.Join(edgeNodeIds, ","),
+			}
+			return []interface{}{node}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
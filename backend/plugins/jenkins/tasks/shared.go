@@ -34,6 +34,14 @@ const (
 
 	WORKFLOW_MULTI_BRANCH_PROJECT = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
 	WORKFLOW_JOB                  = "org.jenkinsci.plugins.workflow.job.WorkflowJob"
+
+	// blue ocean pipeline node types and states
+	PIPELINE_NODE_STAGE    = "STAGE"
+	PIPELINE_NODE_PARALLEL = "PARALLEL"
+	NODE_FINISHED          = "FINISHED"
+	NODE_RUNNING           = "RUNNING"
+	NODE_QUEUED            = "QUEUED"
+	NODE_PAUSED            = "PAUSED"
 )
 
 func ignoreHTTPStatus404(res *http.Response) errors.Error {
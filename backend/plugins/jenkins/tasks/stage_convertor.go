@@ -65,7 +65,7 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 	data := taskCtx.GetData().(*JenkinsTaskData)
 
 	clauses := []dal.Clause{
-		dal.Select(`tjb.connection_id, tjs.build_name, tjs.id, tjs._raw_data_remark, tjs.name,
+		dal.Select(`tjb.connection_id, tjs.build_name, tjs.id, tjs._raw_data_remark, tjs.name, tjs.exec_node,
 			tjs._raw_data_id, tjs._raw_data_table, tjs._raw_data_params,
 			tjs.status, tjs.start_time_millis, tjs.duration_millis,
 			tjs.pause_duration_millis, tjs.type, tjb.result,
@@ -89,9 +89,14 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 		return err
 	}
 	defer cursor.Close()
+	graphs, err := loadPipelineNodes(db, data.Options)
+	if err != nil {
+		return err
+	}
 	stageIdGen := didgen.NewDomainIdGenerator(&models.JenkinsStage{})
 	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
 	jobIdGen := didgen.NewDomainIdGenerator(&models.JenkinsJob{})
+	nodeIdGen := didgen.NewDomainIdGenerator(&models.JenkinsNode{})
 
 	convertor, err := api.NewDataConverter(api.DataConverterArgs{
 		InputRowType: reflect.TypeOf(JenkinsBuildWithRepoStage{}),
@@ -115,7 +120,12 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 			} else {
 				durationMillis = int64(0)
 			}
-			durationSec := float64(durationMillis / 1e3)
+			// the time a stage spends paused, e.g. waiting for an executor, is reported as queued
+			var pauseMillis int64
+			if body.PauseDurationMillis > 0 && int64(body.PauseDurationMillis) <= durationMillis {
+				pauseMillis = int64(body.PauseDurationMillis)
+			}
+			durationSec := float64((durationMillis - pauseMillis) / 1e3)
 			jenkinsTaskResult := devops.GetResult(&devops.ResultRule{
 				Success: []string{SUCCESS},
 				Failure: []string{FAILED, FAILURE, ABORTED},
@@ -133,7 +143,9 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 			finishedDateMillis := body.StartTimeMillis + durationMillis
 			finishedDate := time.Unix(finishedDateMillis/1e3, (finishedDateMillis%1e3)*int64(time.Millisecond))
 			jenkinsTaskFinishedDate = &finishedDate
-			startedDate := time.Unix(body.StartTimeMillis/1e3, 0)
+			createdDate := time.Unix(body.StartTimeMillis/1e3, 0)
+			startedDateMillis := body.StartTimeMillis + pauseMillis
+			startedDate := time.Unix(startedDateMillis/1e3, 0)
 
 			jenkinsTask := &devops.CICDTask{
 				DomainEntity: domainlayer.DomainEntity{
@@ -145,7 +157,7 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 				Status:      jenkinsTaskStatus,
 				DurationSec: durationSec,
 				TaskDatesInfo: devops.TaskDatesInfo{
-					CreatedDate:  startedDate,
+					CreatedDate:  createdDate,
 					StartedDate:  &startedDate,
 					FinishedDate: jenkinsTaskFinishedDate,
 				},
@@ -155,6 +167,20 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 				Type:           data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, body.Name),
 				Environment:    data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, body.Name),
 			}
+			jenkinsTask.Type, jenkinsTask.Environment = data.DeploymentRules.Classify(
+				newStageDeploymentCandidate(body.Name, body.Result, data.Options.JobFullName), jenkinsTask.Type, jenkinsTask.Environment,
+			)
+			if pauseMillis > 0 {
+				queuedDurationSec := float64(pauseMillis / 1e3)
+				jenkinsTask.QueuedDurationSec = &queuedDurationSec
+				jenkinsTask.QueuedDate = &createdDate
+			}
+			if parentNodeId := findParentNodeId(graphs[body.BuildName], body.ID); parentNodeId != "" {
+				jenkinsTask.ParentTaskId = stageIdGen.Generate(body.ConnectionId, body.BuildName, parentNodeId)
+			}
+			if body.ExecNode != "" {
+				jenkinsTask.RunnerId = nodeIdGen.Generate(body.ConnectionId, body.ExecNode)
+			}
 			// if the task is not executed, set the result to default, so that it will not be calculated in the dora
 			if jenkinsTask.OriginalStatus == "NOT_EXECUTED" {
 				jenkinsTask.Result = devops.RESULT_DEFAULT
@@ -55,5 +55,6 @@ func All() []plugin.MigrationScript {
 		new(flushJiraIssues),
 		new(updateScopeConfig),
 		new(addFixVersions20250619),
+		new(addIncidentRules),
 	}
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
 type StatusMapping struct {
@@ -49,6 +50,14 @@ type JiraScopeConfig struct {
 	TypeMappings               map[string]TypeMapping `mapstructure:"typeMappings,omitempty" json:"typeMappings" gorm:"type:json;serializer:json"`
 	ApplicationType            string                 `mapstructure:"applicationType,omitempty" json:"applicationType" gorm:"type:varchar(255)"`
 	DueDateField               string                 `mapstructure:"dueDateField,omitempty" json:"dueDateField" gorm:"type:varchar(255)"`
+	// IncidentRules normalizes severity, affected service and customer impact of the issues mapped to INCIDENT
+	IncidentRules *helper.IncidentNormalizationRules `mapstructure:"incidentRules,omitempty" json:"incidentRules" gorm:"type:json;serializer:json"`
+}
+
+var _ helper.IncidentRulesScopeConfig = (*JiraScopeConfig)(nil)
+
+func (r JiraScopeConfig) GetIncidentRules() *helper.IncidentNormalizationRules {
+	return r.IncidentRules
 }
 
 func (r *JiraScopeConfig) SetConnectionId(c *JiraScopeConfig, connectionId uint64) {
@@ -64,6 +73,11 @@ func (r *JiraScopeConfig) Validate() errors.Error {
 			return errors.Convert(err)
 		}
 	}
+	if r.IncidentRules != nil {
+		if _, err := helper.NewIncidentNormalizer(r.IncidentRules); err != nil {
+			return err
+		}
+	}
 	for _, pattern := range r.RemotelinkRepoPattern {
 		if pattern.Regex == "" {
 			return errors.BadInput.New("empty regex in remotelinkRepoPattern")
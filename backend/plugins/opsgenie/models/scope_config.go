@@ -17,10 +17,21 @@ limitations under the License.
 
 package models
 
-import "github.com/apache/incubator-devlake/core/models/common"
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
 
 type OpsenieScopeConfig struct {
 	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	// IncidentRules normalizes severity, affected service and customer impact of the incidents of the service
+	IncidentRules *api.IncidentNormalizationRules `mapstructure:"incidentRules,omitempty" json:"incidentRules" gorm:"type:json;serializer:json"`
+}
+
+var _ api.IncidentRulesScopeConfig = (*OpsenieScopeConfig)(nil)
+
+func (o OpsenieScopeConfig) GetIncidentRules() *api.IncidentNormalizationRules {
+	return o.IncidentRules
 }
 
 func (o OpsenieScopeConfig) TableName() string {
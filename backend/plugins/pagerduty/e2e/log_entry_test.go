@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/impl"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/tasks"
+)
+
+func TestLogEntryDataFlow(t *testing.T) {
+	var plugin impl.PagerDuty
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "pagerduty", plugin)
+	options := tasks.PagerDutyOptions{
+		ConnectionId: 1,
+		ServiceId:    "PIKL83L",
+		ServiceName:  "DevService",
+	}
+	taskData := &tasks.PagerDutyTaskData{
+		Options: &options,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_log_entries.csv", "_raw_pagerduty_log_entries")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_notes.csv", "_raw_pagerduty_notes")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_pagerduty_incidents.csv", &models.Incident{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.LogEntry{})
+	dataflowTester.FlushTabler(&models.LogEntryAssignee{})
+	dataflowTester.FlushTabler(&models.Note{})
+	dataflowTester.FlushTabler(&models.User{})
+	dataflowTester.Subtask(tasks.ExtractLogEntriesMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractNotesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.LogEntry{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_log_entries.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		models.LogEntryAssignee{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_log_entry_assignees.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		models.Note{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_notes.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		models.User{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_users_in_log_entries.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.FlushTabler(&ticket.IncidentAssignee{})
+	dataflowTester.FlushTabler(&ticket.IssueComment{})
+	dataflowTester.Subtask(tasks.ConvertLogEntriesMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertNotesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.IssueAssignee{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_assignees_from_log_entries.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.IncidentAssignee{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/incident_assignees.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.IssueComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_comments.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+}
@@ -77,7 +77,13 @@ func (p PagerDuty) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.CollectIncidentsMeta,
 		tasks.ExtractIncidentsMeta,
+		tasks.CollectLogEntriesMeta,
+		tasks.ExtractLogEntriesMeta,
+		tasks.CollectNotesMeta,
+		tasks.ExtractNotesMeta,
 		tasks.ConvertIncidentsMeta,
+		tasks.ConvertLogEntriesMeta,
+		tasks.ConvertNotesMeta,
 		tasks.ConvertServicesMeta,
 	}
 }
@@ -88,6 +94,9 @@ func (p PagerDuty) GetTablesInfo() []dal.Tabler {
 		&models.Incident{},
 		&models.User{},
 		&models.Assignment{},
+		&models.LogEntry{},
+		&models.LogEntryAssignee{},
+		&models.Note{},
 		&models.PagerDutyConnection{},
 		&models.PagerdutyScopeConfig{},
 	}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	LogEntryTypeTrigger        = "trigger_log_entry"
+	LogEntryTypeAcknowledge    = "acknowledge_log_entry"
+	LogEntryTypeUnacknowledge  = "unacknowledge_log_entry"
+	LogEntryTypeAssign         = "assign_log_entry"
+	LogEntryTypeEscalate       = "escalate_log_entry"
+	LogEntryTypeResolve        = "resolve_log_entry"
+	LogEntryTypePriorityChange = "priority_change_log_entry"
+)
+
+type LogEntry struct {
+	common.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	Id             string `gorm:"primaryKey;type:varchar(100)"`
+	IncidentNumber int    `gorm:"index"`
+	Type           string `gorm:"type:varchar(100)"`
+	Summary        string
+	AgentId        string `gorm:"type:varchar(100)"`
+	AgentType      string `gorm:"type:varchar(100)"`
+	AgentName      string `gorm:"type:varchar(255)"`
+	ChannelType    string `gorm:"type:varchar(100)"`
+	Priority       string `gorm:"type:varchar(255)"`
+	CreatedDate    time.Time
+}
+
+func (LogEntry) TableName() string {
+	return "_tool_pagerduty_log_entries"
+}
+
+// LogEntryAssignee is a user assigned by an assign or escalate log entry
+type LogEntryAssignee struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	LogEntryId   string `gorm:"primaryKey;type:varchar(100)"`
+	UserId       string `gorm:"primaryKey;type:varchar(100)"`
+	UserName     string `gorm:"type:varchar(255)"`
+}
+
+func (LogEntryAssignee) TableName() string {
+	return "_tool_pagerduty_log_entry_assignees"
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/migrationscripts/archived"
+)
+
+type addLogEntryAssignees struct{}
+
+func (*addLogEntryAssignees) Up(baseRes context.BasicRes) errors.Error {
+	db := baseRes.GetDal()
+	err := migrationhelper.AutoMigrateTables(baseRes, &archived.LogEntryAssignee{})
+	if err != nil {
+		return err
+	}
+	// the assignees are re-extracted from the raw log entries by the next collection
+	return db.DropColumns(archived.LogEntry{}.TableName(), "assignee_ids", "assignee_names")
+}
+
+func (*addLogEntryAssignees) Down(baseRes context.BasicRes) errors.Error {
+	err := baseRes.GetDal().DropTables(&archived.LogEntryAssignee{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(baseRes, &archived.LogEntry{})
+}
+
+func (*addLogEntryAssignees) Version() uint64 {
+	return 20261025000002
+}
+
+func (*addLogEntryAssignees) Name() string {
+	return "move the assignees of log entries into _tool_pagerduty_log_entry_assignees"
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type LogEntry struct {
+	archived.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	Id             string `gorm:"primaryKey;type:varchar(100)"`
+	IncidentNumber int    `gorm:"index"`
+	Type           string `gorm:"type:varchar(100)"`
+	Summary        string
+	AgentId        string `gorm:"type:varchar(100)"`
+	AgentType      string `gorm:"type:varchar(100)"`
+	AgentName      string `gorm:"type:varchar(255)"`
+	ChannelType    string `gorm:"type:varchar(100)"`
+	AssigneeIds    string
+	AssigneeNames  string
+	Priority       string `gorm:"type:varchar(255)"`
+	CreatedDate    time.Time
+}
+
+func (LogEntry) TableName() string {
+	return "_tool_pagerduty_log_entries"
+}
+
+type Note struct {
+	archived.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	Id             string `gorm:"primaryKey;type:varchar(100)"`
+	IncidentNumber int    `gorm:"index"`
+	UserId         string `gorm:"type:varchar(100)"`
+	UserName       string `gorm:"type:varchar(255)"`
+	Content        string
+	CreatedDate    time.Time
+}
+
+type LogEntryAssignee struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	LogEntryId   string `gorm:"primaryKey;type:varchar(100)"`
+	UserId       string `gorm:"primaryKey;type:varchar(100)"`
+	UserName     string `gorm:"type:varchar(255)"`
+}
+
+func (LogEntryAssignee) TableName() string {
+	return "_tool_pagerduty_log_entry_assignees"
+}
+
+func (Note) TableName() string {
+	return "_tool_pagerduty_notes"
+}
@@ -34,5 +34,8 @@ func All() []plugin.MigrationScript {
 		new(addIncidentPriority),
 		new(addPagerDutyScopeConfig20231214),
 		new(addPagerDutyScopeConfig20240614),
+		new(addLogEntriesAndNotes),
+		new(addLogEntryAssignees),
+		new(addIncidentRules),
 	}
 }
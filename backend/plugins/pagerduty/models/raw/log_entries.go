@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raw
+
+import "time"
+
+type LogEntry struct {
+	// Agent corresponds to the JSON schema field "agent".
+	Agent *LogEntryReference `json:"agent,omitempty"`
+
+	// Assignees corresponds to the JSON schema field "assignees".
+	Assignees []LogEntryReference `json:"assignees,omitempty"`
+
+	// Channel corresponds to the JSON schema field "channel".
+	Channel *LogEntryChannel `json:"channel,omitempty"`
+
+	// CreatedAt corresponds to the JSON schema field "created_at".
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Priority corresponds to the JSON schema field "priority".
+	Priority *LogEntryReference `json:"priority,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type LogEntryReference struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type LogEntryChannel struct {
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
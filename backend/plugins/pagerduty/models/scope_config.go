@@ -19,10 +19,19 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
 type PagerdutyScopeConfig struct {
 	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	// IncidentRules normalizes severity, affected service and customer impact of the incidents of the service
+	IncidentRules *api.IncidentNormalizationRules `mapstructure:"incidentRules,omitempty" json:"incidentRules" gorm:"type:json;serializer:json"`
+}
+
+var _ api.IncidentRulesScopeConfig = (*PagerdutyScopeConfig)(nil)
+
+func (p PagerdutyScopeConfig) GetIncidentRules() *api.IncidentNormalizationRules {
+	return p.IncidentRules
 }
 
 func (p PagerdutyScopeConfig) TableName() string {
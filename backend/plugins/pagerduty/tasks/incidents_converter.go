@@ -132,16 +132,23 @@ func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 }
 
 func getStatus(incident *models.Incident) string {
-	if incident.Status == models.IncidentStatusTriggered {
-		return ticket.TODO
+	status := getStdStatus(incident.Status)
+	if status == "" {
+		panic("unknown incident status encountered")
 	}
-	if incident.Status == models.IncidentStatusAcknowledged {
+	return status
+}
+
+func getStdStatus(status models.IncidentStatus) string {
+	switch status {
+	case models.IncidentStatusTriggered:
+		return ticket.TODO
+	case models.IncidentStatusAcknowledged:
 		return ticket.IN_PROGRESS
-	}
-	if incident.Status == models.IncidentStatusResolved {
+	case models.IncidentStatusResolved:
 		return ticket.DONE
 	}
-	panic("unknown incident status encountered")
+	return ""
 }
 
 func getTimes(incident *models.Incident) (*uint, *time.Time) {
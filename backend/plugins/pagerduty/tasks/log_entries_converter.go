@@ -0,0 +1,206 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertLogEntries
+
+var logEntryStatuses = map[string]models.IncidentStatus{
+	models.LogEntryTypeTrigger:       models.IncidentStatusTriggered,
+	models.LogEntryTypeAcknowledge:   models.IncidentStatusAcknowledged,
+	models.LogEntryTypeUnacknowledge: models.IncidentStatusTriggered,
+	models.LogEntryTypeResolve:       models.IncidentStatusResolved,
+}
+
+// incidentTimeline keeps the latest values of an incident while walking through its log entries
+type incidentTimeline struct {
+	number        int
+	status        models.IncidentStatus
+	assigneeIds   string
+	assigneeNames string
+	priority      string
+	responders    map[string]bool
+}
+
+func ConvertLogEntries(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	assignees, err := loadLogEntryAssignees(db, data.Options)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select("le.*"),
+		dal.From("_tool_pagerduty_log_entries AS le"),
+		dal.Join(`JOIN _tool_pagerduty_incidents AS pi ON pi.number = le.incident_number AND pi.connection_id = le.connection_id`),
+		dal.Where("le.connection_id = ? AND pi.service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+		dal.Orderby("le.incident_number, le.created_date"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.Incident{})
+	logEntryIdGen := didgen.NewDomainIdGenerator(&models.LogEntry{})
+	timeline := &incidentTimeline{}
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_LOG_ENTRIES_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.LogEntry{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			logEntry := inputRow.(*models.LogEntry)
+			if timeline.number != logEntry.IncidentNumber {
+				timeline = &incidentTimeline{number: logEntry.IncidentNumber, responders: map[string]bool{}}
+			}
+			incidentId := incidentIdGen.Generate(data.Options.ConnectionId, logEntry.IncidentNumber)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: logEntryIdGen.Generate(data.Options.ConnectionId, logEntry.Id),
+				},
+				IssueId:     incidentId,
+				AuthorId:    logEntry.AgentId,
+				AuthorName:  logEntry.AgentName,
+				CreatedDate: logEntry.CreatedDate,
+			}
+			var result []interface{}
+			var responders []responder
+			switch logEntry.Type {
+			case models.LogEntryTypeTrigger, models.LogEntryTypeAcknowledge, models.LogEntryTypeUnacknowledge, models.LogEntryTypeResolve:
+				status := logEntryStatuses[logEntry.Type]
+				changelog.FieldId = "status"
+				changelog.FieldName = "status"
+				changelog.OriginalFromValue = string(timeline.status)
+				changelog.OriginalToValue = string(status)
+				if timeline.status != "" {
+					changelog.FromValue = getStdStatus(timeline.status)
+				}
+				changelog.ToValue = getStdStatus(status)
+				timeline.status = status
+				if logEntry.AgentType == "user_reference" && logEntry.Type != models.LogEntryTypeTrigger {
+					responders = append(responders, responder{logEntry.AgentId, logEntry.AgentName})
+				}
+			case models.LogEntryTypeAssign, models.LogEntryTypeEscalate:
+				changelog.FieldId = "assignee"
+				changelog.FieldName = "assignee"
+				if logEntry.Type == models.LogEntryTypeEscalate {
+					changelog.FieldId = "escalation"
+					changelog.FieldName = "escalation"
+				}
+				var assigneeIds, assigneeNames []string
+				for _, assignee := range assignees[logEntry.Id] {
+					assigneeIds = append(assigneeIds, assignee.UserId)
+					assigneeNames = append(assigneeNames, assignee.UserName)
+					responders = append(responders, responder{assignee.UserId, assignee.UserName})
+				}
+				changelog.OriginalFromValue = timeline.assigneeNames
+				changelog.OriginalToValue = strings.Join(assigneeNames, ",")
+				changelog.FromValue = timeline.assigneeIds
+				changelog.ToValue = strings.Join(assigneeIds, ",")
+				timeline.assigneeIds = changelog.ToValue
+				timeline.assigneeNames = changelog.OriginalToValue
+			case models.LogEntryTypePriorityChange:
+				changelog.FieldId = "priority"
+				changelog.FieldName = "priority"
+				changelog.OriginalFromValue = timeline.priority
+				changelog.OriginalToValue = logEntry.Priority
+				changelog.FromValue = timeline.priority
+				changelog.ToValue = logEntry.Priority
+				timeline.priority = logEntry.Priority
+			default:
+				// notifications, annotations and so on are not changes of the incident
+				return nil, nil
+			}
+			result = append(result, changelog)
+			// everyone who has been assigned to, acknowledged or resolved the incident is a responder
+			for _, r := range responders {
+				if timeline.responders[r.id] {
+					continue
+				}
+				timeline.responders[r.id] = true
+				result = append(result,
+					&ticket.IssueAssignee{
+						IssueId:      incidentId,
+						AssigneeId:   r.id,
+						AssigneeName: r.name,
+					},
+					&ticket.IncidentAssignee{
+						IncidentId:   incidentId,
+						AssigneeId:   r.id,
+						AssigneeName: r.name,
+					},
+				)
+			}
+			return result, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// loadLogEntryAssignees returns the assignees of the log entries of the service by log entry id
+func loadLogEntryAssignees(db dal.Dal, options *PagerDutyOptions) (map[string][]models.LogEntryAssignee, errors.Error) {
+	var assignees []models.LogEntryAssignee
+	err := db.All(&assignees,
+		dal.Select("la.*"),
+		dal.From("_tool_pagerduty_log_entry_assignees AS la"),
+		dal.Join(`JOIN _tool_pagerduty_log_entries AS le ON le.id = la.log_entry_id AND le.connection_id = la.connection_id`),
+		dal.Join(`JOIN _tool_pagerduty_incidents AS pi ON pi.number = le.incident_number AND pi.connection_id = le.connection_id`),
+		dal.Where("la.connection_id = ? AND pi.service_id = ?", options.ConnectionId, options.ServiceId),
+		dal.Orderby("la.log_entry_id, la.user_id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	assigneesByLogEntry := make(map[string][]models.LogEntryAssignee)
+	for _, assignee := range assignees {
+		assigneesByLogEntry[assignee.LogEntryId] = append(assigneesByLogEntry[assignee.LogEntryId], assignee)
+	}
+	return assigneesByLogEntry, nil
+}
+
+type responder struct {
+	id   string
+	name string
+}
+
+var ConvertLogEntriesMeta = plugin.SubTaskMeta{
+	Name:             "convertLogEntries",
+	EntryPoint:       ConvertLogEntries,
+	EnabledByDefault: true,
+	Description:      "Convert incident log entries into domain layer table issue_changelogs, issue_assignees and incident_assignees",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
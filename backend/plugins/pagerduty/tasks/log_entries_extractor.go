@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractLogEntries
+
+func ExtractLogEntries(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_LOG_ENTRIES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &simplifiedIncident{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			logEntryRaw := &raw.LogEntry{}
+			err = errors.Convert(json.Unmarshal(row.Data, logEntryRaw))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, 1)
+			logEntry := &models.LogEntry{
+				ConnectionId:   data.Options.ConnectionId,
+				Id:             *logEntryRaw.Id,
+				IncidentNumber: input.Number,
+				Type:           resolve(logEntryRaw.Type),
+				Summary:        resolve(logEntryRaw.Summary),
+				CreatedDate:    *logEntryRaw.CreatedAt,
+			}
+			if logEntryRaw.Agent != nil {
+				logEntry.AgentId = resolve(logEntryRaw.Agent.Id)
+				logEntry.AgentType = resolve(logEntryRaw.Agent.Type)
+				logEntry.AgentName = resolve(logEntryRaw.Agent.Summary)
+				if user := extractUserReference(data.Options.ConnectionId, logEntryRaw.Agent); user != nil {
+					results = append(results, user)
+				}
+			}
+			if logEntryRaw.Channel != nil {
+				logEntry.ChannelType = resolve(logEntryRaw.Channel.Type)
+			}
+			if logEntryRaw.Priority != nil {
+				logEntry.Priority = resolve(logEntryRaw.Priority.Summary)
+			}
+			for _, assignee := range logEntryRaw.Assignees {
+				results = append(results, &models.LogEntryAssignee{
+					ConnectionId: data.Options.ConnectionId,
+					LogEntryId:   logEntry.Id,
+					UserId:       resolve(assignee.Id),
+					UserName:     resolve(assignee.Summary),
+				})
+				if user := extractUserReference(data.Options.ConnectionId, &assignee); user != nil {
+					results = append(results, user)
+				}
+			}
+			results = append(results, logEntry)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// extractUserReference returns the user if the reference points to a user, nil for services, integrations and so on
+func extractUserReference(connectionId uint64, ref *raw.LogEntryReference) *models.User {
+	if ref.Id == nil || resolve(ref.Type) != "user_reference" {
+		return nil
+	}
+	return &models.User{
+		ConnectionId: connectionId,
+		Id:           *ref.Id,
+		Url:          resolve(ref.HtmlUrl),
+		Name:         resolve(ref.Summary),
+	}
+}
+
+var ExtractLogEntriesMeta = plugin.SubTaskMeta{
+	Name:             "extractLogEntries",
+	EntryPoint:       ExtractLogEntries,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty incident log entries",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
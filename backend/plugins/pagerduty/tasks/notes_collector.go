@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_NOTES_TABLE = "pagerduty_notes"
+
+var _ plugin.SubTaskEntryPoint = CollectNotes
+
+type collectedNotes struct {
+	Notes []json.RawMessage `json:"notes"`
+}
+
+func CollectNotes(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	collector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_NOTES_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+	iterator, err := buildIncidentIterator(db, data, collector)
+	if err != nil {
+		return err
+	}
+	err = collector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.Client,
+		Input:       iterator,
+		UrlTemplate: "incidents/{{ .Input.Number }}/notes",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			rawResult := collectedNotes{}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return rawResult.Notes, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectNotesMeta = plugin.SubTaskMeta{
+	Name:             "collectNotes",
+	EntryPoint:       CollectNotes,
+	EnabledByDefault: true,
+	Description:      "Collect PagerDuty incident notes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
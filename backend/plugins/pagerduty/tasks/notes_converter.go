@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertNotes
+
+func ConvertNotes(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("pn.*"),
+		dal.From("_tool_pagerduty_notes AS pn"),
+		dal.Join(`JOIN _tool_pagerduty_incidents AS pi ON pi.number = pn.incident_number AND pi.connection_id = pn.connection_id`),
+		dal.Where("pn.connection_id = ? AND pi.service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.Incident{})
+	noteIdGen := didgen.NewDomainIdGenerator(&models.Note{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_NOTES_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.Note{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			note := inputRow.(*models.Note)
+			return []interface{}{
+				&ticket.IssueComment{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: noteIdGen.Generate(data.Options.ConnectionId, note.Id),
+					},
+					IssueId:     incidentIdGen.Generate(data.Options.ConnectionId, note.IncidentNumber),
+					Body:        note.Content,
+					AccountId:   note.UserId,
+					CreatedDate: note.CreatedDate,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+var ConvertNotesMeta = plugin.SubTaskMeta{
+	Name:             "convertNotes",
+	EntryPoint:       ConvertNotes,
+	EnabledByDefault: true,
+	Description:      "Convert incident notes into domain layer table issue_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
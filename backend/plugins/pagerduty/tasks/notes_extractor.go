@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractNotes
+
+func ExtractNotes(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_NOTES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &simplifiedIncident{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			noteRaw := &raw.Note{}
+			err = errors.Convert(json.Unmarshal(row.Data, noteRaw))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, 1)
+			note := &models.Note{
+				ConnectionId:   data.Options.ConnectionId,
+				Id:             *noteRaw.Id,
+				IncidentNumber: input.Number,
+				Content:        resolve(noteRaw.Content),
+				CreatedDate:    *noteRaw.CreatedAt,
+			}
+			if noteRaw.User != nil {
+				note.UserId = resolve(noteRaw.User.Id)
+				note.UserName = resolve(noteRaw.User.Summary)
+				if user := extractUserReference(data.Options.ConnectionId, noteRaw.User); user != nil {
+					results = append(results, user)
+				}
+			}
+			results = append(results, note)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractNotesMeta = plugin.SubTaskMeta{
+	Name:             "extractNotes",
+	EntryPoint:       ExtractNotes,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty incident notes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
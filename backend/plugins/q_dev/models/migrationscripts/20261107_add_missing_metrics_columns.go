@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addMissingMetricsColumns)(nil)
+
+type addMissingMetricsColumns struct{}
+
+// Up adds the metrics columns one by one where addMissingMetrics could not, e.g. on sqlite which
+// does not accept multiple columns in one ALTER TABLE statement
+func (*addMissingMetricsColumns) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	columns := []string{
+		"chat_ai_code_lines",
+		"chat_messages_interacted",
+		"chat_messages_sent",
+		"code_fix_acceptance_event_count",
+		"code_fix_accepted_lines",
+		"code_fix_generated_lines",
+		"code_fix_generation_event_count",
+		"code_review_failed_event_count",
+		"dev_acceptance_event_count",
+		"dev_accepted_lines",
+		"dev_generated_lines",
+		"dev_generation_event_count",
+		"doc_generation_accepted_file_updates",
+		"doc_generation_accepted_files_creations",
+		"doc_generation_accepted_line_additions",
+		"doc_generation_accepted_line_updates",
+		"doc_generation_event_count",
+		"doc_generation_rejected_file_creations",
+		"doc_generation_rejected_file_updates",
+		"doc_generation_rejected_line_additions",
+		"doc_generation_rejected_line_updates",
+		"test_generation_accepted_lines",
+		"test_generation_accepted_tests",
+		"test_generation_event_count",
+		"test_generation_generated_lines",
+		"test_generation_generated_tests",
+		"transformation_event_count",
+		"transformation_lines_generated",
+		"transformation_lines_ingested",
+	}
+	for _, column := range columns {
+		if db.HasColumn("_tool_q_dev_user_data", column) {
+			continue
+		}
+		err := db.Exec(fmt.Sprintf("ALTER TABLE _tool_q_dev_user_data ADD COLUMN %s INT DEFAULT 0", column))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to add column %s", column))
+		}
+	}
+	return nil
+}
+
+// Down keeps the columns since they are expected by addMissingMetrics which might have created them already
+func (*addMissingMetricsColumns) Down(basicRes context.BasicRes) errors.Error {
+	return nil
+}
+
+func (*addMissingMetricsColumns) Version() uint64 {
+	return 20261107000001
+}
+
+func (*addMissingMetricsColumns) Name() string {
+	return "add the QDevUserData metrics columns missed by add_missing_metrics"
+}
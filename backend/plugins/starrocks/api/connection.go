@@ -52,5 +52,8 @@ type StarRocksPipelinePlan [][]struct {
 		OrderBy      map[string]string `json:"order_by"`
 		Extra        string            `json:"extra"`
 		DomainLayer  string            `json:"domain_layer"`
+		// export the rows updated since the previous run only
+		Incremental      bool `json:"incremental"`
+		PropagateDeletes bool `json:"propagate_deletes"`
 	} `json:"options"`
 }
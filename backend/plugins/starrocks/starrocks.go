@@ -50,23 +50,27 @@ func main() {
 	_ = cmd.MarkFlagRequired("batch_size")
 	extra := cmd.Flags().StringP("extra", "e", "", "StarRocks create table sql extra")
 	orderBy := cmd.Flags().StringP("order_by", "o", "", "Source tables order by, default is primary key")
+	incremental := cmd.Flags().Bool("incremental", false, "Export the rows updated since the previous run only")
+	propagateDeletes := cmd.Flags().Bool("propagate_deletes", false, "Delete rows from StarRocks if they were deleted from the source, works with incremental")
 	timeAfter := cmd.Flags().StringP("time_after", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
-			"source_type":   sourceType,
-			"source_dsn":    sourceDsn,
-			"update_column": updateColumn,
-			"host":          host,
-			"port":          port,
-			"user":          user,
-			"password":      password,
-			"database":      database,
-			"be_host":       beHost,
-			"be_port":       bePort,
-			"tables":        tables,
-			"batch_size":    batchSize,
-			"extra":         extra,
-			"order_by":      orderBy,
+			"source_type":       sourceType,
+			"source_dsn":        sourceDsn,
+			"update_column":     updateColumn,
+			"host":              host,
+			"port":              port,
+			"user":              user,
+			"password":          password,
+			"database":          database,
+			"be_host":           beHost,
+			"be_port":           bePort,
+			"tables":            tables,
+			"batch_size":        batchSize,
+			"extra":             extra,
+			"order_by":          orderBy,
+			"incremental":       incremental,
+			"propagate_deletes": propagateDeletes,
 		}, *timeAfter)
 	}
 	runner.RunCmd(cmd)
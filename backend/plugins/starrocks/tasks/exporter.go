@@ -0,0 +1,220 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+	"github.com/apache/incubator-devlake/plugins/starrocks/utils"
+)
+
+var _ exporthelper.Exporter = (*starrocksExporter)(nil)
+
+// starrocksExporter exports tables incrementally to StarRocks, tables with primary keys are created with the
+// Primary Key model so rows could be upserted and deleted by stream load
+type starrocksExporter struct {
+	config *StarRocksConfig
+	db     dal.Dal
+}
+
+func newStarrocksExporter(config *StarRocksConfig, db dal.Dal) *starrocksExporter {
+	return &starrocksExporter{config: config, db: db}
+}
+
+func (e *starrocksExporter) Name() string {
+	return fmt.Sprintf("starrocks:%s:%d/%s", e.config.Host, e.config.Port, e.config.Database)
+}
+
+func (e *starrocksExporter) columnDefinition(c *exporthelper.Column) string {
+	dataType := utils.GetStarRocksDataType(c.Type)
+	if c.PrimaryKey {
+		if dataType == "string" {
+			dataType = "varchar(255)"
+		}
+		return fmt.Sprintf("`%s` %s NOT NULL", c.Name, dataType)
+	}
+	return fmt.Sprintf("`%s` %s", c.Name, dataType)
+}
+
+func (e *starrocksExporter) createTable(schema *exporthelper.TableSchema) errors.Error {
+	columns := make([]string, len(schema.Columns))
+	for i, c := range schema.Columns {
+		columns[i] = e.columnDefinition(c)
+	}
+	var keys []string
+	for _, pk := range schema.PrimaryKeys() {
+		keys = append(keys, fmt.Sprintf("`%s`", pk.Name))
+	}
+	replicationNum := os.Getenv("STARROCKS_REPLICAS_NUM")
+	if replicationNum == "" {
+		replicationNum = "1"
+	}
+	var extra string
+	if len(keys) > 0 {
+		extra = fmt.Sprintf(
+			`PRIMARY KEY (%s) DISTRIBUTED BY HASH(%s) PROPERTIES("replication_num" = "%s")`,
+			strings.Join(keys, ", "), strings.Join(keys, ", "), replicationNum,
+		)
+	} else {
+		extra = fmt.Sprintf(`DISTRIBUTED BY HASH(`+"`%s`"+`) PROPERTIES("replication_num" = "%s")`, schema.Columns[0].Name, replicationNum)
+	}
+	if v, ok := e.config.Extra[schema.Name]; ok {
+		extra = v
+	}
+	return e.db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` ( %s ) %s", schema.Name, strings.Join(columns, ","), extra))
+}
+
+func (e *starrocksExporter) ResetTable(schema *exporthelper.TableSchema) errors.Error {
+	err := e.db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS `%s`", schema.Name))
+	if err != nil {
+		return err
+	}
+	return e.createTable(schema)
+}
+
+// SyncSchema adds the missing columns in one statement since StarRocks allows only one schema change at a time
+func (e *starrocksExporter) SyncSchema(schema *exporthelper.TableSchema) errors.Error {
+	err := e.createTable(schema)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	columnMetas, err := e.db.GetColumns(dal.DefaultTabler{Name: schema.Name}, nil)
+	if err != nil {
+		return err
+	}
+	for _, cm := range columnMetas {
+		existing[cm.Name()] = true
+	}
+	var missing []string
+	for _, c := range schema.Columns {
+		if !existing[c.Name] {
+			missing = append(missing, e.columnDefinition(c))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return e.db.Exec(fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN (%s)", schema.Name, strings.Join(missing, ", ")))
+}
+
+func (e *starrocksExporter) load(schema *exporthelper.TableSchema, rows []exporthelper.Row, headers map[string]string) errors.Error {
+	data := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		record := make(map[string]interface{}, len(row))
+		for name, value := range row {
+			if t, ok := value.(time.Time); ok {
+				value = t.UTC().Format("2006-01-02 15:04:05")
+			}
+			record[name] = value
+		}
+		data[i] = record
+	}
+	statusCode, result, b, err := streamLoad(e.config, schema.Name, data, headers)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to stream load %s", schema.Name))
+	}
+	if statusCode != http.StatusOK || result["Status"] != "Success" {
+		return errors.Default.New(fmt.Sprintf("failed to stream load %s: [%d] %s", schema.Name, statusCode, string(b)))
+	}
+	return nil
+}
+
+func (e *starrocksExporter) Upsert(schema *exporthelper.TableSchema, rows []exporthelper.Row) errors.Error {
+	return e.load(schema, rows, nil)
+}
+
+func (e *starrocksExporter) ScanKeys(schema *exporthelper.TableSchema, batchSize int, handle func(keys []exporthelper.Row) errors.Error) errors.Error {
+	pks := schema.PrimaryKeys()
+	if len(pks) == 0 {
+		return nil
+	}
+	params := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		params[i] = dal.ClauseColumn{Name: pk.Name}
+	}
+	cursor, err := e.db.Cursor(
+		dal.Select(strings.TrimSuffix(strings.Repeat("?, ", len(pks)), ", "), params...),
+		dal.From(schema.Name),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	keys := make([]exporthelper.Row, 0, batchSize)
+	for cursor.Next() {
+		values := make([]interface{}, len(pks))
+		pointers := make([]interface{}, len(pks))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if e := cursor.Scan(pointers...); e != nil {
+			return errors.Convert(e)
+		}
+		key := make(exporthelper.Row, len(pks))
+		for i, pk := range pks {
+			key[pk.Name] = exporthelper.NormalizeValue(pk.Kind, values[i])
+		}
+		keys = append(keys, key)
+		if len(keys) == batchSize {
+			err = handle(keys)
+			if err != nil {
+				return err
+			}
+			keys = make([]exporthelper.Row, 0, batchSize)
+		}
+	}
+	if len(keys) > 0 {
+		return handle(keys)
+	}
+	return nil
+}
+
+// Delete removes rows by stream loading the keys with the __op column set to 1, which works for Primary Key tables
+func (e *starrocksExporter) Delete(schema *exporthelper.TableSchema, keys []exporthelper.Row) errors.Error {
+	pks := schema.PrimaryKeys()
+	if len(keys) == 0 || len(pks) == 0 {
+		return nil
+	}
+	columns := make([]string, 0, len(pks)+1)
+	for _, pk := range pks {
+		columns = append(columns, pk.Name)
+	}
+	columns = append(columns, "__op")
+	rows := make([]exporthelper.Row, len(keys))
+	for i, key := range keys {
+		row := make(exporthelper.Row, len(pks)+1)
+		for _, pk := range pks {
+			row[pk.Name] = exporthelper.NormalizeValue(pk.Kind, key[pk.Name])
+		}
+		row["__op"] = 1
+		rows[i] = row
+	}
+	return e.load(schema, rows, map[string]string{"columns": strings.Join(columns, ",")})
+}
+
+func (e *starrocksExporter) Close() errors.Error {
+	return nil
+}
@@ -40,4 +40,9 @@ type StarRocksConfig struct {
 	OrderBy      map[string]string      `mapstructure:"order_by"`
 	DomainLayer  string                 `mapstructure:"domain_layer"`
 	Extra        map[string]string
+	// Incremental exports only the rows updated since the previous export by the UpdateColumn (updated_at by default)
+	// into tables of the Primary Key model, instead of copying whole tables
+	Incremental bool `mapstructure:"incremental"`
+	// PropagateDeletes removes rows from StarRocks if they were deleted from the source, only for Incremental
+	PropagateDeletes bool `mapstructure:"propagate_deletes"`
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
 	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"github.com/apache/incubator-devlake/plugins/starrocks/utils"
 
@@ -99,6 +100,10 @@ func ExportData(c plugin.SubTaskContext) errors.Error {
 	}
 	defer sqlStarrocksDB.Close()
 
+	if config.Incremental {
+		return exportIncrementally(c, db, starrocksDb, starrocksTables)
+	}
+
 	for _, table := range starrocksTables {
 		select {
 		case <-c.GetContext().Done():
@@ -131,6 +136,33 @@ func ExportData(c plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
+// export the tables by the watermarks, new columns and deleted rows are propagated as well
+func exportIncrementally(c plugin.SubTaskContext, db dal.Dal, starrocksDb dal.Dal, tables []string) errors.Error {
+	config := c.GetData().(*StarRocksConfig)
+	exporter := newStarrocksExporter(config, starrocksDb)
+	defer exporter.Close()
+	fullSync := false
+	if syncPolicy := c.TaskContext().SyncPolicy(); syncPolicy != nil {
+		fullSync = syncPolicy.FullSync
+	}
+	for _, table := range tables {
+		tableConfig := config.TableConfigs[table]
+		_, err := exporthelper.ExportTable(c, db, exporter, table, &exporthelper.TableExportOptions{
+			UpdateColumn:     config.UpdateColumn,
+			IncludedColumns:  tableConfig.IncludedColumns,
+			ExcludedColumns:  tableConfig.ExcludedColumns,
+			Where:            tableConfig.Where,
+			BatchSize:        config.BatchSize,
+			PropagateDeletes: config.PropagateDeletes,
+			FullSync:         fullSync,
+		})
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to export %s to starrocks", table))
+		}
+	}
+	return nil
+}
+
 // create temp table for dealing with some complex logic
 func createTmpTableInStarrocks(dc *DataConfigParams) (map[string]string, string, bool, error) {
 	logger := dc.Ctx.GetLogger()
@@ -381,7 +413,21 @@ func copyDataToDst(dc *DataConfigParams, columnMap map[string]string, orderBy st
 func putBatchData(c plugin.SubTaskContext, starrocksTmpTable, table string, data []map[string]interface{}, config *StarRocksConfig, offset int) error {
 	logger := c.GetLogger()
 	// insert data to tmp table
-	loadURL := fmt.Sprintf("http://%s:%d/api/%s/%s/_stream_load", config.BeHost, config.BePort, config.Database, starrocksTmpTable)
+	_, result, b, err := streamLoad(config, starrocksTmpTable, data, nil)
+	if err != nil {
+		return err
+	}
+	if result["Status"] != "Success" {
+		logger.Error(nil, "load %s failed: %s", table, string(b))
+	} else {
+		logger.Debug("load %s success: %s, limit: %d, offset: %d", table, b, config.BatchSize, offset)
+	}
+	return nil
+}
+
+// streamLoad puts the data to the table through the stream load api of the backend
+func streamLoad(config *StarRocksConfig, table string, data interface{}, extraHeaders map[string]string) (int, map[string]interface{}, []byte, error) {
+	loadURL := fmt.Sprintf("http://%s:%d/api/%s/%s/_stream_load", config.BeHost, config.BePort, config.Database, table)
 	headers := map[string]string{
 		"format":            "json",
 		"strip_outer_array": "true",
@@ -389,9 +435,12 @@ func putBatchData(c plugin.SubTaskContext, starrocksTmpTable, table string, data
 		"ignore_json_size":  "true",
 		"Connection":        "close",
 	}
+	for k, v := range extraHeaders {
+		headers[k] = v
+	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
-		return err
+		return 0, nil, nil, err
 	}
 	client := http.Client{
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
@@ -400,7 +449,7 @@ func putBatchData(c plugin.SubTaskContext, starrocksTmpTable, table string, data
 	}
 	req, err := http.NewRequest(http.MethodPut, loadURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return err
+		return 0, nil, nil, err
 	}
 	req.SetBasicAuth(config.User, config.Password)
 	for k, v := range headers {
@@ -408,55 +457,45 @@ func putBatchData(c plugin.SubTaskContext, starrocksTmpTable, table string, data
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return 0, nil, nil, err
 	}
 	defer resp.Body.Close()
-	var b []byte
 
-	if resp.StatusCode == 307 {
+	// the frontend redirects the request to one of the backends
+	if resp.StatusCode == http.StatusTemporaryRedirect {
 		var location *url.URL
 		location, err = resp.Location()
 		if err != nil {
-			return err
+			return 0, nil, nil, err
 		}
 		req, err = http.NewRequest(http.MethodPut, location.String(), bytes.NewBuffer(jsonData))
 		if err != nil {
-			return err
+			return 0, nil, nil, err
 		}
 		req.SetBasicAuth(config.User, config.Password)
 		for k, v := range headers {
 			req.Header.Set(k, v)
 		}
-		respRetry, err := client.Do(req)
-		if err != nil {
-			return err
-		}
-		defer respRetry.Body.Close()
-		b, err = io.ReadAll(respRetry.Body)
-		if err != nil {
-			return err
-		}
-	} else {
-		b, err = io.ReadAll(resp.Body)
+		resp, err = client.Do(req)
 		if err != nil {
-			return err
+			return 0, nil, nil, err
 		}
+		defer resp.Body.Close()
 	}
-
-	var result map[string]interface{}
-	err = json.Unmarshal(b, &result)
+	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return 0, nil, nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		logger.Error(nil, "[%s]: %s", resp.StatusCode, string(b))
+		return resp.StatusCode, nil, b, fmt.Errorf("stream load to %s responded %d: %s", table, resp.StatusCode, string(b))
 	}
-	if result["Status"] != "Success" {
-		logger.Error(nil, "load %s failed: %s", table, string(b))
-	} else {
-		logger.Debug("load %s success: %s, limit: %d, offset: %d", table, b, config.BatchSize, offset)
+
+	var result map[string]interface{}
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		return 0, nil, nil, err
 	}
-	return nil
+	return resp.StatusCode, result, b, nil
 }
 
 // get db instance
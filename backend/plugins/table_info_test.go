@@ -30,7 +30,9 @@ import (
 	customize "github.com/apache/incubator-devlake/plugins/customize/impl"
 	dbt "github.com/apache/incubator-devlake/plugins/dbt/impl"
 	dora "github.com/apache/incubator-devlake/plugins/dora/impl"
+	export "github.com/apache/incubator-devlake/plugins/export/impl"
 	feishu "github.com/apache/incubator-devlake/plugins/feishu/impl"
+	forecast "github.com/apache/incubator-devlake/plugins/forecast/impl"
 	gitee "github.com/apache/incubator-devlake/plugins/gitee/impl"
 	gitextractor "github.com/apache/incubator-devlake/plugins/gitextractor/impl"
 	github "github.com/apache/incubator-devlake/plugins/github/impl"
@@ -70,6 +72,7 @@ func Test_GetPluginTablesInfo(t *testing.T) {
 	checker.FeedIn("customize/models", customize.Customize{}.GetTablesInfo)
 	checker.FeedIn("dbt", dbt.Dbt{}.GetTablesInfo)
 	checker.FeedIn("dora/models", dora.Dora{}.GetTablesInfo)
+	checker.FeedIn("export", export.Export{}.GetTablesInfo)
 	checker.FeedIn("feishu/models", feishu.Feishu{}.GetTablesInfo)
 	checker.FeedIn("gitee/models", gitee.Gitee{}.GetTablesInfo)
 	checker.FeedIn("gitextractor/models", gitextractor.GitExtractor{}.GetTablesInfo)
@@ -96,6 +99,7 @@ func Test_GetPluginTablesInfo(t *testing.T) {
 	checker.FeedIn("linker/models", linker.Linker{}.GetTablesInfo)
 	checker.FeedIn("issue_trace/models", issueTrace.IssueTrace{}.GetTablesInfo)
 	checker.FeedIn("q_dev/models", q_dev.QDev{}.GetTablesInfo)
+	checker.FeedIn("forecast/models", forecast.Forecast{}.GetTablesInfo)
 	err := checker.Verify()
 	if err != nil {
 		t.Error(err)
@@ -73,8 +73,9 @@ func makePipelinePlanV200(
 			subtaskMetas,
 			scopeConfig.Entities,
 			tasks.TrelloOptions{
-				ConnectionId: connection.ID,
-				BoardId:      scope.BoardId,
+				ConnectionId:  connection.ID,
+				BoardId:       scope.BoardId,
+				ScopeConfigId: scopeConfig.ID,
 			},
 		)
 		if err != nil {
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/trello/impl"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"github.com/apache/incubator-devlake/plugins/trello/tasks"
+)
+
+func TestTrelloActionDataFlow(t *testing.T) {
+	var trello impl.Trello
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "trello", trello)
+
+	scopeConfig := &models.TrelloScopeConfig{
+		TodoListPattern:       "Backlog",
+		InProgressListPattern: "Working On|Testing|Bugs",
+		DoneListPattern:       "Done",
+	}
+	regexEnricher, err := tasks.NewRegexEnricher(scopeConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskData := &tasks.TrelloTaskData{
+		Options: &tasks.TrelloOptions{
+			ConnectionId: 1,
+			BoardId:      "6402f643d23aa9af56b28f4b",
+			ScopeConfig:  scopeConfig,
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_actions.csv", "_raw_trello_actions")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_members.csv", "_raw_trello_members")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.TrelloAction{})
+	dataflowTester.Subtask(tasks.ExtractActionMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.TrelloAction{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_trello_actions.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&models.TrelloMember{})
+	dataflowTester.Subtask(tasks.ExtractMemberMeta, taskData)
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertActionMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
@@ -30,11 +31,24 @@ func TestTrelloCardDataFlow(t *testing.T) {
 	var trello impl.Trello
 	dataflowTester := e2ehelper.NewDataFlowTester(t, "trello", trello)
 
+	scopeConfig := &models.TrelloScopeConfig{
+		TodoListPattern:       "Backlog",
+		InProgressListPattern: "Working On|Testing|Bugs",
+		DoneListPattern:       "Done",
+		IssueTypeRequirement:  "Committed to Repo",
+		IssueTypeBug:          "Flagged",
+	}
+	regexEnricher, err := tasks.NewRegexEnricher(scopeConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
 	taskData := &tasks.TrelloTaskData{
 		Options: &tasks.TrelloOptions{
 			ConnectionId: 1,
 			BoardId:      "6402f643d23aa9af56b28f4b",
+			ScopeConfig:  scopeConfig,
 		},
+		RegexEnricher: regexEnricher,
 	}
 
 	// import raw data table
@@ -42,9 +56,51 @@ func TestTrelloCardDataFlow(t *testing.T) {
 
 	// verify extraction
 	dataflowTester.FlushTabler(&models.TrelloCard{})
+	dataflowTester.FlushTabler(&models.TrelloCardLabel{})
+	dataflowTester.FlushTabler(&models.TrelloCardMember{})
 	dataflowTester.Subtask(tasks.ExtractCardMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(models.TrelloCard{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/_tool_trello_cards.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+	dataflowTester.VerifyTableWithOptions(models.TrelloCardLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_trello_card_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_members.csv", "_raw_trello_members")
+	dataflowTester.FlushTabler(&models.TrelloMember{})
+	dataflowTester.Subtask(tasks.ExtractMemberMeta, taskData)
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_boards.csv", &models.TrelloBoard{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_lists.csv", &models.TrelloList{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_labels.csv", &models.TrelloLabel{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_actions.csv", &models.TrelloAction{})
+
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertBoardMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.Board{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/boards.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.Subtask(tasks.ConvertCardMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.IssueLabel{})
+	dataflowTester.Subtask(tasks.ConvertCardLabelMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.IssueLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
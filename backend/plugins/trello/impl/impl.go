@@ -61,6 +61,9 @@ func (p Trello) GetTablesInfo() []dal.Tabler {
 		&models.TrelloLabel{},
 		&models.TrelloMember{},
 		&models.TrelloCheckItem{},
+		&models.TrelloCardLabel{},
+		&models.TrelloCardMember{},
+		&models.TrelloAction{},
 		&models.TrelloScopeConfig{},
 	}
 }
@@ -89,6 +92,15 @@ func (p Trello) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectMemberMeta,
 		tasks.ExtractMemberMeta,
+
+		tasks.CollectActionMeta,
+		tasks.ExtractActionMeta,
+
+		tasks.ConvertBoardMeta,
+		tasks.ConvertMemberMeta,
+		tasks.ConvertCardMeta,
+		tasks.ConvertCardLabelMeta,
+		tasks.ConvertActionMeta,
 	}
 }
 
@@ -120,9 +132,36 @@ func (p Trello) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err != nil {
 		return nil, err
 	}
+
+	db := taskCtx.GetDal()
+	if op.ScopeConfigId == 0 {
+		board := &models.TrelloBoard{}
+		err = db.First(board, dal.Where("connection_id = ? AND board_id = ?", op.ConnectionId, op.BoardId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find board %s", op.BoardId))
+		}
+		op.ScopeConfigId = board.ScopeConfigId
+	}
+	if op.ScopeConfig == nil && op.ScopeConfigId != 0 {
+		scopeConfig := &models.TrelloScopeConfig{}
+		err = db.First(scopeConfig, dal.Where("id = ?", op.ScopeConfigId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, errors.BadInput.Wrap(err, "fail to get scopeConfig")
+		}
+		op.ScopeConfig = scopeConfig
+	}
+	if op.ScopeConfig == nil {
+		op.ScopeConfig = new(models.TrelloScopeConfig)
+	}
+	regexEnricher, err := tasks.NewRegexEnricher(op.ScopeConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &tasks.TrelloTaskData{
-		Options:   &op,
-		ApiClient: apiClient,
+		Options:       &op,
+		ApiClient:     apiClient,
+		RegexEnricher: regexEnricher,
 	}, nil
 }
 
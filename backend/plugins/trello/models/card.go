@@ -36,6 +36,8 @@ type TrelloCard struct {
 	ShortUrl         string `gorm:"type:varchar(255)"`
 	Subscribed       bool
 	Url              string `gorm:"type:varchar(255)"`
+	Desc             string
+	Due              *time.Time
 	common.NoPKModel
 }
 
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type board20261020 struct {
+	archived.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	BoardId       string `gorm:"primaryKey;type:varchar(255)"`
+	ScopeConfigId uint64
+	Name          string `gorm:"type:varchar(255)"`
+}
+
+func (board20261020) TableName() string {
+	return "_tool_trello_boards"
+}
+
+type addBoardIdToPrimaryKey struct{}
+
+// Up transforms the table to add board_id to the primary key. connection_id alone was the primary key, which is
+// auto incremented and therefore has to be turned into a plain column before migrationhelper.TransformTable.
+// The script is not reversible since boards of the same connection can not be kept apart by connection_id alone.
+func (script *addBoardIdToPrimaryKey) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	tableName := board20261020{}.TableName()
+	switch db.Dialect() {
+	case "mysql":
+		err := db.Exec("ALTER TABLE ? MODIFY connection_id BIGINT UNSIGNED NOT NULL", dal.ClauseTable{Name: tableName})
+		if err != nil {
+			return err
+		}
+	case "postgres":
+		err := db.Exec("ALTER TABLE ? ALTER COLUMN connection_id DROP DEFAULT", dal.ClauseTable{Name: tableName})
+		if err != nil {
+			return err
+		}
+	}
+	return migrationhelper.TransformTable(
+		basicRes,
+		script,
+		tableName,
+		func(src *board20261020) (*board20261020, errors.Error) {
+			return src, nil
+		},
+	)
+}
+
+func (*addBoardIdToPrimaryKey) Version() uint64 {
+	return 20261020000001
+}
+
+func (*addBoardIdToPrimaryKey) Name() string {
+	return "add board_id to the primary key of _tool_trello_boards"
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/trello/models/migrationscripts/archived"
+)
+
+type trelloCard20261020 struct {
+	Desc string
+	Due  *time.Time
+}
+
+func (trelloCard20261020) TableName() string {
+	return "_tool_trello_cards"
+}
+
+type trelloScopeConfig20261020 struct {
+	TodoListPattern       string `gorm:"type:varchar(255)"`
+	InProgressListPattern string `gorm:"type:varchar(255)"`
+	DoneListPattern       string `gorm:"type:varchar(255)"`
+	IssueTypeRequirement  string `gorm:"type:varchar(255)"`
+	IssueTypeBug          string `gorm:"type:varchar(255)"`
+	IssueTypeIncident     string `gorm:"type:varchar(255)"`
+}
+
+func (trelloScopeConfig20261020) TableName() string {
+	return "_tool_trello_scope_configs"
+}
+
+type addTicketConversion struct{}
+
+func (*addTicketConversion) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&trelloCard20261020{},
+		&trelloScopeConfig20261020{},
+		&archived.TrelloCardLabel{},
+		&archived.TrelloCardMember{},
+		&archived.TrelloAction{},
+	)
+}
+
+func (*addTicketConversion) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.DropColumns("_tool_trello_cards", "desc", "due"); err != nil {
+		return err
+	}
+	if err := db.DropColumns("_tool_trello_scope_configs", "todo_list_pattern", "in_progress_list_pattern", "done_list_pattern", "issue_type_requirement", "issue_type_bug", "issue_type_incident"); err != nil {
+		return err
+	}
+	return db.DropTables(&archived.TrelloCardLabel{}, &archived.TrelloCardMember{}, &archived.TrelloAction{})
+}
+
+func (*addTicketConversion) Version() uint64 {
+	return 20261020000002
+}
+
+func (*addTicketConversion) Name() string {
+	return "add status and type mappings to trello scope configs and tables for card labels, members and actions"
+}
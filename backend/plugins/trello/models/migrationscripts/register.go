@@ -28,5 +28,7 @@ func All() []plugin.MigrationScript {
 		new(addConnectionIdToTransformationRule),
 		new(renameTr2ScopeConfig),
 		new(addRawParamTableForScope),
+		new(addBoardIdToPrimaryKey),
+		new(addTicketConversion),
 	}
 }
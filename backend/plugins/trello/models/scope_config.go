@@ -22,7 +22,13 @@ import (
 )
 
 type TrelloScopeConfig struct {
-	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	common.ScopeConfig    `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	TodoListPattern       string `mapstructure:"todoListPattern,omitempty" json:"todoListPattern" gorm:"type:varchar(255)"`
+	InProgressListPattern string `mapstructure:"inProgressListPattern,omitempty" json:"inProgressListPattern" gorm:"type:varchar(255)"`
+	DoneListPattern       string `mapstructure:"doneListPattern,omitempty" json:"doneListPattern" gorm:"type:varchar(255)"`
+	IssueTypeRequirement  string `mapstructure:"issueTypeRequirement,omitempty" json:"issueTypeRequirement" gorm:"type:varchar(255)"`
+	IssueTypeBug          string `mapstructure:"issueTypeBug,omitempty" json:"issueTypeBug" gorm:"type:varchar(255)"`
+	IssueTypeIncident     string `mapstructure:"issueTypeIncident,omitempty" json:"issueTypeIncident" gorm:"type:varchar(255)"`
 }
 
 func (TrelloScopeConfig) TableName() string {
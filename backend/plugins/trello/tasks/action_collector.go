@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ACTION_TABLE = "trello_actions"
+
+// actions changing the list or the archived state of cards, which are needed for the status history
+const actionFilter = "createCard,updateCard:idList,updateCard:closed"
+
+var _ plugin.SubTaskEntryPoint = CollectAction
+
+var CollectActionMeta = plugin.SubTaskMeta{
+	Name:             "CollectAction",
+	EntryPoint:       CollectAction,
+	EnabledByDefault: true,
+	Description:      "Collect card action data from Trello api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectAction(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+
+	pageSize := 1000
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ACTION_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		UrlTemplate: "1/boards/{{ .Params.BoardId }}/actions",
+		PageSize:    pageSize,
+		// actions are returned from the newest to the oldest, the id of the last action is the cursor of next page
+		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+			var actions []struct {
+				ID string `json:"id"`
+			}
+			err := api.UnmarshalResponse(prevPageResponse, &actions)
+			if err != nil {
+				return nil, err
+			}
+			if len(actions) < pageSize {
+				return nil, api.ErrFinishCollect
+			}
+			return actions[len(actions)-1].ID, nil
+		},
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("filter", actionFilter)
+			query.Set("limit", strconv.Itoa(pageSize))
+			if before, ok := reqData.CustomData.(string); ok && before != "" {
+				query.Set("before", before)
+			}
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data []json.RawMessage
+			err := api.UnmarshalResponse(res, &data)
+			return data, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertAction
+
+var ConvertActionMeta = plugin.SubTaskMeta{
+	Name:             "ConvertAction",
+	EntryPoint:       ConvertAction,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_actions into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertAction(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ACTION_TABLE)
+	db := taskCtx.GetDal()
+	var members []models.TrelloMember
+	err := db.All(&members, dal.Where("_raw_data_table = ? AND _raw_data_params = ?", "_raw_"+RAW_MEMBER_TABLE, rawDataParams(data)))
+	if err != nil {
+		return err
+	}
+	memberNames := make(map[string]string, len(members))
+	for _, member := range members {
+		memberNames[member.ID] = member.FullName
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloAction{}),
+		dal.Where("id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloAction{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			action := inputRow.(*models.TrelloAction)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getActionIdGen().Generate(action.ID),
+				},
+				IssueId:         getCardIdGen().Generate(action.IDCard),
+				AuthorId:        getMemberIdGen().Generate(action.IDMemberCreator),
+				AuthorName:      memberNames[action.IDMemberCreator],
+				FieldId:         "idList",
+				FieldName:       "status",
+				OriginalToValue: action.ListAfterName,
+				CreatedDate:     action.Date,
+			}
+			// archiving is considered a status change as well since archived cards are DONE
+			if action.Closed != nil {
+				changelog.FieldId = "closed"
+				changelog.OriginalFromValue = strconv.FormatBool(!*action.Closed)
+				changelog.OriginalToValue = strconv.FormatBool(*action.Closed)
+			} else {
+				changelog.OriginalFromValue = action.ListBeforeName
+			}
+			changelog.FromValue, changelog.ToValue = getStatusChange(data.RegexEnricher, action)
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
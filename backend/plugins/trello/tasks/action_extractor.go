@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractAction
+
+var ExtractActionMeta = plugin.SubTaskMeta{
+	Name:             "ExtractAction",
+	EntryPoint:       ExtractAction,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table trello_actions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type TrelloApiActionRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type TrelloApiAction struct {
+	ID              string    `json:"id"`
+	IDMemberCreator string    `json:"idMemberCreator"`
+	Type            string    `json:"type"`
+	Date            time.Time `json:"date"`
+	Data            struct {
+		Card struct {
+			ID     string `json:"id"`
+			Closed *bool  `json:"closed"`
+		} `json:"card"`
+		Board      TrelloApiActionRef     `json:"board"`
+		List       *TrelloApiActionRef    `json:"list"`
+		ListBefore *TrelloApiActionRef    `json:"listBefore"`
+		ListAfter  *TrelloApiActionRef    `json:"listAfter"`
+		Old        map[string]interface{} `json:"old"`
+	} `json:"data"`
+}
+
+func ExtractAction(taskCtx plugin.SubTaskContext) errors.Error {
+	taskData := taskCtx.GetData().(*TrelloTaskData)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: taskData.Options.ConnectionId,
+				BoardId:      taskData.Options.BoardId,
+			},
+			Table: RAW_ACTION_TABLE,
+		},
+		Extract: func(resData *api.RawData) ([]interface{}, errors.Error) {
+			apiAction := &TrelloApiAction{}
+			err := errors.Convert(json.Unmarshal(resData.Data, apiAction))
+			if err != nil {
+				return nil, err
+			}
+			if apiAction.Data.Card.ID == "" {
+				return nil, nil
+			}
+			action := &models.TrelloAction{
+				ID:              apiAction.ID,
+				IDBoard:         taskData.Options.BoardId,
+				IDCard:          apiAction.Data.Card.ID,
+				IDMemberCreator: apiAction.IDMemberCreator,
+				Type:            apiAction.Type,
+				Date:            apiAction.Date,
+			}
+			// cards are moved from listBefore to listAfter, other actions happen to the card in the list
+			if apiAction.Data.List != nil {
+				action.ListAfterId = apiAction.Data.List.ID
+				action.ListAfterName = apiAction.Data.List.Name
+			}
+			if apiAction.Data.ListBefore != nil {
+				action.ListBeforeId = apiAction.Data.ListBefore.ID
+				action.ListBeforeName = apiAction.Data.ListBefore.Name
+			}
+			if apiAction.Data.ListAfter != nil {
+				action.ListAfterId = apiAction.Data.ListAfter.ID
+				action.ListAfterName = apiAction.Data.ListAfter.Name
+			}
+			if _, ok := apiAction.Data.Old["closed"]; ok {
+				action.Closed = apiAction.Data.Card.Closed
+			}
+			return []interface{}{action}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
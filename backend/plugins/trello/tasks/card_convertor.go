@@ -0,0 +1,231 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCard
+
+var ConvertCardMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCard",
+	EntryPoint:       ConvertCard,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_cards into domain layer table issues, board_issues and issue_assignees",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type cardHistory struct {
+	creatorId    string
+	resolvedDate *time.Time
+}
+
+func ConvertCard(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CARD_TABLE)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	boardId := data.Options.BoardId
+
+	lists, err := loadListNames(db, boardId)
+	if err != nil {
+		return err
+	}
+	labels, err := loadCardLabelNames(db, boardId)
+	if err != nil {
+		return err
+	}
+	histories, err := loadCardHistories(db, data.RegexEnricher, boardId)
+	if err != nil {
+		return err
+	}
+	var members []models.TrelloMember
+	err = db.All(&members, dal.Where("_raw_data_table = ? AND _raw_data_params = ?", "_raw_"+RAW_MEMBER_TABLE, rawDataParams(data)))
+	if err != nil {
+		return err
+	}
+	memberNames := make(map[string]string, len(members))
+	for _, member := range members {
+		memberNames[member.ID] = member.FullName
+	}
+	var cardMembers []models.TrelloCardMember
+	err = db.All(&cardMembers,
+		dal.Select("cm.*"),
+		dal.From("_tool_trello_card_members cm"),
+		dal.Join("LEFT JOIN _tool_trello_cards c ON c.id = cm.card_id"),
+		dal.Where("c.id_board = ?", boardId),
+		dal.Orderby("cm.card_id, cm.member_id"),
+	)
+	if err != nil {
+		return err
+	}
+	assignees := make(map[string][]string)
+	for _, cardMember := range cardMembers {
+		assignees[cardMember.CardId] = append(assignees[cardMember.CardId], cardMember.MemberId)
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCard{}),
+		dal.Where("id_board = ?", boardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardDomainId := getBoardIdGen().Generate(connectionId, boardId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloCard{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			card := inputRow.(*models.TrelloCard)
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getCardIdGen().Generate(card.ID),
+				},
+				Url:             card.Url,
+				IssueKey:        strconv.Itoa(card.IDShort),
+				Title:           card.Name,
+				Description:     card.Desc,
+				OriginalStatus:  lists[card.IDList],
+				Status:          getStdStatus(data.RegexEnricher, lists[card.IDList], card.Closed),
+				CreatedDate:     getCreatedDate(card.ID),
+				UpdatedDate:     &card.DateLastActivity,
+				DueDate:         card.Due,
+				OriginalProject: boardId,
+			}
+			issue.Type, issue.OriginalType = getStdType(data.RegexEnricher, labels[card.ID])
+			history := histories[card.ID]
+			if history != nil && history.creatorId != "" {
+				issue.CreatorId = getMemberIdGen().Generate(history.creatorId)
+				issue.CreatorName = memberNames[history.creatorId]
+			}
+			if issue.Status == ticket.DONE {
+				if history != nil && history.resolvedDate != nil {
+					issue.ResolutionDate = history.resolvedDate
+				} else {
+					issue.ResolutionDate = &card.DateLastActivity
+				}
+				if issue.CreatedDate != nil && issue.ResolutionDate.After(*issue.CreatedDate) {
+					leadTimeMinutes := uint(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+					issue.LeadTimeMinutes = &leadTimeMinutes
+				}
+			}
+			results := []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardDomainId,
+					IssueId: issue.Id,
+				},
+			}
+			for i, memberId := range assignees[card.ID] {
+				assignee := &ticket.IssueAssignee{
+					IssueId:      issue.Id,
+					AssigneeId:   getMemberIdGen().Generate(memberId),
+					AssigneeName: memberNames[memberId],
+				}
+				if i == 0 {
+					issue.AssigneeId = assignee.AssigneeId
+					issue.AssigneeName = assignee.AssigneeName
+				}
+				results = append(results, assignee)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func loadListNames(db dal.Dal, boardId string) (map[string]string, errors.Error) {
+	var lists []models.TrelloList
+	err := db.All(&lists, dal.Where("id_board = ?", boardId))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(lists))
+	for _, list := range lists {
+		names[list.ID] = list.Name
+	}
+	return names, nil
+}
+
+func loadCardLabelNames(db dal.Dal, boardId string) (map[string][]string, errors.Error) {
+	var cardLabels []struct {
+		CardId string
+		Name   string
+	}
+	err := db.All(&cardLabels,
+		dal.Select("cl.card_id, l.name"),
+		dal.From("_tool_trello_card_labels cl"),
+		dal.Join("LEFT JOIN _tool_trello_labels l ON l.id = cl.label_id"),
+		dal.Join("LEFT JOIN _tool_trello_cards c ON c.id = cl.card_id"),
+		dal.Where("c.id_board = ? AND l.name != ''", boardId),
+		dal.Orderby("cl.card_id, l.name"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string][]string)
+	for _, cardLabel := range cardLabels {
+		names[cardLabel.CardId] = append(names[cardLabel.CardId], cardLabel.Name)
+	}
+	return names, nil
+}
+
+// loadCardHistories replays the actions of cards to find out who created them and when they were done
+func loadCardHistories(db dal.Dal, regexEnricher *api.RegexEnricher, boardId string) (map[string]*cardHistory, errors.Error) {
+	var actions []models.TrelloAction
+	err := db.All(&actions, dal.Where("id_board = ?", boardId), dal.Orderby("date, id"))
+	if err != nil {
+		return nil, err
+	}
+	histories := make(map[string]*cardHistory)
+	for i := range actions {
+		action := &actions[i]
+		history := histories[action.IDCard]
+		if history == nil {
+			history = &cardHistory{}
+			histories[action.IDCard] = history
+		}
+		if action.Type == "createCard" {
+			history.creatorId = action.IDMemberCreator
+		}
+		from, to := getStatusChange(regexEnricher, action)
+		if to == ticket.DONE && from != ticket.DONE {
+			history.resolvedDate = &action.Date
+		} else if from == ticket.DONE && to != ticket.DONE {
+			history.resolvedDate = nil
+		}
+	}
+	return histories, nil
+}
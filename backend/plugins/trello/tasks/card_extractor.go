@@ -44,7 +44,7 @@ type TrelloApiCard struct {
 	DateLastActivity      time.Time     `json:"dateLastActivity"`
 	Desc                  string        `json:"desc"`
 	DescData              interface{}   `json:"descData"`
-	Due                   interface{}   `json:"due"`
+	Due                   *time.Time    `json:"due"`
 	DueReminder           interface{}   `json:"dueReminder"`
 	Email                 interface{}   `json:"email"`
 	IDBoard               string        `json:"idBoard"`
@@ -87,7 +87,7 @@ func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, err
 			}
-			return []interface{}{
+			results := []interface{}{
 				&models.TrelloCard{
 					ID:               apiCard.ID,
 					Name:             apiCard.Name,
@@ -102,8 +102,23 @@ func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
 					ShortUrl:         apiCard.ShortUrl,
 					Subscribed:       apiCard.Subscribed,
 					Url:              apiCard.Url,
+					Desc:             apiCard.Desc,
+					Due:              apiCard.Due,
 				},
-			}, nil
+			}
+			for _, labelId := range apiCard.IDLabels {
+				results = append(results, &models.TrelloCardLabel{
+					CardId:  apiCard.ID,
+					LabelId: labelId,
+				})
+			}
+			for _, memberId := range apiCard.IDMembers {
+				results = append(results, &models.TrelloCardMember{
+					CardId:   apiCard.ID,
+					MemberId: memberId,
+				})
+			}
+			return results, nil
 		},
 	})
 	if err != nil {
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCardLabel
+
+var ConvertCardLabelMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCardLabel",
+	EntryPoint:       ConvertCardLabel,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_card_labels into domain layer table issue_labels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCardLabel(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CARD_TABLE)
+	db := taskCtx.GetDal()
+	var labels []models.TrelloLabel
+	err := db.All(&labels, dal.Where("id_board = ?", data.Options.BoardId))
+	if err != nil {
+		return err
+	}
+	labelNames := make(map[string]string, len(labels))
+	for _, label := range labels {
+		labelNames[label.ID] = label.Name
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("cl.*"),
+		dal.From("_tool_trello_card_labels cl"),
+		dal.Join("LEFT JOIN _tool_trello_cards c ON c.id = cl.card_id"),
+		dal.Where("c.id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloCardLabel{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			cardLabel := inputRow.(*models.TrelloCardLabel)
+			labelName := labelNames[cardLabel.LabelId]
+			if labelName == "" {
+				return nil, nil
+			}
+			return []interface{}{
+				&ticket.IssueLabel{
+					IssueId:   getCardIdGen().Generate(cardLabel.CardId),
+					LabelName: labelName,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
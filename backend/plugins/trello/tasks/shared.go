@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+// trello ids are globally unique, domain ids of entities other than boards are generated by them only
+// since the tool layer tables are not keyed by connections
+var boardIdGen *didgen.DomainIdGenerator
+var cardIdGen *didgen.DomainIdGenerator
+var memberIdGen *didgen.DomainIdGenerator
+var actionIdGen *didgen.DomainIdGenerator
+
+func getBoardIdGen() *didgen.DomainIdGenerator {
+	if boardIdGen == nil {
+		boardIdGen = didgen.NewDomainIdGenerator(&models.TrelloBoard{})
+	}
+	return boardIdGen
+}
+
+func getCardIdGen() *didgen.DomainIdGenerator {
+	if cardIdGen == nil {
+		cardIdGen = didgen.NewDomainIdGenerator(&models.TrelloCard{})
+	}
+	return cardIdGen
+}
+
+func getMemberIdGen() *didgen.DomainIdGenerator {
+	if memberIdGen == nil {
+		memberIdGen = didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	}
+	return memberIdGen
+}
+
+func getActionIdGen() *didgen.DomainIdGenerator {
+	if actionIdGen == nil {
+		actionIdGen = didgen.NewDomainIdGenerator(&models.TrelloAction{})
+	}
+	return actionIdGen
+}
+
+// CreateRawDataSubTaskArgs returns the raw data args of the board being processed
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, rawTable string) (*api.RawDataSubTaskArgs, *TrelloTaskData) {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	return &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: TrelloApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			BoardId:      data.Options.BoardId,
+		},
+		Table: rawTable,
+	}, data
+}
+
+// rawDataParams returns the _raw_data_params of records extracted for the board
+func rawDataParams(data *TrelloTaskData) string {
+	return string(errors.Must1(json.Marshal(TrelloApiParams{
+		ConnectionId: data.Options.ConnectionId,
+		BoardId:      data.Options.BoardId,
+	})))
+}
+
+// NewRegexEnricher compiles the list and label patterns of the scope config
+func NewRegexEnricher(scopeConfig *models.TrelloScopeConfig) (*api.RegexEnricher, errors.Error) {
+	regexEnricher := api.NewRegexEnricher()
+	patterns := []struct {
+		name, field, pattern string
+	}{
+		{ticket.TODO, "todoListPattern", scopeConfig.TodoListPattern},
+		{ticket.IN_PROGRESS, "inProgressListPattern", scopeConfig.InProgressListPattern},
+		{ticket.DONE, "doneListPattern", scopeConfig.DoneListPattern},
+		{ticket.REQUIREMENT, "issueTypeRequirement", scopeConfig.IssueTypeRequirement},
+		{ticket.BUG, "issueTypeBug", scopeConfig.IssueTypeBug},
+		{ticket.INCIDENT, "issueTypeIncident", scopeConfig.IssueTypeIncident},
+	}
+	for _, p := range patterns {
+		if err := regexEnricher.TryAdd(p.name, p.pattern); err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `"+p.field+"`")
+		}
+	}
+	return regexEnricher, nil
+}
+
+// getStdStatus maps the list holding the card to a standard status, archived cards are always DONE and
+// cards in lists matching none of the patterns are considered TODO
+func getStdStatus(regexEnricher *api.RegexEnricher, listName string, closed bool) string {
+	if closed {
+		return ticket.DONE
+	}
+	for _, status := range []string{ticket.DONE, ticket.IN_PROGRESS, ticket.TODO} {
+		if regexEnricher.ReturnNameIfMatched(status, listName) != "" {
+			return status
+		}
+	}
+	return ticket.TODO
+}
+
+// getStdType maps the label names of the card to a standard issue type and returns the matched label as well,
+// cards without matching labels are considered TASK
+func getStdType(regexEnricher *api.RegexEnricher, labelNames []string) (string, string) {
+	for _, issueType := range []string{ticket.INCIDENT, ticket.BUG, ticket.REQUIREMENT} {
+		for _, labelName := range labelNames {
+			if regexEnricher.ReturnNameIfMatched(issueType, labelName) != "" {
+				return issueType, labelName
+			}
+		}
+	}
+	return ticket.TASK, ""
+}
+
+// getCreatedDate extracts the creation time embedded in the first 8 hex digits of trello ids
+func getCreatedDate(id string) *time.Time {
+	if len(id) < 8 {
+		return nil
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return nil
+	}
+	createdDate := time.Unix(seconds, 0).UTC()
+	return &createdDate
+}
+
+// getStatusChange returns the standard statuses of the card before and after the action
+func getStatusChange(regexEnricher *api.RegexEnricher, action *models.TrelloAction) (string, string) {
+	switch {
+	case action.Closed != nil:
+		return getStdStatus(regexEnricher, action.ListAfterName, !*action.Closed),
+			getStdStatus(regexEnricher, action.ListAfterName, *action.Closed)
+	case action.ListBeforeId != "":
+		return getStdStatus(regexEnricher, action.ListBeforeName, false),
+			getStdStatus(regexEnricher, action.ListAfterName, false)
+	}
+	return "", getStdStatus(regexEnricher, action.ListAfterName, false)
+}
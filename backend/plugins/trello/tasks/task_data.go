@@ -23,14 +23,16 @@ import (
 )
 
 type TrelloOptions struct {
-	ConnectionId  uint64 `json:"connectionId" mapstructure:"connectionId,omitempty"`
-	BoardId       string `json:"boardId" mapstructure:"boardId,omitempty"`
-	ScopeConfigId uint64 `json:"scopeConfigId" mapstructure:"scopeConfigId,omitempty"`
+	ConnectionId  uint64                    `json:"connectionId" mapstructure:"connectionId,omitempty"`
+	BoardId       string                    `json:"boardId" mapstructure:"boardId,omitempty"`
+	ScopeConfigId uint64                    `json:"scopeConfigId" mapstructure:"scopeConfigId,omitempty"`
+	ScopeConfig   *models.TrelloScopeConfig `json:"scopeConfig" mapstructure:"scopeConfig,omitempty"`
 }
 
 type TrelloTaskData struct {
-	Options   *TrelloOptions
-	ApiClient *api.ApiAsyncClient
+	Options       *TrelloOptions
+	ApiClient     *api.ApiAsyncClient
+	RegexEnricher *api.RegexEnricher
 }
 
 type TrelloApiParams models.TrelloApiParams
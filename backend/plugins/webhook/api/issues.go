@@ -69,6 +69,12 @@ func saveIncidentRelatedRecordsFromIssue(db dal.Transaction, logger log.Logger,
 	if err != nil {
 		return err
 	}
+	// webhook incidents are normalized by the default rules, as webhooks have no scope configs
+	normalizer, err := helper.NewIncidentNormalizer(nil)
+	if err != nil {
+		return err
+	}
+	normalizer.Normalize(incident, "")
 	if err := db.CreateOrUpdate(incident); err != nil {
 		return err
 	}
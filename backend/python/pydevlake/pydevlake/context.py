@@ -14,11 +14,23 @@
 # limitations under the License.
 
 
+import threading
+
 from sqlalchemy.engine import Engine
 
 from pydevlake.model import Connection, ScopeConfig, ToolScope
 
 
+_cancelled = threading.Event()
+
+
+def cancel():
+    """
+    Marks the running subtask as cancelled, it gets called once DevLake sends SIGTERM.
+    """
+    _cancelled.set()
+
+
 class Context:
     def __init__(self,
                  engine: Engine,
@@ -36,3 +48,7 @@ class Context:
     @property
     def incremental(self) -> bool:
         return self.options.get('incremental') is True
+
+    @property
+    def cancelled(self) -> bool:
+        return _cancelled.is_set()
@@ -16,6 +16,7 @@
 
 import os
 import json
+import signal
 from functools import wraps
 from typing import Generator, TextIO, Optional
 
@@ -24,7 +25,8 @@ from fire.decorators import SetParseFn
 from sqlmodel import create_engine
 from sqlalchemy.engine import Engine
 
-from pydevlake.context import Context
+from pydevlake import logger
+from pydevlake.context import Context, cancel
 from pydevlake.message import Message
 from pydevlake.model import SubtaskRun
 from pydevlake.config import set_config
@@ -49,12 +51,18 @@ def plugin_method(func):
         except json.JSONDecodeError as e:
             raise Exception(f"Invalid JSON {arg}: {e.msg}")
 
+    def on_terminate(signum, frame):
+        # let the subtask save its state and stop at the next record
+        logger.info("Received SIGTERM, cancelling")
+        cancel()
+
     @wraps(func)
     @SetParseFn(parse_arg)
     def wrapper(self, *args):
         # first arg will always be arg - pluck it out
         cfg = args[0]
         set_config(cfg)
+        signal.signal(signal.SIGTERM, on_terminate)
         ret = func(self, *args)
         if ret is not None:
             with open_send_channel() as send_ch:
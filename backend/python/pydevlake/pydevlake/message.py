@@ -15,6 +15,7 @@
 
 
 from typing import Optional
+from datetime import datetime
 
 from pydantic import BaseModel, Field
 
@@ -53,11 +54,23 @@ class PluginInfo(Message):
 
 
 class RemoteProgress(Message):
+    type: str = "progress"
     increment: int = 0
     current: int = 0
     total: int = 0
 
 
+class CollectorState(Message):
+    """
+    Sent once a collector succeeded, it is saved in the `_devlake_collector_latest_state` table like Go collectors.
+    """
+    type: str = "collector_state"
+    raw_data_table: str
+    raw_data_params: str
+    latest_success_start: datetime
+    time_after: Optional[datetime]
+
+
 class PipelineTask(Message):
     plugin: str
     skip_on_fail: bool = Field(default=False, alias="skipOnFail")
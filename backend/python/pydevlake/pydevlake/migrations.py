@@ -0,0 +1,29 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""
+Migrations of the tables shared by all python plugins, they are registered along with the scripts of each plugin.
+"""
+
+from pydevlake.migration import migration, MigrationScriptBuilder
+from pydevlake.model import SubtaskRun
+
+
+@migration(20261021000001, name="add scope_id to _pydevlake_subtask_runs")
+def add_scope_id_to_subtask_runs(b: MigrationScriptBuilder):
+    # `scope_id` keeps the states of scopes collected concurrently apart.
+    # The table is created with the column when a plugin connects to the database for the first time, so it
+    # might not exist yet, and the column might have been added by the same script of another python plugin.
+    b.execute(f'ALTER TABLE {SubtaskRun.__tablename__} ADD COLUMN scope_id VARCHAR(255)', ignore_error=True)
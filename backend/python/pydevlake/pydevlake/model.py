@@ -213,6 +213,7 @@ class SubtaskRun(SQLModel, table=True):
     id: Optional[int] = Field(primary_key=True)
     subtask_name: str
     connection_id: int
+    scope_id: Optional[str]
     started: datetime
     completed: Optional[datetime]
     state: str = Field(sa_column=Column(Text))  # JSON encoded dict of atomic values
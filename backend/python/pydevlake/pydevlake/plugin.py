@@ -30,6 +30,7 @@ from pydevlake.context import Context
 from pydevlake.stream import Stream
 from pydevlake.model import ToolScope, DomainScope, Connection, ScopeConfig, raw_data_params
 from pydevlake.migration import MIGRATION_SCRIPTS
+import pydevlake.migrations  # registers the migrations of the tables shared by python plugins
 
 
 ScopeConfigPair = tuple[ToolScope, ScopeConfig]
@@ -153,11 +154,18 @@ class Plugin(ABC):
     def make_pipeline_plan(self, scope_config_pairs: list[ScopeConfigPair],
                            connection: Connection) -> list[list[msg.PipelineTask]]:
         """
-        Generate a pipeline plan with one stage per scope, plus optional additional stages.
+        Generate a pipeline plan with a first stage holding the tasks of all scopes, so that the scopes
+        are processed concurrently, plus optional additional stages.
+        The number of processes running at the same time can be limited by `REMOTE_PLUGIN_MAX_PROCESSES`.
         Redefine `extra_stages` to add stages at the end of this pipeline.
         """
+        scope_stage = [
+            task
+            for scope, config in scope_config_pairs
+            for task in self.make_pipeline_stage(scope, config, connection)
+        ]
         return [
-            *(self.make_pipeline_stage(scope, config, connection) for scope, config in scope_config_pairs),
+            *([scope_stage] if scope_stage else []),
             *self.extra_stages(scope_config_pairs, connection)
         ]
 
@@ -172,7 +180,8 @@ class Plugin(ABC):
     def make_pipeline_stage(self, scope: ToolScope, config: ScopeConfig,
                             connection: Connection) -> list[msg.PipelineTask]:
         """
-        Generate a pipeline stage for the given scope, plus optional additional tasks.
+        Generate the pipeline tasks of the given scope, plus optional additional tasks.
+        They run in the first stage of the plan alongside the tasks of the other scopes.
         Subtasks are selected from `entity_types` via `select_subtasks`.
         Redefine `extra_tasks` to add tasks to this stage.
         """
@@ -24,6 +24,10 @@ from pydevlake.extractor import autoextract
 
 
 class Stream:
+    # Set to True if `collect` continues from the state yielded along the records,
+    # so a half-finished collection can be resumed
+    resumable = False
+
     def __init__(self, plugin_name: str):
         self.plugin_name = plugin_name
         self.collector = Collector(self)
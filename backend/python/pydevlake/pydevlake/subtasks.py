@@ -16,18 +16,22 @@
 
 import json
 from abc import abstractmethod
-from datetime import datetime
-from typing import Tuple, Dict, Iterable, Generator
+from datetime import datetime, timezone
+from typing import Tuple, Dict, Iterable, Generator, Optional
 
 import sqlalchemy.sql as sql
 from sqlmodel import Session, select
 
 from pydevlake import logger
 from pydevlake.context import Context
-from pydevlake.message import RemoteProgress
+from pydevlake.message import Message, RemoteProgress, CollectorState
 from pydevlake.model import RawModel, ToolModel, DomainModel, SubtaskRun, raw_data_params
 
 
+class SubtaskCancelled(Exception):
+    pass
+
+
 class Subtask:
     def __init__(self, stream):
         self.stream = stream
@@ -47,12 +51,17 @@ class Subtask:
 
     def run(self, ctx: Context, sync_point_interval=100):
         with Session(ctx.engine) as session:
-            subtask_run = self._start_subtask(session, ctx.connection.id)
-            if ctx.incremental:
-                state = self._get_last_state(session, ctx.connection.id)
+            subtask_run = self._get_unfinished_run(session, ctx) if self.resumable else None
+            if subtask_run is not None:
+                state = json.loads(subtask_run.state)
+                logger.info(f'Resuming {self.name} from the state {state}')
             else:
-                self.delete(session, ctx)
-                state = dict()
+                subtask_run = self._start_subtask(session, ctx)
+                if ctx.incremental:
+                    state = self._get_last_state(session, ctx)
+                else:
+                    self.delete(session, ctx)
+                    state = dict()
 
             try:
                 records = self.fetch(state, session, ctx)
@@ -60,11 +69,13 @@ class Subtask:
                 for data, state in records:
                     progress += 1
                     self.process(data, session, ctx)
+                    if ctx.cancelled:
+                        # Save the state of processed records so the subtask can be resumed
+                        self._save_state(session, subtask_run, state)
+                        raise SubtaskCancelled(f'{self.name} was cancelled after {progress} records')
                     if progress % sync_point_interval == 0:
                         # Save current state
-                        subtask_run.state = json.dumps(state)
-                        session.merge(subtask_run)
-                        session.commit()
+                        self._save_state(session, subtask_run, state)
                         # Send progress
                         yield RemoteProgress(
                             increment=sync_point_interval,
@@ -85,11 +96,31 @@ class Subtask:
             subtask_run.completed = datetime.now()
             session.merge(subtask_run)
             session.commit()
+            yield from self.on_completed(subtask_run, ctx)
+
+    @property
+    def resumable(self) -> bool:
+        """
+        Whether a half-finished run can be resumed from its last saved state instead of starting over.
+        """
+        return False
+
+    def on_completed(self, subtask_run: SubtaskRun, ctx: Context) -> Iterable[Message]:
+        """
+        Called after the subtask completed, the returned messages are sent to DevLake.
+        """
+        return []
+
+    def _save_state(self, session, subtask_run, state):
+        subtask_run.state = json.dumps(state)
+        session.merge(subtask_run)
+        session.commit()
 
-    def _start_subtask(self, session, connection_id):
+    def _start_subtask(self, session, ctx: Context):
         subtask_run = SubtaskRun(
             subtask_name=self.name,
-            connection_id=connection_id,
+            connection_id=ctx.connection.id,
+            scope_id=ctx.scope.id,
             started=datetime.now(),
             state=json.dumps({})
         )
@@ -113,11 +144,12 @@ class Subtask:
         """
         pass
 
-    def _get_last_state(self, session, connection_id):
+    def _get_last_state(self, session, ctx: Context):
         stmt = (
             select(SubtaskRun)
             .where(SubtaskRun.subtask_name == self.name)
-            .where(SubtaskRun.connection_id == connection_id)
+            .where(SubtaskRun.connection_id == ctx.connection.id)
+            .where(SubtaskRun.scope_id == ctx.scope.id)
             .where(SubtaskRun.completed != None)
             .order_by(sql.desc(SubtaskRun.started))
         )
@@ -126,6 +158,19 @@ class Subtask:
             return json.loads(subtask_run.state)
         return {}
 
+    def _get_unfinished_run(self, session, ctx: Context) -> Optional[SubtaskRun]:
+        stmt = (
+            select(SubtaskRun)
+            .where(SubtaskRun.subtask_name == self.name)
+            .where(SubtaskRun.connection_id == ctx.connection.id)
+            .where(SubtaskRun.scope_id == ctx.scope.id)
+            .order_by(sql.desc(SubtaskRun.started))
+        )
+        subtask_run = session.exec(stmt).first()
+        if subtask_run is not None and subtask_run.completed is None:
+            return subtask_run
+        return None
+
     def _params(self, ctx: Context) -> str:
         return raw_data_params(ctx.connection.id, ctx.scope.id)
 
@@ -139,6 +184,18 @@ class Collector(Subtask):
     def verb(self):
         return 'collect'
 
+    @property
+    def resumable(self) -> bool:
+        return self.stream.resumable
+
+    def on_completed(self, subtask_run: SubtaskRun, ctx: Context) -> Iterable[Message]:
+        # time_after would be filled by DevLake with the sync policy of the pipeline
+        yield CollectorState(
+            raw_data_table=self.stream.raw_model_table,
+            raw_data_params=self._params(ctx),
+            latest_success_start=subtask_run.started.astimezone(timezone.utc),
+        )
+
     def fetch(self, state: Dict, _, ctx: Context) -> Iterable[Tuple[object, Dict]]:
         return self.stream.collect(state, ctx)
 
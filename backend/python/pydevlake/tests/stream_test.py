@@ -20,7 +20,10 @@ import pytest
 from sqlmodel import SQLModel, Session, Field, create_engine
 
 from pydevlake import Stream, Connection, Context, DomainType
+from pydevlake.context import cancel, _cancelled
+from pydevlake.message import CollectorState
 from pydevlake.model import ScopeConfig, ToolModel, DomainModel, ToolScope, raw_data_params
+from pydevlake.subtasks import SubtaskCancelled
 
 
 class DummyToolModel(ToolModel, table=True):
@@ -54,6 +57,15 @@ class DummyStream(Stream):
         )
 
 
+class ResumableStream(DummyStream):
+    resumable = True
+
+    def collect(self, state, context):
+        start = state.get("next", 0)
+        for i, each in enumerate(context.connection.raw_data[start:], start):
+            yield each, {"next": i + 1}
+
+
 class DummyConnection(Connection):
     raw_data: list[dict]
 
@@ -108,6 +120,33 @@ def test_collect_data(stream, raw_data, ctx):
         assert all_raw == raw_data
 
 
+def test_collector_state(stream, ctx):
+    messages = list(stream.collector.run(ctx))
+
+    state = messages[-1]
+    assert isinstance(state, CollectorState)
+    assert state.raw_data_table == '_raw_test_dummystream'
+    assert state.raw_data_params == raw_data_params(ctx.connection.id, ctx.scope.id)
+
+
+def test_cancel_and_resume(raw_data, ctx):
+    stream = ResumableStream("test")
+    cancel()
+    try:
+        with pytest.raises(SubtaskCancelled):
+            list(stream.collector.run(ctx))
+    finally:
+        _cancelled.clear()
+
+    # the second run continues from the first record
+    list(stream.collector.run(ctx))
+
+    with Session(ctx.engine) as session:
+        raw_model = stream.raw_model(session)
+        all_raw = [json.loads(r.data) for r in session.query(raw_model).all()]
+        assert all_raw == raw_data
+
+
 def test_extract_data(stream, raw_data, ctx):
     with Session(ctx.engine) as session:
         for each in raw_data:
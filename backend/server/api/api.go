@@ -37,6 +37,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	_ "github.com/apache/incubator-devlake/server/api/docs"
+	"github.com/apache/incubator-devlake/server/api/migrations"
 	"github.com/apache/incubator-devlake/server/api/ping"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/version"
@@ -127,6 +128,10 @@ func SetupApiServer(router *gin.Engine) {
 		// Return success response
 		shared.ApiOutputSuccess(ctx, nil, http.StatusOK)
 	})
+	// Endpoints to review or revert database migration, they have to be accessible while waiting for confirmation
+	router.GET("/migrations/pending", migrations.GetPending)
+	router.GET("/migrations/dry-run", migrations.GetDryRun)
+	router.POST("/migrations/rollback", migrations.PostRollback)
 
 	// Restrict access if database migration is required
 	router.Use(func(ctx *gin.Context) {
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrations
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+type RollbackRequest struct {
+	// all executed scripts since the version would be reverted
+	Version uint64 `json:"version" binding:"required"`
+}
+
+// @Summary Get pending migration scripts
+// @Description list the migration scripts to be executed in order, along with the plugin/comment they were registered with
+// @Tags framework/migrations
+// @Success 200  {object} []plugin.MigrationScriptInfo
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /migrations/pending [get]
+func GetPending(c *gin.Context) {
+	shared.ApiOutputSuccess(c, services.GetPendingMigrationScripts(), http.StatusOK)
+}
+
+// @Summary Dry-run pending migration scripts
+// @Description return the statements the pending migration scripts would execute, the database would not be changed
+// @Tags framework/migrations
+// @Success 200  {object} []plugin.MigrationScriptPlan
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /migrations/dry-run [get]
+func GetDryRun(c *gin.Context) {
+	plans, err := services.DryRunMigration()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error dry-running migration"))
+		return
+	}
+	shared.ApiOutputSuccess(c, plans, http.StatusOK)
+}
+
+// @Summary Rollback migration scripts
+// @Description revert all executed migration scripts since the specified version in the reversed order, all of them must be reversible
+// @Tags framework/migrations
+// @Accept application/json
+// @Param request body RollbackRequest true "json"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /migrations/rollback [post]
+func PostRollback(c *gin.Context) {
+	request := &RollbackRequest{}
+	err := c.ShouldBind(request)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.RollbackMigration(request.Version)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error rolling back migration"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
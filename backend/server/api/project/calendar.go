@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the working calendar of a project
+// @Description Get the working calendar and holidays of a project, Monday to Friday 09:00-17:00 UTC if it was never set
+// @Tags framework/projects
+// @Accept application/json
+// @Param projectName path string true "project name"
+// @Success 200  {object} models.ApiProjectCalendar
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/calendar [get]
+func GetProjectCalendar(c *gin.Context) {
+	projectName := c.Param("projectName")
+
+	calendar, err := services.GetProjectCalendar(projectName)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting project calendar"))
+		return
+	}
+	shared.ApiOutputSuccess(c, calendar, http.StatusOK)
+}
+
+// @Summary Set the working calendar of a project
+// @Description Replace the working calendar and holidays of a project
+// @Tags framework/projects
+// @Accept application/json
+// @Param projectName path string true "project name"
+// @Param calendar body models.ApiProjectCalendar true "json"
+// @Success 200  {object} models.ApiProjectCalendar
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/calendar [put]
+func PutProjectCalendar(c *gin.Context) {
+	projectName := c.Param("projectName")
+
+	input := &models.ApiProjectCalendar{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	calendar, err := services.PutProjectCalendar(projectName, input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error saving project calendar"))
+		return
+	}
+	shared.ApiOutputSuccess(c, calendar, http.StatusOK)
+}
+
+// @Summary Import holidays from an iCalendar file
+// @Description Add the days of the events of an iCalendar (.ics) file to the holidays of a project,
+// @Description the file is either the request body or the "file" field of a multipart form
+// @Tags framework/projects
+// @Accept text/calendar,multipart/form-data
+// @Param projectName path string true "project name"
+// @Param file formData file false "iCalendar file"
+// @Success 200  {object} models.ApiProjectCalendar
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/calendar/holidays [post]
+func PostProjectHolidays(c *gin.Context) {
+	projectName := c.Param("projectName")
+
+	var ics io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(err, "missing iCalendar file"))
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error opening iCalendar file"))
+			return
+		}
+		defer f.Close()
+		ics = f
+	}
+	calendar, err := services.ImportProjectHolidays(projectName, ics)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error importing project holidays"))
+		return
+	}
+	shared.ApiOutputSuccess(c, calendar, http.StatusOK)
+}
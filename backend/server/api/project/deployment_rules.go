@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Try deployment rules against the recent pipelines of a project
+// @Description Classify the finished pipelines and tasks of the last days (30 by default) of a project with the given
+// @Description deployment rules, without saving anything, and list the pipelines that would become production deployments
+// @Tags framework/projects
+// @Accept application/json
+// @Param projectName path string true "project name"
+// @Param input body services.DeploymentRulesDryRunInput true "json"
+// @Success 200  {object} services.DeploymentRulesDryRunOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/deployment-rules/dry-run [post]
+func DryRunProjectDeploymentRules(c *gin.Context) {
+	projectName := c.Param("projectName")
+
+	input := &services.DeploymentRulesDryRunInput{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	output, err := services.DryRunProjectDeploymentRules(projectName, input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error trying deployment rules"))
+		return
+	}
+	shared.ApiOutputSuccess(c, output, http.StatusOK)
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the release notes of a deployment
+// @Description List the commits, pull requests and issues shipped by a deployment commit of a project since the previous
+// @Description successful deployment commit, as JSON or as Markdown rendered with the release notes template of the project
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Param deploymentCommitId query string true "deployment commit id"
+// @Param format query string false "markdown (default) or json"
+// @Produce text/markdown,application/json
+// @Success 200  {object} api.ReleaseNotes
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/release-notes [get]
+func GetProjectReleaseNotes(c *gin.Context) {
+	projectName := c.Param("projectName")
+	deploymentCommitId := c.Query("deploymentCommitId")
+	if deploymentCommitId == "" {
+		shared.ApiOutputError(c, errors.BadInput.New("deploymentCommitId is required"))
+		return
+	}
+
+	switch c.DefaultQuery("format", "markdown") {
+	case "json":
+		notes, err := services.GetProjectReleaseNotes(projectName, deploymentCommitId)
+		if err != nil {
+			shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting release notes"))
+			return
+		}
+		shared.ApiOutputSuccess(c, notes, http.StatusOK)
+	case "markdown":
+		markdown, err := services.RenderProjectReleaseNotes(projectName, deploymentCommitId)
+		if err != nil {
+			shared.ApiOutputError(c, errors.Default.Wrap(err, "error rendering release notes"))
+			return
+		}
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(markdown))
+	default:
+		shared.ApiOutputError(c, errors.BadInput.New("format must be markdown or json"))
+	}
+}
+
+// @Summary Get the release notes template of a project
+// @Description Get the Go text/template rendering the release notes of a project, the default Markdown one if it was never set
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Success 200  {object} models.ProjectReleaseNotesTemplate
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/release-notes/template [get]
+func GetProjectReleaseNotesTemplate(c *gin.Context) {
+	projectName := c.Param("projectName")
+
+	tmpl, err := services.GetProjectReleaseNotesTemplate(projectName)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting release notes template"))
+		return
+	}
+	shared.ApiOutputSuccess(c, tmpl, http.StatusOK)
+}
+
+// @Summary Set the release notes template of a project
+// @Description Replace the Go text/template rendering the release notes of a project, an empty template restores the default one
+// @Tags framework/projects
+// @Accept application/json
+// @Param projectName path string true "project name"
+// @Param template body models.ProjectReleaseNotesTemplate true "json"
+// @Success 200  {object} models.ProjectReleaseNotesTemplate
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/release-notes/template [put]
+func PutProjectReleaseNotesTemplate(c *gin.Context) {
+	projectName := c.Param("projectName")
+
+	input := &models.ProjectReleaseNotesTemplate{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	tmpl, err := services.PutProjectReleaseNotesTemplate(projectName, input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error saving release notes template"))
+		return
+	}
+	shared.ApiOutputSuccess(c, tmpl, http.StatusOK)
+}
@@ -72,6 +72,13 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	// project api
 	r.GET("/projects/:projectName", project.GetProject)
 	r.GET("/projects/:projectName/check", project.GetProjectCheck)
+	r.GET("/projects/:projectName/calendar", project.GetProjectCalendar)
+	r.PUT("/projects/:projectName/calendar", project.PutProjectCalendar)
+	r.POST("/projects/:projectName/calendar/holidays", project.PostProjectHolidays)
+	r.GET("/projects/:projectName/release-notes", project.GetProjectReleaseNotes)
+	r.GET("/projects/:projectName/release-notes/template", project.GetProjectReleaseNotesTemplate)
+	r.PUT("/projects/:projectName/release-notes/template", project.PutProjectReleaseNotesTemplate)
+	r.POST("/projects/:projectName/deployment-rules/dry-run", project.DryRunProjectDeploymentRules)
 	r.PATCH("/projects/:projectName", project.PatchProject)
 	r.DELETE("/projects/:projectName", project.DeleteProject)
 	r.POST("/projects", project.PostProject)
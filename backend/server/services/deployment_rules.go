@@ -0,0 +1,318 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const defaultDeploymentRulesDryRunDays = 30
+
+// DeploymentRulesDryRunInput holds the rules to try against the recent pipelines of a project
+type DeploymentRulesDryRunInput struct {
+	Rules []*helper.DeploymentRule `json:"rules"`
+	Days  int                      `json:"days"`
+}
+
+// DeploymentRulesDryRunTask is a task of a pipeline and its classification with and without the rules
+type DeploymentRulesDryRunTask struct {
+	Id                 string `json:"id"`
+	Name               string `json:"name"`
+	Result             string `json:"result"`
+	MatchedRule        string `json:"matchedRule"`
+	Type               string `json:"type"`
+	Environment        string `json:"environment"`
+	CurrentType        string `json:"currentType"`
+	CurrentEnvironment string `json:"currentEnvironment"`
+}
+
+// DeploymentRulesDryRunPipeline is a pipeline or a deployment the rules classify as a production deployment
+type DeploymentRulesDryRunPipeline struct {
+	Id                 string                       `json:"id"`
+	Name               string                       `json:"name"`
+	Branch             string                       `json:"branch"`
+	Result             string                       `json:"result"`
+	FinishedDate       *time.Time                   `json:"finishedDate"`
+	MatchedRule        string                       `json:"matchedRule"`
+	Type               string                       `json:"type"`
+	Environment        string                       `json:"environment"`
+	CurrentType        string                       `json:"currentType"`
+	CurrentEnvironment string                       `json:"currentEnvironment"`
+	Tasks              []*DeploymentRulesDryRunTask `json:"tasks"`
+}
+
+// DeploymentRulesDryRunOutput lists the pipelines and deployments of the last days the rules classify as production
+// deployments, CurrentCount tells how many production deployments the present classification of the same ones yields
+type DeploymentRulesDryRunOutput struct {
+	Days                  int                              `json:"days"`
+	PipelineCount         int                              `json:"pipelineCount"`
+	DeploymentCount       int                              `json:"deploymentCount"`
+	CurrentCount          int                              `json:"currentCount"`
+	ProductionDeployments []*DeploymentRulesDryRunPipeline `json:"productionDeployments"`
+}
+
+type dryRunPipeline struct {
+	Id           string
+	Name         string
+	Branch       string
+	Result       string
+	Type         string
+	Environment  string
+	FinishedDate *time.Time
+}
+
+type dryRunTask struct {
+	Id          string
+	PipelineId  string
+	Name        string
+	Result      string
+	Type        string
+	Environment string
+}
+
+type dryRunDeployment struct {
+	Id           string
+	Name         string
+	Branch       string
+	Result       string
+	Environment  string
+	FinishedDate *time.Time
+}
+
+// DryRunProjectDeploymentRules shows which finished pipelines and deployments of the project in the last days would
+// become production deployments if the rules classified them, the way the dora plugin generates cicd_deployments.
+// The plugins of the scopes describe their pipelines, tasks and deployments to the rules, the ones their plugins
+// don't classify by deployment rules keep their present classification.
+func DryRunProjectDeploymentRules(projectName string, input *DeploymentRulesDryRunInput) (*DeploymentRulesDryRunOutput, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := helper.NewDeploymentRuleEngine(input.Rules)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid deployment rules")
+	}
+	if engine == nil {
+		return nil, errors.BadInput.New("rules are required")
+	}
+	days := input.Days
+	if days <= 0 {
+		days = defaultDeploymentRulesDryRunDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	candidates, err := loadProjectDeploymentCandidates(projectName, since)
+	if err != nil {
+		return nil, err
+	}
+	finishedResults := []string{devops.RESULT_SUCCESS, devops.RESULT_FAILURE}
+	projectScope := dal.Join("JOIN project_mapping pm ON pm.row_id = p.cicd_scope_id AND pm.table = 'cicd_scopes'")
+	recentPipelines := dal.Where("pm.project_name = ? AND p.created_date >= ? AND p.result IN ?", projectName, since, finishedResults)
+
+	var pipelines []*dryRunPipeline
+	err = db.All(
+		&pipelines,
+		dal.Select("p.id, p.name, p.result, p.type, p.environment, p.finished_date, MAX(pc.branch) AS branch"),
+		dal.From("cicd_pipelines p"),
+		projectScope,
+		dal.Join("LEFT JOIN cicd_pipeline_commits pc ON pc.pipeline_id = p.id"),
+		recentPipelines,
+		dal.Groupby("p.id, p.name, p.result, p.type, p.environment, p.finished_date, p.created_date"),
+		dal.Orderby("p.created_date DESC"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting pipelines")
+	}
+	var tasks []*dryRunTask
+	err = db.All(
+		&tasks,
+		dal.Select("t.id, t.pipeline_id, t.name, t.result, t.type, t.environment"),
+		dal.From("cicd_tasks t"),
+		dal.Join("JOIN cicd_pipelines p ON p.id = t.pipeline_id"),
+		projectScope,
+		recentPipelines,
+		dal.Orderby("t.pipeline_id, t.id"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting tasks")
+	}
+	tasksByPipeline := make(map[string][]*dryRunTask)
+	for _, task := range tasks {
+		tasksByPipeline[task.PipelineId] = append(tasksByPipeline[task.PipelineId], task)
+	}
+	var deployments []*dryRunDeployment
+	err = db.All(
+		&deployments,
+		dal.Select("d.id, d.name, d.result, d.environment, d.finished_date, MAX(dc.ref_name) AS branch"),
+		dal.From("cicd_deployments d"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = d.cicd_scope_id AND pm.table = 'cicd_scopes'"),
+		dal.Join("LEFT JOIN cicd_deployment_commits dc ON dc.cicd_deployment_id = d.id"),
+		dal.Where("pm.project_name = ? AND d.created_date >= ? AND d.result IN ?", projectName, since, finishedResults),
+		dal.Groupby("d.id, d.name, d.result, d.environment, d.finished_date, d.created_date"),
+		dal.Orderby("d.created_date DESC"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting deployments")
+	}
+
+	output := &DeploymentRulesDryRunOutput{
+		Days:                  days,
+		PipelineCount:         len(pipelines),
+		ProductionDeployments: make([]*DeploymentRulesDryRunPipeline, 0),
+	}
+	for _, pipeline := range pipelines {
+		result := &DeploymentRulesDryRunPipeline{
+			Id:                 pipeline.Id,
+			Name:               pipeline.Name,
+			Branch:             pipeline.Branch,
+			Result:             pipeline.Result,
+			FinishedDate:       pipeline.FinishedDate,
+			Type:               pipeline.Type,
+			Environment:        pipeline.Environment,
+			CurrentType:        pipeline.Type,
+			CurrentEnvironment: pipeline.Environment,
+		}
+		if matched := engine.Match(candidates.Pipelines[pipeline.Id]); matched != nil {
+			result.MatchedRule = matched.Rule
+			result.Type, result.Environment = matched.Type, matched.Environment
+		}
+		taskResults := make([]*DeploymentRulesDryRunTask, 0, len(tasksByPipeline[pipeline.Id]))
+		for _, task := range tasksByPipeline[pipeline.Id] {
+			taskResult := &DeploymentRulesDryRunTask{
+				Id:                 task.Id,
+				Name:               task.Name,
+				Result:             task.Result,
+				Type:               task.Type,
+				Environment:        task.Environment,
+				CurrentType:        task.Type,
+				CurrentEnvironment: task.Environment,
+			}
+			if matched := engine.Match(candidates.Tasks[task.Id]); matched != nil {
+				taskResult.MatchedRule = matched.Rule
+				taskResult.Type, taskResult.Environment = matched.Type, matched.Environment
+			}
+			taskResults = append(taskResults, taskResult)
+		}
+		if isProductionDeployment(result.CurrentType, result.CurrentEnvironment, taskResults, true) {
+			output.CurrentCount++
+		}
+		if !isProductionDeployment(result.Type, result.Environment, taskResults, false) {
+			continue
+		}
+		for _, taskResult := range taskResults {
+			if taskResult.Type == devops.DEPLOYMENT || taskResult.Environment != "" {
+				result.Tasks = append(result.Tasks, taskResult)
+			}
+		}
+		output.ProductionDeployments = append(output.ProductionDeployments, result)
+	}
+	// the deployments the dora plugin generates from the pipelines are covered by them, only the ones the plugins
+	// classify by the rules, like the deploy builds of bamboo, are tried
+	for _, deployment := range deployments {
+		candidate := candidates.Deployments[deployment.Id]
+		if candidate == nil {
+			continue
+		}
+		result := &DeploymentRulesDryRunPipeline{
+			Id:                 deployment.Id,
+			Name:               deployment.Name,
+			Branch:             deployment.Branch,
+			Result:             deployment.Result,
+			FinishedDate:       deployment.FinishedDate,
+			Type:               devops.DEPLOYMENT,
+			Environment:        deployment.Environment,
+			CurrentType:        devops.DEPLOYMENT,
+			CurrentEnvironment: deployment.Environment,
+		}
+		if matched := engine.Match(candidate); matched != nil {
+			result.MatchedRule = matched.Rule
+			result.Environment = matched.Environment
+		}
+		if result.CurrentEnvironment == devops.PRODUCTION {
+			output.CurrentCount++
+		}
+		if result.Environment == devops.PRODUCTION {
+			output.ProductionDeployments = append(output.ProductionDeployments, result)
+		}
+	}
+	output.DeploymentCount = len(output.ProductionDeployments)
+	return output, nil
+}
+
+// loadProjectDeploymentCandidates collects the deployment candidates created since the time from the plugins of the
+// scopes of the project, the plugins not classifying by deployment rules or not loaded are skipped
+func loadProjectDeploymentCandidates(projectName string, since time.Time) (*helper.DeploymentCandidates, errors.Error) {
+	candidates := helper.NewDeploymentCandidates()
+	blueprint, err := GetBlueprintByProjectName(projectName)
+	if err != nil || blueprint == nil {
+		return candidates, err
+	}
+	for _, connection := range blueprint.Connections {
+		pluginMeta, err := plugin.GetPlugin(connection.PluginName)
+		if err != nil {
+			continue
+		}
+		source, ok := pluginMeta.(helper.DeploymentCandidateSource)
+		if !ok {
+			continue
+		}
+		for _, scope := range connection.Scopes {
+			scopeCandidates, err := source.DeploymentCandidates(db, connection.ConnectionId, scope.ScopeId, since)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("error getting the deployment candidates of %s scope %s", connection.PluginName, scope.ScopeId))
+			}
+			candidates.Merge(scopeCandidates)
+		}
+	}
+	return candidates, nil
+}
+
+// isProductionDeployment follows dora's generateDeployment: a finished pipeline is a deployment when it or one of its
+// finished tasks is, and it deploys to the environment of the pipeline or else to the production one of its tasks
+func isProductionDeployment(pipelineType, pipelineEnvironment string, tasks []*DeploymentRulesDryRunTask, current bool) bool {
+	isDeployment := pipelineType == devops.DEPLOYMENT
+	hasProductionTasks := false
+	for _, task := range tasks {
+		if task.Result != devops.RESULT_SUCCESS && task.Result != devops.RESULT_FAILURE {
+			continue
+		}
+		taskType, taskEnvironment := task.Type, task.Environment
+		if current {
+			taskType, taskEnvironment = task.CurrentType, task.CurrentEnvironment
+		}
+		if taskType == devops.DEPLOYMENT {
+			isDeployment = true
+		}
+		if taskEnvironment == devops.PRODUCTION {
+			hasProductionTasks = true
+		}
+	}
+	if !isDeployment {
+		return false
+	}
+	if pipelineEnvironment != "" {
+		return pipelineEnvironment == devops.PRODUCTION
+	}
+	return hasProductionTasks
+}
@@ -26,9 +26,11 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/migrationscripts"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/services"
 	"github.com/go-playground/validator/v10"
 	"github.com/robfig/cron/v3"
@@ -136,6 +138,7 @@ func InjectCustomService(customPipelineNotifier PipelineNotificationService, cus
 }
 
 var statusLock sync.Mutex
+var pipelineServiceStarted bool
 
 // ExecuteMigration executes all pending migration scripts and initialize services module
 // This might be called concurrently across multiple API requests
@@ -157,19 +160,91 @@ func ExecuteMigration() errors.Error {
 		logger.Error(err, "failed to execute migration")
 		return err
 	}
+	// drop the expired backup tables created by the migration scripts
+	err = migrationhelper.CleanupBackupTables(basicRes)
+	if err != nil {
+		logger.Error(err, "failed to cleanup migration backup tables")
+	}
 
-	// cronjob for blueprint triggering
-	location := cron.WithLocation(time.UTC)
-	cronManager = cron.New(location)
+	// the pipeline service keeps running if the migration was re-executed after a rollback
+	if !pipelineServiceStarted {
+		// cronjob for blueprint triggering
+		location := cron.WithLocation(time.UTC)
+		cronManager = cron.New(location)
 
-	// initialize pipeline server, mainly to start the pipeline consuming process
-	pipelineServiceInit()
+		// initialize pipeline server, mainly to start the pipeline consuming process
+		pipelineServiceInit()
+		pipelineServiceStarted = true
+	}
 	statusLock.Lock()
 	serviceStatus = SERVICE_STATUS_READY
 	statusLock.Unlock()
 	return nil
 }
 
+// GetPendingMigrationScripts returns the migration scripts to be executed in order
+func GetPendingMigrationScripts() []plugin.MigrationScriptInfo {
+	return migrator.PendingScripts()
+}
+
+// DryRunMigration returns the statements the pending migration scripts would execute without changing the database
+func DryRunMigration() ([]plugin.MigrationScriptPlan, errors.Error) {
+	statusLock.Lock()
+	defer statusLock.Unlock()
+	if serviceStatus == SERVICE_STATUS_MIGRATING {
+		return nil, errors.BadInput.New("There is a migration in progress.")
+	}
+	return migrator.DryRun(), nil
+}
+
+// RollbackMigration reverts the executed migration scripts since the specified version, the service would be waiting
+// for migration confirmation afterward since the database no longer matches the current version
+func RollbackMigration(version uint64) errors.Error {
+	statusLock.Lock()
+	if serviceStatus == SERVICE_STATUS_MIGRATING {
+		statusLock.Unlock()
+		return errors.BadInput.New("There is a migration in progress.")
+	}
+	previousStatus := serviceStatus
+	serviceStatus = SERVICE_STATUS_MIGRATING
+	statusLock.Unlock()
+
+	// no more pipelines would be dequeued while migrating, but the running ones must finish first
+	runningCount, err := db.Count(
+		dal.From(&models.Pipeline{}),
+		dal.Where("status = ?", models.TASK_RUNNING),
+	)
+	if err == nil && runningCount > 0 {
+		err = errors.BadInput.New("There are pipelines running, please wait for them to finish or cancel them before rollback.")
+	}
+	if err != nil {
+		statusLock.Lock()
+		serviceStatus = previousStatus
+		statusLock.Unlock()
+		return err
+	}
+
+	err = migrator.Rollback(version)
+	if err != nil {
+		logger.Error(err, "failed to rollback migration")
+	}
+	statusLock.Lock()
+	defer statusLock.Unlock()
+	// some of the scripts might have been reverted even if it failed halfway
+	if migrator.HasPendingScripts() {
+		serviceStatus = SERVICE_STATUS_WAIT_CONFIRM
+	} else {
+		serviceStatus = previousStatus
+	}
+	return err
+}
+
 func CurrentStatus() string {
 	return serviceStatus
 }
+
+func isMigrating() bool {
+	statusLock.Lock()
+	defer statusLock.Unlock()
+	return serviceStatus == SERVICE_STATUS_MIGRATING
+}
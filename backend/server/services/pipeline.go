@@ -326,9 +326,12 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		globalPipelineLog.Info("get lock and wait next pipeline")
 		var dbPipeline *models.Pipeline
 		for {
-			dbPipeline, err = dequeuePipeline(runningParallelLabels)
-			if err == nil && dbPipeline != nil {
-				break
+			// pipelines must not start while the migration scripts are being executed or reverted
+			if !isMigrating() {
+				dbPipeline, err = dequeuePipeline(runningParallelLabels)
+				if err == nil && dbPipeline != nil {
+					break
+				}
 			}
 			time.Sleep(time.Second)
 		}
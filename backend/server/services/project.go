@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
@@ -266,6 +267,92 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 			return nil, err
 		}
 
+		// ProjectCalendar, ProjectHoliday and the release notes
+		for _, table := range []interface{}{&models.ProjectCalendar{}, &models.ProjectHoliday{}, &models.ProjectReleaseNotesTemplate{}, &models.ProjectReleaseNote{}} {
+			err = tx.UpdateColumn(
+				table,
+				"project_name", project.Name,
+				dal.Where("project_name = ?", name),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// ProjectRunnerPoolMetric
+		err = tx.UpdateColumn(
+			&devops.ProjectRunnerPoolMetric{},
+			"project_name", project.Name,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// ProjectPrRisk
+		err = tx.UpdateColumn(
+			&crossdomain.ProjectPrRisk{},
+			"project_name", project.Name,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// ProjectReviewEdge, ProjectReviewerLoad and ProjectReviewConcentration
+		for _, table := range []interface{}{&crossdomain.ProjectReviewEdge{}, &crossdomain.ProjectReviewerLoad{}, &crossdomain.ProjectReviewConcentration{}} {
+			err = tx.UpdateColumn(
+				table,
+				"project_name", project.Name,
+				dal.Where("project_name = ?", name),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// ProjectIssueLeadTime
+		err = tx.UpdateColumn(
+			&crossdomain.ProjectIssueLeadTime{},
+			"project_name", project.Name,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// ProjectPrReviewMetric and ProjectRepoReviewMetric
+		for _, table := range []interface{}{&crossdomain.ProjectPrReviewMetric{}, &crossdomain.ProjectRepoReviewMetric{}} {
+			err = tx.UpdateColumn(
+				table,
+				"project_name", project.Name,
+				dal.Where("project_name = ?", name),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// ProjectFlakyTest
+		err = tx.UpdateColumn(
+			&crossdomain.ProjectFlakyTest{},
+			"project_name", project.Name,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// ProjectIssueMetric
+		err = tx.UpdateColumn(
+			&crossdomain.ProjectIssueMetric{},
+			"project_name", project.Name,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
+
 		// Blueprint
 		err = tx.UpdateColumn(
 			&models.Blueprint{},
@@ -399,6 +486,54 @@ func DeleteProject(name string) errors.Error {
 	if err != nil {
 		return errors.Default.Wrap(err, "error deleting project Issue metric")
 	}
+	err = tx.Delete(&models.ProjectCalendar{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project calendar")
+	}
+	err = tx.Delete(&models.ProjectHoliday{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project holidays")
+	}
+	err = tx.Delete(&models.ProjectReleaseNotesTemplate{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project release notes template")
+	}
+	err = tx.Delete(&models.ProjectReleaseNote{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project release notes")
+	}
+	err = tx.Delete(&devops.ProjectRunnerPoolMetric{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project runner pool metrics")
+	}
+	err = tx.Delete(&crossdomain.ProjectPrRisk{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project PR risks")
+	}
+	for _, table := range []interface{}{&crossdomain.ProjectReviewEdge{}, &crossdomain.ProjectReviewerLoad{}, &crossdomain.ProjectReviewConcentration{}} {
+		err = tx.Delete(table, dal.Where("project_name = ?", name))
+		if err != nil {
+			return errors.Default.Wrap(err, "error deleting project review network")
+		}
+	}
+	err = tx.Delete(&crossdomain.ProjectIssueLeadTime{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project issue lead times")
+	}
+	for _, table := range []interface{}{&crossdomain.ProjectPrReviewMetric{}, &crossdomain.ProjectRepoReviewMetric{}} {
+		err = tx.Delete(table, dal.Where("project_name = ?", name))
+		if err != nil {
+			return errors.Default.Wrap(err, "error deleting project review metrics")
+		}
+	}
+	err = tx.Delete(&crossdomain.ProjectFlakyTest{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project flaky tests")
+	}
+	err = tx.Delete(&crossdomain.ProjectIssueMetric{}, dal.Where("project_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting project issue metrics")
+	}
 	return tx.Commit()
 }
 
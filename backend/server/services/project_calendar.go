@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// GetProjectCalendar returns the working calendar of the project, or the default one if it was never set
+func GetProjectCalendar(projectName string) (*models.ApiProjectCalendar, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	calendar := &models.ProjectCalendar{}
+	err = db.First(calendar, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, "error getting project calendar")
+		}
+		calendar.BaseProjectCalendar = helper.DefaultProjectCalendar
+		calendar.ProjectName = projectName
+	}
+	output := &models.ApiProjectCalendar{BaseProjectCalendar: calendar.BaseProjectCalendar}
+	err = db.All(&output.Holidays, dal.Where("project_name = ?", projectName), dal.Orderby("date"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting project holidays")
+	}
+	return output, nil
+}
+
+// PutProjectCalendar replaces the working calendar and the holidays of the project
+func PutProjectCalendar(projectName string, input *models.ApiProjectCalendar) (*models.ApiProjectCalendar, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	input.ProjectName = projectName
+	dates := make([]string, 0, len(input.Holidays))
+	seen := make(map[string]bool)
+	for _, holiday := range input.Holidays {
+		if seen[holiday.Date] {
+			return nil, errors.BadInput.New(fmt.Sprintf("duplicated holiday %s", holiday.Date))
+		}
+		seen[holiday.Date] = true
+		holiday.ProjectName = projectName
+		dates = append(dates, holiday.Date)
+	}
+	_, err = helper.NewWorkingCalendar(&input.BaseProjectCalendar, dates...)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			err = tx.Rollback()
+			if err != nil {
+				logger.Error(err, "PutProjectCalendar: failed to rollback")
+			}
+		}
+	}()
+	err = tx.CreateOrUpdate(&models.ProjectCalendar{BaseProjectCalendar: input.BaseProjectCalendar})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving project calendar")
+	}
+	err = tx.Delete(&models.ProjectHoliday{}, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting project holidays")
+	}
+	if len(input.Holidays) > 0 {
+		err = tx.CreateOrUpdate(input.Holidays)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error saving project holidays")
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return GetProjectCalendar(projectName)
+}
+
+// ImportProjectHolidays adds the days of the events in the iCalendar file to the holidays of the project,
+// existing holidays on the same dates are renamed
+func ImportProjectHolidays(projectName string, ics io.Reader) (*models.ApiProjectCalendar, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	icalHolidays, err := helper.ParseICalHolidays(ics)
+	if err != nil {
+		return nil, err
+	}
+	holidays := make([]*models.ProjectHoliday, 0, len(icalHolidays))
+	seen := make(map[string]bool)
+	for _, holiday := range icalHolidays {
+		// overlapping events would update the same row twice within one statement
+		if seen[holiday.Date] {
+			continue
+		}
+		seen[holiday.Date] = true
+		holidays = append(holidays, &models.ProjectHoliday{
+			ProjectName: projectName,
+			Date:        holiday.Date,
+			Name:        holiday.Name,
+		})
+	}
+	if len(holidays) > 0 {
+		err = db.CreateOrUpdate(holidays)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error saving project holidays")
+		}
+	}
+	return GetProjectCalendar(projectName)
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// GetProjectReleaseNotesTemplate returns the release notes template of the project, or the default one if it was never set
+func GetProjectReleaseNotesTemplate(projectName string) (*models.ProjectReleaseNotesTemplate, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	text, err := helper.LoadReleaseNotesTemplate(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ProjectReleaseNotesTemplate{ProjectName: projectName, Template: text}, nil
+}
+
+// PutProjectReleaseNotesTemplate replaces the release notes template of the project, an empty one restores the default
+func PutProjectReleaseNotesTemplate(projectName string, input *models.ProjectReleaseNotesTemplate) (*models.ProjectReleaseNotesTemplate, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(input.Template) == "" {
+		err = db.Delete(&models.ProjectReleaseNotesTemplate{}, dal.Where("project_name = ?", projectName))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error deleting release notes template")
+		}
+		return GetProjectReleaseNotesTemplate(projectName)
+	}
+	// rendering empty notes catches references to unknown fields as well
+	_, err = helper.RenderReleaseNotes(&helper.ReleaseNotes{}, input.Template)
+	if err != nil {
+		return nil, err
+	}
+	err = db.CreateOrUpdate(&models.ProjectReleaseNotesTemplate{ProjectName: projectName, Template: input.Template})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving release notes template")
+	}
+	return GetProjectReleaseNotesTemplate(projectName)
+}
+
+// GetProjectReleaseNotes lists what shipped with a deployment commit of the project
+func GetProjectReleaseNotes(projectName string, deploymentCommitId string) (*helper.ReleaseNotes, errors.Error) {
+	_, err := getProjectByName(db, projectName)
+	if err != nil {
+		return nil, err
+	}
+	count, err := db.Count(
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = dc.cicd_scope_id AND pm.table = 'cicd_scopes'"),
+		dal.Where("pm.project_name = ? AND dc.id = ?", projectName, deploymentCommitId),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting deployment commit")
+	}
+	if count == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("deployment commit %s not found in project %s", deploymentCommitId, projectName))
+	}
+	return helper.BuildReleaseNotes(db, deploymentCommitId)
+}
+
+// RenderProjectReleaseNotes renders what shipped with a deployment commit of the project with the template of the project
+func RenderProjectReleaseNotes(projectName string, deploymentCommitId string) (string, errors.Error) {
+	notes, err := GetProjectReleaseNotes(projectName, deploymentCommitId)
+	if err != nil {
+		return "", err
+	}
+	text, err := helper.LoadReleaseNotesTemplate(db, projectName)
+	if err != nil {
+		return "", err
+	}
+	return helper.RenderReleaseNotes(notes, text)
+}
@@ -19,6 +19,7 @@ package bridge
 
 import (
 	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/server/services/remote/models"
 )
@@ -45,20 +46,55 @@ func (b *Bridge) RemoteSubtaskEntrypointHandler(subtaskMeta models.SubtaskMeta)
 		}
 		stream := b.invoker.Stream(subtaskMeta.EntryPointName, NewChildRemoteContext(ctx), args...)
 		for recv := range stream.Receive() {
-			if recv.Err != nil {
-				return recv.Err
-			}
-			progress := RemoteProgress{}
-			err := recv.Get(&progress)
+			err := handleRemoteMessage(ctx, recv)
 			if err != nil {
+				// keep draining so the process would not be blocked
+				go func() {
+					for range stream.Receive() {
+					}
+				}()
 				return err
 			}
-			if progress.Total != 0 {
-				ctx.SetProgress(progress.Current, progress.Total)
-			} else if progress.Increment != 0 {
-				ctx.IncProgress(progress.Increment)
-			}
 		}
-		return nil
+		return errors.Convert(ctx.GetContext().Err())
+	}
+}
+
+func handleRemoteMessage(ctx plugin.SubTaskContext, recv *StreamResult) errors.Error {
+	if recv.Err != nil {
+		return recv.Err
+	}
+	msg := RemoteMessage{}
+	err := recv.Get(&msg)
+	if err != nil {
+		return err
+	}
+	switch msg.Type {
+	case "", RemoteMessageProgress:
+		if msg.Total != 0 {
+			ctx.SetProgress(msg.Current, msg.Total)
+		} else if msg.Increment != 0 {
+			ctx.IncProgress(msg.Increment)
+		}
+	case RemoteMessageCollectorState:
+		state := &coreModels.CollectorLatestState{
+			RawDataTable:       msg.RawDataTable,
+			RawDataParams:      msg.RawDataParams,
+			TimeAfter:          msg.TimeAfter,
+			LatestSuccessStart: msg.LatestSuccessStart,
+		}
+		if state.RawDataTable == "" || state.RawDataParams == "" {
+			return errors.Default.New("raw_data_table and raw_data_params are required for the collector state")
+		}
+		if syncPolicy := ctx.TaskContext().SyncPolicy(); state.TimeAfter == nil && syncPolicy != nil {
+			state.TimeAfter = syncPolicy.TimeAfter
+		}
+		err = ctx.GetDal().CreateOrUpdate(state)
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to save the collector state")
+		}
+	default:
+		ctx.GetLogger().Warn(nil, "unknown message type %s from the remote subtask", msg.Type)
 	}
+	return nil
 }
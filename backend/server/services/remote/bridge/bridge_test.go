@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	remoteModels "github.com/apache/incubator-devlake/server/services/remote/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeInvoker struct {
+	messages []string
+}
+
+func (f *fakeInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any) *CallResult {
+	return NewCallResult(nil, nil)
+}
+
+func (f *fakeInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...any) *MethodStream {
+	recvChannel := make(chan *StreamResult, len(f.messages))
+	for _, msg := range f.messages {
+		recvChannel <- NewStreamResult([]byte(msg), nil)
+	}
+	close(recvChannel)
+	return &MethodStream{inbound: recvChannel}
+}
+
+func TestRemoteSubtaskEntrypointHandler(t *testing.T) {
+	invoker := &fakeInvoker{messages: []string{
+		`{"total":10,"current":0}`,
+		`{"type":"progress","increment":5}`,
+		`{"type":"collector_state","raw_data_table":"_raw_issues","raw_data_params":"{\"ConnectionId\":1}","latest_success_start":"2023-01-02T03:04:05Z"}`,
+	}}
+	db := new(mockdal.Dal)
+	db.On("CreateOrUpdate", mock.MatchedBy(func(state *models.CollectorLatestState) bool {
+		return state.RawDataTable == "_raw_issues" &&
+			state.RawDataParams == `{"ConnectionId":1}` &&
+			state.LatestSuccessStart.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)) &&
+			state.TimeAfter == nil
+	}), []dal.Clause(nil)).Return(nil).Once()
+	ctx := new(mockplugin.SubTaskContext)
+	ctx.On("GetData").Return(nil)
+	ctx.On("GetLogger").Return(logruslog.Global)
+	ctx.On("GetContext").Return(context.Background())
+	ctx.On("GetDal").Return(db)
+	taskCtx := new(mockplugin.TaskContext)
+	taskCtx.On("SyncPolicy").Return(nil)
+	ctx.On("TaskContext").Return(taskCtx)
+	ctx.On("SetProgress", 0, 10).Once()
+	ctx.On("IncProgress", 5).Once()
+
+	entryPoint := NewBridge(invoker).RemoteSubtaskEntrypointHandler(remoteModels.SubtaskMeta{EntryPointName: "collect"})
+	assert.Nil(t, entryPoint(ctx))
+	ctx.AssertExpectations(t)
+	db.AssertExpectations(t)
+}
+
+func TestCmdInvokerStreamCancel(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "run.sh")
+	err := os.WriteFile(script, []byte(`#!/bin/sh
+trap 'echo "{\"increment\":2}" >&3; exit 1' TERM
+echo '{"increment":1}' >&3
+while true; do sleep 0.1; done
+`), 0755)
+	assert.Nil(t, err)
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := new(mockplugin.ExecContext)
+	ctx.On("GetLogger").Return(logruslog.Global)
+	ctx.On("GetContext").Return(cancelCtx)
+	invoker := NewCmdInvoker(script)
+	invoker.cancelGracePeriod = 5 * time.Second
+
+	var received []string
+	for recv := range invoker.Stream("collect", ctx).Receive() {
+		assert.Nil(t, recv.Err)
+		received = append(received, string(recv.Results))
+		if len(received) == 1 {
+			cancel()
+		}
+	}
+	assert.Equal(t, []string{`{"increment":1}`, `{"increment":2}`}, received)
+}
+
+func TestCmdInvokerStreamKill(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "run.sh")
+	err := os.WriteFile(script, []byte(`#!/bin/sh
+trap '' TERM
+echo '{"increment":1}' >&3
+while true; do sleep 0.1; done
+`), 0755)
+	assert.Nil(t, err)
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := new(mockplugin.ExecContext)
+	ctx.On("GetLogger").Return(logruslog.Global)
+	ctx.On("GetContext").Return(cancelCtx)
+	invoker := NewCmdInvoker(script)
+	invoker.cancelGracePeriod = 100 * time.Millisecond
+
+	count := 0
+	for recv := range invoker.Stream("collect", ctx).Receive() {
+		assert.Nil(t, recv.Err)
+		count++
+		cancel()
+	}
+	assert.Equal(t, 1, count)
+}
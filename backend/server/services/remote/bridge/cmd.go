@@ -22,17 +22,24 @@ import (
 	"fmt"
 	"os/exec"
 	"path"
+	"time"
 
+	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 )
 
+// DefaultCancelGracePeriod is how long a remote process can take to stop after being cancelled before it gets killed
+const DefaultCancelGracePeriod = 30 * time.Second
+
 type CmdInvoker struct {
 	resolveCmd  func(methodName string, args ...string) (string, []string)
-	cancelled   bool
 	workingPath string
+	// slots limits the number of streaming processes running at the same time, nil for unlimited
+	slots             chan struct{}
+	cancelGracePeriod time.Duration
 }
 
 func NewCmdInvoker(execPath string) *CmdInvoker {
@@ -44,10 +51,15 @@ func NewCmdInvoker(execPath string) *CmdInvoker {
 		return fmt.Sprintf("./%s", file), allArgs
 	}
 
-	return &CmdInvoker{
-		resolveCmd:  resolveCmd,
-		workingPath: dir,
+	invoker := &CmdInvoker{
+		resolveCmd:        resolveCmd,
+		workingPath:       dir,
+		cancelGracePeriod: DefaultCancelGracePeriod,
+	}
+	if maxProcesses := config.GetConfig().GetInt("REMOTE_PLUGIN_MAX_PROCESSES"); maxProcesses > 0 {
+		invoker.slots = make(chan struct{}, maxProcesses)
 	}
+	return invoker
 }
 
 func (c *CmdInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any) *CallResult {
@@ -86,57 +98,54 @@ func (c *CmdInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any
 	return NewCallResult(response.GetFdOut(), nil)
 }
 
+// Stream runs the remote method in a new process and streams its outputs, the process would receive a SIGTERM
+// once the context of ctx is done and be killed if it doesn't exit within the grace period.
 func (c *CmdInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...any) *MethodStream {
 	recvChannel := make(chan *StreamResult)
 	stream := &MethodStream{
 		outbound: nil,
 		inbound:  recvChannel,
 	}
-	serializedArgs, err := serialize(append([]any{DefaultContext.GetRemoteConfig()}, args...)...)
-	if err != nil {
-		recvChannel <- NewStreamResult(nil, err)
-		return stream
-	}
-	executable, inputArgs := c.resolveCmd(methodName, serializedArgs...)
-	cmdCtx := DefaultContext.GetContext() // grabbing context off of ctx kills the cmd after a couple of seconds... why?
-	cmd := exec.CommandContext(cmdCtx, executable, inputArgs...)
-	if c.workingPath != "" {
-		cmd.Dir = c.workingPath
-	}
-	processHandle, err := utils.StreamProcess(cmd, &utils.StreamProcessOptions{
-		OnStdout: func(b []byte) {
-			msg := string(b)
-			c.logRemoteMessage(ctx.GetLogger(), msg)
-		},
-		OnStderr: func(b []byte) {
-			msg := string(b)
-			c.logRemoteError(ctx.GetLogger(), msg)
-		},
-		UseFdOut: true,
-	})
-	if err != nil {
-		recvChannel <- NewStreamResult(nil, err)
-		return stream
-	}
 	go func() {
 		defer close(recvChannel)
+		if !c.acquireSlot(ctx) {
+			recvChannel <- NewStreamResult(nil, errors.Convert(ctx.GetContext().Err()))
+			return
+		}
+		defer c.releaseSlot()
+		serializedArgs, err := serialize(append([]any{DefaultContext.GetRemoteConfig()}, args...)...)
+		if err != nil {
+			recvChannel <- NewStreamResult(nil, err)
+			return
+		}
+		executable, inputArgs := c.resolveCmd(methodName, serializedArgs...)
+		cmdCtx := DefaultContext.GetContext() // grabbing context off of ctx kills the cmd after a couple of seconds... why?
+		cmd := exec.CommandContext(cmdCtx, executable, inputArgs...)
+		if c.workingPath != "" {
+			cmd.Dir = c.workingPath
+		}
+		processHandle, err := utils.StreamProcess(cmd, &utils.StreamProcessOptions{
+			OnStdout: func(b []byte) {
+				msg := string(b)
+				c.logRemoteMessage(ctx.GetLogger(), msg)
+			},
+			OnStderr: func(b []byte) {
+				msg := string(b)
+				c.logRemoteError(ctx.GetLogger(), msg)
+			},
+			UseFdOut: true,
+		})
+		if err != nil {
+			recvChannel <- NewStreamResult(nil, err)
+			return
+		}
+		done := make(chan struct{})
+		defer close(done)
+		go c.watchCancellation(ctx, processHandle, done)
 		for msg := range processHandle.Receive() {
 			if err = msg.GetError(); err != nil {
 				recvChannel <- NewStreamResult(nil, err)
 			}
-			if !c.cancelled {
-				select {
-				case <-ctx.GetContext().Done():
-					err = processHandle.Cancel()
-					if err != nil {
-						recvChannel <- NewStreamResult(nil, errors.Default.Wrap(err, "error cancelling python target"))
-						return
-					}
-					c.cancelled = true
-					// continue until the stream gets closed by the child
-				default:
-				}
-			}
 			response := msg.GetFdOut()
 			if response != nil {
 				recvChannel <- NewStreamResult(response, nil)
@@ -146,6 +155,45 @@ func (c *CmdInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...a
 	return stream
 }
 
+func (c *CmdInvoker) acquireSlot(ctx plugin.ExecContext) bool {
+	if c.slots == nil {
+		return true
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	case <-ctx.GetContext().Done():
+		return false
+	}
+}
+
+func (c *CmdInvoker) releaseSlot() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// watchCancellation signals the process to stop gracefully once the context is done, and kills it after the grace period
+func (c *CmdInvoker) watchCancellation(ctx plugin.ExecContext, processHandle *utils.ProcessStream, done <-chan struct{}) {
+	select {
+	case <-done:
+		return
+	case <-ctx.GetContext().Done():
+	}
+	ctx.GetLogger().Info("cancelling the remote process")
+	if err := processHandle.Cancel(); err != nil {
+		ctx.GetLogger().Warn(err, "failed to cancel the remote process")
+	}
+	select {
+	case <-done:
+	case <-time.After(c.cancelGracePeriod):
+		ctx.GetLogger().Warn(nil, "the remote process didn't stop within %s, killing it", c.cancelGracePeriod)
+		if err := processHandle.Kill(); err != nil {
+			ctx.GetLogger().Error(err, "failed to kill the remote process")
+		}
+	}
+}
+
 func serialize(args ...any) ([]string, errors.Error) {
 	var serializedArgs []string
 	for _, arg := range args {
@@ -19,6 +19,7 @@ package bridge
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/config"
 	ctx "github.com/apache/incubator-devlake/core/context"
@@ -31,12 +32,34 @@ import (
 
 var DefaultContext = NewRemoteContext(logruslog.Global, config.GetConfig())
 
+const (
+	// RemoteMessageProgress reports the progress of the subtask, messages without type are progresses as well
+	RemoteMessageProgress = "progress"
+	// RemoteMessageCollectorState reports the state of a successful collection to be saved
+	// in the _devlake_collector_latest_state table
+	RemoteMessageCollectorState = "collector_state"
+)
+
+// RemoteMessage is streamed from the remote subtasks line by line
+type RemoteMessage struct {
+	Type string `json:"type"`
+	RemoteProgress
+	RemoteCollectorState
+}
+
 type RemoteProgress struct {
 	Current   int `json:"current"`
 	Total     int `json:"total"`
 	Increment int `json:"increment"`
 }
 
+type RemoteCollectorState struct {
+	RawDataTable       string     `json:"raw_data_table"`
+	RawDataParams      string     `json:"raw_data_params"`
+	TimeAfter          *time.Time `json:"time_after"`
+	LatestSuccessStart *time.Time `json:"latest_success_start"`
+}
+
 type RemoteContext interface {
 	plugin.ExecContext
 	GetRemoteConfig() *RemoteConfig
@@ -39,3 +39,11 @@ func UsePostgres(host string, port int) string {
 	config.GetConfig().Set("E2E_DB_URL", conn)
 	return conn
 }
+
+// UseSqlite points E2E_DB_URL to an embedded sqlite database file, an empty path means in-memory
+func UseSqlite(path string) string {
+	conn := fmt.Sprintf("sqlite://%s", path)
+	_ = os.Setenv("E2E_DB_URL", conn)
+	config.GetConfig().Set("E2E_DB_URL", conn)
+	return conn
+}